@@ -17,6 +17,13 @@ import (
 	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
 	adminuiusecase "proto-gin-web/internal/contexts/admin/ui/usecase"
 	adminusecase "proto-gin-web/internal/contexts/admin/auth/usecase"
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	webhookusecase "proto-gin-web/internal/contexts/admin/webhook/usecase"
+	menuusecase "proto-gin-web/internal/contexts/blog/menu/usecase"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	newsletterusecase "proto-gin-web/internal/contexts/blog/newsletter/usecase"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
 	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
 	appdb "proto-gin-web/internal/infrastructure/pg"
@@ -24,6 +31,10 @@ import (
 	redisstore "proto-gin-web/internal/infrastructure/redis"
 	"proto-gin-web/internal/platform/config"
 	httpapp "proto-gin-web/internal/platform/http"
+	"proto-gin-web/internal/platform/jobs"
+	"proto-gin-web/internal/platform/mail"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/storage"
 )
 
 // @title           Proto Gin Web API
@@ -39,6 +50,11 @@ func main() {
 	log := platformlog.NewLogger(cfg.Env, cfg.LogFile)
 	slog.SetDefault(log)
 
+	if err := cfg.Validate(); err != nil {
+		log.Error("invalid configuration", slog.Any("err", err))
+		os.Exit(1)
+	}
+
 	pool, err := appdb.NewPool(context.Background(), cfg)
 	if err != nil {
 		log.Error("failed to initialize database pool", slog.Any("err", err))
@@ -46,14 +62,48 @@ func main() {
 	}
 	defer pool.Close()
 
+	uploads, err := storage.Open(context.Background(), storage.OptionsFromConfig(cfg, mediadomain.PathPrefix))
+	if err != nil {
+		log.Error("failed to initialize upload storage", slog.Any("err", err))
+		os.Exit(1)
+	}
+
 	queries := appdb.New(pool)
 	rememberRepo := appdb.NewRememberTokenRepository(pool)
 	postRepo := appdb.NewPostRepository(pool)
-	postSvc := postusecase.NewService(postRepo)
+	fieldRepo := appdb.NewCustomFieldRepository(pool)
+	outboxRepo := appdb.NewOutboxRepository(pool)
+	webhookSvc := webhookusecase.NewService(appdb.NewWebhookRepository(pool), cfg.BaseURL, nil)
+	relay := outbox.NewRelay(outboxRepo)
+	relay.Register(webhookSvc.OutboxHandler())
+	var mailer mail.Mailer = mail.NewLogMailer(log, cfg.MailFrom, cfg.MailDir)
+	if cfg.MailDriver == "smtp" {
+		mailer = mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	}
+	newsletterSvc := newsletterusecase.NewService(appdb.NewNewsletterRepository(pool), mailer, newsletterusecase.Config{
+		BaseURL:  cfg.BaseURL,
+		SiteName: cfg.SiteName,
+		Secret:   cfg.NewsletterSecret,
+	})
+	relay.Register(newsletterSvc.OutboxHandler())
+	postSvc := postusecase.NewService(postRepo, fieldRepo, outboxRepo)
+	fieldSvc := postusecase.NewFieldService(fieldRepo)
+	reviewRepo := appdb.NewReviewRepository(pool)
+	reviewSvc := postusecase.NewReviewService(postRepo, reviewRepo, outboxRepo)
+	autosaveSvc := postusecase.NewAutosaveService(postRepo, appdb.NewAutosaveRepository(pool))
+	pageRepo := appdb.NewPageRepository(pool)
+	pageSvc := pageusecase.NewService(pageRepo, outboxRepo)
 	adminRepo := appdb.NewAdminAccountRepository(queries)
 	adminSvc := adminusecase.NewService(adminRepo, adminusecase.Config{
-		AdminRoleName: "admin",
-	})
+		AdminRoleName:    "admin",
+		RegisterRoleName: "writer",
+	}, outboxRepo)
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     cfg.RedisAddr,
 		Password: cfg.RedisPassword,
@@ -65,13 +115,103 @@ func main() {
 	}
 	defer redisClient.Close()
 	sessionStore := redisstore.NewAdminSessionStore(redisClient)
+	lockSvc := postusecase.NewLockService(postRepo, redisstore.NewEditLockStore(redisClient))
 	sessionManager := authsession.NewManager(sessionStore, rememberRepo, authsession.Config{})
 	taxonomyRepo := appdb.NewTaxonomyRepository(queries)
-	taxonomySvc := taxonomyusecase.NewService(taxonomyRepo)
-	adminContentSvc := admincontentusecase.NewService(postSvc, taxonomySvc)
-	adminUISvc := adminuiusecase.NewService(postSvc)
+	taxonomySvc := taxonomyusecase.NewService(taxonomyRepo, outboxRepo)
+	menuRepo := appdb.NewMenuRepository(pool)
+	menuCache := redisstore.NewMenuCache(redisClient, 10*time.Minute)
+	menuSvc := menuusecase.NewService(menuRepo, menuCache)
+	relay.Register(menuSvc.OutboxHandler())
+	linkCheckSvc := postusecase.NewLinkCheckService(postRepo, taxonomyRepo, appdb.NewLinkCheckRepository(pool), cfg.BaseURL, uploads)
+	tagHintSvc := postusecase.NewTagSuggestionService(appdb.NewTermIndexRepository(pool), taxonomyRepo)
+	relay.Register(tagHintSvc.OutboxHandler())
+	mediaSvc := mediausecase.NewService(appdb.NewMediaRepository(pool), uploads, mediadomain.VariantOptions{
+		Widths:      cfg.ImageVariantWidths,
+		JPEGQuality: cfg.ImageJPEGQuality,
+	})
+	adminContentSvc := admincontentusecase.NewService(postSvc, reviewSvc, lockSvc, autosaveSvc, linkCheckSvc, taxonomySvc, fieldSvc, pageSvc, menuSvc, webhookSvc, newsletterSvc, tagHintSvc, mediaSvc)
+	adminUISvc := adminuiusecase.NewService(postSvc, reviewSvc, lockSvc, autosaveSvc, linkCheckSvc, fieldSvc, pageSvc, menuSvc, webhookSvc, newsletterSvc, taxonomySvc, tagHintSvc, mediaSvc)
+
+	jobRunner := jobs.NewRunner(log)
+	jobRunner.Add(jobs.Job{
+		Name:     "archive-expired-posts",
+		Interval: time.Minute,
+		Run: func(ctx context.Context) error {
+			slugs, err := postSvc.ArchiveExpired(ctx)
+			if len(slugs) > 0 {
+				log.Info("archived expired posts", slog.Any("slugs", slugs))
+			}
+			return err
+		},
+	})
+	jobRunner.Add(jobs.Job{
+		Name: "check-post-links",
+		Next: jobs.DailyAt(3, 0),
+		Run: func(ctx context.Context) error {
+			found, err := linkCheckSvc.CheckAll(ctx)
+			log.Info("checked post links", slog.Int("broken", found))
+			return err
+		},
+	})
+	jobRunner.Add(jobs.Job{
+		Name:     "index-post-terms",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			indexed, err := tagHintSvc.IndexMissing(ctx)
+			if indexed > 0 {
+				log.Info("indexed posts for tag suggestions", slog.Int("count", indexed))
+			}
+			return err
+		},
+	})
+	jobRunner.Add(jobs.Job{
+		Name:     "relay-outbox",
+		Interval: 2 * time.Second,
+		Run: func(ctx context.Context) error {
+			_, err := relay.Dispatch(ctx)
+			return err
+		},
+	})
+	jobRunner.Add(jobs.Job{
+		Name: "cleanup-outbox",
+		Next: jobs.DailyAt(4, 0),
+		Run: func(ctx context.Context) error {
+			deleted, err := relay.Cleanup(ctx, 7*24*time.Hour)
+			log.Info("cleaned up outbox", slog.Int64("deleted", deleted))
+			return err
+		},
+	})
+	jobRunner.Add(jobs.Job{
+		Name:     "deliver-webhooks",
+		Interval: 10 * time.Second,
+		Run: func(ctx context.Context) error {
+			delivered, err := webhookSvc.DeliverDue(ctx)
+			if delivered > 0 {
+				log.Info("delivered webhooks", slog.Int("count", delivered))
+			}
+			return err
+		},
+	})
+	newsletterJob := jobs.Job{
+		Name:     "send-newsletter",
+		Interval: time.Minute,
+		Run: func(ctx context.Context) error {
+			sent, err := newsletterSvc.SendPending(ctx)
+			if sent > 0 {
+				log.Info("sent newsletter", slog.Int("emails", sent))
+			}
+			return err
+		},
+	}
+	if cfg.NewsletterMode == newsletterdomain.ModeDigest {
+		newsletterJob.Next = jobs.DailyAt(cfg.NewsletterDigestHour, 0)
+	}
+	jobRunner.Add(newsletterJob)
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	jobRunner.Start(jobCtx)
 
-	r := httpapp.NewRouter(cfg, postSvc, adminSvc, adminContentSvc, adminUISvc, sessionManager)
+	r := httpapp.NewRouter(cfg, postSvc, pageSvc, menuSvc, taxonomySvc, adminSvc, adminContentSvc, adminUISvc, newsletterSvc, mediaSvc, uploads, sessionManager)
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.Port,
@@ -99,6 +239,8 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Error("graceful shutdown failed", slog.Any("err", err))
 	}
+	stopJobs()
+	jobRunner.Wait()
 	log.Info("server stopped")
 }
 
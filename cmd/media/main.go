@@ -0,0 +1,132 @@
+// Command media maintains the media library outside the API server.
+//
+//	go run ./cmd/media variants
+//	go run ./cmd/media copy-storage local s3
+//
+// variants rebuilds the resized copies of every uploaded image for the widths in
+// IMAGE_VARIANT_WIDTHS; run it after changing the widths or the JPEG quality.
+//
+// copy-storage copies every uploaded file from one storage backend to another, both
+// configured from the usual STORAGE_ and S3_ variables. Files already present at the
+// same size are skipped, so it can be rerun after an interruption, and nothing is
+// removed from the source. Point STORAGE_DRIVER at the target once it succeeds.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	appdb "proto-gin-web/internal/infrastructure/pg"
+	platformlog "proto-gin-web/internal/infrastructure/platform"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/storage"
+)
+
+const usage = `usage:
+  media variants
+  media copy-storage <local|s3> <local|s3>`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	_ = godotenv.Load()
+
+	cfg := config.Load()
+	log := platformlog.NewLogger(cfg.Env, cfg.LogFile)
+	slog.SetDefault(log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch args := os.Args[2:]; {
+	case os.Args[1] == "variants" && len(args) == 0:
+		err = regenerateVariants(ctx, cfg)
+	case os.Args[1] == "copy-storage" && len(args) == 2:
+		err = copyStorage(ctx, cfg, args[0], args[1])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Error(os.Args[1]+" failed", slog.Any("err", err))
+		os.Exit(1)
+	}
+}
+
+func regenerateVariants(ctx context.Context, cfg config.Config) error {
+	pool, err := appdb.NewPool(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("initialize database pool: %w", err)
+	}
+	defer pool.Close()
+
+	uploads, err := storage.Open(ctx, storage.OptionsFromConfig(cfg, mediadomain.PathPrefix))
+	if err != nil {
+		return err
+	}
+	svc := mediausecase.NewService(appdb.NewMediaRepository(pool), uploads, mediadomain.VariantOptions{
+		Widths:      cfg.ImageVariantWidths,
+		JPEGQuality: cfg.ImageJPEGQuality,
+	})
+	result, err := svc.RegenerateVariants(ctx)
+	fmt.Printf("%d images, %d variants written, %d failures\n", result.Files, result.Variants, len(result.Failures))
+	for _, f := range result.Failures {
+		fmt.Printf("  %s: %s\n", f.Filename, f.Error)
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("%d images failed", len(result.Failures))
+	}
+	return nil
+}
+
+func copyStorage(ctx context.Context, cfg config.Config, from, to string) error {
+	if from == to {
+		return fmt.Errorf("source and target are both %q", from)
+	}
+	open := func(driver string) (storage.Storage, error) {
+		opts := storage.OptionsFromConfig(cfg, mediadomain.PathPrefix)
+		opts.Driver = driver
+		return storage.Open(ctx, opts)
+	}
+	src, err := open(from)
+	if err != nil {
+		return err
+	}
+	dst, err := open(to)
+	if err != nil {
+		return err
+	}
+
+	result, err := storage.Copy(ctx, src, dst)
+	fmt.Printf("%d copied, %d already present, %d failed\n", result.Copied, result.Skipped, len(result.Failed))
+	keys := make([]string, 0, len(result.Failed))
+	for key := range result.Failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %s: %v\n", key, result.Failed[key])
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d files failed", len(result.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+-- Admin-defined custom fields and their per-post values
+
+CREATE TABLE IF NOT EXISTS custom_field (
+    id          BIGSERIAL PRIMARY KEY,
+    key         TEXT NOT NULL UNIQUE,
+    label       TEXT NOT NULL,
+    type        TEXT NOT NULL CHECK (type IN ('text', 'number', 'date', 'url', 'enum')),
+    required    BOOLEAN NOT NULL DEFAULT FALSE,
+    options     TEXT[] NOT NULL DEFAULT '{}',
+    category_id BIGINT REFERENCES category(id) ON DELETE CASCADE, -- NULL applies to every post
+    position    INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_field_category ON custom_field (category_id);
+
+ALTER TABLE post
+    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_post_custom_fields ON post USING GIN (custom_fields jsonb_path_ops);
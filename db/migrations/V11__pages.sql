@@ -0,0 +1,17 @@
+-- Standalone pages (About, Privacy, ...) kept out of post listings and feeds
+
+CREATE TABLE IF NOT EXISTS page (
+    id          BIGSERIAL PRIMARY KEY,
+    title       TEXT NOT NULL,
+    slug        TEXT NOT NULL UNIQUE,
+    summary     TEXT NOT NULL DEFAULT '',
+    content_md  TEXT NOT NULL DEFAULT '',
+    template    TEXT NOT NULL DEFAULT 'default' CHECK (template IN ('default', 'wide')),
+    status      TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published')),
+    parent_id   BIGINT REFERENCES page(id) ON DELETE SET NULL,
+    position    INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_page_parent ON page (parent_id, position);
@@ -0,0 +1,32 @@
+-- Admin-managed navigation menus
+
+CREATE TABLE IF NOT EXISTS menu (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL UNIQUE,
+    label       TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS menu_item (
+    id           BIGSERIAL PRIMARY KEY,
+    menu_id      BIGINT NOT NULL REFERENCES menu(id) ON DELETE CASCADE,
+    parent_id    BIGINT REFERENCES menu_item(id) ON DELETE CASCADE,
+    label        TEXT NOT NULL,
+    target_type  TEXT NOT NULL CHECK (target_type IN ('post', 'page', 'category', 'tag', 'url')),
+    target_id    BIGINT, -- id in the table named by target_type; resolved to a slug on read
+    url          TEXT NOT NULL DEFAULT '',
+    position     INT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((target_type = 'url') = (target_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_menu_item_menu ON menu_item (menu_id, parent_id, position);
+
+INSERT INTO menu (name, label) VALUES ('header', 'Header'), ('footer', 'Footer')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO menu_item (menu_id, label, target_type, url, position)
+SELECT m.id, 'Posts', 'url', '/posts', 0
+FROM menu m
+WHERE m.name = 'header'
+  AND NOT EXISTS (SELECT 1 FROM menu_item i WHERE i.menu_id = m.id);
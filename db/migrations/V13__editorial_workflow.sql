@@ -0,0 +1,25 @@
+-- Editorial review workflow: writer/editor roles, review statuses, reviewer assignment
+-- and review comments
+
+INSERT INTO role (name) VALUES ('writer'), ('editor') ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE post
+    ADD COLUMN IF NOT EXISTS reviewer_id BIGINT REFERENCES app_user(id) ON DELETE SET NULL;
+
+ALTER TABLE post
+    ADD CONSTRAINT post_status_check
+    CHECK (status IN ('draft', 'in_review', 'changes_requested', 'approved', 'published', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_post_reviewer ON post (reviewer_id) WHERE reviewer_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS post_review_comment (
+    id           BIGSERIAL PRIMARY KEY,
+    post_id      BIGINT NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+    author_id    BIGINT REFERENCES app_user(id) ON DELETE SET NULL,
+    body         TEXT NOT NULL DEFAULT '',
+    from_status  TEXT, -- set when the comment was left with a status change
+    to_status    TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_review_comment_post ON post_review_comment (post_id, created_at);
@@ -0,0 +1,14 @@
+-- Autosaved working copies of posts being edited, one per post and user, kept apart
+-- from the post itself until the editor saves
+
+CREATE TABLE IF NOT EXISTS post_autosave (
+    post_id        BIGINT NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+    user_id        BIGINT NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    title          TEXT NOT NULL DEFAULT '',
+    summary        TEXT NOT NULL DEFAULT '',
+    content_md     TEXT NOT NULL DEFAULT '',
+    cover_url      TEXT NOT NULL DEFAULT '',
+    custom_fields  JSONB NOT NULL DEFAULT '{}'::jsonb,
+    saved_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
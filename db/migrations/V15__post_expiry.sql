@@ -0,0 +1,13 @@
+-- Content expiry: posts may carry an unpublish date after which a background job
+-- archives them; expiry_mode decides whether an expired post answers 410 Gone or
+-- still renders behind an "expired" banner
+
+ALTER TABLE post
+    ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS expiry_mode TEXT NOT NULL DEFAULT 'gone';
+
+ALTER TABLE post
+    ADD CONSTRAINT post_expiry_mode_check CHECK (expiry_mode IN ('gone', 'banner'));
+
+CREATE INDEX IF NOT EXISTS idx_post_unpublish_at ON post (unpublish_at)
+    WHERE status = 'published' AND unpublish_at IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Findings of the post link checker: internal links and upload references in post
+-- content that no longer resolve, replaced per post on every check
+
+CREATE TABLE IF NOT EXISTS post_link_finding (
+    id          BIGSERIAL PRIMARY KEY,
+    post_id     BIGINT NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+    kind        TEXT NOT NULL CHECK (kind IN ('post', 'category', 'tag', 'upload')),
+    url         TEXT NOT NULL,
+    checked_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_link_finding_post ON post_link_finding (post_id);
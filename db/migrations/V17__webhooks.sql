@@ -0,0 +1,40 @@
+-- Outgoing webhooks: admin-managed subscriptions to post lifecycle events, a delivery
+-- queue worked by a background job, and the log of every attempt
+
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    id          BIGSERIAL PRIMARY KEY,
+    url         TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    events      TEXT[] NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id                BIGSERIAL PRIMARY KEY,
+    subscription_id   BIGINT NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+    event             TEXT NOT NULL,
+    payload           JSONB NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts          INT NOT NULL DEFAULT 0,
+    next_attempt_at   TIMESTAMPTZ,
+    last_status_code  INT NOT NULL DEFAULT 0,
+    last_error        TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_subscription ON webhook_delivery (subscription_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempt (
+    id            BIGSERIAL PRIMARY KEY,
+    delivery_id   BIGINT NOT NULL REFERENCES webhook_delivery(id) ON DELETE CASCADE,
+    attempted_at  TIMESTAMPTZ NOT NULL,
+    status_code   INT NOT NULL DEFAULT 0,
+    error         TEXT NOT NULL DEFAULT '',
+    duration_ms   BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempt_delivery ON webhook_delivery_attempt (delivery_id);
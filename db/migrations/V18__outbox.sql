@@ -0,0 +1,26 @@
+-- Transactional outbox: domain events written in the same transaction as the change
+-- that caused them, and how far each in-process relay handler has read.
+--
+-- Ids are handed out before commit, so a lower id can become visible after a higher
+-- one. tx_id records the writing transaction; the relay reads in (tx_id, id) order and
+-- only rows whose transaction is older than every one still running, so no row can
+-- appear behind a handler's offset.
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id           BIGSERIAL PRIMARY KEY,
+    tx_id        BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint,
+    type         TEXT NOT NULL,
+    key          TEXT NOT NULL DEFAULT '',
+    payload      JSONB NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_position ON outbox (tx_id, id);
+CREATE INDEX IF NOT EXISTS idx_outbox_occurred_at ON outbox (occurred_at);
+
+CREATE TABLE IF NOT EXISTS outbox_offset (
+    handler     TEXT PRIMARY KEY,
+    last_tx_id  BIGINT NOT NULL,
+    last_id     BIGINT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
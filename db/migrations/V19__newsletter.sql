@@ -0,0 +1,33 @@
+-- Email newsletter: double opt-in subscribers, posts queued by the publish event and
+-- the issues that mail them, with a cursor so an interrupted send resumes
+
+CREATE TABLE IF NOT EXISTS newsletter_subscriber (
+    id                  BIGSERIAL PRIMARY KEY,
+    email               TEXT NOT NULL UNIQUE,
+    status              TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'unsubscribed')),
+    confirm_token_hash  TEXT UNIQUE,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at        TIMESTAMPTZ,
+    unsubscribed_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_newsletter_subscriber_confirmed ON newsletter_subscriber (id) WHERE status = 'confirmed';
+
+CREATE TABLE IF NOT EXISTS newsletter_issue (
+    id                  BIGSERIAL PRIMARY KEY,
+    post_ids            BIGINT[] NOT NULL,
+    last_subscriber_id  BIGINT NOT NULL DEFAULT 0,
+    locked_until        TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_newsletter_issue_open ON newsletter_issue (id) WHERE completed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS newsletter_post (
+    post_id    BIGINT PRIMARY KEY REFERENCES post(id) ON DELETE CASCADE,
+    queued_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    issue_id   BIGINT REFERENCES newsletter_issue(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_newsletter_post_queued ON newsletter_post (post_id) WHERE issue_id IS NULL;
@@ -0,0 +1,5 @@
+-- Nested categories: an optional parent so docs can be filed under "Backend > Go > Gin"
+
+ALTER TABLE category ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES category(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_category_parent ON category (parent_id);
@@ -0,0 +1,9 @@
+-- Tag aliases: slugs of tags merged into another tag, kept so old links redirect
+
+CREATE TABLE IF NOT EXISTS tag_alias (
+    slug        TEXT PRIMARY KEY,
+    tag_id      BIGINT NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_alias_tag ON tag_alias (tag_id);
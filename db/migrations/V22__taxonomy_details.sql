@@ -0,0 +1,16 @@
+-- Taxonomy details: intro text, share image, SEO overrides and display order for the
+-- category and tag landing pages
+
+ALTER TABLE category
+    ADD COLUMN IF NOT EXISTS description_md   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cover_url        TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_title       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS position         INT  NOT NULL DEFAULT 0;
+
+ALTER TABLE tag
+    ADD COLUMN IF NOT EXISTS description_md   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cover_url        TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_title       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS position         INT  NOT NULL DEFAULT 0;
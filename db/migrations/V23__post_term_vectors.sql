@@ -0,0 +1,8 @@
+-- Term counts of each post's title, summary and content for tag suggestions, kept
+-- current from the outbox whenever a post changes
+
+CREATE TABLE IF NOT EXISTS post_term_vector (
+    post_id     BIGINT PRIMARY KEY REFERENCES post(id) ON DELETE CASCADE,
+    terms       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    indexed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
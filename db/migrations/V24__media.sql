@@ -0,0 +1,21 @@
+-- Media library: one row per uploaded file under web/static/uploads. Which posts, pages,
+-- categories and tags use a file is looked up from their content when needed
+
+CREATE TABLE IF NOT EXISTS media (
+    id             BIGSERIAL PRIMARY KEY,
+    filename       TEXT NOT NULL UNIQUE,
+    original_name  TEXT NOT NULL DEFAULT '',
+    mime_type      TEXT NOT NULL,
+    size_bytes     BIGINT NOT NULL,
+    width          INT NOT NULL DEFAULT 0,
+    height         INT NOT NULL DEFAULT 0,
+    alt_text       TEXT NOT NULL DEFAULT '',
+    caption        TEXT NOT NULL DEFAULT '',
+    uploaded_by    BIGINT REFERENCES app_user(id) ON DELETE SET NULL,
+    checksum       TEXT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_checksum ON media (checksum);
+CREATE INDEX IF NOT EXISTS idx_media_created_at ON media (created_at DESC, id DESC);
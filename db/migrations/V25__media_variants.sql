@@ -0,0 +1,12 @@
+-- Resized copies of media library images, one row per generated width. Rows are
+-- rebuilt whenever the variants of a file are regenerated
+
+CREATE TABLE IF NOT EXISTS media_variant (
+    media_id    BIGINT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    width       INT NOT NULL,
+    height      INT NOT NULL,
+    filename    TEXT NOT NULL UNIQUE,
+    mime_type   TEXT NOT NULL,
+    size_bytes  BIGINT NOT NULL,
+    PRIMARY KEY (media_id, width)
+);
@@ -0,0 +1,31 @@
+-- Stored model of the tag suggester: the number of indexed posts each term occurs in
+-- and the TF-IDF centroid of each tag's posts. Both are updated from the outbox as
+-- posts are indexed, so suggesting only reads the rows of the draft's terms
+
+CREATE TABLE IF NOT EXISTS term_document_frequency (
+    term   TEXT PRIMARY KEY,
+    posts  INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tag_term_centroid (
+    tag_id  BIGINT NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    term    TEXT NOT NULL,
+    weight  DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (tag_id, term)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_term_centroid_term ON tag_term_centroid (term);
+
+-- Term rows outlive their post until the delete event is handled, so document
+-- frequencies can be decremented. tag_ids lists the tags whose centroids count the post.
+ALTER TABLE post_term_vector DROP CONSTRAINT IF EXISTS post_term_vector_post_id_fkey;
+ALTER TABLE post_term_vector ADD COLUMN IF NOT EXISTS tag_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+UPDATE post_term_vector v
+SET tag_ids = ARRAY(SELECT pt.tag_id FROM post_tag pt WHERE pt.post_id = v.post_id ORDER BY pt.tag_id);
+
+INSERT INTO term_document_frequency (term, posts)
+SELECT t.term, COUNT(*)
+FROM post_term_vector v, jsonb_object_keys(v.terms) AS t(term)
+GROUP BY t.term
+ON CONFLICT (term) DO NOTHING;
@@ -0,0 +1,8 @@
+-- The writer and editor demo accounts now come from db/seeds/dev_accounts.sql. Databases
+-- that created them with the public "password" hash get a random hash that matches no
+-- password, so the accounts keep their posts and comments but can no longer sign in
+
+UPDATE app_user
+SET password_hash = '$argon2id$v=19$m=65536,t=2,p=1$' || md5(random()::text) || '$' || md5(random()::text) || md5(random()::text)
+WHERE email IN ('writer@example.com', 'editor@example.com')
+  AND password_hash = $$$argon2id$v=19$m=65536,t=2,p=1$v9QLeR8Xy34zeByEU/5wFw$T1Z2bsXDSvdsVwMpz1iC8Nf1F9uwM88a43Uzinn6d9c$$;
@@ -0,0 +1,24 @@
+-- name: SavePostAutosave :one
+INSERT INTO post_autosave (post_id, user_id, title, summary, content_md, cover_url, custom_fields, saved_at)
+SELECT p.id, $2, $3, $4, $5, $6, COALESCE($7::jsonb, '{}'::jsonb), NOW()
+FROM post p
+WHERE p.slug = $1
+ON CONFLICT (post_id, user_id) DO UPDATE
+SET title = EXCLUDED.title,
+    summary = EXCLUDED.summary,
+    content_md = EXCLUDED.content_md,
+    cover_url = EXCLUDED.cover_url,
+    custom_fields = EXCLUDED.custom_fields,
+    saved_at = EXCLUDED.saved_at
+RETURNING post_id, user_id, title, summary, content_md, cover_url, custom_fields, saved_at;
+
+-- name: GetPostAutosave :one
+SELECT a.post_id, a.user_id, a.title, a.summary, a.content_md, a.cover_url, a.custom_fields, a.saved_at
+FROM post_autosave a
+JOIN post p ON p.id = a.post_id
+WHERE p.slug = $1 AND a.user_id = $2;
+
+-- name: DeletePostAutosave :exec
+DELETE FROM post_autosave a
+USING post p
+WHERE p.id = a.post_id AND p.slug = $1 AND a.user_id = $2;
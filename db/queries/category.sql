@@ -1,13 +1,48 @@
 -- name: CreateCategory :one
-INSERT INTO category (name, slug) VALUES ($1, $2)
-RETURNING id, name, slug;
+INSERT INTO category (name, slug, parent_id, description_md, cover_url, meta_title, meta_description, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, name, slug, parent_id, description_md, cover_url, meta_title, meta_description, position;
+
+-- name: UpdateCategoryBySlug :one
+UPDATE category
+SET name = $2, slug = $3, parent_id = $4, description_md = $5, cover_url = $6, meta_title = $7, meta_description = $8, position = $9
+WHERE slug = $1
+RETURNING id, name, slug, parent_id, description_md, cover_url, meta_title, meta_description, position;
 
 -- name: GetCategoryBySlug :one
-SELECT id, name, slug FROM category WHERE slug = $1;
+SELECT c.id, c.name, c.slug, c.parent_id, c.description_md, c.cover_url, c.meta_title, c.meta_description, c.position,
+       COUNT(p.id) AS post_count
+FROM category c
+LEFT JOIN post_category pc ON pc.category_id = c.id
+LEFT JOIN post p ON p.id = pc.post_id AND p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+WHERE c.slug = $1
+GROUP BY c.id;
+
+-- name: CategorySlugExists :one
+SELECT EXISTS (SELECT 1 FROM category WHERE slug = $1);
 
 -- name: ListCategories :many
-SELECT id, name, slug FROM category ORDER BY name ASC LIMIT $1 OFFSET $2;
+SELECT c.id, c.name, c.slug, c.parent_id, c.description_md, c.cover_url, c.meta_title, c.meta_description, c.position,
+       COUNT(p.id) AS post_count
+FROM category c
+LEFT JOIN post_category pc ON pc.category_id = c.id
+LEFT JOIN post p ON p.id = pc.post_id AND p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+GROUP BY c.id
+ORDER BY c.position ASC, c.name ASC;
 
 -- name: DeleteCategoryBySlug :exec
 DELETE FROM category WHERE slug = $1;
 
+-- name: ListCategoryAncestors :many
+WITH RECURSIVE chain AS (
+    SELECT c.id, c.name, c.slug, c.parent_id, 1 AS depth
+    FROM category c
+    WHERE c.id = (SELECT parent_id FROM category WHERE id = $1)
+    UNION ALL
+    SELECT c.id, c.name, c.slug, c.parent_id, chain.depth + 1
+    FROM category c
+    JOIN chain ON c.id = chain.parent_id
+    WHERE chain.depth < 32
+)
+SELECT id, name, slug, parent_id FROM chain
+ORDER BY depth DESC;
@@ -0,0 +1,50 @@
+-- name: ListCustomFields :many
+SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at
+FROM custom_field f
+LEFT JOIN category c ON c.id = f.category_id
+ORDER BY f.position ASC, f.id ASC;
+
+-- name: GetCustomFieldByKey :one
+SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at
+FROM custom_field f
+LEFT JOIN category c ON c.id = f.category_id
+WHERE f.key = $1;
+
+-- name: GetCategoryIDBySlug :one
+SELECT id FROM category WHERE slug = $1;
+
+-- name: CreateCustomField :one
+WITH f AS (
+  INSERT INTO custom_field (key, label, type, required, options, category_id, position)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  RETURNING *
+)
+SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at
+FROM f
+LEFT JOIN category c ON c.id = f.category_id;
+
+-- name: UpdateCustomField :one
+WITH f AS (
+  UPDATE custom_field
+  SET label = $2,
+      type = $3,
+      required = $4,
+      options = $5,
+      category_id = $6,
+      position = $7
+  WHERE key = $1
+  RETURNING *
+)
+SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at
+FROM f
+LEFT JOIN category c ON c.id = f.category_id;
+
+-- name: DeleteCustomField :one
+WITH deleted AS (
+  DELETE FROM custom_field WHERE key = $1 RETURNING key
+), stripped AS (
+  UPDATE post
+  SET custom_fields = custom_fields - $1::text
+  WHERE custom_fields ? $1::text AND EXISTS (SELECT 1 FROM deleted)
+)
+SELECT COUNT(*) FROM deleted;
@@ -0,0 +1,25 @@
+-- name: ListLinkSources :many
+SELECT id, slug, title, content_md, COALESCE(cover_url, '')
+FROM post
+ORDER BY id ASC;
+
+-- name: ReplaceLinkFindings :exec
+WITH cleared AS (
+    DELETE FROM post_link_finding WHERE post_id = $1
+)
+INSERT INTO post_link_finding (post_id, kind, url, checked_at)
+SELECT $1, f.kind, f.url, $4
+FROM unnest($2::text[], $3::text[]) AS f(kind, url);
+
+-- name: ListLinkFindings :many
+SELECT f.post_id, p.slug, p.title, f.kind, f.url, f.checked_at
+FROM post_link_finding f
+JOIN post p ON p.id = f.post_id
+ORDER BY p.slug ASC, f.id ASC;
+
+-- name: ListLinkFindingsByPost :many
+SELECT f.post_id, p.slug, p.title, f.kind, f.url, f.checked_at
+FROM post_link_finding f
+JOIN post p ON p.id = f.post_id
+WHERE p.slug = $1
+ORDER BY f.id ASC;
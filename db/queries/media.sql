@@ -0,0 +1,95 @@
+-- name: ListMedia :many
+-- Newest first; $1 searches the original name, alt text and caption.
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at,
+       (SELECT COUNT(*) FROM post p WHERE strpos(COALESCE(p.cover_url, ''), '/' || m.filename) > 0 OR strpos(p.content_md, '/' || m.filename) > 0)
+       + (SELECT COUNT(*) FROM page pg WHERE strpos(pg.content_md, '/' || m.filename) > 0)
+       + (SELECT COUNT(*) FROM category c WHERE strpos(c.cover_url, '/' || m.filename) > 0 OR strpos(c.description_md, '/' || m.filename) > 0)
+       + (SELECT COUNT(*) FROM tag t WHERE strpos(t.cover_url, '/' || m.filename) > 0 OR strpos(t.description_md, '/' || m.filename) > 0)
+FROM media m
+LEFT JOIN app_user u ON u.id = m.uploaded_by
+WHERE $1 = '' OR m.original_name ILIKE '%' || $1 || '%' OR m.alt_text ILIKE '%' || $1 || '%' OR m.caption ILIKE '%' || $1 || '%'
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetMedia :one
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at
+FROM media m
+LEFT JOIN app_user u ON u.id = m.uploaded_by
+WHERE m.id = $1;
+
+-- name: GetMediaByChecksum :one
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at
+FROM media m
+LEFT JOIN app_user u ON u.id = m.uploaded_by
+WHERE m.checksum = $1
+ORDER BY m.id ASC
+LIMIT 1;
+
+-- name: CreateMedia :one
+WITH m AS (
+    INSERT INTO media (filename, original_name, mime_type, size_bytes, width, height, alt_text, caption, uploaded_by, checksum)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    RETURNING *
+)
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at
+FROM m
+LEFT JOIN app_user u ON u.id = m.uploaded_by;
+
+-- name: UpdateMediaText :one
+WITH m AS (
+    UPDATE media SET alt_text = $2, caption = $3, updated_at = NOW()
+    WHERE id = $1
+    RETURNING *
+)
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at
+FROM m
+LEFT JOIN app_user u ON u.id = m.uploaded_by;
+
+-- name: DeleteMedia :execrows
+DELETE FROM media WHERE id = $1;
+
+-- name: ListMediaUsage :many
+-- Posts, pages, categories and tags whose cover or content mentions the file.
+SELECT 'post' AS kind, p.slug, p.title FROM post p
+WHERE strpos(COALESCE(p.cover_url, ''), '/' || $1) > 0 OR strpos(p.content_md, '/' || $1) > 0
+UNION ALL
+SELECT 'page', pg.slug, pg.title FROM page pg WHERE strpos(pg.content_md, '/' || $1) > 0
+UNION ALL
+SELECT 'category', c.slug, c.name FROM category c
+WHERE strpos(c.cover_url, '/' || $1) > 0 OR strpos(c.description_md, '/' || $1) > 0
+UNION ALL
+SELECT 'tag', t.slug, t.name FROM tag t
+WHERE strpos(t.cover_url, '/' || $1) > 0 OR strpos(t.description_md, '/' || $1) > 0
+ORDER BY 1, 2;
+
+-- name: GetMediaByFilename :one
+SELECT m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height,
+       m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum,
+       m.created_at, m.updated_at
+FROM media m
+LEFT JOIN app_user u ON u.id = m.uploaded_by
+WHERE m.filename = $1;
+
+-- name: ListMediaVariants :many
+-- Variants of the given files, narrowest first.
+SELECT media_id, width, height, filename, mime_type, size_bytes
+FROM media_variant
+WHERE media_id = ANY($1::bigint[])
+ORDER BY media_id, width;
+
+-- name: DeleteMediaVariants :exec
+DELETE FROM media_variant WHERE media_id = $1;
+
+-- name: CreateMediaVariant :exec
+INSERT INTO media_variant (media_id, width, height, filename, mime_type, size_bytes)
+VALUES ($1, $2, $3, $4, $5, $6);
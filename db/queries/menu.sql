@@ -0,0 +1,98 @@
+-- name: ListMenus :many
+SELECT id, name, label, created_at FROM menu ORDER BY name ASC;
+
+-- name: GetMenuByName :one
+SELECT id, name, label, created_at FROM menu WHERE name = $1;
+
+-- name: CreateMenu :one
+INSERT INTO menu (name, label) VALUES ($1, $2)
+RETURNING id, name, label, created_at;
+
+-- name: DeleteMenu :execrows
+DELETE FROM menu WHERE name = $1;
+
+-- name: ListMenuItems :many
+-- Resolves each internal target to its current slug; page targets also get their full
+-- path, built top-down from the root pages.
+WITH RECURSIVE page_path AS (
+  SELECT id, '/p/' || slug AS path FROM page WHERE parent_id IS NULL
+  UNION ALL
+  SELECT c.id, pp.path || '/' || c.slug FROM page c JOIN page_path pp ON c.parent_id = pp.id
+)
+SELECT i.id, i.menu_id, i.parent_id, i.label, i.target_type, i.target_id, i.url, i.position,
+       COALESCE(p.slug, pg.slug, c.slug, t.slug, '') AS target_slug,
+       COALESCE(pp.path, '') AS target_path,
+       CASE i.target_type
+         WHEN 'post' THEN COALESCE(p.status = 'published', FALSE)
+         WHEN 'page' THEN COALESCE(pg.status = 'published', FALSE)
+         WHEN 'category' THEN c.id IS NOT NULL
+         WHEN 'tag' THEN t.id IS NOT NULL
+         ELSE TRUE
+       END AS target_live
+FROM menu_item i
+LEFT JOIN post p ON i.target_type = 'post' AND p.id = i.target_id
+LEFT JOIN page pg ON i.target_type = 'page' AND pg.id = i.target_id
+LEFT JOIN page_path pp ON i.target_type = 'page' AND pp.id = i.target_id
+LEFT JOIN category c ON i.target_type = 'category' AND c.id = i.target_id
+LEFT JOIN tag t ON i.target_type = 'tag' AND t.id = i.target_id
+WHERE i.menu_id = $1
+ORDER BY i.position ASC, i.id ASC;
+
+-- name: GetMenuItem :one
+-- Same projection and joins as ListMenuItems.
+WITH RECURSIVE page_path AS (
+  SELECT id, '/p/' || slug AS path FROM page WHERE parent_id IS NULL
+  UNION ALL
+  SELECT c.id, pp.path || '/' || c.slug FROM page c JOIN page_path pp ON c.parent_id = pp.id
+)
+SELECT i.id, i.menu_id, i.parent_id, i.label, i.target_type, i.target_id, i.url, i.position,
+       COALESCE(p.slug, pg.slug, c.slug, t.slug, '') AS target_slug,
+       COALESCE(pp.path, '') AS target_path,
+       CASE i.target_type
+         WHEN 'post' THEN COALESCE(p.status = 'published', FALSE)
+         WHEN 'page' THEN COALESCE(pg.status = 'published', FALSE)
+         WHEN 'category' THEN c.id IS NOT NULL
+         WHEN 'tag' THEN t.id IS NOT NULL
+         ELSE TRUE
+       END AS target_live
+FROM menu_item i
+LEFT JOIN post p ON i.target_type = 'post' AND p.id = i.target_id
+LEFT JOIN page pg ON i.target_type = 'page' AND pg.id = i.target_id
+LEFT JOIN page_path pp ON i.target_type = 'page' AND pp.id = i.target_id
+LEFT JOIN category c ON i.target_type = 'category' AND c.id = i.target_id
+LEFT JOIN tag t ON i.target_type = 'tag' AND t.id = i.target_id
+WHERE i.id = $1;
+
+-- name: GetPostIDBySlug :one
+SELECT id FROM post WHERE slug = $1;
+
+-- name: GetPageIDBySlug :one
+SELECT id FROM page WHERE slug = $1;
+
+-- name: GetTagIDBySlug :one
+SELECT id FROM tag WHERE slug = $1;
+
+-- name: CreateMenuItem :one
+INSERT INTO menu_item (menu_id, parent_id, label, target_type, target_id, url, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: UpdateMenuItem :execrows
+UPDATE menu_item
+SET parent_id = $3,
+    label = $4,
+    target_type = $5,
+    target_id = $6,
+    url = $7,
+    position = $8
+WHERE id = $1 AND menu_id = $2;
+
+-- name: DeleteMenuItem :execrows
+DELETE FROM menu_item WHERE id = $1 AND menu_id = $2;
+
+-- name: ReorderMenuItems :exec
+UPDATE menu_item m
+SET parent_id = u.parent_id,
+    position = u.position
+FROM unnest($2::bigint[], $3::bigint[], $4::int[]) AS u(id, parent_id, position)
+WHERE m.id = u.id AND m.menu_id = $1;
@@ -0,0 +1,96 @@
+-- name: GetNewsletterSubscriberByEmail :one
+SELECT id, email, status, created_at, confirmed_at, unsubscribed_at
+FROM newsletter_subscriber
+WHERE email = $1;
+
+-- name: CreateNewsletterSubscriber :one
+INSERT INTO newsletter_subscriber (email, confirm_token_hash)
+VALUES ($1, $2)
+RETURNING id, email, status, created_at, confirmed_at, unsubscribed_at;
+
+-- name: ResetNewsletterSubscriber :one
+UPDATE newsletter_subscriber
+SET status = 'pending', confirm_token_hash = $2, confirmed_at = NULL, unsubscribed_at = NULL
+WHERE id = $1
+RETURNING id, email, status, created_at, confirmed_at, unsubscribed_at;
+
+-- name: ConfirmNewsletterSubscriber :one
+UPDATE newsletter_subscriber
+SET status = 'confirmed', confirmed_at = $2, confirm_token_hash = NULL
+WHERE confirm_token_hash = $1 AND status = 'pending'
+RETURNING id, email, status, created_at, confirmed_at, unsubscribed_at;
+
+-- name: UnsubscribeNewsletterSubscriber :one
+UPDATE newsletter_subscriber
+SET status = 'unsubscribed',
+    unsubscribed_at = CASE WHEN status = 'unsubscribed' THEN unsubscribed_at ELSE $2 END,
+    confirm_token_hash = NULL
+WHERE id = $1
+RETURNING id, email, status, created_at, confirmed_at, unsubscribed_at;
+
+-- name: ListNewsletterSubscribers :many
+SELECT id, email, status, created_at, confirmed_at, unsubscribed_at
+FROM newsletter_subscriber
+WHERE $1 = '' OR status = $1
+ORDER BY id DESC;
+
+-- name: ListNewsletterRecipients :many
+SELECT id, email, status, created_at, confirmed_at, unsubscribed_at
+FROM newsletter_subscriber
+WHERE status = 'confirmed' AND id > $1
+ORDER BY id ASC
+LIMIT $2;
+
+-- name: QueueNewsletterPost :exec
+INSERT INTO newsletter_post (post_id)
+VALUES ($1)
+ON CONFLICT (post_id) DO NOTHING;
+
+-- name: ClaimNewsletterIssue :one
+UPDATE newsletter_issue
+SET locked_until = $2
+WHERE id = (
+    SELECT id
+    FROM newsletter_issue
+    WHERE completed_at IS NULL AND (locked_until IS NULL OR locked_until <= $1)
+    ORDER BY id ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, post_ids, last_subscriber_id;
+
+-- name: CreateNewsletterIssue :one
+WITH queued AS (
+    SELECT post_id
+    FROM newsletter_post
+    WHERE issue_id IS NULL
+    FOR UPDATE SKIP LOCKED
+), issue AS (
+    INSERT INTO newsletter_issue (post_ids, locked_until)
+    SELECT array_agg(post_id ORDER BY post_id), $1
+    FROM queued
+    HAVING COUNT(*) > 0
+    RETURNING id, post_ids, last_subscriber_id
+), linked AS (
+    UPDATE newsletter_post p
+    SET issue_id = issue.id
+    FROM issue
+    WHERE p.post_id = ANY(issue.post_ids)
+)
+SELECT id, post_ids, last_subscriber_id FROM issue;
+
+-- name: ListNewsletterIssuePosts :many
+SELECT id, title, slug, summary
+FROM post
+WHERE id = ANY($1::bigint[]) AND status = 'published'
+ORDER BY COALESCE(published_at, created_at) ASC, id ASC;
+
+-- name: AdvanceNewsletterIssue :exec
+UPDATE newsletter_issue
+SET last_subscriber_id = $2, locked_until = $3
+WHERE id = $1;
+
+-- name: CompleteNewsletterIssue :exec
+UPDATE newsletter_issue
+SET completed_at = $2, locked_until = NULL
+WHERE id = $1;
@@ -0,0 +1,36 @@
+-- name: AppendOutbox :exec
+INSERT INTO outbox (type, key, payload, occurred_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: FetchOutbox :many
+SELECT id, tx_id, type, key, payload, occurred_at
+FROM outbox
+WHERE (tx_id, id) > ($1, $2)
+  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+ORDER BY tx_id ASC, id ASC
+LIMIT $3;
+
+-- name: EnsureOutboxOffset :exec
+INSERT INTO outbox_offset (handler, last_tx_id, last_id)
+VALUES ($1, pg_snapshot_xmin(pg_current_snapshot())::text::bigint, 0)
+ON CONFLICT (handler) DO NOTHING;
+
+-- name: LockOutboxOffset :one
+SELECT last_tx_id, last_id
+FROM outbox_offset
+WHERE handler = $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: SaveOutboxOffset :exec
+UPDATE outbox_offset
+SET last_tx_id = $2, last_id = $3, updated_at = NOW()
+WHERE handler = $1;
+
+-- name: CleanupOutbox :execrows
+DELETE FROM outbox o
+WHERE o.occurred_at < $1
+  AND NOT EXISTS (
+    SELECT 1 FROM outbox_offset f
+    WHERE f.handler = ANY($2)
+      AND (o.tx_id, o.id) > (f.last_tx_id, f.last_id)
+  );
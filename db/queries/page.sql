@@ -0,0 +1,49 @@
+-- name: ListPages :many
+SELECT id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at
+FROM page
+ORDER BY position ASC, title ASC;
+
+-- name: ListPublishedChildPages :many
+SELECT id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at
+FROM page
+WHERE parent_id = $1 AND status = 'published'
+ORDER BY position ASC, title ASC;
+
+-- name: ListPageAncestors :many
+WITH RECURSIVE chain AS (
+  SELECT p.*, 1 AS depth FROM page p WHERE p.id = (SELECT parent_id FROM page WHERE id = $1)
+  UNION ALL
+  SELECT p.*, chain.depth + 1 FROM page p JOIN chain ON p.id = chain.parent_id WHERE chain.depth < 32
+)
+SELECT id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at
+FROM chain
+ORDER BY depth DESC;
+
+-- name: GetPageBySlug :one
+SELECT id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at
+FROM page
+WHERE slug = $1;
+
+-- name: PageSlugExists :one
+SELECT EXISTS(SELECT 1 FROM page WHERE slug = $1);
+
+-- name: CreatePage :one
+INSERT INTO page (title, slug, summary, content_md, template, status, parent_id, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at;
+
+-- name: UpdatePage :one
+UPDATE page
+SET title = $2,
+    summary = $3,
+    content_md = $4,
+    template = $5,
+    status = $6,
+    parent_id = $7,
+    position = $8,
+    updated_at = NOW()
+WHERE slug = $1
+RETURNING id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at;
+
+-- name: DeletePage :execrows
+DELETE FROM page WHERE slug = $1;
@@ -1,77 +1,88 @@
 -- name: CreatePost :one
-INSERT INTO post (title, slug, summary, content_md, cover_url, status, author_id, published_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at;
+INSERT INTO post (title, slug, summary, content_md, cover_url, status, author_id, published_at, custom_fields, unpublish_at, expiry_mode)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::jsonb, '{}'::jsonb), $10, $11)
+RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode;
 
 -- name: GetPostBySlug :one
-SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at
+SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode
 FROM post
 WHERE slug = $1;
 
 -- name: ListPublishedPosts :many
-SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at
+SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode
 FROM post
-WHERE status = 'published'
+WHERE status = 'published' AND (unpublish_at IS NULL OR unpublish_at > NOW())
 ORDER BY COALESCE(published_at, created_at) DESC
 LIMIT $1 OFFSET $2;
 
 -- name: ListPublishedPostsByCategory :many
-SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at
+-- Includes posts filed under descendants of the category.
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth FROM category WHERE slug = $1
+    UNION ALL
+    SELECT c.id, subtree.depth + 1 FROM category c JOIN subtree ON c.parent_id = subtree.id WHERE subtree.depth < 32
+)
+SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode
 FROM post p
-JOIN post_category pc ON pc.post_id = p.id
-JOIN category c ON c.id = pc.category_id
-WHERE p.status = 'published' AND c.slug = $1
+WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+  AND EXISTS (SELECT 1 FROM post_category pc WHERE pc.post_id = p.id AND pc.category_id IN (SELECT id FROM subtree))
 ORDER BY COALESCE(p.published_at, p.created_at) DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListPublishedPostsByTag :many
-SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at
+SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode
 FROM post p
 JOIN post_tag pt ON pt.post_id = p.id
 JOIN tag t ON t.id = pt.tag_id
-WHERE p.status = 'published' AND t.slug = $1
+WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW()) AND t.slug = $1
 ORDER BY COALESCE(p.published_at, p.created_at) DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListPublishedPostsSorted :many
-SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at
+SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode
 FROM post
-WHERE status = 'published'
+WHERE status = 'published' AND (unpublish_at IS NULL OR unpublish_at > NOW()) AND custom_fields @> $2::jsonb
 ORDER BY
   CASE WHEN $1 = 'published_at_asc' THEN published_at END ASC,
   CASE WHEN $1 = 'published_at_desc' THEN published_at END DESC,
   CASE WHEN $1 = 'created_at_asc' THEN created_at END ASC,
   CASE WHEN $1 = 'created_at_desc' OR $1 = '' THEN created_at END DESC
 NULLS LAST
-LIMIT $2 OFFSET $3;
+LIMIT $3 OFFSET $4;
 
 -- name: ListPublishedPostsByCategorySorted :many
-SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at
+-- Includes posts filed under descendants of the category.
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth FROM category WHERE slug = $1
+    UNION ALL
+    SELECT c.id, subtree.depth + 1 FROM category c JOIN subtree ON c.parent_id = subtree.id WHERE subtree.depth < 32
+)
+SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode
 FROM post p
-JOIN post_category pc ON pc.post_id = p.id
-JOIN category c ON c.id = pc.category_id
-WHERE p.status = 'published' AND c.slug = $1
+WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+  AND EXISTS (SELECT 1 FROM post_category pc WHERE pc.post_id = p.id AND pc.category_id IN (SELECT id FROM subtree))
+  AND p.custom_fields @> $3::jsonb
 ORDER BY
   CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC,
   CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC,
   CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC,
   CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC
 NULLS LAST
-LIMIT $3 OFFSET $4;
+LIMIT $4 OFFSET $5;
 
 -- name: ListPublishedPostsByTagSorted :many
-SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at
+SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode
 FROM post p
 JOIN post_tag pt ON pt.post_id = p.id
 JOIN tag t ON t.id = pt.tag_id
-WHERE p.status = 'published' AND t.slug = $1
+WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW()) AND t.slug = $1 AND p.custom_fields @> $3::jsonb
 ORDER BY
   CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC,
   CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC,
   CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC,
   CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC
 NULLS LAST
-LIMIT $3 OFFSET $4;
+LIMIT $4 OFFSET $5;
 
 -- name: UpdatePostBySlug :one
 UPDATE post
@@ -80,10 +91,45 @@ SET title = $2,
     content_md = $4,
     cover_url = $5,
     status = $6,
+    custom_fields = COALESCE($7::jsonb, custom_fields),
+    unpublish_at = $8,
+    expiry_mode = COALESCE(NULLIF($9, ''), expiry_mode),
+    updated_at = NOW()
+WHERE slug = $1
+RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode;
+
+-- name: PatchPostBySlug :one
+UPDATE post
+SET title = CASE WHEN $2::bool THEN $3::text ELSE title END,
+    summary = CASE WHEN $4::bool THEN $5::text ELSE summary END,
+    content_md = CASE WHEN $6::bool THEN $7::text ELSE content_md END,
+    cover_url = CASE WHEN $8::bool THEN $9::text ELSE cover_url END,
+    status = CASE WHEN $10::bool THEN $11::text ELSE status END,
+    author_id = CASE WHEN $12::bool THEN $13::bigint ELSE author_id END,
+    published_at = CASE WHEN $14::bool THEN $15::timestamptz ELSE published_at END,
+    custom_fields = CASE WHEN $16::bool THEN $17::jsonb ELSE custom_fields END,
+    unpublish_at = CASE WHEN $18::bool THEN $19::timestamptz ELSE unpublish_at END,
+    expiry_mode = CASE WHEN $20::bool THEN $21::text ELSE expiry_mode END,
     updated_at = NOW()
 WHERE slug = $1
-RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at;
+RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode;
+
+-- name: ArchiveExpiredPosts :many
+UPDATE post
+SET status = 'archived',
+    updated_at = NOW()
+WHERE status = 'published' AND unpublish_at <= NOW()
+RETURNING slug;
 
 -- name: DeletePostBySlug :exec
 DELETE FROM post WHERE slug = $1;
 
+-- name: PostSlugExists :one
+SELECT EXISTS (SELECT 1 FROM post WHERE slug = $1);
+
+-- name: AuthorExists :one
+SELECT EXISTS (SELECT 1 FROM app_user WHERE id = $1);
+
+-- name: GetUserRole :one
+SELECT COALESCE(r.name, '') FROM app_user u LEFT JOIN role r ON r.id = u.role_id WHERE u.id = $1;
+
@@ -18,13 +18,78 @@ DELETE FROM post_tag USING post p, tag t
 WHERE post_tag.post_id = p.id AND post_tag.tag_id = t.id
   AND p.slug = $1 AND t.slug = $2;
 
+-- name: ReplacePostCategories :exec
+-- Makes the given categories the complete category set of the post; unknown slugs are ignored.
+WITH target AS (SELECT id FROM post WHERE slug = $1),
+wanted AS (SELECT id FROM category WHERE slug = ANY($2)),
+removed AS (
+    DELETE FROM post_category pc USING target
+    WHERE pc.post_id = target.id AND pc.category_id NOT IN (SELECT id FROM wanted)
+)
+INSERT INTO post_category (post_id, category_id)
+SELECT target.id, wanted.id FROM target, wanted
+ON CONFLICT DO NOTHING;
+
+-- name: ReplacePostTags :exec
+-- Makes the given tags the complete tag set of the post; unknown slugs are ignored.
+WITH target AS (SELECT id FROM post WHERE slug = $1),
+wanted AS (SELECT id FROM tag WHERE slug = ANY($2)),
+removed AS (
+    DELETE FROM post_tag pt USING target
+    WHERE pt.post_id = target.id AND pt.tag_id NOT IN (SELECT id FROM wanted)
+)
+INSERT INTO post_tag (post_id, tag_id)
+SELECT target.id, wanted.id FROM target, wanted
+ON CONFLICT DO NOTHING;
+
+-- name: ListExistingCategorySlugs :many
+SELECT slug FROM category WHERE slug = ANY($1);
+
+-- name: MatchTags :many
+-- Matches each entry (with its slug form in $2) to a tag by slug, then alias, then name.
+SELECT k.entry, m.slug
+FROM unnest($1::text[], $2::text[]) AS k(entry, key)
+JOIN LATERAL (
+    SELECT t.slug, 0 AS rank FROM tag t WHERE t.slug = k.key
+    UNION ALL
+    SELECT t.slug, 1 FROM tag_alias a JOIN tag t ON t.id = a.tag_id WHERE a.slug = k.key
+    UNION ALL
+    SELECT t.slug, 2 FROM tag t WHERE lower(t.name) = lower(k.entry)
+    ORDER BY rank
+    LIMIT 1
+) m ON true;
+
 -- name: ListCategoriesByPostSlug :many
-SELECT c.id, c.name, c.slug
+SELECT c.id, c.name, c.slug, c.parent_id
 FROM category c
 JOIN post_category pc ON pc.category_id = c.id
 JOIN post p ON p.id = pc.post_id
 WHERE p.slug = $1
-ORDER BY c.name ASC;
+ORDER BY c.position ASC, c.name ASC;
+
+-- name: ListCategoryTrailByPostSlug :many
+-- The most deeply nested category of the post (ties by name) and its ancestors, root first.
+WITH RECURSIVE chain AS (
+    SELECT c.id AS leaf_id, c.name AS leaf_name, c.id, c.name, c.slug, c.parent_id, 0 AS depth
+    FROM category c
+    JOIN post_category pc ON pc.category_id = c.id
+    JOIN post p ON p.id = pc.post_id
+    WHERE p.slug = $1
+    UNION ALL
+    SELECT chain.leaf_id, chain.leaf_name, c.id, c.name, c.slug, c.parent_id, chain.depth + 1
+    FROM category c
+    JOIN chain ON c.id = chain.parent_id
+    WHERE chain.depth < 32
+), leaf AS (
+    SELECT leaf_id FROM chain
+    GROUP BY leaf_id, leaf_name
+    ORDER BY MAX(depth) DESC, leaf_name ASC
+    LIMIT 1
+)
+SELECT chain.id, chain.name, chain.slug, chain.parent_id
+FROM chain
+JOIN leaf ON leaf.leaf_id = chain.leaf_id
+ORDER BY chain.depth DESC;
 
 -- name: ListTagsByPostSlug :many
 SELECT t.id, t.name, t.slug
@@ -32,5 +97,5 @@ FROM tag t
 JOIN post_tag pt ON pt.tag_id = t.id
 JOIN post p ON p.id = pt.post_id
 WHERE p.slug = $1
-ORDER BY t.name ASC;
+ORDER BY t.position ASC, t.name ASC;
 
@@ -0,0 +1,41 @@
+-- name: GetPostReview :one
+SELECT p.id, p.slug, p.title, p.status, p.author_id, a.display_name, p.reviewer_id, COALESCE(rv.display_name, ''), p.updated_at
+FROM post p
+JOIN app_user a ON a.id = p.author_id
+LEFT JOIN app_user rv ON rv.id = p.reviewer_id
+WHERE p.slug = $1;
+
+-- name: ListPostReviewsByStatus :many
+SELECT p.id, p.slug, p.title, p.status, p.author_id, a.display_name, p.reviewer_id, COALESCE(rv.display_name, ''), p.updated_at
+FROM post p
+JOIN app_user a ON a.id = p.author_id
+LEFT JOIN app_user rv ON rv.id = p.reviewer_id
+WHERE p.status = $1
+ORDER BY p.updated_at ASC, p.id ASC;
+
+-- name: SetPostReviewer :execrows
+UPDATE post SET reviewer_id = $2 WHERE slug = $1;
+
+-- name: ListReviewers :many
+SELECT u.id, u.display_name, u.email, r.name
+FROM app_user u
+JOIN role r ON r.id = u.role_id
+WHERE r.name IN ('editor', 'admin')
+ORDER BY u.display_name ASC, u.id ASC;
+
+-- name: CreateReviewComment :one
+WITH c AS (
+    INSERT INTO post_review_comment (post_id, author_id, body, from_status, to_status)
+    VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''))
+    RETURNING id, post_id, author_id, body, from_status, to_status, created_at
+)
+SELECT c.id, c.post_id, c.author_id, COALESCE(u.display_name, ''), c.body, COALESCE(c.from_status, ''), COALESCE(c.to_status, ''), c.created_at
+FROM c
+LEFT JOIN app_user u ON u.id = c.author_id;
+
+-- name: ListReviewComments :many
+SELECT c.id, c.post_id, c.author_id, COALESCE(u.display_name, ''), c.body, COALESCE(c.from_status, ''), COALESCE(c.to_status, ''), c.created_at
+FROM post_review_comment c
+LEFT JOIN app_user u ON u.id = c.author_id
+WHERE c.post_id = $1
+ORDER BY c.created_at ASC, c.id ASC;
@@ -1,13 +1,74 @@
 -- name: CreateTag :one
-INSERT INTO tag (name, slug) VALUES ($1, $2)
-RETURNING id, name, slug;
+INSERT INTO tag (name, slug, description_md, cover_url, meta_title, meta_description, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, name, slug, description_md, cover_url, meta_title, meta_description, position;
 
--- name: GetTagBySlug :one
-SELECT id, name, slug FROM tag WHERE slug = $1;
+-- name: UpdateTagBySlug :one
+UPDATE tag
+SET name = $2, slug = $3, description_md = $4, cover_url = $5, meta_title = $6, meta_description = $7, position = $8
+WHERE slug = $1
+RETURNING id, name, slug, description_md, cover_url, meta_title, meta_description, position;
+
+-- name: TagSlugExists :one
+SELECT EXISTS (SELECT 1 FROM tag WHERE slug = $1);
 
 -- name: ListTags :many
-SELECT id, name, slug FROM tag ORDER BY name ASC LIMIT $1 OFFSET $2;
+SELECT t.id, t.name, t.slug, t.description_md, t.cover_url, t.meta_title, t.meta_description, t.position,
+       COUNT(p.id) AS post_count
+FROM tag t
+LEFT JOIN post_tag pt ON pt.tag_id = t.id
+LEFT JOIN post p ON p.id = pt.post_id AND p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+GROUP BY t.id
+ORDER BY t.position ASC, t.name ASC;
+
+-- name: GetTagBySlug :one
+SELECT t.id, t.name, t.slug, t.description_md, t.cover_url, t.meta_title, t.meta_description, t.position,
+       COUNT(p.id) AS post_count
+FROM tag t
+LEFT JOIN post_tag pt ON pt.tag_id = t.id
+LEFT JOIN post p ON p.id = pt.post_id AND p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())
+WHERE t.slug = $1
+GROUP BY t.id;
 
 -- name: DeleteTagBySlug :exec
 DELETE FROM tag WHERE slug = $1;
 
+-- name: LockTagBySlug :one
+SELECT t.id, t.name, t.slug, t.description_md, t.cover_url, t.meta_title, t.meta_description, t.position
+FROM tag t WHERE t.slug = $1 FOR UPDATE;
+
+-- name: LockTagIDsBySlugs :many
+SELECT id FROM tag WHERE slug = ANY($1) ORDER BY id FOR UPDATE;
+
+-- MergeTags runs these in one transaction: $1 is the target tag id, $2 the source ids.
+
+-- name: MoveTagPosts :exec
+INSERT INTO post_tag (post_id, tag_id)
+SELECT DISTINCT post_id, $1 FROM post_tag WHERE tag_id = ANY($2)
+ON CONFLICT DO NOTHING;
+
+-- name: MoveTagAliases :exec
+UPDATE tag_alias SET tag_id = $1 WHERE tag_id = ANY($2);
+
+-- name: CreateTagAliases :exec
+INSERT INTO tag_alias (slug, tag_id)
+SELECT slug, $1 FROM tag WHERE id = ANY($2)
+ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id;
+
+-- name: DeleteMergedTags :exec
+DELETE FROM tag WHERE id = ANY($2) AND id <> $1;
+
+-- name: ResolveTagAlias :one
+SELECT t.id, t.name, t.slug
+FROM tag_alias a
+JOIN tag t ON t.id = a.tag_id
+WHERE a.slug = $1 AND NOT EXISTS (SELECT 1 FROM tag WHERE slug = $1);
+
+-- name: ListTagAliases :many
+SELECT a.slug, a.created_at, t.id, t.name, t.slug
+FROM tag_alias a
+JOIN tag t ON t.id = a.tag_id
+ORDER BY a.slug ASC;
+
+-- name: DeleteTagAlias :execrows
+DELETE FROM tag_alias WHERE slug = $1;
@@ -0,0 +1,78 @@
+-- name: LockPostTerms :one
+SELECT terms, tag_ids FROM post_term_vector WHERE post_id = $1 FOR UPDATE;
+
+-- name: SavePostTerms :one
+-- Skips posts that no longer exist; tag_ids becomes the tags the post carries now.
+INSERT INTO post_term_vector (post_id, terms, tag_ids, indexed_at)
+SELECT p.id, $2::jsonb, ARRAY(SELECT pt.tag_id FROM post_tag pt WHERE pt.post_id = p.id ORDER BY pt.tag_id), NOW()
+FROM post p WHERE p.id = $1
+ON CONFLICT (post_id) DO UPDATE SET terms = EXCLUDED.terms, tag_ids = EXCLUDED.tag_ids, indexed_at = EXCLUDED.indexed_at
+RETURNING tag_ids;
+
+-- name: DeletePostTerms :one
+DELETE FROM post_term_vector WHERE post_id = $1 RETURNING terms, tag_ids;
+
+-- name: AddTermDocumentFrequencies :exec
+INSERT INTO term_document_frequency (term, posts)
+SELECT UNNEST($1::text[]), 1
+ON CONFLICT (term) DO UPDATE SET posts = term_document_frequency.posts + 1;
+
+-- RemoveTermDocumentFrequencies runs these in turn: $1 lists the terms of a post that
+-- left the index.
+
+-- name: DecrementTermDocumentFrequencies :exec
+UPDATE term_document_frequency SET posts = posts - 1 WHERE term = ANY($1::text[]);
+
+-- name: DeleteUnusedTermDocumentFrequencies :exec
+DELETE FROM term_document_frequency WHERE term = ANY($1::text[]) AND posts <= 0;
+
+-- name: CountIndexedPosts :one
+SELECT COUNT(*) FROM post_term_vector;
+
+-- name: ListTermDocumentFrequencies :many
+SELECT term, posts FROM term_document_frequency WHERE term = ANY($1::text[]);
+
+-- name: ListTagTermCounts :many
+SELECT v.terms
+FROM post_term_vector v
+JOIN post_tag pt ON pt.post_id = v.post_id
+WHERE pt.tag_id = $1
+ORDER BY v.post_id ASC;
+
+-- ReplaceTagCentroid runs these in one transaction: $1 is the tag id, $2 and $3 the
+-- centroid's terms and weights.
+
+-- name: DeleteTagCentroid :exec
+DELETE FROM tag_term_centroid WHERE tag_id = $1;
+
+-- name: InsertTagCentroid :exec
+INSERT INTO tag_term_centroid (tag_id, term, weight)
+SELECT t.id, w.term, w.weight
+FROM tag t, UNNEST($2::text[], $3::float8[]) AS w(term, weight)
+WHERE t.id = $1;
+
+-- name: MarkTagPostsCounted :exec
+UPDATE post_term_vector v SET tag_ids = array_append(v.tag_ids, $1)
+FROM post_tag pt
+WHERE pt.post_id = v.post_id AND pt.tag_id = $1 AND NOT ($1 = ANY(v.tag_ids));
+
+-- name: ListTagCentroidWeights :many
+SELECT t.slug, c.term, c.weight
+FROM tag_term_centroid c
+JOIN tag t ON t.id = c.tag_id
+WHERE c.term = ANY($1::text[]);
+
+-- name: ListTagsWithoutCentroid :many
+SELECT DISTINCT pt.tag_id
+FROM post_tag pt
+JOIN post_term_vector v ON v.post_id = pt.post_id
+WHERE NOT EXISTS (SELECT 1 FROM tag_term_centroid c WHERE c.tag_id = pt.tag_id)
+ORDER BY pt.tag_id ASC;
+
+-- name: ListUnindexedTermSources :many
+SELECT p.id, p.title, p.summary, p.content_md
+FROM post p
+LEFT JOIN post_term_vector v ON v.post_id = p.id
+WHERE v.post_id IS NULL
+ORDER BY p.id ASC
+LIMIT $1;
@@ -0,0 +1,94 @@
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, secret, events, active, created_at, updated_at
+FROM webhook_subscription
+ORDER BY id ASC;
+
+-- name: GetWebhookSubscription :one
+SELECT id, url, secret, events, active, created_at, updated_at
+FROM webhook_subscription
+WHERE id = $1;
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscription (url, secret, events, active)
+VALUES ($1, $2, $3, $4)
+RETURNING id, url, secret, events, active, created_at, updated_at;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscription
+SET url = $2, secret = $3, events = $4, active = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING id, url, secret, events, active, created_at, updated_at;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscription WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_delivery (subscription_id, event, payload, next_attempt_at)
+SELECT id, $1, $2, NOW()
+FROM webhook_subscription
+WHERE active AND $1 = ANY(events);
+
+-- name: ClaimDueWebhookDeliveries :many
+WITH due AS (
+    SELECT d.id
+    FROM webhook_delivery d
+    JOIN webhook_subscription s ON s.id = d.subscription_id
+    WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND s.active
+    ORDER BY d.next_attempt_at ASC, d.id ASC
+    LIMIT $2
+    FOR UPDATE OF d SKIP LOCKED
+)
+UPDATE webhook_delivery d
+SET next_attempt_at = $3
+FROM due, webhook_subscription s
+WHERE d.id = due.id AND s.id = d.subscription_id
+RETURNING d.id, d.subscription_id, s.url, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+          d.last_status_code, d.last_error, d.created_at, d.delivered_at, s.secret;
+
+-- name: RecordWebhookAttempt :exec
+WITH attempt AS (
+    INSERT INTO webhook_delivery_attempt (delivery_id, attempted_at, status_code, error, duration_ms)
+    VALUES ($1, $2, $3, $4, $5)
+)
+UPDATE webhook_delivery
+SET status = $6,
+    attempts = attempts + 1,
+    next_attempt_at = $7,
+    last_status_code = $3,
+    last_error = $4,
+    delivered_at = CASE WHEN $6 = 'delivered' THEN $2 ELSE delivered_at END
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT d.id, d.subscription_id, s.url, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+       d.last_status_code, d.last_error, d.created_at, d.delivered_at
+FROM webhook_delivery d
+JOIN webhook_subscription s ON s.id = d.subscription_id
+WHERE d.subscription_id = $1
+ORDER BY d.id DESC
+LIMIT $2;
+
+-- name: GetWebhookDelivery :one
+SELECT d.id, d.subscription_id, s.url, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+       d.last_status_code, d.last_error, d.created_at, d.delivered_at
+FROM webhook_delivery d
+JOIN webhook_subscription s ON s.id = d.subscription_id
+WHERE d.id = $1;
+
+-- name: ListWebhookAttempts :many
+SELECT id, delivery_id, attempted_at, status_code, error, duration_ms
+FROM webhook_delivery_attempt
+WHERE delivery_id = $1
+ORDER BY id DESC;
+
+-- name: RequeueWebhookDelivery :one
+WITH d AS (
+    UPDATE webhook_delivery
+    SET status = 'pending', attempts = 0, next_attempt_at = $2
+    WHERE id = $1
+    RETURNING *
+)
+SELECT d.id, d.subscription_id, s.url, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+       d.last_status_code, d.last_error, d.created_at, d.delivered_at
+FROM d
+JOIN webhook_subscription s ON s.id = d.subscription_id;
@@ -0,0 +1,12 @@
+-- Development-only accounts for trying the editorial workflow (password = "password").
+-- Never load this into a shared or production database; run it with `make seed-dev`.
+
+INSERT INTO app_user (email, display_name, password_hash, role_id)
+SELECT 'writer@example.com', 'Writer', $$$argon2id$v=19$m=65536,t=2,p=1$v9QLeR8Xy34zeByEU/5wFw$T1Z2bsXDSvdsVwMpz1iC8Nf1F9uwM88a43Uzinn6d9c$$, r.id
+FROM role r WHERE r.name = 'writer'
+ON CONFLICT (email) DO UPDATE SET password_hash = EXCLUDED.password_hash, role_id = EXCLUDED.role_id;
+
+INSERT INTO app_user (email, display_name, password_hash, role_id)
+SELECT 'editor@example.com', 'Editor', $$$argon2id$v=19$m=65536,t=2,p=1$v9QLeR8Xy34zeByEU/5wFw$T1Z2bsXDSvdsVwMpz1iC8Nf1F9uwM88a43Uzinn6d9c$$, r.id
+FROM role r WHERE r.name = 'editor'
+ON CONFLICT (email) DO UPDATE SET password_hash = EXCLUDED.password_hash, role_id = EXCLUDED.role_id;
@@ -228,7 +228,7 @@ func RegisterRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Admi
 		c.JSON(http.StatusOK, gin.H{
 			"ok":     true,
 			"user":   gin.H{"email": created.Email, "display_name": created.DisplayName},
-			"role":   "admin",
+			"role":   "writer",
 			"status": "registered",
 		})
 	})
@@ -23,6 +23,10 @@ var (
 	ErrAdminDisplayNameRequired = errors.New("admin: display name is required")
 	// ErrAdminRoleNotFound indicates the role lookup failed.
 	ErrAdminRoleNotFound = errors.New("admin: role not found")
+	// ErrAdminRoleForbidden indicates the acting account may not assign roles.
+	ErrAdminRoleForbidden = errors.New("admin: only admins can assign roles")
+	// ErrAdminOwnRole indicates an attempt to change the acting account's own role.
+	ErrAdminOwnRole = errors.New("admin: cannot change your own role")
 	// ErrAdminSessionNotFound indicates the session was missing or expired.
 	ErrAdminSessionNotFound = errors.New("admin: session not found")
 	// ErrAdminSessionExpired indicates the session exceeded its absolute lifetime.
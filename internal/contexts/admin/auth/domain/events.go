@@ -0,0 +1,10 @@
+package authdomain
+
+// EventAdminLoggedIn is the outbox event type recorded for every successful login.
+const EventAdminLoggedIn = "AdminLoggedIn"
+
+// LoginEvent is the payload of EventAdminLoggedIn.
+type LoginEvent struct {
+	AdminID int64  `json:"admin_id"`
+	Email   string `json:"email"`
+}
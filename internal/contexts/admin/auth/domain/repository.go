@@ -8,5 +8,6 @@ type AdminRepository interface {
 	GetByID(ctx context.Context, id int64) (StoredAdmin, error)
 	Create(ctx context.Context, params AdminCreateParams) (StoredAdmin, error)
 	UpdateProfile(ctx context.Context, email string, params AdminProfileUpdateParams) (StoredAdmin, error)
+	UpdateRole(ctx context.Context, id, roleID int64) (StoredAdmin, error)
 	FindRoleByName(ctx context.Context, role string) (AdminRole, error)
 }
@@ -13,16 +13,20 @@ import (
 	"golang.org/x/crypto/argon2"
 
 	authdomain "proto-gin-web/internal/contexts/admin/auth/domain"
+	"proto-gin-web/internal/platform/outbox"
 )
 
 const (
 	defaultPasswordMinLength = 8
 	defaultAdminRoleName     = "admin"
+	defaultRegisterRoleName  = "writer"
 )
 
-// Config exposes optional knobs impacting admin behaviour.
+// Config exposes optional knobs impacting admin behaviour. AdminRoleName is the
+// role allowed to assign roles; RegisterRoleName is given to self-registered accounts.
 type Config struct {
 	AdminRoleName     string
+	RegisterRoleName  string
 	PasswordMinLength int
 }
 
@@ -33,25 +37,31 @@ type AdminService interface {
 	GetProfile(ctx context.Context, email string) (authdomain.Admin, error)
 	GetProfileByID(ctx context.Context, id int64) (authdomain.Admin, error)
 	UpdateProfile(ctx context.Context, email string, input authdomain.AdminProfileInput) (authdomain.Admin, error)
+	SetRole(ctx context.Context, actorID, userID int64, role string) (authdomain.Admin, error)
 }
 
-// Service implements admin use cases.
+// Service implements admin use cases. Successful logins are recorded in the event
+// outbox when one is set.
 type Service struct {
-	repo authdomain.AdminRepository
-	cfg  Config
+	repo   authdomain.AdminRepository
+	cfg    Config
+	outbox outbox.Writer
 }
 
 var _ AdminService = (*Service)(nil)
 
-// NewService creates an admin service backed by a repository.
-func NewService(repo authdomain.AdminRepository, cfg Config) *Service {
+// NewService creates an admin service backed by a repository and the event outbox.
+func NewService(repo authdomain.AdminRepository, cfg Config, events outbox.Writer) *Service {
 	if cfg.AdminRoleName == "" {
 		cfg.AdminRoleName = defaultAdminRoleName
 	}
+	if cfg.RegisterRoleName == "" {
+		cfg.RegisterRoleName = defaultRegisterRoleName
+	}
 	if cfg.PasswordMinLength <= 0 {
 		cfg.PasswordMinLength = defaultPasswordMinLength
 	}
-	return &Service{repo: repo, cfg: cfg}
+	return &Service{repo: repo, cfg: cfg, outbox: events}
 }
 
 // Login authenticates an admin account.
@@ -77,10 +87,23 @@ func (s *Service) Login(ctx context.Context, input authdomain.AdminLoginInput) (
 	if !ok {
 		return authdomain.Admin{}, authdomain.ErrAdminInvalidCredentials
 	}
+	err = outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		msg, err := outbox.NewMessage(authdomain.EventAdminLoggedIn, strconv.FormatInt(stored.ID, 10), authdomain.LoginEvent{
+			AdminID: stored.ID,
+			Email:   stored.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []outbox.Message{msg}, nil
+	})
+	if err != nil {
+		return authdomain.Admin{}, err
+	}
 	return stored.Admin, nil
 }
 
-// Register provisions a new admin account.
+// Register provisions a new account with the register role.
 func (s *Service) Register(ctx context.Context, input authdomain.AdminRegisterInput) (authdomain.Admin, error) {
 	email := authdomain.NormalizeEmail(input.Email)
 	if email == "" || !isLikelyEmail(email) {
@@ -104,7 +127,7 @@ func (s *Service) Register(ctx context.Context, input authdomain.AdminRegisterIn
 		return authdomain.Admin{}, err
 	}
 
-	role, err := s.repo.FindRoleByName(ctx, s.cfg.AdminRoleName)
+	role, err := s.repo.FindRoleByName(ctx, s.cfg.RegisterRoleName)
 	if err != nil {
 		return authdomain.Admin{}, err
 	}
@@ -190,6 +213,41 @@ func (s *Service) UpdateProfile(ctx context.Context, email string, input authdom
 	return stored.Admin, nil
 }
 
+// SetRole assigns a role to another account. Only accounts holding the admin
+// role may do so, and never on themselves.
+func (s *Service) SetRole(ctx context.Context, actorID, userID int64, role string) (authdomain.Admin, error) {
+	if userID <= 0 {
+		return authdomain.Admin{}, authdomain.ErrAdminNotFound
+	}
+	if actorID == userID {
+		return authdomain.Admin{}, authdomain.ErrAdminOwnRole
+	}
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		if errors.Is(err, authdomain.ErrAdminNotFound) {
+			return authdomain.Admin{}, authdomain.ErrAdminRoleForbidden
+		}
+		return authdomain.Admin{}, err
+	}
+	adminRole, err := s.repo.FindRoleByName(ctx, s.cfg.AdminRoleName)
+	if err != nil {
+		return authdomain.Admin{}, err
+	}
+	if actor.RoleID == nil || *actor.RoleID != adminRole.ID {
+		return authdomain.Admin{}, authdomain.ErrAdminRoleForbidden
+	}
+
+	target, err := s.repo.FindRoleByName(ctx, strings.ToLower(strings.TrimSpace(role)))
+	if err != nil {
+		return authdomain.Admin{}, err
+	}
+	stored, err := s.repo.UpdateRole(ctx, userID, target.ID)
+	if err != nil {
+		return authdomain.Admin{}, err
+	}
+	return stored.Admin, nil
+}
+
 func hashArgon2idPassword(password string) (string, error) {
 	const (
 		time    = 2
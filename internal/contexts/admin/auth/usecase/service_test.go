@@ -2,10 +2,12 @@
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	authdomain "proto-gin-web/internal/contexts/admin/auth/domain"
+	"proto-gin-web/internal/platform/outbox"
 )
 
 type mockAdminRepo struct {
@@ -17,6 +19,7 @@ type mockAdminRepo struct {
 	roleErr         error
 	createInput     authdomain.AdminCreateParams
 	updateInput     authdomain.AdminProfileUpdateParams
+	roleUpdates     map[int64]int64
 	lastEmail       string
 	lastUpdateEmail string
 }
@@ -24,7 +27,12 @@ type mockAdminRepo struct {
 func newMockAdminRepo() *mockAdminRepo {
 	return &mockAdminRepo{
 		adminByEmail: map[string]authdomain.StoredAdmin{},
-		roleByName:   map[string]authdomain.AdminRole{"admin": {ID: 1, Name: "admin"}},
+		roleByName: map[string]authdomain.AdminRole{
+			"admin":  {ID: 1, Name: "admin"},
+			"writer": {ID: 2, Name: "writer"},
+			"editor": {ID: 3, Name: "editor"},
+		},
+		roleUpdates: map[int64]int64{},
 	}
 }
 
@@ -80,6 +88,18 @@ func (m *mockAdminRepo) UpdateProfile(ctx context.Context, email string, params
 	return admin, nil
 }
 
+func (m *mockAdminRepo) UpdateRole(ctx context.Context, id, roleID int64) (authdomain.StoredAdmin, error) {
+	for email, admin := range m.adminByEmail {
+		if admin.ID == id {
+			admin.RoleID = &roleID
+			m.adminByEmail[email] = admin
+			m.roleUpdates[id] = roleID
+			return admin, nil
+		}
+	}
+	return authdomain.StoredAdmin{}, authdomain.ErrAdminNotFound
+}
+
 func (m *mockAdminRepo) FindRoleByName(ctx context.Context, name string) (authdomain.AdminRole, error) {
 	if m.roleErr != nil {
 		return authdomain.AdminRole{}, m.roleErr
@@ -104,7 +124,7 @@ func TestService_Login_Success(t *testing.T) {
 		},
 		PasswordHash: hash,
 	}
-	svc := NewService(repo, Config{})
+	svc := NewService(repo, Config{}, nil)
 
 	admin, err := svc.Login(context.Background(), authdomain.AdminLoginInput{
 		Email:    "user@example.com",
@@ -118,9 +138,37 @@ func TestService_Login_Success(t *testing.T) {
 	}
 }
 
+func TestService_Login_RecordsEvent(t *testing.T) {
+	repo := newMockAdminRepo()
+	hash, err := hashArgon2idPassword("pass1234")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	repo.adminByEmail["user@example.com"] = authdomain.StoredAdmin{
+		Admin:        authdomain.Admin{ID: 7, Email: "user@example.com"},
+		PasswordHash: hash,
+	}
+	events := &recordingOutbox{}
+	svc := NewService(repo, Config{}, events)
+
+	if _, err := svc.Login(context.Background(), authdomain.AdminLoginInput{Email: "user@example.com", Password: "wrong-pass"}); err == nil {
+		t.Fatal("expected invalid credentials")
+	}
+	if _, err := svc.Login(context.Background(), authdomain.AdminLoginInput{Email: "user@example.com", Password: "pass1234"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.messages) != 1 || events.messages[0].Type != authdomain.EventAdminLoggedIn || events.messages[0].Key != "7" {
+		t.Fatalf("expected one login event, got %+v", events.messages)
+	}
+	var payload authdomain.LoginEvent
+	if err := json.Unmarshal(events.messages[0].Payload, &payload); err != nil || payload.AdminID != 7 || payload.Email != "user@example.com" {
+		t.Fatalf("unexpected payload %s (%v)", events.messages[0].Payload, err)
+	}
+}
+
 func TestService_Login_InvalidCredentials(t *testing.T) {
 	repo := newMockAdminRepo()
-	svc := NewService(repo, Config{})
+	svc := NewService(repo, Config{}, nil)
 
 	_, err := svc.Login(context.Background(), authdomain.AdminLoginInput{
 		Email:    "missing@example.com",
@@ -132,7 +180,7 @@ func TestService_Login_InvalidCredentials(t *testing.T) {
 }
 
 func TestService_Register_Validation(t *testing.T) {
-	svc := NewService(newMockAdminRepo(), Config{})
+	svc := NewService(newMockAdminRepo(), Config{}, nil)
 	_, err := svc.Register(context.Background(), authdomain.AdminRegisterInput{
 		Email:           "invalid",
 		Password:        "12345678",
@@ -163,7 +211,7 @@ func TestService_Register_Validation(t *testing.T) {
 
 func TestService_Register_Success(t *testing.T) {
 	repo := newMockAdminRepo()
-	svc := NewService(repo, Config{})
+	svc := NewService(repo, Config{}, nil)
 
 	admin, err := svc.Register(context.Background(), authdomain.AdminRegisterInput{
 		Email:           "user@example.com",
@@ -179,6 +227,57 @@ func TestService_Register_Success(t *testing.T) {
 	if repo.createInput.Email != "user@example.com" {
 		t.Fatalf("expected repo create email to be set")
 	}
+	if repo.createInput.RoleID != 2 {
+		t.Fatalf("expected registration to assign the writer role, got role %d", repo.createInput.RoleID)
+	}
+}
+
+func TestService_SetRole(t *testing.T) {
+	adminRoleID, writerRoleID := int64(1), int64(2)
+	repo := newMockAdminRepo()
+	repo.adminByEmail["admin@example.com"] = authdomain.StoredAdmin{
+		Admin: authdomain.Admin{ID: 1, Email: "admin@example.com", RoleID: &adminRoleID},
+	}
+	repo.adminByEmail["writer@example.com"] = authdomain.StoredAdmin{
+		Admin: authdomain.Admin{ID: 2, Email: "writer@example.com", RoleID: &writerRoleID},
+	}
+	svc := NewService(repo, Config{}, nil)
+
+	updated, err := svc.SetRole(context.Background(), 1, 2, " Editor ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.RoleID == nil || *updated.RoleID != 3 || repo.roleUpdates[2] != 3 {
+		t.Fatalf("expected writer to become editor, got %+v", updated)
+	}
+}
+
+func TestService_SetRole_Forbidden(t *testing.T) {
+	adminRoleID, writerRoleID := int64(1), int64(2)
+	repo := newMockAdminRepo()
+	repo.adminByEmail["admin@example.com"] = authdomain.StoredAdmin{
+		Admin: authdomain.Admin{ID: 1, Email: "admin@example.com", RoleID: &adminRoleID},
+	}
+	repo.adminByEmail["writer@example.com"] = authdomain.StoredAdmin{
+		Admin: authdomain.Admin{ID: 2, Email: "writer@example.com", RoleID: &writerRoleID},
+	}
+	svc := NewService(repo, Config{}, nil)
+
+	if _, err := svc.SetRole(context.Background(), 2, 1, "writer"); !errors.Is(err, authdomain.ErrAdminRoleForbidden) {
+		t.Fatalf("expected writer to be refused, got %v", err)
+	}
+	if _, err := svc.SetRole(context.Background(), 2, 2, "admin"); !errors.Is(err, authdomain.ErrAdminOwnRole) {
+		t.Fatalf("expected own role change to be refused, got %v", err)
+	}
+	if _, err := svc.SetRole(context.Background(), 1, 1, "writer"); !errors.Is(err, authdomain.ErrAdminOwnRole) {
+		t.Fatalf("expected admin self-demotion to be refused, got %v", err)
+	}
+	if _, err := svc.SetRole(context.Background(), 1, 2, "owner"); !errors.Is(err, authdomain.ErrAdminRoleNotFound) {
+		t.Fatalf("expected unknown role error, got %v", err)
+	}
+	if len(repo.roleUpdates) != 0 {
+		t.Fatalf("expected no role updates, got %v", repo.roleUpdates)
+	}
 }
 
 func TestService_UpdateProfile_Validation(t *testing.T) {
@@ -186,7 +285,7 @@ func TestService_UpdateProfile_Validation(t *testing.T) {
 	repo.adminByEmail["user@example.com"] = authdomain.StoredAdmin{
 		Admin: authdomain.Admin{Email: "user@example.com", DisplayName: "User"},
 	}
-	svc := NewService(repo, Config{})
+	svc := NewService(repo, Config{}, nil)
 
 	_, err := svc.UpdateProfile(context.Background(), "user@example.com", authdomain.AdminProfileInput{
 		DisplayName: "",
@@ -212,7 +311,7 @@ func TestService_UpdateProfile_Success(t *testing.T) {
 		Admin:        authdomain.Admin{Email: "user@example.com", DisplayName: "Old"},
 		PasswordHash: hash,
 	}
-	svc := NewService(repo, Config{})
+	svc := NewService(repo, Config{}, nil)
 
 	admin, err := svc.UpdateProfile(context.Background(), "user@example.com", authdomain.AdminProfileInput{
 		DisplayName:     "New",
@@ -233,3 +332,15 @@ func TestService_UpdateProfile_Success(t *testing.T) {
 	}
 }
 
+type recordingOutbox struct {
+	messages []outbox.Message
+}
+
+func (r *recordingOutbox) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *recordingOutbox) Append(ctx context.Context, messages ...outbox.Message) error {
+	r.messages = append(r.messages, messages...)
+	return nil
+}
@@ -0,0 +1,105 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminAutosaveRequest is the editor's working copy of a post. Every field is stored
+// as sent; nothing is validated beyond length limits until the post is saved.
+type AdminAutosaveRequest struct {
+	Title        string         `json:"title"`
+	Summary      string         `json:"summary"`
+	ContentMD    string         `json:"content_md"`
+	CoverURL     string         `json:"cover_url"`
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// getPostAutosaveHandler godoc
+// @Summary      Get the pending autosave of a post
+// @Description  Returns the acting user's working copy when it is newer than the saved post; 404 otherwise.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug  path      string  true  "Post slug"
+// @Success      200   {object}  admincontentusecase.AdminAutosaveResponse
+// @Failure      404   {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500   {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/autosave [get]
+func getPostAutosaveHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		autosave, err := contentSvc.PendingPostAutosave(c.Request.Context(), c.Param("slug"), actorID(c))
+		if errors.Is(err, postdomain.ErrAutosaveNotFound) {
+			responder.JSONError(c, http.StatusNotFound, "no pending autosave")
+			return
+		}
+		if err != nil {
+			respondPostError(c, err, "failed to load autosave")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, autosave)
+	}
+}
+
+// savePostAutosaveHandler godoc
+// @Summary      Autosave the working copy of a post
+// @Description  Replaces the acting user's working copy. The saved post is not touched.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Post slug"
+// @Param        payload  body      AdminAutosaveRequest  true  "Working copy"
+// @Success      200      {object}  admincontentusecase.AdminAutosaveResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/autosave [put]
+func savePostAutosaveHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminAutosaveRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		autosave, err := contentSvc.SavePostAutosave(c.Request.Context(), postdomain.AutosaveInput{
+			Slug:         c.Param("slug"),
+			UserID:       actorID(c),
+			Title:        body.Title,
+			Summary:      body.Summary,
+			ContentMD:    body.ContentMD,
+			CoverURL:     body.CoverURL,
+			CustomFields: body.CustomFields,
+		})
+		if err != nil {
+			respondPostError(c, err, "failed to save autosave")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, autosave)
+	}
+}
+
+// discardPostAutosaveHandler godoc
+// @Summary      Discard the autosave of a post
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        slug  path  string  true  "Post slug"
+// @Success      204  {string}  string  ""
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/autosave [delete]
+func discardPostAutosaveHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.DiscardPostAutosave(c.Request.Context(), c.Param("slug"), actorID(c)); err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to discard autosave")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
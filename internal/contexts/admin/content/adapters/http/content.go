@@ -1,7 +1,11 @@
 ﻿package contenthttp
 
 import (
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,43 +16,204 @@ import (
 )
 
 // AdminCreatePostRequest describes the payload to create a post.
+// When slug is omitted it is generated from the title. A post with unpublish_at is
+// archived once that time passes; expiry_mode (gone or banner, default gone) decides
+// what it serves afterwards. categories and tags are stored with the post; see
+// AdminPostTagsRequest for how tags match and create_missing_tags.
 type AdminCreatePostRequest struct {
-	Title     string `json:"title" binding:"required"`
-	Slug      string `json:"slug" binding:"required"`
-	Summary   string `json:"summary"`
-	ContentMD string `json:"content_md" binding:"required"`
-	CoverURL  string `json:"cover_url"`
-	Status    string `json:"status"`
-	AuthorID  int64  `json:"author_id"`
+	Title        string         `json:"title" binding:"required"`
+	Slug         string         `json:"slug"`
+	Summary      string         `json:"summary"`
+	ContentMD    string         `json:"content_md" binding:"required"`
+	CoverURL     string         `json:"cover_url"`
+	Status       string         `json:"status"`
+	AuthorID     int64          `json:"author_id"`
+	CustomFields map[string]any `json:"custom_fields"`
+	UnpublishAt  *time.Time     `json:"unpublish_at"`
+	ExpiryMode   string         `json:"expiry_mode"`
+	AdminPostRelations
 }
 
 // AdminUpdatePostRequest describes the payload to update a post.
+// Omitting custom_fields keeps the stored values; an object replaces them. Omitting
+// unpublish_at clears it, omitting expiry_mode keeps the stored mode. Omitting
+// categories or tags keeps them; a list replaces them in the same transaction.
 type AdminUpdatePostRequest struct {
-	Title     string `json:"title" binding:"required"`
-	Summary   string `json:"summary"`
-	ContentMD string `json:"content_md" binding:"required"`
-	CoverURL  string `json:"cover_url"`
-	Status    string `json:"status"`
+	Title        string         `json:"title" binding:"required"`
+	Summary      string         `json:"summary"`
+	ContentMD    string         `json:"content_md" binding:"required"`
+	CoverURL     string         `json:"cover_url"`
+	Status       string         `json:"status"`
+	CustomFields map[string]any `json:"custom_fields"`
+	UnpublishAt  *time.Time     `json:"unpublish_at"`
+	ExpiryMode   string         `json:"expiry_mode"`
+	AdminPostRelations
 }
 
-// AdminTaxonomyRequest describes a category/tag payload.
+// AdminPostRelations holds the optional category and tag sets of a post payload.
+type AdminPostRelations struct {
+	Categories        []string `json:"categories"`
+	Tags              []string `json:"tags"`
+	CreateMissingTags bool     `json:"create_missing_tags"`
+}
+
+func (r AdminPostRelations) set() postdomain.RelationSet {
+	return postdomain.RelationSet{Categories: r.Categories, Tags: r.Tags, CreateMissingTags: r.CreateMissingTags}
+}
+
+// AdminPostCategoriesRequest is the complete category set of a post, by slug. An empty
+// list removes every category.
+type AdminPostCategoriesRequest struct {
+	Categories []string `json:"categories" binding:"required"`
+}
+
+// AdminPostTagsRequest is the complete tag set of a post. Entries are tag slugs, aliases
+// or names; with create_missing_tags an entry matching no tag becomes a new tag named
+// after it, otherwise it fails validation. An empty list removes every tag.
+type AdminPostTagsRequest struct {
+	Tags              []string `json:"tags" binding:"required"`
+	CreateMissingTags bool     `json:"create_missing_tags"`
+}
+
+// AdminPatchPostRequest documents the JSON Merge Patch (RFC 7396) accepted by PATCH /admin/posts/{slug}.
+// Omitted members are left unchanged; null clears cover_url, published_at and unpublish_at and resets summary.
+// custom_fields is merged key by key: null removes a value, null for the whole object removes all.
+type AdminPatchPostRequest struct {
+	Title        *string         `json:"title,omitempty"`
+	Summary      *string         `json:"summary,omitempty"`
+	ContentMD    *string         `json:"content_md,omitempty"`
+	CoverURL     *string         `json:"cover_url,omitempty"`
+	Status       *string         `json:"status,omitempty"`
+	AuthorID     *int64          `json:"author_id,omitempty"`
+	PublishedAt  *time.Time      `json:"published_at,omitempty"`
+	CustomFields *map[string]any `json:"custom_fields,omitempty"`
+	UnpublishAt  *time.Time      `json:"unpublish_at,omitempty"`
+	ExpiryMode   *string         `json:"expiry_mode,omitempty"`
+}
+
+// AdminTaxonomyRequest describes a tag payload.
+// When slug is omitted it is generated from the name (on create) or kept (on update).
 type AdminTaxonomyRequest struct {
 	Name string `json:"name" binding:"required"`
-	Slug string `json:"slug" binding:"required"`
+	Slug string `json:"slug"`
+	AdminTaxonomyDetails
+}
+
+// AdminCategoryRequest describes a category payload. When slug is omitted it is
+// generated from the name (on create) or kept (on update). parent is the slug of the
+// parent category: on create omit it for a top-level category; on update omit it to
+// keep the current parent or send "" to move the category to the top level.
+type AdminCategoryRequest struct {
+	Name   string  `json:"name" binding:"required"`
+	Slug   string  `json:"slug"`
+	Parent *string `json:"parent"`
+	AdminTaxonomyDetails
+}
+
+// AdminTaxonomyDetails holds the landing page fields shared by categories and tags.
+// Omitted fields keep their current value; send "" to clear one.
+type AdminTaxonomyDetails struct {
+	DescriptionMD   *string `json:"description_md"`
+	CoverURL        *string `json:"cover_url"`
+	MetaTitle       *string `json:"meta_title"`
+	MetaDescription *string `json:"meta_description"`
+	Position        *int32  `json:"position"`
+}
+
+func (d AdminTaxonomyDetails) patch() taxdomain.DetailsPatch {
+	return taxdomain.DetailsPatch{
+		DescriptionMD:   d.DescriptionMD,
+		CoverURL:        d.CoverURL,
+		MetaTitle:       d.MetaTitle,
+		MetaDescription: d.MetaDescription,
+		Position:        d.Position,
+	}
+}
+
+// AdminTagMergeRequest lists the tags merged into the tag named in the path.
+type AdminTagMergeRequest struct {
+	Sources []string `json:"sources" binding:"required"`
+}
+
+// AdminTagSuggestRequest is the draft tags are suggested for. tags lists the slugs the
+// post already carries.
+type AdminTagSuggestRequest struct {
+	Title     string   `json:"title"`
+	ContentMD string   `json:"content_md"`
+	Tags      []string `json:"tags"`
+}
+
+// AdminSlugSuggestion is the payload returned by the slug suggestion endpoint.
+type AdminSlugSuggestion struct {
+	Slug string `json:"slug"`
 }
 
 func RegisterRoutes(group *gin.RouterGroup, contentSvc *admincontentusecase.Service) {
 	group.POST("/posts", createPostHandler(contentSvc))
+	group.POST("/posts/suggest-tags", suggestTagsHandler(contentSvc))
 	group.PUT("/posts/:slug", updatePostHandler(contentSvc))
+	group.PATCH("/posts/:slug", patchPostHandler(contentSvc))
 	group.DELETE("/posts/:slug", deletePostHandler(contentSvc))
 	group.POST("/posts/:slug/categories/:cat", addCategoryHandler(contentSvc))
 	group.DELETE("/posts/:slug/categories/:cat", removeCategoryHandler(contentSvc))
+	group.PUT("/posts/:slug/categories", replaceCategoriesHandler(contentSvc))
 	group.POST("/posts/:slug/tags/:tag", addTagHandler(contentSvc))
 	group.DELETE("/posts/:slug/tags/:tag", removeTagHandler(contentSvc))
+	group.PUT("/posts/:slug/tags", replaceTagsHandler(contentSvc))
+	group.GET("/posts/:slug/review", reviewStateHandler(contentSvc))
+	group.POST("/posts/:slug/transitions", transitionPostHandler(contentSvc))
+	group.PUT("/posts/:slug/reviewer", assignReviewerHandler(contentSvc))
+	group.POST("/posts/:slug/comments", commentPostHandler(contentSvc))
+	group.GET("/reviews/queue", reviewQueueHandler(contentSvc))
+	group.GET("/posts/:slug/lock", getPostLockHandler(contentSvc))
+	group.POST("/posts/:slug/lock", acquirePostLockHandler(contentSvc))
+	group.DELETE("/posts/:slug/lock", releasePostLockHandler(contentSvc))
+	group.GET("/posts/:slug/autosave", getPostAutosaveHandler(contentSvc))
+	group.PUT("/posts/:slug/autosave", savePostAutosaveHandler(contentSvc))
+	group.DELETE("/posts/:slug/autosave", discardPostAutosaveHandler(contentSvc))
 	group.POST("/categories", createCategoryHandler(contentSvc))
+	group.PUT("/categories/:slug", updateCategoryHandler(contentSvc))
 	group.DELETE("/categories/:slug", deleteCategoryHandler(contentSvc))
 	group.POST("/tags", createTagHandler(contentSvc))
+	group.PUT("/tags/:slug", updateTagHandler(contentSvc))
 	group.DELETE("/tags/:slug", deleteTagHandler(contentSvc))
+	group.POST("/tags/:slug/merge", mergeTagsHandler(contentSvc))
+	group.GET("/tag-aliases", listTagAliasesHandler(contentSvc))
+	group.DELETE("/tag-aliases/:slug", deleteTagAliasHandler(contentSvc))
+	group.GET("/slugs/suggest", suggestSlugHandler(contentSvc))
+	group.GET("/content/link-report", linkReportHandler(contentSvc))
+	group.GET("/custom-fields", listFieldsHandler(contentSvc))
+	group.POST("/custom-fields", createFieldHandler(contentSvc))
+	group.PUT("/custom-fields/:key", updateFieldHandler(contentSvc))
+	group.DELETE("/custom-fields/:key", deleteFieldHandler(contentSvc))
+	group.GET("/pages", listPagesHandler(contentSvc))
+	group.POST("/pages", createPageHandler(contentSvc))
+	group.GET("/pages/:slug", getPageHandler(contentSvc))
+	group.PUT("/pages/:slug", updatePageHandler(contentSvc))
+	group.DELETE("/pages/:slug", deletePageHandler(contentSvc))
+	group.GET("/menus", listMenusHandler(contentSvc))
+	group.POST("/menus", createMenuHandler(contentSvc))
+	group.GET("/menus/:name", getMenuHandler(contentSvc))
+	group.DELETE("/menus/:name", deleteMenuHandler(contentSvc))
+	group.POST("/menus/:name/items", createMenuItemHandler(contentSvc))
+	group.PUT("/menus/:name/items/:id", updateMenuItemHandler(contentSvc))
+	group.DELETE("/menus/:name/items/:id", deleteMenuItemHandler(contentSvc))
+	group.PUT("/menus/:name/order", reorderMenuHandler(contentSvc))
+	group.GET("/webhooks", listWebhooksHandler(contentSvc))
+	group.POST("/webhooks", createWebhookHandler(contentSvc))
+	group.GET("/webhooks/:id", getWebhookHandler(contentSvc))
+	group.PUT("/webhooks/:id", updateWebhookHandler(contentSvc))
+	group.DELETE("/webhooks/:id", deleteWebhookHandler(contentSvc))
+	group.GET("/webhooks/:id/deliveries", listWebhookDeliveriesHandler(contentSvc))
+	group.GET("/webhook-deliveries/:id", getWebhookDeliveryHandler(contentSvc))
+	group.POST("/webhook-deliveries/:id/redeliver", redeliverWebhookHandler(contentSvc))
+	group.POST("/media", uploadMediaHandler(contentSvc))
+	group.GET("/media", listMediaHandler(contentSvc))
+	group.GET("/media/:id", getMediaHandler(contentSvc))
+	group.PUT("/media/:id", updateMediaHandler(contentSvc))
+	group.DELETE("/media/:id", deleteMediaHandler(contentSvc))
+	group.GET("/subscribers", listSubscribersHandler(contentSvc))
+	group.GET("/subscribers/export", exportSubscribersHandler(contentSvc))
 }
 
 // createPostHandler godoc
@@ -61,6 +226,7 @@ func RegisterRoutes(group *gin.RouterGroup, contentSvc *admincontentusecase.Serv
 // @Param        payload  body      AdminCreatePostRequest  true  "Post payload"
 // @Success      200      {object}  admincontentusecase.AdminPostResponse
 // @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
 // @Failure      500      {object}  admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts [post]
 func createPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
@@ -73,22 +239,27 @@ func createPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc
 
 		cover := body.CoverURL
 		input := postdomain.CreatePostInput{
-			Title:       body.Title,
-			Slug:        body.Slug,
-			Summary:     body.Summary,
-			ContentMD:   body.ContentMD,
-			Status:      body.Status,
-			AuthorID:    body.AuthorID,
-			PublishedAt: nil,
+			ActorID:      actorID(c),
+			Title:        body.Title,
+			Slug:         body.Slug,
+			Summary:      body.Summary,
+			ContentMD:    body.ContentMD,
+			Status:       body.Status,
+			AuthorID:     body.AuthorID,
+			PublishedAt:  nil,
+			CustomFields: body.CustomFields,
+			UnpublishAt:  body.UnpublishAt,
+			ExpiryMode:   body.ExpiryMode,
+			Relations:    body.set(),
 		}
 		input.CoverURL = &cover
 
 		row, err := contentSvc.CreatePost(c.Request.Context(), input)
 		if err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to create post")
+			respondPostError(c, err, "failed to create post")
 			return
 		}
-		responder.JSONSuccess(c, http.StatusOK, row)
+		respondPostSaved(c, contentSvc, row)
 	}
 }
 
@@ -103,6 +274,8 @@ func createPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc
 // @Param        payload  body      AdminUpdatePostRequest   true  "Post payload"
 // @Success      200      {object}  admincontentusecase.AdminPostResponse
 // @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
 // @Failure      500      {object}  admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts/{slug} [put]
 func updatePostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
@@ -115,23 +288,105 @@ func updatePostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc
 		}
 		cover := body.CoverURL
 		input := postdomain.UpdatePostInput{
-			Slug:      slug,
-			Title:     body.Title,
-			Summary:   body.Summary,
-			ContentMD: body.ContentMD,
-			Status:    body.Status,
+			ActorID:      actorID(c),
+			Slug:         slug,
+			Title:        body.Title,
+			Summary:      body.Summary,
+			ContentMD:    body.ContentMD,
+			Status:       body.Status,
+			CustomFields: body.CustomFields,
+			UnpublishAt:  body.UnpublishAt,
+			ExpiryMode:   body.ExpiryMode,
+			Relations:    body.set(),
 		}
 		input.CoverURL = &cover
 
 		row, err := contentSvc.UpdatePost(c.Request.Context(), input)
 		if err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to update post")
+			respondPostError(c, err, "failed to update post")
+			return
+		}
+		respondPostSaved(c, contentSvc, row)
+	}
+}
+
+// patchPostHandler godoc
+// @Summary      Partially update a post
+// @Description  Applies a JSON Merge Patch (RFC 7396); only the supplied members are changed.
+// @Tags         Admin
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                 true  "Post slug"
+// @Param        payload  body      AdminPatchPostRequest  true  "Merge patch"
+// @Success      200      {object}  admincontentusecase.AdminPostResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      415      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug} [patch]
+func patchPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMergePatchContentType(c.ContentType()) {
+			responder.JSONError(c, http.StatusUnsupportedMediaType, "content type must be application/merge-patch+json")
+			return
+		}
+		raw, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
 			return
 		}
-		responder.JSONSuccess(c, http.StatusOK, row)
+		input, err := decodePostMergePatch(c.Param("slug"), raw)
+		if err != nil {
+			responder.JSONError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		input.ActorID = actorID(c)
+
+		row, err := contentSvc.PatchPost(c.Request.Context(), input)
+		if err != nil {
+			respondPostError(c, err, "failed to update post")
+			return
+		}
+		respondPostSaved(c, contentSvc, row)
+	}
+}
+
+// respondPostError maps post use case errors onto HTTP responses: validation failures
+// become 422 with the offending fields, missing posts 404, users without an editorial
+// role 403, anything else 500.
+func respondPostError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, postdomain.ErrPostNotFound):
+		responder.JSONError(c, http.StatusNotFound, "post not found")
+	case errors.Is(err, postdomain.ErrWorkflowForbidden):
+		responder.JSONError(c, http.StatusForbidden, "action not permitted for your role")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
 	}
 }
 
+// respondPostSaved answers a successful save and checks the links of the saved post on
+// the way out. Broken internal links and missing uploads come back as warnings; a
+// failed check is logged and does not fail the save.
+func respondPostSaved(c *gin.Context, contentSvc *admincontentusecase.Service, post postdomain.Post) {
+	findings, err := contentSvc.CheckPostLinks(c.Request.Context(), post.Slug)
+	if err != nil {
+		slog.Default().Error("admin content: check post links",
+			slog.String("slug", post.Slug),
+			slog.Any("err", err))
+	}
+	if len(findings) == 0 {
+		responder.JSONSuccess(c, http.StatusOK, post)
+		return
+	}
+	responder.JSONSuccessWarnings(c, http.StatusOK, post, findings)
+}
+
 // deletePostHandler godoc
 // @Summary      Delete a post
 // @Description  Deletes a post identified by slug.
@@ -159,12 +414,14 @@ func deletePostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc
 // @Param        slug  path  string  true  "Post slug"
 // @Param        cat   path  string  true  "Category slug"
 // @Success      204 {string} string ""
+// @Failure      403 {object} admincontentusecase.AdminErrorResponse
+// @Failure      404 {object} admincontentusecase.AdminErrorResponse
 // @Failure      500 {object} admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts/{slug}/categories/{cat} [post]
 func addCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := contentSvc.AddCategory(c.Request.Context(), c.Param("slug"), c.Param("cat")); err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to add category")
+		if err := contentSvc.AddCategory(c.Request.Context(), c.Param("slug"), actorID(c), c.Param("cat")); err != nil {
+			respondPostError(c, err, "failed to add category")
 			return
 		}
 		c.Status(http.StatusNoContent)
@@ -178,12 +435,14 @@ func addCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc
 // @Param        slug  path  string  true  "Post slug"
 // @Param        cat   path  string  true  "Category slug"
 // @Success      204 {string} string ""
+// @Failure      403 {object} admincontentusecase.AdminErrorResponse
+// @Failure      404 {object} admincontentusecase.AdminErrorResponse
 // @Failure      500 {object} admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts/{slug}/categories/{cat} [delete]
 func removeCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := contentSvc.RemoveCategory(c.Request.Context(), c.Param("slug"), c.Param("cat")); err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to remove category")
+		if err := contentSvc.RemoveCategory(c.Request.Context(), c.Param("slug"), actorID(c), c.Param("cat")); err != nil {
+			respondPostError(c, err, "failed to remove category")
 			return
 		}
 		c.Status(http.StatusNoContent)
@@ -197,12 +456,14 @@ func removeCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerF
 // @Param        slug  path  string  true  "Post slug"
 // @Param        tag   path  string  true  "Tag slug"
 // @Success      204 {string} string ""
+// @Failure      403 {object} admincontentusecase.AdminErrorResponse
+// @Failure      404 {object} admincontentusecase.AdminErrorResponse
 // @Failure      500 {object} admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts/{slug}/tags/{tag} [post]
 func addTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := contentSvc.AddTag(c.Request.Context(), c.Param("slug"), c.Param("tag")); err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to add tag")
+		if err := contentSvc.AddTag(c.Request.Context(), c.Param("slug"), actorID(c), c.Param("tag")); err != nil {
+			respondPostError(c, err, "failed to add tag")
 			return
 		}
 		c.Status(http.StatusNoContent)
@@ -216,42 +477,146 @@ func addTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 // @Param        slug  path  string  true  "Post slug"
 // @Param        tag   path  string  true  "Tag slug"
 // @Success      204 {string} string ""
+// @Failure      403 {object} admincontentusecase.AdminErrorResponse
+// @Failure      404 {object} admincontentusecase.AdminErrorResponse
 // @Failure      500 {object} admincontentusecase.AdminErrorResponse
 // @Router       /admin/posts/{slug}/tags/{tag} [delete]
 func removeTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := contentSvc.RemoveTag(c.Request.Context(), c.Param("slug"), c.Param("tag")); err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to remove tag")
+		if err := contentSvc.RemoveTag(c.Request.Context(), c.Param("slug"), actorID(c), c.Param("tag")); err != nil {
+			respondPostError(c, err, "failed to remove tag")
 			return
 		}
 		c.Status(http.StatusNoContent)
 	}
 }
 
+// replaceCategoriesHandler godoc
+// @Summary      Replace the categories of a post
+// @Description  Makes the listed categories the complete category set of the post in one transaction. Unknown categories answer 422 and change nothing.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                      true  "Post slug"
+// @Param        payload  body      AdminPostCategoriesRequest  true  "Category slugs"
+// @Success      200      {object}  admincontentusecase.AdminPostRelationsResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      403      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/categories [put]
+func replaceCategoriesHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminPostCategoriesRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		relations, err := contentSvc.ReplacePostCategories(c.Request.Context(), c.Param("slug"), actorID(c), body.Categories)
+		if err != nil {
+			respondPostError(c, err, "failed to replace categories")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, relations)
+	}
+}
+
+// replaceTagsHandler godoc
+// @Summary      Replace the tags of a post
+// @Description  Makes the listed tags the complete tag set of the post in one transaction, optionally creating missing tags. Unknown tags answer 422 and change nothing.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Post slug"
+// @Param        payload  body      AdminPostTagsRequest  true  "Tags"
+// @Success      200      {object}  admincontentusecase.AdminPostRelationsResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      403      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/tags [put]
+func replaceTagsHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminPostTagsRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		relations, err := contentSvc.ReplacePostTags(c.Request.Context(), c.Param("slug"), actorID(c), body.Tags, body.CreateMissingTags)
+		if err != nil {
+			respondPostError(c, err, "failed to replace tags")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, relations)
+	}
+}
+
 // createCategoryHandler godoc
 // @Summary      Create category
 // @Tags         Admin
 // @Accept       json
 // @Produce      json
 // @Security     AdminCookieAuth
-// @Param        payload  body      AdminTaxonomyRequest  true  "Category payload"
+// @Param        payload  body      AdminCategoryRequest  true  "Category payload"
 // @Success      200      {object}  admincontentusecase.AdminCategoryResponse
 // @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409      {object}  admincontentusecase.AdminErrorResponse
 // @Failure      500      {object}  admincontentusecase.AdminErrorResponse
 // @Router       /admin/categories [post]
 func createCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var body AdminTaxonomyRequest
+		var body AdminCategoryRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		input := taxdomain.CreateCategoryInput{Name: body.Name, Slug: body.Slug, Details: body.patch()}
+		if body.Parent != nil {
+			input.ParentSlug = *body.Parent
+		}
+		category, err := contentSvc.CreateCategory(c.Request.Context(), input)
+		if err != nil {
+			respondTaxonomyError(c, err, "failed to create category")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, category)
+	}
+}
+
+// updateCategoryHandler godoc
+// @Summary      Update category
+// @Description  Renames, re-parents or edits a category. A new slug moves it; omitting slug keeps the current one. Omitting parent keeps the current parent, "" makes it top-level. Omitted description, cover, meta and position fields are kept. Posts keep the category.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Category slug"
+// @Param        payload  body      AdminCategoryRequest  true  "Category payload"
+// @Success      200      {object}  admincontentusecase.AdminCategoryResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/categories/{slug} [put]
+func updateCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminCategoryRequest
 		if err := c.ShouldBindJSON(&body); err != nil {
 			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
 			return
 		}
-		category, err := contentSvc.CreateCategory(c.Request.Context(), taxdomain.CreateCategoryInput{
-			Name: body.Name,
-			Slug: body.Slug,
+		category, err := contentSvc.UpdateCategory(c.Request.Context(), c.Param("slug"), taxdomain.UpdateCategoryInput{
+			Name:       body.Name,
+			Slug:       body.Slug,
+			ParentSlug: body.Parent,
+			Details:    body.patch(),
 		})
 		if err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to create category")
+			respondTaxonomyError(c, err, "failed to update category")
 			return
 		}
 		responder.JSONSuccess(c, http.StatusOK, category)
@@ -285,6 +650,7 @@ func deleteCategoryHandler(contentSvc *admincontentusecase.Service) gin.HandlerF
 // @Param        payload  body      AdminTaxonomyRequest  true  "Tag payload"
 // @Success      200      {object}  admincontentusecase.AdminTagResponse
 // @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409      {object}  admincontentusecase.AdminErrorResponse
 // @Failure      500      {object}  admincontentusecase.AdminErrorResponse
 // @Router       /admin/tags [post]
 func createTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
@@ -295,17 +661,76 @@ func createTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 			return
 		}
 		tag, err := contentSvc.CreateTag(c.Request.Context(), taxdomain.CreateTagInput{
-			Name: body.Name,
-			Slug: body.Slug,
+			Name:    body.Name,
+			Slug:    body.Slug,
+			Details: body.patch(),
+		})
+		if err != nil {
+			respondTaxonomyError(c, err, "failed to create tag")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, tag)
+	}
+}
+
+// updateTagHandler godoc
+// @Summary      Update tag
+// @Description  Renames or edits a tag. A new slug moves it; omitting slug keeps the current one. Omitted description, cover, meta and position fields are kept. Posts keep the tag.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Tag slug"
+// @Param        payload  body      AdminTaxonomyRequest  true  "Tag payload"
+// @Success      200      {object}  admincontentusecase.AdminTagResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/tags/{slug} [put]
+func updateTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminTaxonomyRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		tag, err := contentSvc.UpdateTag(c.Request.Context(), c.Param("slug"), taxdomain.UpdateTagInput{
+			Name:    body.Name,
+			Slug:    body.Slug,
+			Details: body.patch(),
 		})
 		if err != nil {
-			responder.JSONError(c, http.StatusInternalServerError, "failed to create tag")
+			respondTaxonomyError(c, err, "failed to update tag")
 			return
 		}
 		responder.JSONSuccess(c, http.StatusOK, tag)
 	}
 }
 
+// respondTaxonomyError maps category and tag errors to HTTP statuses.
+func respondTaxonomyError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, taxdomain.ErrCategoryNotFound):
+		responder.JSONError(c, http.StatusNotFound, "category not found")
+	case errors.Is(err, taxdomain.ErrTagNotFound):
+		responder.JSONError(c, http.StatusNotFound, "tag not found")
+	case errors.Is(err, taxdomain.ErrAliasNotFound):
+		responder.JSONError(c, http.StatusNotFound, "tag alias not found")
+	case errors.Is(err, taxdomain.ErrNameRequired), errors.Is(err, taxdomain.ErrSlugRequired),
+		errors.Is(err, taxdomain.ErrParentNotFound), errors.Is(err, taxdomain.ErrParentCycle),
+		errors.Is(err, taxdomain.ErrMergeSourceRequired), errors.Is(err, taxdomain.ErrMergeIntoSelf),
+		errors.Is(err, taxdomain.ErrCoverInvalid):
+		responder.JSONError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, taxdomain.ErrNameTaken):
+		responder.JSONError(c, http.StatusConflict, "name already in use")
+	case errors.Is(err, taxdomain.ErrSlugTaken):
+		responder.JSONError(c, http.StatusConflict, "slug already in use")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
+
 // deleteTagHandler godoc
 // @Summary      Delete tag
 // @Tags         Admin
@@ -324,4 +749,128 @@ func deleteTagHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
 	}
 }
 
+// mergeTagsHandler godoc
+// @Summary      Merge tags
+// @Description  Moves the posts of the source tags to the tag in the path and deletes the sources. Their slugs stay behind as aliases that redirect to the target.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Target tag slug"
+// @Param        payload  body      AdminTagMergeRequest  true  "Source tags"
+// @Success      200      {object}  admincontentusecase.AdminTagResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/tags/{slug}/merge [post]
+func mergeTagsHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminTagMergeRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		tag, err := contentSvc.MergeTags(c.Request.Context(), c.Param("slug"), body.Sources)
+		if err != nil {
+			respondTaxonomyError(c, err, "failed to merge tags")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, tag)
+	}
+}
 
+// listTagAliasesHandler godoc
+// @Summary      List tag aliases
+// @Description  Lists the slugs left behind by merged tags and the tag each redirects to.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminTagAliasListResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/tag-aliases [get]
+func listTagAliasesHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		aliases, err := contentSvc.ListTagAliases(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list tag aliases")
+			return
+		}
+		if aliases == nil {
+			aliases = []taxdomain.TagAlias{}
+		}
+		responder.JSONSuccess(c, http.StatusOK, aliases)
+	}
+}
+
+// deleteTagAliasHandler godoc
+// @Summary      Delete tag alias
+// @Description  Removes an alias; links using the old slug stop redirecting.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        slug  path  string  true  "Alias slug"
+// @Success      204  {string} string ""
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/tag-aliases/{slug} [delete]
+func deleteTagAliasHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.DeleteTagAlias(c.Request.Context(), c.Param("slug")); err != nil {
+			respondTaxonomyError(c, err, "failed to delete tag alias")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// suggestTagsHandler godoc
+// @Summary      Suggest tags for a post
+// @Description  Ranks existing tags by how closely the draft resembles the posts carrying them (TF-IDF) and proposes distinctive terms of the draft as new tags. Tags listed in tags are left out.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        payload  body      AdminTagSuggestRequest  true  "Draft"
+// @Success      200      {object}  admincontentusecase.AdminTagSuggestionsResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/suggest-tags [post]
+func suggestTagsHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminTagSuggestRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		suggestions, err := contentSvc.SuggestTags(c.Request.Context(), postdomain.SuggestTagsInput{
+			Title:     body.Title,
+			ContentMD: body.ContentMD,
+			Tags:      body.Tags,
+		})
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to suggest tags")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, suggestions)
+	}
+}
+
+// suggestSlugHandler godoc
+// @Summary      Suggest a post slug
+// @Description  Transliterates the title into a slug that is not used by any post yet.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        title  query     string  true  "Post title"
+// @Success      200    {object}  AdminSlugSuggestion
+// @Failure      500    {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/slugs/suggest [get]
+func suggestSlugHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		suggested, err := contentSvc.SuggestSlug(c.Request.Context(), c.Query("title"))
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to suggest slug")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, AdminSlugSuggestion{Slug: suggested})
+	}
+}
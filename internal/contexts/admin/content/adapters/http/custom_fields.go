@@ -0,0 +1,145 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminCustomFieldRequest describes a custom field definition payload. The key is
+// taken from the path on update. An empty category makes the field global.
+type AdminCustomFieldRequest struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label" binding:"required"`
+	Type     string   `json:"type" binding:"required"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+	Category string   `json:"category"`
+	Position int32    `json:"position"`
+}
+
+func (r AdminCustomFieldRequest) input(key string) postdomain.FieldDefinitionInput {
+	return postdomain.FieldDefinitionInput{
+		Key:          key,
+		Label:        r.Label,
+		Type:         postdomain.FieldType(r.Type),
+		Required:     r.Required,
+		Options:      r.Options,
+		CategorySlug: r.Category,
+		Position:     r.Position,
+	}
+}
+
+// listFieldsHandler godoc
+// @Summary      List custom fields
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminFieldListResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/custom-fields [get]
+func listFieldsHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields, err := contentSvc.ListFields(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list custom fields")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, fields)
+	}
+}
+
+// createFieldHandler godoc
+// @Summary      Create a custom field
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        payload  body      AdminCustomFieldRequest  true  "Custom field payload"
+// @Success      200      {object}  admincontentusecase.AdminFieldResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/custom-fields [post]
+func createFieldHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminCustomFieldRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		field, err := contentSvc.CreateField(c.Request.Context(), body.input(body.Key))
+		if err != nil {
+			respondFieldError(c, err, "failed to create custom field")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, field)
+	}
+}
+
+// updateFieldHandler godoc
+// @Summary      Update a custom field
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        key      path      string                   true  "Field key"
+// @Param        payload  body      AdminCustomFieldRequest  true  "Custom field payload"
+// @Success      200      {object}  admincontentusecase.AdminFieldResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/custom-fields/{key} [put]
+func updateFieldHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminCustomFieldRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		field, err := contentSvc.UpdateField(c.Request.Context(), body.input(c.Param("key")))
+		if err != nil {
+			respondFieldError(c, err, "failed to update custom field")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, field)
+	}
+}
+
+// deleteFieldHandler godoc
+// @Summary      Delete a custom field
+// @Description  Deletes the definition and removes its values from every post.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        key  path  string  true  "Field key"
+// @Success      204  {string}  string  ""
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/custom-fields/{key} [delete]
+func deleteFieldHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.DeleteField(c.Request.Context(), c.Param("key")); err != nil {
+			respondFieldError(c, err, "failed to delete custom field")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func respondFieldError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, postdomain.ErrFieldNotFound):
+		responder.JSONError(c, http.StatusNotFound, "custom field not found")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,30 @@
+package contenthttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// linkReportHandler godoc
+// @Summary      List broken links in posts
+// @Description  Lists internal links to missing posts, categories and tags and references to missing uploads, as found by the last check of each post. Posts are checked when saved and every night.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminLinkReportResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/content/link-report [get]
+func linkReportHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		findings, err := contentSvc.LinkReport(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to load link report")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, findings)
+	}
+}
@@ -0,0 +1,109 @@
+package contenthttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminLockRequest acquires or renews an edit lock. take_over replaces a lock held by
+// someone else; the body may be omitted for a plain heartbeat.
+type AdminLockRequest struct {
+	TakeOver bool `json:"take_over"`
+}
+
+// lockHolderName is the name other editors see on the lock banner.
+func lockHolderName(c *gin.Context) string {
+	profile, _ := ctxkeys.AdminProfileFromContext(c)
+	if profile.DisplayName != "" {
+		return profile.DisplayName
+	}
+	return profile.Email
+}
+
+// getPostLockHandler godoc
+// @Summary      Get the edit lock of a post
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug  path      string  true  "Post slug"
+// @Success      200   {object}  admincontentusecase.AdminLockResponse
+// @Failure      500   {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/lock [get]
+func getPostLockHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, err := contentSvc.PostLock(c.Request.Context(), c.Param("slug"), actorID(c))
+		if err != nil {
+			respondPostError(c, err, "failed to load lock")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, state)
+	}
+}
+
+// acquirePostLockHandler godoc
+// @Summary      Acquire or renew the edit lock of a post
+// @Description  Locks are soft and expire unless renewed. When another user holds the lock the response is 409 with their lock in data; take_over replaces it and the previous holder gets a 409 on their next renewal.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string            true   "Post slug"
+// @Param        payload  body      AdminLockRequest  false  "Lock options"
+// @Success      200      {object}  admincontentusecase.AdminLockResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409      {object}  admincontentusecase.AdminLockResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/lock [post]
+func acquirePostLockHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminLockRequest
+		if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		state, err := contentSvc.AcquirePostLock(c.Request.Context(), postdomain.LockInput{
+			Slug:       c.Param("slug"),
+			HolderID:   actorID(c),
+			HolderName: lockHolderName(c),
+			TakeOver:   body.TakeOver,
+		})
+		switch {
+		case errors.Is(err, postdomain.ErrPostLocked):
+			responder.JSONErrorData(c, http.StatusConflict, "post is being edited by "+state.Lock.HolderName, state)
+		case errors.Is(err, postdomain.ErrLockTakenOver):
+			responder.JSONErrorData(c, http.StatusConflict, state.Lock.HolderName+" took over editing this post", state)
+		case err != nil:
+			respondPostError(c, err, "failed to acquire lock")
+		default:
+			responder.JSONSuccess(c, http.StatusOK, state)
+		}
+	}
+}
+
+// releasePostLockHandler godoc
+// @Summary      Release the edit lock of a post
+// @Description  Releasing a lock held by someone else is a no-op.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        slug  path  string  true  "Post slug"
+// @Success      204  {string}  string  ""
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/lock [delete]
+func releasePostLockHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.ReleasePostLock(c.Request.Context(), c.Param("slug"), actorID(c)); err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to release lock")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,192 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// uploadMediaHandler godoc
+// @Summary      Upload a file to the media library
+// @Description  Accepts JPG, PNG, GIF and WebP images up to 5 MB. A file identical to one already in the library is not stored again; the existing record is returned.
+// @Tags         Admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        file      formData  file    true   "Image file"
+// @Param        alt_text  formData  string  false  "Alternative text"
+// @Param        caption   formData  string  false  "Caption"
+// @Success      200       {object}  admincontentusecase.AdminMediaResponse
+// @Failure      422       {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500       {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/media [post]
+func uploadMediaHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		input := mediadomain.UploadInput{
+			AltText:    c.PostForm("alt_text"),
+			Caption:    c.PostForm("caption"),
+			UploaderID: actorID(c),
+		}
+		// a missing file leaves Body nil, which validation reports on the file field
+		if header, err := c.FormFile("file"); err == nil {
+			file, err := header.Open()
+			if err != nil {
+				responder.JSONError(c, http.StatusInternalServerError, "failed to read upload")
+				return
+			}
+			defer file.Close()
+			input.Filename, input.Size, input.Body = header.Filename, header.Size, file
+		}
+		media, err := contentSvc.UploadMedia(c.Request.Context(), input)
+		if err != nil {
+			respondMediaError(c, err, "failed to upload file")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, media)
+	}
+}
+
+// listMediaHandler godoc
+// @Summary      List the media library
+// @Description  Newest first with the number of posts, pages, categories and tags using each file. q searches the original name, alt text and caption.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        q       query     string  false  "Search term"
+// @Param        limit   query     int     false  "Page size (default 50, max 200)"
+// @Param        offset  query     int     false  "Offset"
+// @Success      200     {object}  admincontentusecase.AdminMediaListResponse
+// @Failure      500     {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/media [get]
+func listMediaHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		offset, _ := strconv.Atoi(c.Query("offset"))
+		media, err := contentSvc.ListMedia(c.Request.Context(), mediadomain.ListOptions{
+			Query:  c.Query("q"),
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		})
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list media")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, media)
+	}
+}
+
+// getMediaHandler godoc
+// @Summary      Get a media library file with its usage
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id   path      int  true  "Media ID"
+// @Success      200  {object}  admincontentusecase.AdminMediaDetailResponse
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/media/{id} [get]
+func getMediaHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := mediaIDParam(c)
+		if !ok {
+			return
+		}
+		detail, err := contentSvc.GetMedia(c.Request.Context(), id)
+		if err != nil {
+			respondMediaError(c, err, "failed to load file")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, detail)
+	}
+}
+
+// updateMediaHandler godoc
+// @Summary      Update the alt text and caption of a media library file
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id       path      int                    true  "Media ID"
+// @Param        payload  body      mediadomain.TextInput  true  "Alt text and caption"
+// @Success      200      {object}  admincontentusecase.AdminMediaResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/media/{id} [put]
+func updateMediaHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := mediaIDParam(c)
+		if !ok {
+			return
+		}
+		var body mediadomain.TextInput
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		media, err := contentSvc.UpdateMedia(c.Request.Context(), id, body)
+		if err != nil {
+			respondMediaError(c, err, "failed to update file")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, media)
+	}
+}
+
+// deleteMediaHandler godoc
+// @Summary      Delete a media library file
+// @Description  Refused with 409 and the referencing posts, pages, categories and tags while any still uses the file.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        id  path  int  true  "Media ID"
+// @Success      204  {string}  string  ""
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      409  {object}  admincontentusecase.AdminMediaInUseResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/media/{id} [delete]
+func deleteMediaHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := mediaIDParam(c)
+		if !ok {
+			return
+		}
+		if err := contentSvc.DeleteMedia(c.Request.Context(), id); err != nil {
+			respondMediaError(c, err, "failed to delete file")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func mediaIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responder.JSONError(c, http.StatusBadRequest, "invalid media id")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondMediaError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	var inUse *mediadomain.InUseError
+	switch {
+	case errors.As(err, &inUse):
+		responder.JSONErrorData(c, http.StatusConflict, "file is still in use", inUse.Usage)
+	case errors.Is(err, mediadomain.ErrMediaNotFound):
+		responder.JSONError(c, http.StatusNotFound, "file not found")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,270 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminMenuRequest describes a new menu.
+type AdminMenuRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Label string `json:"label" binding:"required"`
+}
+
+// AdminMenuItemRequest describes a menu item. Internal targets (post, page, category,
+// tag) are referenced by slug in target; url targets use url.
+type AdminMenuItemRequest struct {
+	Label      string `json:"label" binding:"required"`
+	TargetType string `json:"target_type" binding:"required"`
+	Target     string `json:"target"`
+	URL        string `json:"url"`
+	ParentID   *int64 `json:"parent_id"`
+	Position   int32  `json:"position"`
+}
+
+func (r AdminMenuItemRequest) input() menudomain.ItemInput {
+	return menudomain.ItemInput{
+		Label:      r.Label,
+		TargetType: r.TargetType,
+		Target:     r.Target,
+		URL:        r.URL,
+		ParentID:   r.ParentID,
+		Position:   r.Position,
+	}
+}
+
+// AdminMenuOrderRequest lists the new parent and position of each moved item.
+type AdminMenuOrderRequest struct {
+	Items []menudomain.ItemPosition `json:"items" binding:"required"`
+}
+
+// listMenusHandler godoc
+// @Summary      List menus
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminMenuListResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus [get]
+func listMenusHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		menus, err := contentSvc.ListMenus(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list menus")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, menus)
+	}
+}
+
+// createMenuHandler godoc
+// @Summary      Create a menu
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        payload  body      AdminMenuRequest  true  "Menu payload"
+// @Success      200      {object}  admincontentusecase.AdminMenuResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus [post]
+func createMenuHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminMenuRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		menu, err := contentSvc.CreateMenu(c.Request.Context(), menudomain.MenuInput{Name: body.Name, Label: body.Label})
+		if err != nil {
+			respondMenuError(c, err, "failed to create menu")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, menu)
+	}
+}
+
+// getMenuHandler godoc
+// @Summary      Get a menu with its items
+// @Description  Items are nested; href is empty for items whose target is deleted or unpublished.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        name  path      string  true  "Menu name"
+// @Success      200   {object}  admincontentusecase.AdminMenuDetailResponse
+// @Failure      404   {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500   {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name} [get]
+func getMenuHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		menu, err := contentSvc.GetMenu(c.Request.Context(), c.Param("name"))
+		if err != nil {
+			respondMenuError(c, err, "failed to load menu")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, menu)
+	}
+}
+
+// deleteMenuHandler godoc
+// @Summary      Delete a menu
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        name  path  string  true  "Menu name"
+// @Success      204  {string}  string  ""
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name} [delete]
+func deleteMenuHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.DeleteMenu(c.Request.Context(), c.Param("name")); err != nil {
+			respondMenuError(c, err, "failed to delete menu")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// createMenuItemHandler godoc
+// @Summary      Add a menu item
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        name     path      string                true  "Menu name"
+// @Param        payload  body      AdminMenuItemRequest  true  "Menu item payload"
+// @Success      200      {object}  admincontentusecase.AdminMenuItemResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name}/items [post]
+func createMenuItemHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminMenuItemRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		item, err := contentSvc.CreateMenuItem(c.Request.Context(), c.Param("name"), body.input())
+		if err != nil {
+			respondMenuError(c, err, "failed to create menu item")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, item)
+	}
+}
+
+// updateMenuItemHandler godoc
+// @Summary      Update a menu item
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        name     path      string                true  "Menu name"
+// @Param        id       path      int                   true  "Item ID"
+// @Param        payload  body      AdminMenuItemRequest  true  "Menu item payload"
+// @Success      200      {object}  admincontentusecase.AdminMenuItemResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name}/items/{id} [put]
+func updateMenuItemHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid item id")
+			return
+		}
+		var body AdminMenuItemRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		item, err := contentSvc.UpdateMenuItem(c.Request.Context(), c.Param("name"), id, body.input())
+		if err != nil {
+			respondMenuError(c, err, "failed to update menu item")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, item)
+	}
+}
+
+// deleteMenuItemHandler godoc
+// @Summary      Delete a menu item
+// @Description  Nested items are deleted with their parent.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        name  path  string  true  "Menu name"
+// @Param        id    path  int     true  "Item ID"
+// @Success      204  {string}  string  ""
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name}/items/{id} [delete]
+func deleteMenuItemHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid item id")
+			return
+		}
+		if err := contentSvc.DeleteMenuItem(c.Request.Context(), c.Param("name"), id); err != nil {
+			respondMenuError(c, err, "failed to delete menu item")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// reorderMenuHandler godoc
+// @Summary      Reorder menu items
+// @Description  Moves the listed items to new parents and positions in one step. Used by the admin UI drag-reorder.
+// @Tags         Admin
+// @Accept       json
+// @Security     AdminCookieAuth
+// @Param        name     path  string                 true  "Menu name"
+// @Param        payload  body  AdminMenuOrderRequest  true  "New order"
+// @Success      204  {string}  string  ""
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422  {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/menus/{name}/order [put]
+func reorderMenuHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminMenuOrderRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		if err := contentSvc.ReorderMenuItems(c.Request.Context(), c.Param("name"), body.Items); err != nil {
+			respondMenuError(c, err, "failed to reorder menu")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func respondMenuError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, menudomain.ErrMenuNotFound):
+		responder.JSONError(c, http.StatusNotFound, "menu not found")
+	case errors.Is(err, menudomain.ErrItemNotFound):
+		responder.JSONError(c, http.StatusNotFound, "menu item not found")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
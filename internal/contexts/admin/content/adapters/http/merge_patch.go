@@ -0,0 +1,79 @@
+package contenthttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+var errMergePatchNotObject = errors.New("merge patch must be a JSON object")
+
+// isMergePatchContentType accepts the RFC 7396 media type and plain JSON for convenience.
+func isMergePatchContentType(contentType string) bool {
+	return contentType == mergePatchContentType || contentType == "application/json"
+}
+
+// decodePostMergePatch turns a merge patch document into a PatchPostInput, recording which
+// members were present so absent fields stay untouched downstream.
+func decodePostMergePatch(slug string, body []byte) (postdomain.PatchPostInput, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return postdomain.PatchPostInput{}, errMergePatchNotObject
+	}
+
+	input := postdomain.PatchPostInput{Slug: slug}
+	var err error
+	for key, raw := range members {
+		switch key {
+		case "title":
+			input.Title, err = patchMember[string](key, raw, false)
+		case "summary":
+			// summary is NOT NULL in storage, so removing it resets to the empty default.
+			var summary postdomain.PatchField[*string]
+			summary, err = patchMember[*string](key, raw, true)
+			input.Summary.Set = summary.Set
+			if summary.Value != nil {
+				input.Summary.Value = *summary.Value
+			}
+		case "content_md":
+			input.ContentMD, err = patchMember[string](key, raw, false)
+		case "cover_url":
+			input.CoverURL, err = patchMember[*string](key, raw, true)
+		case "status":
+			input.Status, err = patchMember[string](key, raw, false)
+		case "author_id":
+			input.AuthorID, err = patchMember[int64](key, raw, false)
+		case "published_at":
+			input.PublishedAt, err = patchMember[*time.Time](key, raw, true)
+		case "custom_fields":
+			input.CustomFields, err = patchMember[map[string]any](key, raw, true)
+		case "unpublish_at":
+			input.UnpublishAt, err = patchMember[*time.Time](key, raw, true)
+		case "expiry_mode":
+			input.ExpiryMode, err = patchMember[string](key, raw, false)
+		default:
+			err = fmt.Errorf("unknown field %q", key)
+		}
+		if err != nil {
+			return postdomain.PatchPostInput{}, err
+		}
+	}
+	return input, nil
+}
+
+func patchMember[T any](field string, raw json.RawMessage, nullable bool) (postdomain.PatchField[T], error) {
+	if !nullable && bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return postdomain.PatchField[T]{}, fmt.Errorf("%s cannot be null", field)
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return postdomain.PatchField[T]{}, fmt.Errorf("%s has an invalid value", field)
+	}
+	return postdomain.PatchField[T]{Set: true, Value: value}, nil
+}
@@ -0,0 +1,173 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminPageRequest describes a page payload. The slug is only read on create, where
+// an empty value is generated from the title. An empty parent makes a top-level page.
+type AdminPageRequest struct {
+	Title     string `json:"title" binding:"required"`
+	Slug      string `json:"slug"`
+	Summary   string `json:"summary"`
+	ContentMD string `json:"content_md"`
+	Template  string `json:"template"`
+	Status    string `json:"status"`
+	Parent    string `json:"parent"`
+	Position  int32  `json:"position"`
+}
+
+// listPagesHandler godoc
+// @Summary      List pages
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminPageListResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/pages [get]
+func listPagesHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pages, err := contentSvc.ListPages(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list pages")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, pages)
+	}
+}
+
+// getPageHandler godoc
+// @Summary      Get a page
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug  path      string  true  "Page slug"
+// @Success      200   {object}  admincontentusecase.AdminPageDetailResponse
+// @Failure      404   {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500   {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/pages/{slug} [get]
+func getPageHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, err := contentSvc.GetPage(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			respondPageError(c, err, "failed to load page")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, page)
+	}
+}
+
+// createPageHandler godoc
+// @Summary      Create a page
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        payload  body      AdminPageRequest  true  "Page payload"
+// @Success      200      {object}  admincontentusecase.AdminPageResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/pages [post]
+func createPageHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminPageRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		page, err := contentSvc.CreatePage(c.Request.Context(), pagedomain.CreatePageInput{
+			Title:      body.Title,
+			Slug:       body.Slug,
+			Summary:    body.Summary,
+			ContentMD:  body.ContentMD,
+			Template:   body.Template,
+			Status:     body.Status,
+			ParentSlug: body.Parent,
+			Position:   body.Position,
+		})
+		if err != nil {
+			respondPageError(c, err, "failed to create page")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, page)
+	}
+}
+
+// updatePageHandler godoc
+// @Summary      Update a page
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string            true  "Page slug"
+// @Param        payload  body      AdminPageRequest  true  "Page payload"
+// @Success      200      {object}  admincontentusecase.AdminPageResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/pages/{slug} [put]
+func updatePageHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminPageRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		page, err := contentSvc.UpdatePage(c.Request.Context(), pagedomain.UpdatePageInput{
+			Slug:       c.Param("slug"),
+			Title:      body.Title,
+			Summary:    body.Summary,
+			ContentMD:  body.ContentMD,
+			Template:   body.Template,
+			Status:     body.Status,
+			ParentSlug: body.Parent,
+			Position:   body.Position,
+		})
+		if err != nil {
+			respondPageError(c, err, "failed to update page")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, page)
+	}
+}
+
+// deletePageHandler godoc
+// @Summary      Delete a page
+// @Description  Child pages are kept and become top-level pages.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        slug  path  string  true  "Page slug"
+// @Success      204  {string}  string  ""
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/pages/{slug} [delete]
+func deletePageHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := contentSvc.DeletePage(c.Request.Context(), c.Param("slug")); err != nil {
+			respondPageError(c, err, "failed to delete page")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func respondPageError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, pagedomain.ErrPageNotFound):
+		responder.JSONError(c, http.StatusNotFound, "page not found")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
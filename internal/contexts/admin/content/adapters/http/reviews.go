@@ -0,0 +1,181 @@
+package contenthttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminTransitionRequest moves a post to another workflow status.
+// A comment is required when requesting changes.
+type AdminTransitionRequest struct {
+	Status  string `json:"status" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+// AdminReviewerRequest assigns a reviewer; 0 or null clears the assignment.
+type AdminReviewerRequest struct {
+	ReviewerID *int64 `json:"reviewer_id"`
+}
+
+// AdminReviewCommentRequest adds a review comment without changing status.
+type AdminReviewCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// actorID returns the id of the signed-in user AdminAuth resolved, 0 when absent.
+func actorID(c *gin.Context) int64 {
+	profile, _ := ctxkeys.AdminProfileFromContext(c)
+	return profile.ID
+}
+
+// reviewStateHandler godoc
+// @Summary      Get the review state of a post
+// @Description  Returns the post's workflow status, reviewer, comment history and the statuses the caller may move it to.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug  path      string  true  "Post slug"
+// @Success      200   {object}  admincontentusecase.AdminReviewStateResponse
+// @Failure      404   {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500   {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/review [get]
+func reviewStateHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, err := contentSvc.ReviewState(c.Request.Context(), c.Param("slug"), actorID(c))
+		if err != nil {
+			respondPostError(c, err, "failed to load review")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, state)
+	}
+}
+
+// transitionPostHandler godoc
+// @Summary      Change the workflow status of a post
+// @Description  Moves a post along the editorial workflow. Writers submit for review, editors and admins approve, request changes and publish.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                  true  "Post slug"
+// @Param        payload  body      AdminTransitionRequest  true  "Target status"
+// @Success      200      {object}  admincontentusecase.AdminPostResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      403      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/transitions [post]
+func transitionPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminTransitionRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		post, err := contentSvc.TransitionPost(c.Request.Context(), postdomain.TransitionInput{
+			Slug:    c.Param("slug"),
+			ActorID: actorID(c),
+			Status:  body.Status,
+			Comment: body.Comment,
+		})
+		if err != nil {
+			respondPostError(c, err, "failed to change status")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, post)
+	}
+}
+
+// assignReviewerHandler godoc
+// @Summary      Assign a reviewer to a post
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                true  "Post slug"
+// @Param        payload  body      AdminReviewerRequest  true  "Reviewer"
+// @Success      200      {object}  admincontentusecase.AdminReviewResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      403      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/reviewer [put]
+func assignReviewerHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminReviewerRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		var reviewerID int64
+		if body.ReviewerID != nil {
+			reviewerID = *body.ReviewerID
+		}
+		review, err := contentSvc.AssignReviewer(c.Request.Context(), c.Param("slug"), actorID(c), reviewerID)
+		if err != nil {
+			respondPostError(c, err, "failed to assign reviewer")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, review)
+	}
+}
+
+// commentPostHandler godoc
+// @Summary      Comment on a post under review
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        slug     path      string                     true  "Post slug"
+// @Param        payload  body      AdminReviewCommentRequest  true  "Comment"
+// @Success      201      {object}  admincontentusecase.AdminReviewCommentResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      403      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/posts/{slug}/comments [post]
+func commentPostHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminReviewCommentRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		comment, err := contentSvc.CommentOnPost(c.Request.Context(), c.Param("slug"), actorID(c), body.Body)
+		if err != nil {
+			respondPostError(c, err, "failed to add comment")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusCreated, comment)
+	}
+}
+
+// reviewQueueHandler godoc
+// @Summary      List the caller's review queue
+// @Description  Posts in review assigned to the caller, unassigned posts in review (editors and admins) and the caller's posts returned with requested changes.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminReviewQueueResponse
+// @Failure      403  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/reviews/queue [get]
+func reviewQueueHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		queue, err := contentSvc.ReviewQueue(c.Request.Context(), actorID(c))
+		if err != nil {
+			respondPostError(c, err, "failed to load review queue")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, queue)
+	}
+}
@@ -0,0 +1,263 @@
+package contenthttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	admincontentusecase "proto-gin-web/internal/contexts/admin/content/usecase"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// AdminWebhookRequest describes a webhook subscription. An empty secret is generated on
+// create and kept on update; active defaults to true.
+type AdminWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" binding:"required"`
+	Active *bool    `json:"active"`
+}
+
+func (r AdminWebhookRequest) input() webhookdomain.SubscriptionInput {
+	active := r.Active == nil || *r.Active
+	return webhookdomain.SubscriptionInput{URL: r.URL, Secret: r.Secret, Events: r.Events, Active: active}
+}
+
+// listWebhooksHandler godoc
+// @Summary      List webhook subscriptions
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Success      200  {object}  admincontentusecase.AdminWebhookListResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks [get]
+func listWebhooksHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subs, err := contentSvc.ListWebhooks(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list webhooks")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, subs)
+	}
+}
+
+// createWebhookHandler godoc
+// @Summary      Create a webhook subscription
+// @Description  Events are any of post.published, post.updated, post.unpublished and post.deleted. Deliveries are POSTed as JSON with X-Webhook-Event, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature headers; the signature is sha256= followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        payload  body      AdminWebhookRequest  true  "Webhook payload"
+// @Success      200      {object}  admincontentusecase.AdminWebhookResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks [post]
+func createWebhookHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body AdminWebhookRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		sub, err := contentSvc.CreateWebhook(c.Request.Context(), body.input())
+		if err != nil {
+			respondWebhookError(c, err, "failed to create webhook")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, sub)
+	}
+}
+
+// getWebhookHandler godoc
+// @Summary      Get a webhook subscription
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  admincontentusecase.AdminWebhookResponse
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks/{id} [get]
+func getWebhookHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid webhook id")
+		if !ok {
+			return
+		}
+		sub, err := contentSvc.GetWebhook(c.Request.Context(), id)
+		if err != nil {
+			respondWebhookError(c, err, "failed to load webhook")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, sub)
+	}
+}
+
+// updateWebhookHandler godoc
+// @Summary      Update a webhook subscription
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id       path      int                  true  "Subscription ID"
+// @Param        payload  body      AdminWebhookRequest  true  "Webhook payload"
+// @Success      200      {object}  admincontentusecase.AdminWebhookResponse
+// @Failure      400      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404      {object}  admincontentusecase.AdminErrorResponse
+// @Failure      422      {object}  admincontentusecase.AdminValidationErrorResponse
+// @Failure      500      {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks/{id} [put]
+func updateWebhookHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid webhook id")
+		if !ok {
+			return
+		}
+		var body AdminWebhookRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		sub, err := contentSvc.UpdateWebhook(c.Request.Context(), id, body.input())
+		if err != nil {
+			respondWebhookError(c, err, "failed to update webhook")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, sub)
+	}
+}
+
+// deleteWebhookHandler godoc
+// @Summary      Delete a webhook subscription
+// @Description  Also drops its queued and logged deliveries.
+// @Tags         Admin
+// @Security     AdminCookieAuth
+// @Param        id  path  int  true  "Subscription ID"
+// @Success      204  {string}  string  ""
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks/{id} [delete]
+func deleteWebhookHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid webhook id")
+		if !ok {
+			return
+		}
+		if err := contentSvc.DeleteWebhook(c.Request.Context(), id); err != nil {
+			respondWebhookError(c, err, "failed to delete webhook")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// listWebhookDeliveriesHandler godoc
+// @Summary      List recent deliveries of a webhook subscription
+// @Description  Newest first, with the status, attempt count and last response code of each.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  admincontentusecase.AdminWebhookDeliveryListResponse
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func listWebhookDeliveriesHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid webhook id")
+		if !ok {
+			return
+		}
+		deliveries, err := contentSvc.WebhookDeliveries(c.Request.Context(), id)
+		if err != nil {
+			respondWebhookError(c, err, "failed to list deliveries")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, deliveries)
+	}
+}
+
+// getWebhookDeliveryHandler godoc
+// @Summary      Get a webhook delivery with its attempts
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id   path      int  true  "Delivery ID"
+// @Success      200  {object}  admincontentusecase.AdminWebhookDeliveryDetailResponse
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhook-deliveries/{id} [get]
+func getWebhookDeliveryHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid delivery id")
+		if !ok {
+			return
+		}
+		detail, err := contentSvc.WebhookDelivery(c.Request.Context(), id)
+		if err != nil {
+			respondWebhookError(c, err, "failed to load delivery")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, detail)
+	}
+}
+
+// redeliverWebhookHandler godoc
+// @Summary      Redeliver a webhook delivery
+// @Description  Queues the delivery again with a fresh set of attempts; it is sent within seconds.
+// @Tags         Admin
+// @Produce      json
+// @Security     AdminCookieAuth
+// @Param        id   path      int  true  "Delivery ID"
+// @Success      200  {object}  admincontentusecase.AdminWebhookDeliveryResponse
+// @Failure      400  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      404  {object}  admincontentusecase.AdminErrorResponse
+// @Failure      500  {object}  admincontentusecase.AdminErrorResponse
+// @Router       /admin/webhook-deliveries/{id}/redeliver [post]
+func redeliverWebhookHandler(contentSvc *admincontentusecase.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookIDParam(c, "invalid delivery id")
+		if !ok {
+			return
+		}
+		delivery, err := contentSvc.RedeliverWebhook(c.Request.Context(), id)
+		if err != nil {
+			respondWebhookError(c, err, "failed to redeliver")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, delivery)
+	}
+}
+
+func webhookIDParam(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responder.JSONError(c, http.StatusBadRequest, message)
+		return 0, false
+	}
+	return id, true
+}
+
+func respondWebhookError(c *gin.Context, err error, fallback string) {
+	if responder.JSONInvalidInput(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, webhookdomain.ErrSubscriptionNotFound):
+		responder.JSONError(c, http.StatusNotFound, "webhook not found")
+	case errors.Is(err, webhookdomain.ErrDeliveryNotFound):
+		responder.JSONError(c, http.StatusNotFound, "delivery not found")
+	default:
+		responder.JSONError(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -1,14 +1,71 @@
 ﻿package usecase
 
 import (
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/validation"
 )
 
-// AdminPostResponse documents the admin post JSON envelope.
+// AdminPostResponse documents the admin post JSON envelope. Saves list the broken
+// internal links and missing uploads found in the post under warnings.
 type AdminPostResponse struct {
-	Ok   bool            `json:"ok"`
-	Data postdomain.Post `json:"data"`
+	Ok       bool                     `json:"ok"`
+	Data     postdomain.Post          `json:"data"`
+	Warnings []postdomain.LinkFinding `json:"warnings,omitempty"`
+}
+
+// AdminReviewStateResponse documents the admin post workflow state envelope.
+type AdminReviewStateResponse struct {
+	Ok   bool                   `json:"ok"`
+	Data postdomain.ReviewState `json:"data"`
+}
+
+// AdminReviewResponse documents the admin post review envelope.
+type AdminReviewResponse struct {
+	Ok   bool              `json:"ok"`
+	Data postdomain.Review `json:"data"`
+}
+
+// AdminReviewCommentResponse documents the admin review comment envelope.
+type AdminReviewCommentResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data postdomain.ReviewComment `json:"data"`
+}
+
+// AdminReviewQueueResponse documents the admin review queue envelope.
+type AdminReviewQueueResponse struct {
+	Ok   bool                   `json:"ok"`
+	Data postdomain.ReviewQueue `json:"data"`
+}
+
+// AdminLockResponse documents the admin post edit lock envelope. Conflicts (409) use
+// the error envelope with the current lock in data.
+type AdminLockResponse struct {
+	Ok   bool                 `json:"ok"`
+	Data postdomain.LockState `json:"data"`
+}
+
+// AdminAutosaveResponse documents the admin post autosave JSON envelope.
+type AdminAutosaveResponse struct {
+	Ok   bool                `json:"ok"`
+	Data postdomain.Autosave `json:"data"`
+}
+
+// AdminLinkReportResponse documents the admin link report envelope.
+type AdminLinkReportResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data []postdomain.LinkFinding `json:"data"`
+}
+
+// AdminPostRelationsResponse documents the admin post categories and tags envelope.
+type AdminPostRelationsResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data postdomain.PostRelations `json:"data"`
 }
 
 // AdminCategoryResponse documents the admin category JSON envelope.
@@ -23,9 +80,144 @@ type AdminTagResponse struct {
 	Data taxdomain.Tag `json:"data"`
 }
 
+// AdminTagAliasListResponse documents the admin tag alias list envelope.
+type AdminTagAliasListResponse struct {
+	Ok   bool                 `json:"ok"`
+	Data []taxdomain.TagAlias `json:"data"`
+}
+
+// AdminTagSuggestionsResponse documents the admin tag suggestion envelope.
+type AdminTagSuggestionsResponse struct {
+	Ok   bool                      `json:"ok"`
+	Data postdomain.TagSuggestions `json:"data"`
+}
+
+// AdminFieldResponse documents the admin custom field JSON envelope.
+type AdminFieldResponse struct {
+	Ok   bool                       `json:"ok"`
+	Data postdomain.FieldDefinition `json:"data"`
+}
+
+// AdminFieldListResponse documents the admin custom field list envelope.
+type AdminFieldListResponse struct {
+	Ok   bool                         `json:"ok"`
+	Data []postdomain.FieldDefinition `json:"data"`
+}
+
+// AdminPageResponse documents the admin page JSON envelope.
+type AdminPageResponse struct {
+	Ok   bool            `json:"ok"`
+	Data pagedomain.Page `json:"data"`
+}
+
+// AdminPageDetailResponse documents the admin page envelope including ancestors and children.
+type AdminPageDetailResponse struct {
+	Ok   bool                         `json:"ok"`
+	Data pagedomain.PageWithRelations `json:"data"`
+}
+
+// AdminPageListResponse documents the admin page list envelope.
+type AdminPageListResponse struct {
+	Ok   bool              `json:"ok"`
+	Data []pagedomain.Page `json:"data"`
+}
+
+// AdminMenuResponse documents the admin menu JSON envelope.
+type AdminMenuResponse struct {
+	Ok   bool            `json:"ok"`
+	Data menudomain.Menu `json:"data"`
+}
+
+// AdminMenuDetailResponse documents the admin menu envelope including its item tree.
+type AdminMenuDetailResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data menudomain.MenuWithItems `json:"data"`
+}
+
+// AdminMenuListResponse documents the admin menu list envelope.
+type AdminMenuListResponse struct {
+	Ok   bool              `json:"ok"`
+	Data []menudomain.Menu `json:"data"`
+}
+
+// AdminMenuItemResponse documents the admin menu item JSON envelope.
+type AdminMenuItemResponse struct {
+	Ok   bool            `json:"ok"`
+	Data menudomain.Item `json:"data"`
+}
+
+// AdminWebhookResponse documents the admin webhook subscription JSON envelope.
+type AdminWebhookResponse struct {
+	Ok   bool                       `json:"ok"`
+	Data webhookdomain.Subscription `json:"data"`
+}
+
+// AdminWebhookListResponse documents the admin webhook subscription list envelope.
+type AdminWebhookListResponse struct {
+	Ok   bool                         `json:"ok"`
+	Data []webhookdomain.Subscription `json:"data"`
+}
+
+// AdminWebhookDeliveryListResponse documents the webhook delivery log envelope.
+type AdminWebhookDeliveryListResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data []webhookdomain.Delivery `json:"data"`
+}
+
+// AdminWebhookDeliveryResponse documents a webhook delivery envelope.
+type AdminWebhookDeliveryResponse struct {
+	Ok   bool                   `json:"ok"`
+	Data webhookdomain.Delivery `json:"data"`
+}
+
+// AdminWebhookDeliveryDetailResponse documents a webhook delivery envelope with its attempts.
+type AdminWebhookDeliveryDetailResponse struct {
+	Ok   bool                         `json:"ok"`
+	Data webhookdomain.DeliveryDetail `json:"data"`
+}
+
+// AdminMediaResponse documents the media library file envelope.
+type AdminMediaResponse struct {
+	Ok   bool              `json:"ok"`
+	Data mediadomain.Media `json:"data"`
+}
+
+// AdminMediaListResponse documents the media library listing envelope.
+type AdminMediaListResponse struct {
+	Ok   bool                `json:"ok"`
+	Data []mediadomain.Media `json:"data"`
+}
+
+// AdminMediaDetailResponse documents a media library file envelope with its usage.
+type AdminMediaDetailResponse struct {
+	Ok   bool                    `json:"ok"`
+	Data mediadomain.MediaDetail `json:"data"`
+}
+
+// AdminMediaInUseResponse documents the 409 envelope of a delete refused because
+// content still references the file.
+type AdminMediaInUseResponse struct {
+	Ok    bool                `json:"ok"`
+	Error string              `json:"error"`
+	Data  []mediadomain.Usage `json:"data"`
+}
+
+// AdminSubscriberListResponse documents the newsletter subscriber list envelope.
+type AdminSubscriberListResponse struct {
+	Ok   bool                          `json:"ok"`
+	Data []newsletterdomain.Subscriber `json:"data"`
+}
+
 // AdminErrorResponse documents admin error messaging.
 type AdminErrorResponse struct {
 	Ok    bool   `json:"ok"`
 	Error string `json:"error"`
 }
 
+// AdminValidationErrorResponse documents the 422 envelope listing invalid fields.
+type AdminValidationErrorResponse struct {
+	Ok     bool                    `json:"ok"`
+	Error  string                  `json:"error"`
+	Fields []validation.FieldError `json:"fields"`
+}
+
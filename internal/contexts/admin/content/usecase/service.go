@@ -5,6 +5,16 @@ import (
 	"errors"
 	"strings"
 
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	webhookusecase "proto-gin-web/internal/contexts/admin/webhook/usecase"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	menuusecase "proto-gin-web/internal/contexts/blog/menu/usecase"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	newsletterusecase "proto-gin-web/internal/contexts/blog/newsletter/usecase"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
@@ -12,15 +22,27 @@ import (
 )
 
 // Service coordinates admin REST content operations.
-// It intentionally depends on blog/post + taxonomy use cases to orchestrate cross-context changes.
+// It intentionally depends on blog/post, page, menu + taxonomy use cases to orchestrate cross-context changes,
+// and on the webhook and newsletter use cases for subscription management and the media library.
 type Service struct {
-	posts    postusecase.PostService
-	taxonomy taxonomyusecase.TaxonomyService
+	posts      postusecase.PostService
+	reviews    postusecase.ReviewService
+	locks      postusecase.LockService
+	autosaves  postusecase.AutosaveService
+	links      postusecase.LinkCheckService
+	taxonomy   taxonomyusecase.TaxonomyService
+	fields     postusecase.CustomFieldService
+	pages      pageusecase.PageService
+	menus      menuusecase.MenuService
+	webhooks   webhookusecase.WebhookService
+	newsletter newsletterusecase.NewsletterService
+	tagHints   postusecase.TagSuggestionService
+	media      mediausecase.MediaService
 }
 
 // NewService constructs a Service.
-func NewService(posts postusecase.PostService, taxonomy taxonomyusecase.TaxonomyService) *Service {
-	return &Service{posts: posts, taxonomy: taxonomy}
+func NewService(posts postusecase.PostService, reviews postusecase.ReviewService, locks postusecase.LockService, autosaves postusecase.AutosaveService, links postusecase.LinkCheckService, taxonomy taxonomyusecase.TaxonomyService, fields postusecase.CustomFieldService, pages pageusecase.PageService, menus menuusecase.MenuService, webhooks webhookusecase.WebhookService, newsletter newsletterusecase.NewsletterService, tagHints postusecase.TagSuggestionService, media mediausecase.MediaService) *Service {
+	return &Service{posts: posts, reviews: reviews, locks: locks, autosaves: autosaves, links: links, taxonomy: taxonomy, fields: fields, pages: pages, menus: menus, webhooks: webhooks, newsletter: newsletter, tagHints: tagHints, media: media}
 }
 
 // CreatePost creates a post from API payload.
@@ -29,10 +51,40 @@ func (s *Service) CreatePost(ctx context.Context, input postdomain.CreatePostInp
 	return s.posts.Create(ctx, input)
 }
 
-// UpdatePost updates a post by slug.
+// UpdatePost updates a post by slug and discards the actor's autosave of it.
 func (s *Service) UpdatePost(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error) {
 	normalizeUpdate(&input)
-	return s.posts.Update(ctx, input)
+	post, err := s.posts.Update(ctx, input)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	s.autosaves.DiscardSaved(ctx, post.Slug, input.ActorID)
+	return post, nil
+}
+
+// PatchPost applies a JSON Merge Patch to a post by slug and discards the actor's
+// autosave of it.
+func (s *Service) PatchPost(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+	input.Slug = strings.TrimSpace(input.Slug)
+	if input.Slug == "" {
+		return postdomain.Post{}, errors.New("admincontent: slug is required")
+	}
+	post, err := s.posts.Patch(ctx, input)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	s.autosaves.DiscardSaved(ctx, post.Slug, input.ActorID)
+	return post, nil
+}
+
+// SuggestSlug proposes an unused post slug for title.
+func (s *Service) SuggestSlug(ctx context.Context, title string) (string, error) {
+	return s.posts.SuggestSlug(ctx, strings.TrimSpace(title))
+}
+
+// SuggestTags ranks existing tags for a draft and proposes new ones.
+func (s *Service) SuggestTags(ctx context.Context, input postdomain.SuggestTagsInput) (postdomain.TagSuggestions, error) {
+	return s.tagHints.Suggest(ctx, input)
 }
 
 // DeletePost removes a post.
@@ -44,20 +96,107 @@ func (s *Service) DeletePost(ctx context.Context, slug string) error {
 	return s.posts.Delete(ctx, slug)
 }
 
-func (s *Service) AddCategory(ctx context.Context, slug, categorySlug string) error {
-	return s.posts.AddCategory(ctx, strings.TrimSpace(slug), strings.TrimSpace(categorySlug))
+// ReviewState returns the workflow state of a post as seen by the acting user.
+func (s *Service) ReviewState(ctx context.Context, slug string, actorID int64) (postdomain.ReviewState, error) {
+	return s.reviews.State(ctx, slug, actorID)
+}
+
+// TransitionPost moves a post to a new workflow status.
+func (s *Service) TransitionPost(ctx context.Context, input postdomain.TransitionInput) (postdomain.Post, error) {
+	return s.reviews.Transition(ctx, input)
+}
+
+// AssignReviewer assigns or, with a zero reviewerID, unassigns the reviewer of a post.
+func (s *Service) AssignReviewer(ctx context.Context, slug string, actorID, reviewerID int64) (postdomain.Review, error) {
+	return s.reviews.AssignReviewer(ctx, slug, actorID, reviewerID)
+}
+
+// CommentOnPost adds a review comment to a post.
+func (s *Service) CommentOnPost(ctx context.Context, slug string, actorID int64, body string) (postdomain.ReviewComment, error) {
+	return s.reviews.Comment(ctx, slug, actorID, body)
+}
+
+// ReviewQueue lists the posts waiting on the acting user.
+func (s *Service) ReviewQueue(ctx context.Context, actorID int64) (postdomain.ReviewQueue, error) {
+	return s.reviews.Queue(ctx, actorID)
+}
+
+// PostLock returns the edit lock of a post as seen by the acting user.
+func (s *Service) PostLock(ctx context.Context, slug string, actorID int64) (postdomain.LockState, error) {
+	return s.locks.State(ctx, slug, actorID)
+}
+
+// AcquirePostLock takes, renews or takes over the edit lock of a post.
+func (s *Service) AcquirePostLock(ctx context.Context, input postdomain.LockInput) (postdomain.LockState, error) {
+	return s.locks.Acquire(ctx, input)
+}
+
+// ReleasePostLock drops the edit lock of a post held by the acting user.
+func (s *Service) ReleasePostLock(ctx context.Context, slug string, actorID int64) error {
+	return s.locks.Release(ctx, slug, actorID)
+}
+
+// SavePostAutosave stores the acting user's working copy of a post.
+func (s *Service) SavePostAutosave(ctx context.Context, input postdomain.AutosaveInput) (postdomain.Autosave, error) {
+	return s.autosaves.Save(ctx, input)
+}
+
+// PendingPostAutosave returns the acting user's working copy of a post when it is
+// newer than the saved post.
+func (s *Service) PendingPostAutosave(ctx context.Context, slug string, actorID int64) (postdomain.Autosave, error) {
+	return s.autosaves.Pending(ctx, slug, actorID)
+}
+
+// DiscardPostAutosave drops the acting user's working copy of a post.
+func (s *Service) DiscardPostAutosave(ctx context.Context, slug string, actorID int64) error {
+	return s.autosaves.Discard(ctx, slug, actorID)
+}
+
+// CheckPostLinks re-checks the internal links and uploads referenced by a saved post.
+// Its findings are warnings: they never undo the save.
+func (s *Service) CheckPostLinks(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	return s.links.CheckPost(ctx, slug)
+}
+
+// LinkReport lists the broken internal links and missing uploads found in posts.
+func (s *Service) LinkReport(ctx context.Context) ([]postdomain.LinkFinding, error) {
+	return s.links.Report(ctx)
+}
+
+func (s *Service) AddCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
+	return s.posts.AddCategory(ctx, strings.TrimSpace(slug), actorID, strings.TrimSpace(categorySlug))
 }
 
-func (s *Service) RemoveCategory(ctx context.Context, slug, categorySlug string) error {
-	return s.posts.RemoveCategory(ctx, strings.TrimSpace(slug), strings.TrimSpace(categorySlug))
+func (s *Service) RemoveCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
+	return s.posts.RemoveCategory(ctx, strings.TrimSpace(slug), actorID, strings.TrimSpace(categorySlug))
 }
 
-func (s *Service) AddTag(ctx context.Context, slug, tagSlug string) error {
-	return s.posts.AddTag(ctx, strings.TrimSpace(slug), strings.TrimSpace(tagSlug))
+func (s *Service) AddTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
+	return s.posts.AddTag(ctx, strings.TrimSpace(slug), actorID, strings.TrimSpace(tagSlug))
 }
 
-func (s *Service) RemoveTag(ctx context.Context, slug, tagSlug string) error {
-	return s.posts.RemoveTag(ctx, strings.TrimSpace(slug), strings.TrimSpace(tagSlug))
+func (s *Service) RemoveTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
+	return s.posts.RemoveTag(ctx, strings.TrimSpace(slug), actorID, strings.TrimSpace(tagSlug))
+}
+
+// ReplacePostCategories makes categorySlugs the complete category set of a post.
+func (s *Service) ReplacePostCategories(ctx context.Context, slug string, actorID int64, categorySlugs []string) (postdomain.PostRelations, error) {
+	return s.posts.ReplaceRelations(ctx, strings.TrimSpace(slug), actorID, postdomain.RelationSet{Categories: nonNil(categorySlugs)})
+}
+
+// ReplacePostTags makes tags the complete tag set of a post, creating the tags that do
+// not exist yet when createMissing is set.
+func (s *Service) ReplacePostTags(ctx context.Context, slug string, actorID int64, tags []string, createMissing bool) (postdomain.PostRelations, error) {
+	return s.posts.ReplaceRelations(ctx, strings.TrimSpace(slug), actorID, postdomain.RelationSet{Tags: nonNil(tags), CreateMissingTags: createMissing})
+}
+
+// nonNil keeps an absent list from reading as "leave unchanged" where the caller
+// always means a complete set.
+func nonNil(entries []string) []string {
+	if entries == nil {
+		return []string{}
+	}
+	return entries
 }
 
 func (s *Service) CreateCategory(ctx context.Context, input taxdomain.CreateCategoryInput) (taxdomain.Category, error) {
@@ -65,6 +204,17 @@ func (s *Service) CreateCategory(ctx context.Context, input taxdomain.CreateCate
 	return s.taxonomy.CreateCategory(ctx, input)
 }
 
+// UpdateCategory renames the category at slug; a blank input slug keeps the current one.
+func (s *Service) UpdateCategory(ctx context.Context, slug string, input taxdomain.UpdateCategoryInput) (taxdomain.Category, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Slug = strings.TrimSpace(strings.ToLower(input.Slug))
+	if input.ParentSlug != nil {
+		parent := strings.TrimSpace(strings.ToLower(*input.ParentSlug))
+		input.ParentSlug = &parent
+	}
+	return s.taxonomy.UpdateCategory(ctx, strings.TrimSpace(slug), input)
+}
+
 func (s *Service) DeleteCategory(ctx context.Context, slug string) error {
 	slug = strings.TrimSpace(slug)
 	if slug == "" {
@@ -78,6 +228,13 @@ func (s *Service) CreateTag(ctx context.Context, input taxdomain.CreateTagInput)
 	return s.taxonomy.CreateTag(ctx, input)
 }
 
+// UpdateTag renames the tag at slug; a blank input slug keeps the current one.
+func (s *Service) UpdateTag(ctx context.Context, slug string, input taxdomain.UpdateTagInput) (taxdomain.Tag, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Slug = strings.TrimSpace(strings.ToLower(input.Slug))
+	return s.taxonomy.UpdateTag(ctx, strings.TrimSpace(slug), input)
+}
+
 func (s *Service) DeleteTag(ctx context.Context, slug string) error {
 	slug = strings.TrimSpace(slug)
 	if slug == "" {
@@ -86,11 +243,112 @@ func (s *Service) DeleteTag(ctx context.Context, slug string) error {
 	return s.taxonomy.DeleteTag(ctx, slug)
 }
 
+// MergeTags folds the source tags into the tag at slug.
+func (s *Service) MergeTags(ctx context.Context, slug string, sources []string) (taxdomain.Tag, error) {
+	return s.taxonomy.MergeTags(ctx, slug, sources)
+}
+
+// ListTagAliases lists the slugs left behind by merged tags.
+func (s *Service) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	return s.taxonomy.ListTagAliases(ctx)
+}
+
+// DeleteTagAlias removes a merged tag's alias.
+func (s *Service) DeleteTagAlias(ctx context.Context, slug string) error {
+	return s.taxonomy.DeleteTagAlias(ctx, slug)
+}
+
+// ListFields returns every custom field definition in display order.
+func (s *Service) ListFields(ctx context.Context) ([]postdomain.FieldDefinition, error) {
+	return s.fields.List(ctx)
+}
+
+// CreateField defines a new custom field.
+func (s *Service) CreateField(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	return s.fields.Create(ctx, input)
+}
+
+// UpdateField replaces the attributes of an existing custom field.
+func (s *Service) UpdateField(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	return s.fields.Update(ctx, input)
+}
+
+// DeleteField removes a custom field and its values.
+func (s *Service) DeleteField(ctx context.Context, key string) error {
+	return s.fields.Delete(ctx, key)
+}
+
+// ListPages returns every page, drafts included.
+func (s *Service) ListPages(ctx context.Context) ([]pagedomain.Page, error) {
+	return s.pages.List(ctx)
+}
+
+// GetPage loads a page with its ancestors and published children.
+func (s *Service) GetPage(ctx context.Context, slug string) (pagedomain.PageWithRelations, error) {
+	return s.pages.Get(ctx, slug)
+}
+
+// CreatePage creates a page from API payload.
+func (s *Service) CreatePage(ctx context.Context, input pagedomain.CreatePageInput) (pagedomain.Page, error) {
+	return s.pages.Create(ctx, input)
+}
+
+// UpdatePage replaces the editable fields of a page by slug.
+func (s *Service) UpdatePage(ctx context.Context, input pagedomain.UpdatePageInput) (pagedomain.Page, error) {
+	return s.pages.Update(ctx, input)
+}
+
+// DeletePage removes a page; its children become top-level pages.
+func (s *Service) DeletePage(ctx context.Context, slug string) error {
+	return s.pages.Delete(ctx, slug)
+}
+
+// ListMenus returns every navigation menu.
+func (s *Service) ListMenus(ctx context.Context) ([]menudomain.Menu, error) {
+	return s.menus.ListMenus(ctx)
+}
+
+// GetMenu loads a menu with its item tree.
+func (s *Service) GetMenu(ctx context.Context, name string) (menudomain.MenuWithItems, error) {
+	return s.menus.GetMenu(ctx, name)
+}
+
+// CreateMenu defines a new named menu.
+func (s *Service) CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error) {
+	return s.menus.CreateMenu(ctx, input)
+}
+
+// DeleteMenu removes a menu and all of its items.
+func (s *Service) DeleteMenu(ctx context.Context, name string) error {
+	return s.menus.DeleteMenu(ctx, name)
+}
+
+// CreateMenuItem adds an item to a menu.
+func (s *Service) CreateMenuItem(ctx context.Context, menuName string, input menudomain.ItemInput) (menudomain.Item, error) {
+	return s.menus.CreateItem(ctx, menuName, input)
+}
+
+// UpdateMenuItem replaces an item of a menu.
+func (s *Service) UpdateMenuItem(ctx context.Context, menuName string, id int64, input menudomain.ItemInput) (menudomain.Item, error) {
+	return s.menus.UpdateItem(ctx, menuName, id, input)
+}
+
+// DeleteMenuItem removes an item and its nested items.
+func (s *Service) DeleteMenuItem(ctx context.Context, menuName string, id int64) error {
+	return s.menus.DeleteItem(ctx, menuName, id)
+}
+
+// ReorderMenuItems moves items to new parents and positions.
+func (s *Service) ReorderMenuItems(ctx context.Context, menuName string, order []menudomain.ItemPosition) error {
+	return s.menus.ReorderItems(ctx, menuName, order)
+}
+
 func normalizeCreate(input *postdomain.CreatePostInput) {
 	input.Title = strings.TrimSpace(input.Title)
 	input.Slug = strings.TrimSpace(input.Slug)
 	input.Summary = strings.TrimSpace(input.Summary)
 	input.Status = strings.TrimSpace(input.Status)
+	input.ExpiryMode = strings.TrimSpace(input.ExpiryMode)
 	if input.CoverURL != nil {
 		trimmed := strings.TrimSpace(*input.CoverURL)
 		input.CoverURL = &trimmed
@@ -102,6 +360,7 @@ func normalizeUpdate(input *postdomain.UpdatePostInput) {
 	input.Title = strings.TrimSpace(input.Title)
 	input.Summary = strings.TrimSpace(input.Summary)
 	input.Status = strings.TrimSpace(input.Status)
+	input.ExpiryMode = strings.TrimSpace(input.ExpiryMode)
 	if input.CoverURL != nil {
 		trimmed := strings.TrimSpace(*input.CoverURL)
 		input.CoverURL = &trimmed
@@ -111,6 +370,7 @@ func normalizeUpdate(input *postdomain.UpdatePostInput) {
 func normalizeCat(input *taxdomain.CreateCategoryInput) {
 	input.Name = strings.TrimSpace(input.Name)
 	input.Slug = strings.TrimSpace(strings.ToLower(input.Slug))
+	input.ParentSlug = strings.TrimSpace(strings.ToLower(input.ParentSlug))
 }
 
 func normalizeTag(input *taxdomain.CreateTagInput) {
@@ -118,6 +378,72 @@ func normalizeTag(input *taxdomain.CreateTagInput) {
 	input.Slug = strings.TrimSpace(strings.ToLower(input.Slug))
 }
 
+// ListWebhooks lists every webhook subscription.
+func (s *Service) ListWebhooks(ctx context.Context) ([]webhookdomain.Subscription, error) {
+	return s.webhooks.ListSubscriptions(ctx)
+}
 
+// GetWebhook fetches a webhook subscription.
+func (s *Service) GetWebhook(ctx context.Context, id int64) (webhookdomain.Subscription, error) {
+	return s.webhooks.GetSubscription(ctx, id)
+}
 
+// CreateWebhook creates a webhook subscription.
+func (s *Service) CreateWebhook(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	return s.webhooks.CreateSubscription(ctx, input)
+}
 
+// UpdateWebhook updates a webhook subscription.
+func (s *Service) UpdateWebhook(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	return s.webhooks.UpdateSubscription(ctx, id, input)
+}
+
+// DeleteWebhook removes a webhook subscription and its deliveries.
+func (s *Service) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.webhooks.DeleteSubscription(ctx, id)
+}
+
+// WebhookDeliveries lists the recent deliveries of a webhook subscription.
+func (s *Service) WebhookDeliveries(ctx context.Context, id int64) ([]webhookdomain.Delivery, error) {
+	return s.webhooks.Deliveries(ctx, id)
+}
+
+// WebhookDelivery fetches a delivery with its attempts.
+func (s *Service) WebhookDelivery(ctx context.Context, id int64) (webhookdomain.DeliveryDetail, error) {
+	return s.webhooks.Delivery(ctx, id)
+}
+
+// RedeliverWebhook queues a delivery to be sent again.
+func (s *Service) RedeliverWebhook(ctx context.Context, id int64) (webhookdomain.Delivery, error) {
+	return s.webhooks.Redeliver(ctx, id)
+}
+
+// UploadMedia stores a file in the media library, reusing an identical one.
+func (s *Service) UploadMedia(ctx context.Context, input mediadomain.UploadInput) (mediadomain.Media, error) {
+	return s.media.Upload(ctx, input)
+}
+
+// ListMedia pages through the media library, newest first.
+func (s *Service) ListMedia(ctx context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error) {
+	return s.media.List(ctx, opts)
+}
+
+// GetMedia fetches a file with the content that references it.
+func (s *Service) GetMedia(ctx context.Context, id int64) (mediadomain.MediaDetail, error) {
+	return s.media.Get(ctx, id)
+}
+
+// UpdateMedia changes the alt text and caption of a file.
+func (s *Service) UpdateMedia(ctx context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error) {
+	return s.media.UpdateText(ctx, id, input)
+}
+
+// DeleteMedia removes a file no content references any more.
+func (s *Service) DeleteMedia(ctx context.Context, id int64) error {
+	return s.media.Delete(ctx, id)
+}
+
+// ListSubscribers lists newsletter subscribers, optionally only those with status.
+func (s *Service) ListSubscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error) {
+	return s.newsletter.Subscribers(ctx, status)
+}
@@ -14,7 +14,8 @@ func TestService_CreateAndUpdatePost_normalizesInput(t *testing.T) {
 		createResult: postdomain.Post{ID: 1, Slug: "hello-world"},
 		updateResult: postdomain.Post{ID: 1, Slug: "hello-world"},
 	}
-	svc := NewService(postSvc, &stubTaxonomySvc{})
+	autosaves := &stubAutosaveSvc{}
+	svc := NewService(postSvc, nil, nil, autosaves, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
 
 	cover := "  https://example.com/image.jpg  "
 	if _, err := svc.CreatePost(context.Background(), postdomain.CreatePostInput{
@@ -45,6 +46,7 @@ func TestService_CreateAndUpdatePost_normalizesInput(t *testing.T) {
 		ContentMD: "content",
 		Status:    " published ",
 		CoverURL:  &updateCover,
+		ActorID:   7,
 	}); err != nil {
 		t.Fatalf("UpdatePost returned error: %v", err)
 	}
@@ -56,11 +58,46 @@ func TestService_CreateAndUpdatePost_normalizesInput(t *testing.T) {
 	if postSvc.updateInput.CoverURL == nil || *postSvc.updateInput.CoverURL != "/cover.png" {
 		t.Fatalf("expected trimmed cover url on update, got %v", postSvc.updateInput.CoverURL)
 	}
+	if autosaves.discardSlug != "hello-world" || autosaves.discardActor != 7 {
+		t.Fatalf("expected the actor's autosave to be discarded, got %q/%d", autosaves.discardSlug, autosaves.discardActor)
+	}
+}
+
+func TestService_PatchPost_trimsSlug(t *testing.T) {
+	postSvc := &stubPostSvc{patchResult: postdomain.Post{ID: 1, Slug: "hello-world"}}
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
+
+	if _, err := svc.PatchPost(context.Background(), postdomain.PatchPostInput{
+		Slug:   "  hello-world ",
+		Status: postdomain.PatchField[string]{Set: true, Value: "published"},
+	}); err != nil {
+		t.Fatalf("PatchPost returned error: %v", err)
+	}
+	if postSvc.patchInput.Slug != "hello-world" || !postSvc.patchInput.Status.Set {
+		t.Fatalf("unexpected patch input: %+v", postSvc.patchInput)
+	}
+
+	if _, err := svc.PatchPost(context.Background(), postdomain.PatchPostInput{Slug: " "}); err == nil || err.Error() != "admincontent: slug is required" {
+		t.Fatalf("expected slug required error, got %v", err)
+	}
+}
+
+func TestService_SuggestSlug_trimsTitle(t *testing.T) {
+	postSvc := &stubPostSvc{slugResult: "hello-world"}
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
+
+	got, err := svc.SuggestSlug(context.Background(), "  Hello World  ")
+	if err != nil {
+		t.Fatalf("SuggestSlug returned error: %v", err)
+	}
+	if got != "hello-world" || postSvc.slugTitle != "Hello World" {
+		t.Fatalf("unexpected suggestion %q for title %q", got, postSvc.slugTitle)
+	}
 }
 
 func TestService_DeletePost_validatesSlug(t *testing.T) {
 	postSvc := &stubPostSvc{}
-	svc := NewService(postSvc, &stubTaxonomySvc{})
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
 
 	if err := svc.DeletePost(context.Background(), "  hello-world  "); err != nil {
 		t.Fatalf("DeletePost returned error: %v", err)
@@ -79,7 +116,7 @@ func TestService_TaxonomyOperations_normalizeInput(t *testing.T) {
 		categoryResult: taxdomain.Category{ID: 1, Name: "Foo", Slug: "foo"},
 		tagResult:      taxdomain.Tag{ID: 2, Name: "Bar", Slug: "bar"},
 	}
-	svc := NewService(&stubPostSvc{}, taxSvc)
+	svc := NewService(&stubPostSvc{}, nil, nil, &stubAutosaveSvc{}, nil, taxSvc, nil, nil, nil, nil, nil, nil, nil)
 
 	if _, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{
 		Name: "  Foo ",
@@ -120,34 +157,48 @@ func TestService_TaxonomyOperations_normalizeInput(t *testing.T) {
 	if err := svc.DeleteTag(context.Background(), " "); err == nil || err.Error() != "admincontent: tag slug is required" {
 		t.Fatalf("expected tag slug required error, got %v", err)
 	}
+
+	if _, err := svc.UpdateCategory(context.Background(), " foo ", taxdomain.UpdateCategoryInput{Name: " Food ", Slug: " FOOD "}); err != nil {
+		t.Fatalf("UpdateCategory returned error: %v", err)
+	}
+	if taxSvc.updateSlug != "foo" || taxSvc.categoryUpdate != (taxdomain.UpdateCategoryInput{Name: "Food", Slug: "food"}) {
+		t.Fatalf("category update not normalized: %q %+v", taxSvc.updateSlug, taxSvc.categoryUpdate)
+	}
+
+	if _, err := svc.UpdateTag(context.Background(), " bar ", taxdomain.UpdateTagInput{Name: " Bars "}); err != nil {
+		t.Fatalf("UpdateTag returned error: %v", err)
+	}
+	if taxSvc.updateSlug != "bar" || taxSvc.tagUpdate != (taxdomain.UpdateTagInput{Name: "Bars"}) {
+		t.Fatalf("tag update not normalized: %q %+v", taxSvc.updateSlug, taxSvc.tagUpdate)
+	}
 }
 
 func TestService_CategoryAndTagAssignments_trimSlugs(t *testing.T) {
 	postSvc := &stubPostSvc{}
-	svc := NewService(postSvc, &stubTaxonomySvc{})
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
 
-	if err := svc.AddCategory(context.Background(), "  post-slug  ", "  category "); err != nil {
+	if err := svc.AddCategory(context.Background(), "  post-slug  ", 1, "  category "); err != nil {
 		t.Fatalf("AddCategory returned error: %v", err)
 	}
 	if postSvc.addCategoryArgs != ([2]string{"post-slug", "category"}) {
 		t.Fatalf("expected trimmed args, got %+v", postSvc.addCategoryArgs)
 	}
 
-	if err := svc.RemoveCategory(context.Background(), "  post-slug  ", "  category "); err != nil {
+	if err := svc.RemoveCategory(context.Background(), "  post-slug  ", 1, "  category "); err != nil {
 		t.Fatalf("RemoveCategory returned error: %v", err)
 	}
 	if postSvc.removeCategoryArgs != ([2]string{"post-slug", "category"}) {
 		t.Fatalf("expected trimmed args, got %+v", postSvc.removeCategoryArgs)
 	}
 
-	if err := svc.AddTag(context.Background(), "  post-slug  ", "  tag "); err != nil {
+	if err := svc.AddTag(context.Background(), "  post-slug  ", 1, "  tag "); err != nil {
 		t.Fatalf("AddTag returned error: %v", err)
 	}
 	if postSvc.addTagArgs != ([2]string{"post-slug", "tag"}) {
 		t.Fatalf("expected trimmed args, got %+v", postSvc.addTagArgs)
 	}
 
-	if err := svc.RemoveTag(context.Background(), "  post-slug  ", "  tag "); err != nil {
+	if err := svc.RemoveTag(context.Background(), "  post-slug  ", 1, "  tag "); err != nil {
 		t.Fatalf("RemoveTag returned error: %v", err)
 	}
 	if postSvc.removeTagArgs != ([2]string{"post-slug", "tag"}) {
@@ -155,6 +206,25 @@ func TestService_CategoryAndTagAssignments_trimSlugs(t *testing.T) {
 	}
 }
 
+func TestService_ReplacePostRelations_sendCompleteSets(t *testing.T) {
+	postSvc := &stubPostSvc{}
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, &stubTaxonomySvc{}, nil, nil, nil, nil, nil, nil, nil)
+
+	if _, err := svc.ReplacePostCategories(context.Background(), " post-slug ", 1, nil); err != nil {
+		t.Fatalf("ReplacePostCategories returned error: %v", err)
+	}
+	if postSvc.relationsSlug != "post-slug" || postSvc.relationsSet.Categories == nil || postSvc.relationsSet.Tags != nil {
+		t.Fatalf("expected an empty category set and untouched tags, got %q %+v", postSvc.relationsSlug, postSvc.relationsSet)
+	}
+
+	if _, err := svc.ReplacePostTags(context.Background(), "post-slug", 1, []string{"go"}, true); err != nil {
+		t.Fatalf("ReplacePostTags returned error: %v", err)
+	}
+	if postSvc.relationsSet.Categories != nil || len(postSvc.relationsSet.Tags) != 1 || !postSvc.relationsSet.CreateMissingTags {
+		t.Fatalf("expected the tag set only, got %+v", postSvc.relationsSet)
+	}
+}
+
 func TestService_ErrorPropagation(t *testing.T) {
 	postSvc := &stubPostSvc{
 		errCreate:      errors.New("create failed"),
@@ -171,7 +241,7 @@ func TestService_ErrorPropagation(t *testing.T) {
 		errCreateTag:      errors.New("create tag failed"),
 		errDeleteTag:      errors.New("delete tag failed"),
 	}
-	svc := NewService(postSvc, taxSvc)
+	svc := NewService(postSvc, nil, nil, &stubAutosaveSvc{}, nil, taxSvc, nil, nil, nil, nil, nil, nil, nil)
 
 	cover := "cover"
 	if _, err := svc.CreatePost(context.Background(), postdomain.CreatePostInput{
@@ -197,19 +267,19 @@ func TestService_ErrorPropagation(t *testing.T) {
 		t.Fatalf("expected delete error, got %v", err)
 	}
 
-	if err := svc.AddCategory(context.Background(), "slug", "cat"); err == nil || err.Error() != "add cat failed" {
+	if err := svc.AddCategory(context.Background(), "slug", 1, "cat"); err == nil || err.Error() != "add cat failed" {
 		t.Fatalf("expected add category error, got %v", err)
 	}
 
-	if err := svc.RemoveCategory(context.Background(), "slug", "cat"); err == nil || err.Error() != "remove cat failed" {
+	if err := svc.RemoveCategory(context.Background(), "slug", 1, "cat"); err == nil || err.Error() != "remove cat failed" {
 		t.Fatalf("expected remove category error, got %v", err)
 	}
 
-	if err := svc.AddTag(context.Background(), "slug", "tag"); err == nil || err.Error() != "add tag failed" {
+	if err := svc.AddTag(context.Background(), "slug", 1, "tag"); err == nil || err.Error() != "add tag failed" {
 		t.Fatalf("expected add tag error, got %v", err)
 	}
 
-	if err := svc.RemoveTag(context.Background(), "slug", "tag"); err == nil || err.Error() != "remove tag failed" {
+	if err := svc.RemoveTag(context.Background(), "slug", 1, "tag"); err == nil || err.Error() != "remove tag failed" {
 		t.Fatalf("expected remove tag error, got %v", err)
 	}
 
@@ -233,18 +303,25 @@ func TestService_ErrorPropagation(t *testing.T) {
 type stubPostSvc struct {
 	createInput postdomain.CreatePostInput
 	updateInput postdomain.UpdatePostInput
+	patchInput  postdomain.PatchPostInput
 	deleteSlug  string
+	slugTitle   string
 
 	addCategoryArgs    [2]string
 	removeCategoryArgs [2]string
 	addTagArgs         [2]string
 	removeTagArgs      [2]string
+	relationsSlug      string
+	relationsSet       postdomain.RelationSet
 
 	createResult postdomain.Post
 	updateResult postdomain.Post
+	patchResult  postdomain.Post
+	slugResult   string
 
 	errCreate      error
 	errUpdate      error
+	errPatch       error
 	errDelete      error
 	errAddCategory error
 	errRemoveCat   error
@@ -270,31 +347,67 @@ func (s *stubPostSvc) Update(ctx context.Context, input postdomain.UpdatePostInp
 	return s.updateResult, s.errUpdate
 }
 
+func (s *stubPostSvc) Patch(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+	s.patchInput = input
+	return s.patchResult, s.errPatch
+}
+
 func (s *stubPostSvc) Delete(ctx context.Context, slug string) error {
 	s.deleteSlug = slug
 	return s.errDelete
 }
 
-func (s *stubPostSvc) AddCategory(ctx context.Context, slug, categorySlug string) error {
+func (s *stubPostSvc) SuggestSlug(ctx context.Context, title string) (string, error) {
+	s.slugTitle = title
+	return s.slugResult, nil
+}
+
+func (s *stubPostSvc) AddCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
 	s.addCategoryArgs = [2]string{slug, categorySlug}
 	return s.errAddCategory
 }
 
-func (s *stubPostSvc) RemoveCategory(ctx context.Context, slug, categorySlug string) error {
+func (s *stubPostSvc) RemoveCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
 	s.removeCategoryArgs = [2]string{slug, categorySlug}
 	return s.errRemoveCat
 }
 
-func (s *stubPostSvc) AddTag(ctx context.Context, slug, tagSlug string) error {
+func (s *stubPostSvc) AddTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
 	s.addTagArgs = [2]string{slug, tagSlug}
 	return s.errAddTag
 }
 
-func (s *stubPostSvc) RemoveTag(ctx context.Context, slug, tagSlug string) error {
+func (s *stubPostSvc) RemoveTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
 	s.removeTagArgs = [2]string{slug, tagSlug}
 	return s.errRemoveTag
 }
 
+func (s *stubPostSvc) ReplaceRelations(ctx context.Context, slug string, actorID int64, set postdomain.RelationSet) (postdomain.PostRelations, error) {
+	s.relationsSlug, s.relationsSet = slug, set
+	return postdomain.PostRelations{}, nil
+}
+
+type stubAutosaveSvc struct {
+	discardSlug  string
+	discardActor int64
+}
+
+func (s *stubAutosaveSvc) Save(context.Context, postdomain.AutosaveInput) (postdomain.Autosave, error) {
+	return postdomain.Autosave{}, nil
+}
+
+func (s *stubAutosaveSvc) Pending(context.Context, string, int64) (postdomain.Autosave, error) {
+	return postdomain.Autosave{}, postdomain.ErrAutosaveNotFound
+}
+
+func (s *stubAutosaveSvc) Discard(context.Context, string, int64) error {
+	return nil
+}
+
+func (s *stubAutosaveSvc) DiscardSaved(ctx context.Context, slug string, actorID int64) {
+	s.discardSlug, s.discardActor = slug, actorID
+}
+
 type stubTaxonomySvc struct {
 	categoryInput taxdomain.CreateCategoryInput
 	tagInput      taxdomain.CreateTagInput
@@ -308,6 +421,40 @@ type stubTaxonomySvc struct {
 	errDeleteCategory error
 	errCreateTag      error
 	errDeleteTag      error
+
+	updateSlug     string
+	categoryUpdate taxdomain.UpdateCategoryInput
+	tagUpdate      taxdomain.UpdateTagInput
+}
+
+func (s *stubTaxonomySvc) ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error) {
+	return nil, nil
+}
+
+func (s *stubTaxonomySvc) ListCategoryTree(ctx context.Context) ([]taxdomain.CategoryNode, error) {
+	return nil, nil
+}
+
+func (s *stubTaxonomySvc) GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error) {
+	return taxdomain.CategoryWithCount{Category: s.categoryResult}, nil
+}
+
+func (s *stubTaxonomySvc) UpdateCategory(ctx context.Context, slug string, input taxdomain.UpdateCategoryInput) (taxdomain.Category, error) {
+	s.updateSlug, s.categoryUpdate = slug, input
+	return s.categoryResult, nil
+}
+
+func (s *stubTaxonomySvc) ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error) {
+	return nil, nil
+}
+
+func (s *stubTaxonomySvc) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	return taxdomain.TagWithCount{Tag: s.tagResult}, nil
+}
+
+func (s *stubTaxonomySvc) UpdateTag(ctx context.Context, slug string, input taxdomain.UpdateTagInput) (taxdomain.Tag, error) {
+	s.updateSlug, s.tagUpdate = slug, input
+	return s.tagResult, nil
 }
 
 func (s *stubTaxonomySvc) CreateCategory(ctx context.Context, input taxdomain.CreateCategoryInput) (taxdomain.Category, error) {
@@ -330,3 +477,23 @@ func (s *stubTaxonomySvc) DeleteTag(ctx context.Context, slug string) error {
 	return s.errDeleteTag
 }
 
+func (s *stubTaxonomySvc) MergeTags(ctx context.Context, target string, sources []string) (taxdomain.Tag, error) {
+	return s.tagResult, nil
+}
+
+func (s *stubTaxonomySvc) ListDuplicateTags(ctx context.Context) ([]taxdomain.DuplicateTagGroup, error) {
+	return nil, nil
+}
+
+func (s *stubTaxonomySvc) ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error) {
+	return taxdomain.Tag{}, taxdomain.ErrAliasNotFound
+}
+
+func (s *stubTaxonomySvc) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	return nil, nil
+}
+
+func (s *stubTaxonomySvc) DeleteTagAlias(ctx context.Context, slug string) error {
+	return nil
+}
+
@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"context"
+	"html/template"
+	"strconv"
+	"strings"
+
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+)
+
+// ImageAttrs looks up a library image by URL and returns its srcset, sizes, width and
+// height attributes, so the browser can pick a variant and reserve space before it
+// loads. Handlers call it with the request context and hand the result to the view
+// model; URLs outside the library yield no attributes.
+//
+//	<img src="{{ .CoverURL }}" {{ .CoverAttrs }} alt="">
+func ImageAttrs(ctx context.Context, images mediausecase.ImageLookup, url, sizes string) template.HTMLAttr {
+	if images == nil || url == "" {
+		return ""
+	}
+	media, ok := images.Image(ctx, url)
+	if !ok {
+		return ""
+	}
+	var attrs []string
+	if srcset := media.SrcSet(); srcset != "" {
+		attrs = append(attrs, attr("srcset", srcset))
+		if sizes != "" {
+			attrs = append(attrs, attr("sizes", sizes))
+		}
+	}
+	if media.Width > 0 && media.Height > 0 {
+		attrs = append(attrs,
+			attr("width", strconv.Itoa(int(media.Width))),
+			attr("height", strconv.Itoa(int(media.Height))))
+	}
+	return template.HTMLAttr(strings.Join(attrs, " "))
+}
+
+func attr(name, value string) string {
+	return name + `="` + template.HTMLEscapeString(value) + `"`
+}
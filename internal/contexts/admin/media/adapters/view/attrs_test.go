@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"context"
+	"html/template"
+	"strings"
+	"testing"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+)
+
+type stubImages map[string]mediadomain.Media
+
+func (s stubImages) Image(_ context.Context, url string) (mediadomain.Media, bool) {
+	m, ok := s[url]
+	return m, ok
+}
+
+func TestImageAttrsDescribesVariants(t *testing.T) {
+	images := stubImages{
+		"/static/uploads/1.jpg": {
+			URL: "/static/uploads/1.jpg", Width: 1600, Height: 900,
+			Variants: []mediadomain.Variant{{Width: 320, URL: "/static/uploads/1-320w.jpg"}},
+		},
+		"/static/uploads/2.png": {URL: "/static/uploads/2.png", Width: 40, Height: 30},
+	}
+	ctx := context.Background()
+
+	got := string(ImageAttrs(ctx, images, "/static/uploads/1.jpg", `(max-width: 960px) 100vw, "960px"`))
+	want := `srcset="/static/uploads/1-320w.jpg 320w, /static/uploads/1.jpg 1600w" sizes="(max-width: 960px) 100vw, &#34;960px&#34;" width="1600" height="900"`
+	if got != want {
+		t.Fatalf("unexpected attrs\n got %s\nwant %s", got, want)
+	}
+	if got := ImageAttrs(ctx, images, "/static/uploads/2.png", "100vw"); got != `width="40" height="30"` {
+		t.Fatalf("expected only dimensions without variants, got %s", got)
+	}
+	if got := ImageAttrs(ctx, images, "https://cdn.example.com/x.jpg", "100vw"); got != "" {
+		t.Fatalf("expected nothing for foreign URLs, got %s", got)
+	}
+}
+
+func TestImageAttrsRendersInsideImgTag(t *testing.T) {
+	images := stubImages{"/static/uploads/2.png": {Width: 40, Height: 30}}
+	tmpl := template.Must(template.New("t").Parse(`<img src="{{ .URL }}" {{ .Attrs }} alt="">`))
+	data := map[string]any{
+		"URL":   "/static/uploads/2.png",
+		"Attrs": ImageAttrs(context.Background(), images, "/static/uploads/2.png", "100vw"),
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	if want := `<img src="/static/uploads/2.png" width="40" height="30" alt="">`; buf.String() != want {
+		t.Fatalf("got %s, want %s", buf.String(), want)
+	}
+}
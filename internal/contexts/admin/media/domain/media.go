@@ -0,0 +1,165 @@
+package mediadomain
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PathPrefix is the public URL path uploaded files are served under.
+const PathPrefix = "/static/uploads/"
+
+// Usage kinds: what references a file.
+const (
+	UsagePost     = "post"
+	UsagePage     = "page"
+	UsageCategory = "category"
+	UsageTag      = "tag"
+)
+
+// Media is one uploaded file. Width and Height are zero when they could not be read.
+// Variants lists its resized copies narrowest first. UsageCount is only filled in by
+// listings.
+type Media struct {
+	ID           int64     `json:"id"`
+	Filename     string    `json:"filename"`
+	OriginalName string    `json:"original_name"`
+	URL          string    `json:"url"`
+	MIMEType     string    `json:"mime_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+	AltText      string    `json:"alt_text"`
+	Caption      string    `json:"caption"`
+	UploadedBy   *int64    `json:"uploaded_by,omitempty"`
+	UploaderName string    `json:"uploader_name,omitempty"`
+	Checksum     string    `json:"checksum"`
+	UsageCount   int64     `json:"usage_count"`
+	Variants     []Variant `json:"variants"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SrcSet lists the variants and the original as an HTML srcset value, or "" when the
+// file has no variants or its width is unknown.
+func (m Media) SrcSet() string {
+	if len(m.Variants) == 0 || m.Width == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(m.Variants)+1)
+	for _, v := range m.Variants {
+		parts = append(parts, v.URL+" "+strconv.Itoa(int(v.Width))+"w")
+	}
+	parts = append(parts, m.URL+" "+strconv.Itoa(int(m.Width))+"w")
+	return strings.Join(parts, ", ")
+}
+
+// Variant is a resized copy of an image, narrower than the original.
+type Variant struct {
+	Width     int32  `json:"width"`
+	Height    int32  `json:"height"`
+	Filename  string `json:"filename"`
+	URL       string `json:"url"`
+	MIMEType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// VariantFilename names the copy of filename resized to width, stored as ext.
+func VariantFilename(filename string, width int, ext string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + "-" + strconv.Itoa(width) + "w" + ext
+}
+
+// VariantOptions controls the resized copies made of JPG, PNG and GIF uploads: one per
+// width narrower than the original, JPGs re-encoded at JPEGQuality.
+type VariantOptions struct {
+	Widths      []int
+	JPEGQuality int
+}
+
+// RegenerateResult summarizes a rebuild of every file's variants.
+type RegenerateResult struct {
+	Files    int                 `json:"files"`
+	Variants int                 `json:"variants"`
+	Failures []RegenerateFailure `json:"failures,omitempty"`
+}
+
+// RegenerateFailure is a file whose variants could not be rebuilt.
+type RegenerateFailure struct {
+	Filename string `json:"filename"`
+	Error    string `json:"error"`
+}
+
+// URLFor returns the public URL path of a stored file.
+func URLFor(filename string) string {
+	return PathPrefix + filename
+}
+
+// Usage is a post, page, category or tag whose cover or content references a file.
+type Usage struct {
+	Kind  string `json:"kind"`
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+// MediaDetail is a file together with everything that references it.
+type MediaDetail struct {
+	Media
+	Usage []Usage `json:"usage"`
+}
+
+// UploadInput is a file being uploaded. Size is the size the client declared; the
+// stored size is what Body yields.
+type UploadInput struct {
+	Filename   string
+	Size       int64
+	Body       io.Reader
+	AltText    string
+	Caption    string
+	UploaderID int64
+}
+
+// Record carries the columns of a new media row.
+type Record struct {
+	Filename     string
+	OriginalName string
+	MIMEType     string
+	SizeBytes    int64
+	Width        int32
+	Height       int32
+	AltText      string
+	Caption      string
+	UploadedBy   *int64
+	Checksum     string
+}
+
+// TextInput carries the editable alt text and caption of a file.
+type TextInput struct {
+	AltText string `json:"alt_text"`
+	Caption string `json:"caption"`
+}
+
+// ListOptions pages through the library, optionally searching the original name, alt
+// text and caption. WithUsage fills in UsageCount, which searches all content for
+// every file listed.
+type ListOptions struct {
+	Query     string
+	Limit     int32
+	Offset    int32
+	WithUsage bool
+}
+
+// InUseError reports a delete refused because the file is still referenced.
+type InUseError struct {
+	Usage []Usage
+}
+
+func (e *InUseError) Error() string {
+	return "media: file is used by " + strconv.Itoa(len(e.Usage)) + " item(s)"
+}
+
+// Is makes errors.Is(err, ErrMediaInUse) match.
+func (e *InUseError) Is(target error) bool {
+	return target == ErrMediaInUse
+}
@@ -0,0 +1,27 @@
+package mediadomain
+
+import "context"
+
+// MediaRepository abstracts persistence of the media library. Files are returned with
+// their variants.
+type MediaRepository interface {
+	// ListMedia returns files newest first, with their usage counts when
+	// opts.WithUsage is set.
+	ListMedia(ctx context.Context, opts ListOptions) ([]Media, error)
+	GetMedia(ctx context.Context, id int64) (Media, error)
+	// GetMediaByChecksum returns the oldest file with the checksum.
+	GetMediaByChecksum(ctx context.Context, checksum string) (Media, error)
+	GetMediaByFilename(ctx context.Context, filename string) (Media, error)
+	CreateMedia(ctx context.Context, record Record) (Media, error)
+	UpdateMediaText(ctx context.Context, id int64, input TextInput) (Media, error)
+	DeleteMedia(ctx context.Context, id int64) error
+	// DeleteUnusedMedia deletes a file's record unless content references it, in which
+	// case it returns the references and keeps the record. Content cannot start using
+	// the file between the check and the delete.
+	DeleteUnusedMedia(ctx context.Context, id int64) ([]Usage, error)
+	// ReplaceMediaVariants makes variants the complete set of resized copies of a file.
+	ReplaceMediaVariants(ctx context.Context, mediaID int64, variants []Variant) error
+	// ListMediaUsage returns the posts, pages, categories and tags whose cover or
+	// content mentions the stored name of the file or of one of its variants.
+	ListMediaUsage(ctx context.Context, id int64) ([]Usage, error)
+}
@@ -0,0 +1,98 @@
+package mediadomain
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Limits on uploads and their text, sizes in bytes and lengths in characters. Images
+// are also limited in pixels, read from their header before they are decoded, since a
+// small file can expand to gigabytes.
+const (
+	MaxUploadBytes   = 5 << 20
+	MaxImagePixels   = 30_000_000
+	MaxImageSide     = 10_000
+	MaxAltTextLength = 300
+	MaxCaptionLength = 1000
+)
+
+// allowedTypes maps the accepted file extensions to the MIME type they are stored as.
+// The extension only filters uploads early; the stored type is sniffed from the content.
+var allowedTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+var (
+	ErrMediaNotFound = errors.New("media: file not found")
+	ErrMediaInUse    = errors.New("media: file is still referenced")
+
+	ErrFileRequired    = errors.New("file is required")
+	ErrFileTooLarge    = errors.New("file must be at most " + strconv.Itoa(MaxUploadBytes>>20) + " MB")
+	ErrFileUnsupported = errors.New("file must be a JPG, PNG, GIF or WebP image")
+	ErrFileNotImage    = errors.New("file content is not a valid JPG, PNG, GIF or WebP image")
+	ErrImageTooLarge   = errors.New("image must be at most " + strconv.Itoa(MaxImageSide) + " pixels wide and high and " + strconv.Itoa(MaxImagePixels/1_000_000) + " megapixels")
+	ErrAltTextTooLong  = errors.New("alt text must be at most " + strconv.Itoa(MaxAltTextLength) + " characters")
+	ErrCaptionTooLong  = errors.New("caption must be at most " + strconv.Itoa(MaxCaptionLength) + " characters")
+)
+
+// MIMEType returns the MIME type an upload named filename claims, or "" when its
+// extension is not accepted.
+func MIMEType(filename string) string {
+	return allowedTypes[strings.ToLower(filepath.Ext(filename))]
+}
+
+// Extension returns the extension a file of the accepted MIME type is stored with.
+func Extension(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	}
+	return ""
+}
+
+// ValidateText checks the alt text and caption of a file.
+func ValidateText(input TextInput) *validation.Error {
+	v := &validation.Error{}
+	checkText(v, input)
+	return v
+}
+
+// ValidateUpload checks an upload before it is read: its declared size, its extension
+// and its text. The content is checked once read.
+func ValidateUpload(input UploadInput) *validation.Error {
+	v := &validation.Error{}
+	switch {
+	case input.Body == nil || input.Size <= 0:
+		v.Add("file", ErrFileRequired)
+	case input.Size > MaxUploadBytes:
+		v.Add("file", ErrFileTooLarge)
+	case MIMEType(input.Filename) == "":
+		v.Add("file", ErrFileUnsupported)
+	}
+	checkText(v, TextInput{AltText: input.AltText, Caption: input.Caption})
+	return v
+}
+
+func checkText(v *validation.Error, input TextInput) {
+	if utf8.RuneCountInString(input.AltText) > MaxAltTextLength {
+		v.Add("alt_text", ErrAltTextTooLong)
+	}
+	if utf8.RuneCountInString(input.Caption) > MaxCaptionLength {
+		v.Add("caption", ErrCaptionTooLong)
+	}
+}
@@ -0,0 +1,481 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	"proto-gin-web/internal/platform/imaging"
+	"proto-gin-web/internal/platform/storage"
+	"proto-gin-web/internal/platform/validation"
+)
+
+const (
+	defaultPageSize int32 = 50
+	maxPageSize     int32 = 200
+
+	defaultJPEGQuality = 82
+	// originalJPEGQuality re-encodes uploaded JPEGs, dropping their EXIF data, with
+	// little visible loss.
+	originalJPEGQuality = 92
+	// imageCacheTTL bounds how stale a template's view of a file's variants can get
+	// when another instance regenerates them.
+	imageCacheTTL = 5 * time.Minute
+)
+
+var imageLimits = imaging.Limits{MaxPixels: mediadomain.MaxImagePixels, MaxSide: mediadomain.MaxImageSide}
+
+// MediaService manages the media library: uploaded files, their descriptions and the
+// content that uses them.
+type MediaService interface {
+	// Upload stores a file and records it. A file identical to one already in the
+	// library is not stored twice; the existing record is returned instead.
+	Upload(ctx context.Context, input mediadomain.UploadInput) (mediadomain.Media, error)
+	List(ctx context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error)
+	Get(ctx context.Context, id int64) (mediadomain.MediaDetail, error)
+	UpdateText(ctx context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error)
+	// Delete removes a file and its record. It fails with an *InUseError while a post,
+	// page, category or tag still references the file.
+	Delete(ctx context.Context, id int64) error
+	// RegenerateVariants rebuilds the resized copies of every file for the current
+	// widths, removing copies at widths no longer configured.
+	RegenerateVariants(ctx context.Context) (mediadomain.RegenerateResult, error)
+	ImageLookup
+}
+
+// ImageLookup resolves a public file URL, such as a post cover, to its library record so
+// templates can describe its variants and dimensions.
+type ImageLookup interface {
+	// Image reports false for URLs outside the library and for unknown files.
+	Image(ctx context.Context, url string) (mediadomain.Media, bool)
+}
+
+// Service implements MediaService, keeping files in a storage backend under their
+// stored names; they are served under mediadomain.PathPrefix.
+type Service struct {
+	repo    mediadomain.MediaRepository
+	store   storage.Storage
+	widths  []int
+	quality int
+	now     func() time.Time
+
+	mu     sync.Mutex
+	images map[string]cachedImage
+}
+
+type cachedImage struct {
+	media   mediadomain.Media
+	found   bool
+	expires time.Time
+}
+
+var _ MediaService = (*Service)(nil)
+
+// NewService wires the media repository and the file storage into a media library.
+// Invalid widths are dropped and an out-of-range quality falls back to the default.
+func NewService(repo mediadomain.MediaRepository, store storage.Storage, variants mediadomain.VariantOptions) *Service {
+	widths := make([]int, 0, len(variants.Widths))
+	for _, w := range variants.Widths {
+		if w > 0 {
+			widths = append(widths, w)
+		}
+	}
+	sort.Ints(widths)
+	widths = compactInts(widths)
+	quality := variants.JPEGQuality
+	if quality < 1 || quality > 100 {
+		quality = defaultJPEGQuality
+	}
+	return &Service{
+		repo:    repo,
+		store:   store,
+		widths:  widths,
+		quality: quality,
+		now:     time.Now,
+		images:  make(map[string]cachedImage),
+	}
+}
+
+func (s *Service) Upload(ctx context.Context, input mediadomain.UploadInput) (mediadomain.Media, error) {
+	input.Filename = filepath.Base(strings.TrimSpace(input.Filename))
+	input.AltText = strings.TrimSpace(input.AltText)
+	input.Caption = strings.TrimSpace(input.Caption)
+	if err := mediadomain.ValidateUpload(input).Err(); err != nil {
+		return mediadomain.Media{}, err
+	}
+	// the declared size may lie; read one byte past the limit to notice
+	data, err := io.ReadAll(io.LimitReader(input.Body, mediadomain.MaxUploadBytes+1))
+	if err != nil {
+		return mediadomain.Media{}, fmt.Errorf("read upload: %w", err)
+	}
+	if len(data) > mediadomain.MaxUploadBytes {
+		v := &validation.Error{}
+		v.Add("file", mediadomain.ErrFileTooLarge)
+		return mediadomain.Media{}, v
+	}
+
+	// the extension was only a hint: the content decides what is stored, and a file
+	// that does not decode completely is not served from our origin
+	info, err := imaging.Inspect(data, imageLimits)
+	if err != nil {
+		return mediadomain.Media{}, invalidImage(err)
+	}
+	img, err := imaging.Decode(data, info)
+	if err != nil {
+		return mediadomain.Media{}, invalidImage(err)
+	}
+
+	// the checksum is taken before re-encoding so the same upload is still recognized
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	existing, err := s.repo.GetMediaByChecksum(ctx, checksum)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mediadomain.ErrMediaNotFound) {
+		return mediadomain.Media{}, err
+	}
+
+	if info.MIMEType == "image/jpeg" {
+		// re-encoding keeps only the pixels, dropping EXIF data such as GPS positions
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: originalJPEGQuality}); err != nil {
+			return mediadomain.Media{}, fmt.Errorf("re-encode upload: %w", err)
+		}
+		data = buf.Bytes()
+	}
+	name, err := randomName()
+	if err != nil {
+		return mediadomain.Media{}, err
+	}
+	bounds := img.Bounds()
+	record := mediadomain.Record{
+		Filename:     name + mediadomain.Extension(info.MIMEType),
+		OriginalName: input.Filename,
+		MIMEType:     info.MIMEType,
+		SizeBytes:    int64(len(data)),
+		Width:        int32(bounds.Dx()),
+		Height:       int32(bounds.Dy()),
+		AltText:      input.AltText,
+		Caption:      input.Caption,
+		Checksum:     checksum,
+	}
+	if input.UploaderID > 0 {
+		uploader := input.UploaderID
+		record.UploadedBy = &uploader
+	}
+
+	if err := s.store.Put(ctx, record.Filename, bytes.NewReader(data), record.SizeBytes, record.MIMEType); err != nil {
+		return mediadomain.Media{}, fmt.Errorf("write upload: %w", err)
+	}
+	variants, err := s.writeVariants(ctx, record.Filename, img, info)
+	if err != nil {
+		s.removeFiles(ctx, record.Filename, nil)
+		return mediadomain.Media{}, err
+	}
+	media, err := s.repo.CreateMedia(ctx, record)
+	if err != nil {
+		s.removeFiles(ctx, record.Filename, variants)
+		return mediadomain.Media{}, err
+	}
+	if len(variants) > 0 {
+		if err := s.repo.ReplaceMediaVariants(ctx, media.ID, variants); err != nil {
+			_ = s.repo.DeleteMedia(ctx, media.ID)
+			s.removeFiles(ctx, record.Filename, variants)
+			return mediadomain.Media{}, err
+		}
+	}
+	media.Variants = variants
+	s.forget(media.Filename)
+	return media, nil
+}
+
+func (s *Service) List(ctx context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error) {
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Limit <= 0 {
+		opts.Limit = defaultPageSize
+	}
+	if opts.Limit > maxPageSize {
+		opts.Limit = maxPageSize
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+	opts.WithUsage = true
+	return s.repo.ListMedia(ctx, opts)
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (mediadomain.MediaDetail, error) {
+	media, err := s.repo.GetMedia(ctx, id)
+	if err != nil {
+		return mediadomain.MediaDetail{}, err
+	}
+	usage, err := s.repo.ListMediaUsage(ctx, media.ID)
+	if err != nil {
+		return mediadomain.MediaDetail{}, err
+	}
+	media.UsageCount = int64(len(usage))
+	return mediadomain.MediaDetail{Media: media, Usage: usage}, nil
+}
+
+func (s *Service) UpdateText(ctx context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error) {
+	input.AltText = strings.TrimSpace(input.AltText)
+	input.Caption = strings.TrimSpace(input.Caption)
+	if err := mediadomain.ValidateText(input).Err(); err != nil {
+		return mediadomain.Media{}, err
+	}
+	return s.repo.UpdateMediaText(ctx, id, input)
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	media, err := s.repo.GetMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+	usage, err := s.repo.DeleteUnusedMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(usage) > 0 {
+		return &mediadomain.InUseError{Usage: usage}
+	}
+	s.forget(media.Filename)
+	// the record is gone either way; a leftover file is only disk space
+	names := []string{media.Filename}
+	for _, v := range media.Variants {
+		names = append(names, v.Filename)
+	}
+	for _, name := range names {
+		if err := s.store.Delete(ctx, name); err != nil {
+			slog.Default().Warn("media: remove deleted file",
+				slog.String("filename", name),
+				slog.Any("err", err))
+		}
+	}
+	return nil
+}
+
+func (s *Service) RegenerateVariants(ctx context.Context) (mediadomain.RegenerateResult, error) {
+	// collect first so uploads made meanwhile cannot shift the pages; usage counts are
+	// not needed here and would search all content for every file
+	var all []mediadomain.Media
+	for offset := int32(0); ; offset += maxPageSize {
+		page, err := s.repo.ListMedia(ctx, mediadomain.ListOptions{Limit: maxPageSize, Offset: offset})
+		if err != nil {
+			return mediadomain.RegenerateResult{}, err
+		}
+		all = append(all, page...)
+		if int32(len(page)) < maxPageSize {
+			break
+		}
+	}
+
+	var result mediadomain.RegenerateResult
+	for _, media := range all {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if !resizable(media.Filename) {
+			continue
+		}
+		result.Files++
+		fail := func(err error) {
+			result.Failures = append(result.Failures, mediadomain.RegenerateFailure{Filename: media.Filename, Error: err.Error()})
+		}
+		data, err := s.read(ctx, media.Filename)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		var variants []mediadomain.Variant
+		info, err := imaging.Inspect(data, imageLimits)
+		var img image.Image
+		if err == nil {
+			img, err = imaging.Decode(data, info)
+		}
+		if err == nil {
+			if variants, err = s.writeVariants(ctx, media.Filename, img, info); err != nil {
+				fail(err)
+				continue
+			}
+		} else {
+			// reported, but any variants made from an earlier copy are still dropped
+			fail(err)
+		}
+		if err := s.repo.ReplaceMediaVariants(ctx, media.ID, variants); err != nil {
+			fail(err)
+			continue
+		}
+		s.forget(media.Filename)
+		result.Variants += len(variants)
+		kept := make(map[string]bool, len(variants))
+		for _, v := range variants {
+			kept[v.Filename] = true
+		}
+		for _, old := range media.Variants {
+			if !kept[old.Filename] {
+				if err := s.store.Delete(ctx, old.Filename); err != nil {
+					fail(err)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) Image(ctx context.Context, url string) (mediadomain.Media, bool) {
+	filename, ok := strings.CutPrefix(url, mediadomain.PathPrefix)
+	if !ok || filename == "" || strings.Contains(filename, "/") {
+		return mediadomain.Media{}, false
+	}
+	now := s.now()
+	s.mu.Lock()
+	cached, hit := s.images[filename]
+	s.mu.Unlock()
+	if hit && now.Before(cached.expires) {
+		return cached.media, cached.found
+	}
+
+	media, err := s.repo.GetMediaByFilename(ctx, filename)
+	if err != nil && !errors.Is(err, mediadomain.ErrMediaNotFound) {
+		// a failed lookup only costs the page its srcset; try again next render
+		return mediadomain.Media{}, false
+	}
+	entry := cachedImage{media: media, found: err == nil, expires: now.Add(imageCacheTTL)}
+	s.mu.Lock()
+	s.images[filename] = entry
+	s.mu.Unlock()
+	return entry.media, entry.found
+}
+
+func (s *Service) forget(filename string) {
+	s.mu.Lock()
+	delete(s.images, filename)
+	s.mu.Unlock()
+}
+
+// writeVariants stores a copy of the decoded image for each configured width narrower
+// than it. GIFs are resized from their only frame and stored as PNG; animated ones are
+// left alone since a single frame would lose the animation, and WebP has no encoder.
+func (s *Service) writeVariants(ctx context.Context, filename string, img image.Image, info imaging.Info) ([]mediadomain.Variant, error) {
+	if len(s.widths) == 0 || info.Frames > 1 || info.MIMEType == "image/webp" {
+		return nil, nil
+	}
+	outExt, mimeType := ".png", "image/png"
+	if info.MIMEType == "image/jpeg" {
+		outExt, mimeType = ".jpg", "image/jpeg"
+	}
+	// convert once rather than for every width
+	src := imaging.ToRGBA(img)
+	var (
+		variants []mediadomain.Variant
+		err      error
+	)
+	for _, width := range s.widths {
+		if width >= src.Rect.Dx() {
+			break
+		}
+		resized := imaging.Resize(src, width)
+		var buf bytes.Buffer
+		if outExt == ".jpg" {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: s.quality})
+		} else {
+			err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, resized)
+		}
+		if err != nil {
+			s.removeFiles(ctx, "", variants)
+			return nil, fmt.Errorf("encode %dw: %w", width, err)
+		}
+		name := mediadomain.VariantFilename(filename, width, outExt)
+		size := int64(buf.Len())
+		if err := s.store.Put(ctx, name, &buf, size, mimeType); err != nil {
+			s.removeFiles(ctx, "", variants)
+			return nil, fmt.Errorf("write variant: %w", err)
+		}
+		variants = append(variants, mediadomain.Variant{
+			Width:     int32(width),
+			Height:    int32(resized.Bounds().Dy()),
+			Filename:  name,
+			URL:       mediadomain.URLFor(name),
+			MIMEType:  mimeType,
+			SizeBytes: size,
+		})
+	}
+	return variants, nil
+}
+
+// removeFiles cleans up after a failed upload; errors are ignored as the upload
+// already failed.
+func (s *Service) removeFiles(ctx context.Context, filename string, variants []mediadomain.Variant) {
+	if filename != "" {
+		_ = s.store.Delete(ctx, filename)
+	}
+	for _, v := range variants {
+		_ = s.store.Delete(ctx, v.Filename)
+	}
+}
+
+func (s *Service) read(ctx context.Context, filename string) ([]byte, error) {
+	body, _, err := s.store.Get(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// randomName returns an unguessable stored file name that reveals nothing of the
+// uploaded one.
+func randomName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate file name: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// invalidImage reports content that failed inspection against the file field.
+func invalidImage(err error) error {
+	v := &validation.Error{}
+	if errors.Is(err, imaging.ErrTooLarge) {
+		v.Add("file", mediadomain.ErrImageTooLarge)
+	} else {
+		v.Add("file", mediadomain.ErrFileNotImage)
+	}
+	return v
+}
+
+// resizable reports whether a stored file can have variants, judged by its name since
+// regeneration only has the record.
+func resizable(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".jpg", ".jpeg", ".png", ".gif":
+		return true
+	}
+	return false
+}
+
+func compactInts(sorted []int) []int {
+	out := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
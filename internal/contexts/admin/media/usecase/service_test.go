@@ -0,0 +1,524 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	"proto-gin-web/internal/platform/storage"
+	"proto-gin-web/internal/platform/validation"
+)
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func uploadInput(name string, data []byte) mediadomain.UploadInput {
+	return mediadomain.UploadInput{Filename: name, Size: int64(len(data)), Body: bytes.NewReader(data)}
+}
+
+func TestUploadStoresFileAndRecord(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	data := pngBytes(t, 4, 3)
+
+	input := uploadInput("../Holiday.PNG", data)
+	input.AltText = "  A beach  "
+	input.UploaderID = 7
+	media, err := svc.Upload(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if media.OriginalName != "Holiday.PNG" || media.MIMEType != "image/png" || media.AltText != "A beach" {
+		t.Fatalf("unexpected record: %+v", media)
+	}
+	if media.Width != 4 || media.Height != 3 {
+		t.Fatalf("expected 4x3, got %dx%d", media.Width, media.Height)
+	}
+	if media.UploadedBy == nil || *media.UploadedBy != 7 {
+		t.Fatalf("expected uploader 7, got %v", media.UploadedBy)
+	}
+	if !strings.HasSuffix(media.Filename, ".png") || media.URL != mediadomain.PathPrefix+media.Filename {
+		t.Fatalf("unexpected filename %q url %q", media.Filename, media.URL)
+	}
+	stored, err := os.ReadFile(filepath.Join(dir, media.Filename))
+	if err != nil || !bytes.Equal(stored, data) {
+		t.Fatalf("expected the upload on disk, got err %v", err)
+	}
+}
+
+func TestUploadReusesIdenticalFile(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	data := pngBytes(t, 2, 2)
+
+	first, err := svc.Upload(context.Background(), uploadInput("a.png", data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.Upload(context.Background(), uploadInput("b.png", data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.ID != first.ID || len(repo.media) != 1 {
+		t.Fatalf("expected the existing record, got %+v", second)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected one stored file, got %d", len(entries))
+	}
+}
+
+func TestUploadValidates(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewService(&fakeMediaRepo{}, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+
+	_, err := svc.Upload(context.Background(), uploadInput("notes.txt", []byte("hello")))
+	if !errors.Is(err, mediadomain.ErrFileUnsupported) {
+		t.Fatalf("expected ErrFileUnsupported, got %v", err)
+	}
+
+	// a client that understates the size is caught while reading
+	big := make([]byte, mediadomain.MaxUploadBytes+1)
+	input := uploadInput("big.png", big)
+	input.Size = 10
+	_, err = svc.Upload(context.Background(), input)
+	var verr *validation.Error
+	if !errors.As(err, &verr) || !errors.Is(err, mediadomain.ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("expected nothing stored, got %d files", len(entries))
+	}
+}
+
+func TestUploadWritesVariantsNarrowerThanOriginal(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+
+	media, err := svc.Upload(context.Background(), uploadInput("wide.png", pngBytes(t, 6, 3)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// widths are normalised to [4 8]; 8 is wider than the original
+	if len(media.Variants) != 1 || repo.media[0].Variants == nil {
+		t.Fatalf("expected one recorded variant, got %+v", media.Variants)
+	}
+	v := media.Variants[0]
+	if v.Width != 4 || v.Height != 2 || v.MIMEType != "image/png" {
+		t.Fatalf("unexpected variant %+v", v)
+	}
+	stored, err := os.ReadFile(filepath.Join(dir, v.Filename))
+	if err != nil {
+		t.Fatalf("expected the variant on disk: %v", err)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(stored))
+	if err != nil || cfg.Width != 4 || cfg.Height != 2 {
+		t.Fatalf("expected a 4x2 png, got %+v (%v)", cfg, err)
+	}
+	if got := media.SrcSet(); got != v.URL+" 4w, "+media.URL+" 6w" {
+		t.Fatalf("unexpected srcset %q", got)
+	}
+}
+
+func TestUploadKeepsAnimatedGIFWithoutVariants(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	frame := func() *image.Paletted {
+		return image.NewPaletted(image.Rect(0, 0, 10, 10), color.Palette{color.Black, color.White})
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame(), frame()}, Delay: []int{10, 10}}); err != nil {
+		t.Fatal(err)
+	}
+
+	media, err := svc.Upload(context.Background(), uploadInput("spin.gif", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(media.Variants) != 0 {
+		t.Fatalf("expected no variants, got %+v", media.Variants)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected only the original stored, got %d files", len(entries))
+	}
+}
+
+func TestRegenerateVariantsReplacesStaleWidths(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	media, err := svc.Upload(context.Background(), uploadInput("a.png", pngBytes(t, 12, 6)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(media.Variants) != 2 {
+		t.Fatalf("expected 4w and 8w variants, got %+v", media.Variants)
+	}
+	repo.media = append(repo.media, mediadomain.Media{ID: 2, Filename: "missing.jpg"}, mediadomain.Media{ID: 3, Filename: "clip.webp"})
+
+	svc.widths = []int{6}
+	result, err := svc.RegenerateVariants(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Files != 2 || result.Variants != 1 || len(result.Failures) != 1 || result.Failures[0].Filename != "missing.jpg" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if repo.lastList.WithUsage {
+		t.Fatal("expected regeneration to list files without usage counts")
+	}
+	got := repo.media[0].Variants
+	if len(got) != 1 || got[0].Width != 6 || got[0].Height != 3 {
+		t.Fatalf("expected only the 6w variant recorded, got %+v", got)
+	}
+	for _, old := range media.Variants {
+		if _, err := os.Stat(filepath.Join(dir, old.Filename)); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected %s removed, got %v", old.Filename, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, got[0].Filename)); err != nil {
+		t.Fatalf("expected the new variant on disk: %v", err)
+	}
+}
+
+func TestImageLooksUpLibraryURLsAndCaches(t *testing.T) {
+	repo := &fakeMediaRepo{media: []mediadomain.Media{{ID: 1, Filename: "1.png", Width: 10}}}
+	svc := NewService(repo, storage.NewLocal(t.TempDir(), mediadomain.PathPrefix), mediadomain.VariantOptions{})
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for _, url := range []string{"https://example.com/1.png", mediadomain.PathPrefix + "x/1.png", ""} {
+		if _, ok := svc.Image(ctx, url); ok {
+			t.Fatalf("expected %q outside the library", url)
+		}
+	}
+	if repo.filenameLookups != 0 {
+		t.Fatalf("expected no lookups for foreign URLs, got %d", repo.filenameLookups)
+	}
+	for i := 0; i < 2; i++ {
+		if m, ok := svc.Image(ctx, mediadomain.PathPrefix+"1.png"); !ok || m.ID != 1 {
+			t.Fatalf("expected the record, got %+v %v", m, ok)
+		}
+		if _, ok := svc.Image(ctx, mediadomain.PathPrefix+"2.png"); ok {
+			t.Fatal("expected an unknown file to be missing")
+		}
+	}
+	if repo.filenameLookups != 2 {
+		t.Fatalf("expected cached results, got %d lookups", repo.filenameLookups)
+	}
+	svc.now = func() time.Time { return now.Add(imageCacheTTL) }
+	svc.Image(ctx, mediadomain.PathPrefix+"1.png")
+	if repo.filenameLookups != 3 {
+		t.Fatalf("expected an expired entry to be reloaded, got %d lookups", repo.filenameLookups)
+	}
+}
+
+func TestUploadJudgesContentNotExtension(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	ctx := context.Background()
+
+	for name, data := range map[string][]byte{
+		"page.png":   []byte("<!DOCTYPE html><html><script>alert(document.cookie)</script></html>"),
+		"logo.jpg":   []byte(`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`),
+		"broken.gif": []byte("GIF89a"),
+	} {
+		_, err := svc.Upload(ctx, uploadInput(name, data))
+		if !errors.Is(err, mediadomain.ErrFileNotImage) {
+			t.Errorf("%s: expected ErrFileNotImage, got %v", name, err)
+		}
+	}
+
+	// a header declaring 8000x8000 pixels is refused before anything is decoded
+	ihdr := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, 8000), 8000)
+	chunk := append([]byte("IHDR"), append(ihdr, 8, 6, 0, 0, 0)...)
+	bomb := binary.BigEndian.AppendUint32([]byte("\x89PNG\r\n\x1a\n"), 13)
+	bomb = binary.BigEndian.AppendUint32(append(bomb, chunk...), crc32.ChecksumIEEE(chunk))
+	if _, err := svc.Upload(ctx, uploadInput("bomb.png", bomb)); !errors.Is(err, mediadomain.ErrImageTooLarge) {
+		t.Fatalf("expected ErrImageTooLarge, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 || len(repo.media) != 0 {
+		t.Fatalf("expected nothing stored, got %d files", len(entries))
+	}
+
+	// a real image under the wrong extension is stored as what it is
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil); err != nil {
+		t.Fatal(err)
+	}
+	media, err := svc.Upload(ctx, uploadInput("photo.png", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if media.MIMEType != "image/jpeg" || !strings.HasSuffix(media.Filename, ".jpg") {
+		t.Fatalf("expected a JPEG record, got %q as %q", media.Filename, media.MIMEType)
+	}
+}
+
+func TestUploadStripsJPEGMetadataAndRandomizesName(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 6, 3)), nil); err != nil {
+		t.Fatal(err)
+	}
+	// APP1 with orientation 6 (turn clockwise) and a GPS tag pointer
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x02" +
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00" +
+		"\x88\x25\x00\x04\x00\x00\x00\x01\x00\x00\x00\x00" +
+		"\x00\x00\x00\x00")
+	data := append([]byte{0xFF, 0xD8, 0xFF, 0xE1}, binary.BigEndian.AppendUint16(nil, uint16(len(exif)+2))...)
+	data = append(append(data, exif...), buf.Bytes()[2:]...)
+
+	media, err := svc.Upload(context.Background(), uploadInput("Holiday in Lisbon.jpeg", data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if media.Width != 3 || media.Height != 6 {
+		t.Fatalf("expected the upright 3x6 size, got %dx%d", media.Width, media.Height)
+	}
+	if len(media.Filename) != 36 || !strings.HasSuffix(media.Filename, ".jpg") || strings.Contains(strings.ToLower(media.Filename), "lisbon") {
+		t.Fatalf("expected a random .jpg name, got %q", media.Filename)
+	}
+	if media.OriginalName != "Holiday in Lisbon.jpeg" {
+		t.Fatalf("expected the original name kept on the record, got %q", media.OriginalName)
+	}
+	stored, err := os.ReadFile(filepath.Join(dir, media.Filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stored, []byte("Exif")) || int64(len(stored)) != media.SizeBytes {
+		t.Fatalf("expected a re-encoded file without EXIF of the recorded size")
+	}
+	if cfg, err := jpeg.DecodeConfig(bytes.NewReader(stored)); err != nil || cfg.Width != 3 || cfg.Height != 6 {
+		t.Fatalf("expected an upright 3x6 JPEG, got %+v (%v)", cfg, err)
+	}
+}
+
+func TestUploadRemovesFileWhenRecordFails(t *testing.T) {
+	repo := &fakeMediaRepo{createErr: errors.New("db down")}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+
+	if _, err := svc.Upload(context.Background(), uploadInput("a.png", pngBytes(t, 20, 10))); err == nil || err.Error() != "db down" {
+		t.Fatalf("expected the repository error, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("expected the file removed, got %d files", len(entries))
+	}
+}
+
+func TestDeleteBlockedWhileReferenced(t *testing.T) {
+	repo := &fakeMediaRepo{usage: map[int64][]mediadomain.Usage{
+		1: {{Kind: mediadomain.UsagePost, Slug: "hello", Title: "Hello"}},
+	}}
+	repo.media = []mediadomain.Media{{ID: 1, Filename: "1.png"}}
+	dir := t.TempDir()
+	svc := NewService(repo, storage.NewLocal(dir, mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+	path := filepath.Join(dir, "1.png")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := svc.Delete(context.Background(), 1)
+	var inUse *mediadomain.InUseError
+	if !errors.As(err, &inUse) || !errors.Is(err, mediadomain.ErrMediaInUse) {
+		t.Fatalf("expected InUseError, got %v", err)
+	}
+	if len(inUse.Usage) != 1 || inUse.Usage[0].Slug != "hello" {
+		t.Fatalf("expected the referencing post, got %+v", inUse.Usage)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the file kept, got %v", err)
+	}
+
+	delete(repo.usage, 1)
+	if err := svc.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.media) != 0 {
+		t.Fatal("expected the record deleted")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the file removed, got %v", err)
+	}
+}
+
+func TestDeleteSucceedsWhenFileRemovalFails(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	repo.media = []mediadomain.Media{{ID: 1, Filename: "1.png", Variants: []mediadomain.Variant{{Width: 4, Filename: "1-4w.png"}}}}
+	store := failingDeleteStorage{Storage: storage.NewLocal(t.TempDir(), mediadomain.PathPrefix)}
+	svc := NewService(repo, store, mediadomain.VariantOptions{})
+
+	if err := svc.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("expected the delete to succeed, got %v", err)
+	}
+	if len(repo.media) != 0 {
+		t.Fatal("expected the record deleted")
+	}
+}
+
+type failingDeleteStorage struct {
+	storage.Storage
+}
+
+func (failingDeleteStorage) Delete(context.Context, string) error {
+	return errors.New("bucket unavailable")
+}
+
+func TestListClampsLimit(t *testing.T) {
+	repo := &fakeMediaRepo{}
+	svc := NewService(repo, storage.NewLocal(t.TempDir(), mediadomain.PathPrefix), mediadomain.VariantOptions{Widths: []int{8, 4, 4, 0}, JPEGQuality: 500})
+
+	if _, err := svc.List(context.Background(), mediadomain.ListOptions{Query: " cat ", Limit: 5000, Offset: -3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.lastList; got.Query != "cat" || got.Limit != maxPageSize || got.Offset != 0 {
+		t.Fatalf("unexpected options %+v", got)
+	}
+	if _, err := svc.List(context.Background(), mediadomain.ListOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastList.Limit != defaultPageSize || !repo.lastList.WithUsage {
+		t.Fatalf("expected default limit with usage counts, got %+v", repo.lastList)
+	}
+}
+
+type fakeMediaRepo struct {
+	media     []mediadomain.Media
+	usage     map[int64][]mediadomain.Usage
+	createErr error
+	lastList  mediadomain.ListOptions
+
+	filenameLookups int
+}
+
+func (f *fakeMediaRepo) ListMedia(_ context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error) {
+	f.lastList = opts
+	if int(opts.Offset) >= len(f.media) {
+		return nil, nil
+	}
+	end := min(int(opts.Offset+opts.Limit), len(f.media))
+	return f.media[opts.Offset:end], nil
+}
+
+func (f *fakeMediaRepo) GetMedia(_ context.Context, id int64) (mediadomain.Media, error) {
+	for _, m := range f.media {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return mediadomain.Media{}, mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) GetMediaByChecksum(_ context.Context, checksum string) (mediadomain.Media, error) {
+	for _, m := range f.media {
+		if m.Checksum == checksum {
+			return m, nil
+		}
+	}
+	return mediadomain.Media{}, mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) GetMediaByFilename(_ context.Context, filename string) (mediadomain.Media, error) {
+	f.filenameLookups++
+	for _, m := range f.media {
+		if m.Filename == filename {
+			return m, nil
+		}
+	}
+	return mediadomain.Media{}, mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) ReplaceMediaVariants(_ context.Context, mediaID int64, variants []mediadomain.Variant) error {
+	for i, m := range f.media {
+		if m.ID == mediaID {
+			f.media[i].Variants = variants
+			return nil
+		}
+	}
+	return mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) CreateMedia(_ context.Context, record mediadomain.Record) (mediadomain.Media, error) {
+	if f.createErr != nil {
+		return mediadomain.Media{}, f.createErr
+	}
+	m := mediadomain.Media{
+		ID:           int64(len(f.media) + 1),
+		Filename:     record.Filename,
+		OriginalName: record.OriginalName,
+		URL:          mediadomain.URLFor(record.Filename),
+		MIMEType:     record.MIMEType,
+		SizeBytes:    record.SizeBytes,
+		Width:        record.Width,
+		Height:       record.Height,
+		AltText:      record.AltText,
+		Caption:      record.Caption,
+		UploadedBy:   record.UploadedBy,
+		Checksum:     record.Checksum,
+	}
+	f.media = append(f.media, m)
+	return m, nil
+}
+
+func (f *fakeMediaRepo) UpdateMediaText(_ context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error) {
+	for i, m := range f.media {
+		if m.ID == id {
+			f.media[i].AltText, f.media[i].Caption = input.AltText, input.Caption
+			return f.media[i], nil
+		}
+	}
+	return mediadomain.Media{}, mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) DeleteMedia(_ context.Context, id int64) error {
+	for i, m := range f.media {
+		if m.ID == id {
+			f.media = append(f.media[:i], f.media[i+1:]...)
+			return nil
+		}
+	}
+	return mediadomain.ErrMediaNotFound
+}
+
+func (f *fakeMediaRepo) DeleteUnusedMedia(ctx context.Context, id int64) ([]mediadomain.Usage, error) {
+	if usage := f.usage[id]; len(usage) > 0 {
+		return usage, nil
+	}
+	return nil, f.DeleteMedia(ctx, id)
+}
+
+func (f *fakeMediaRepo) ListMediaUsage(_ context.Context, id int64) ([]mediadomain.Usage, error) {
+	return f.usage[id], nil
+}
@@ -0,0 +1,57 @@
+package adminuihttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/config"
+)
+
+// registerCategoryUIRoutes mounts the category list and edit form under the already
+// guarded admin group.
+func registerCategoryUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/categories", func(c *gin.Context) {
+		categories, err := svc.ListCategories(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list categories", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminCategoriesPage(c, cfg, categories)
+	})
+
+	admin.GET("/categories/:slug/edit", func(c *gin.Context) {
+		category, err := svc.GetCategory(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			redirectWithError(c, "/admin/ui/categories", taxonomyErrorMessage(err, "failed to load category"), err)
+			return
+		}
+		categories, err := svc.ListCategories(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list categories", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminCategoryForm(c, cfg, category.Category, categories)
+	})
+
+	admin.POST("/categories/:slug/edit", func(c *gin.Context) {
+		slug := c.Param("slug")
+		parent := c.PostForm("parent")
+		category, err := svc.UpdateCategory(c.Request.Context(), slug, taxdomain.UpdateCategoryInput{
+			Name:       c.PostForm("name"),
+			Slug:       c.PostForm("slug"),
+			ParentSlug: &parent,
+			Details:    formTaxonomyDetails(c),
+		})
+		if err != nil {
+			redirectWithError(c, "/admin/ui/categories/"+slug+"/edit", taxonomyErrorMessage(err, "failed to save category"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/categories/"+category.Slug+"/edit", "category saved")
+	})
+}
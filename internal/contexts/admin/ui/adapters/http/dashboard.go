@@ -5,12 +5,13 @@ import (
 
 	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
 	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/ctxkeys"
 )
 
 func registerDashboardRoutes(group *gin.RouterGroup, cfg config.Config) {
 	group.GET("", func(c *gin.Context) {
 		userName := "Admin"
-		if profile, ok := adminProfileFromContext(c); ok && profile.DisplayName != "" {
+		if profile, ok := ctxkeys.AdminProfileFromContext(c); ok && profile.DisplayName != "" {
 			userName = profile.DisplayName
 		}
 		adminview.AdminDashboard(c, cfg, userName, c.Query("registered") == "1")
@@ -0,0 +1,43 @@
+package adminuihttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	"proto-gin-web/internal/platform/config"
+)
+
+// registerLinkUIRoutes mounts the broken link report under the already guarded admin group.
+func registerLinkUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/link-report", func(c *gin.Context) {
+		findings, err := svc.LinkReport(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "link report", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminLinkReport(c, cfg, findings)
+	})
+}
+
+// savedPostMessage checks the links of a saved post and mentions broken ones in the
+// success message. The check only warns, so a failure is logged and the save stands.
+func savedPostMessage(c *gin.Context, svc *adminuisvc.Service, slug, message string) string {
+	findings, err := svc.CheckPostLinks(c.Request.Context(), slug)
+	if err != nil {
+		logAdminUIError(c, "check post links", err)
+		return message
+	}
+	switch len(findings) {
+	case 0:
+		return message
+	case 1:
+		return message + ", but it has 1 broken link"
+	default:
+		return fmt.Sprintf("%s, but it has %d broken links", message, len(findings))
+	}
+}
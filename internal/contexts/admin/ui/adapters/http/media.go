@@ -0,0 +1,156 @@
+package adminuihttp
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// mediaPageSize is the number of files per media library page.
+const mediaPageSize = 48
+
+// registerMediaUIRoutes mounts the media library pages under the already guarded admin
+// group.
+func registerMediaUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/media", func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		if page < 1 {
+			page = 1
+		}
+		query := strings.TrimSpace(c.Query("q"))
+		// one extra row tells whether a next page exists
+		media, err := svc.ListMedia(c.Request.Context(), mediadomain.ListOptions{
+			Query:  query,
+			Limit:  mediaPageSize + 1,
+			Offset: int32((page - 1) * mediaPageSize),
+		})
+		if err != nil {
+			logAdminUIError(c, "list media", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		hasNext := len(media) > mediaPageSize
+		if hasNext {
+			media = media[:mediaPageSize]
+		}
+		adminview.AdminMediaPage(c, cfg, media, query, page, hasNext)
+	})
+
+	admin.POST("/media", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			handleAdminProfileError(c, errMissingAdminEmail)
+			return
+		}
+		file, err := c.FormFile("file")
+		if err != nil {
+			redirectWithError(c, "/admin/ui/media", "choose a file to upload", err)
+			return
+		}
+		media, err := uploadMediaFile(c, svc, file, mediadomain.UploadInput{
+			AltText:    c.PostForm("alt_text"),
+			Caption:    c.PostForm("caption"),
+			UploaderID: profile.ID,
+		})
+		if err != nil {
+			redirectWithError(c, "/admin/ui/media", mediaErrorMessage(err, "failed to upload file"), err)
+			return
+		}
+		redirectWithSuccess(c, mediaPath(media.ID), media.OriginalName+" uploaded")
+	})
+
+	admin.GET("/media/:id", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.String(http.StatusNotFound, "file not found")
+			return
+		}
+		detail, err := svc.GetMedia(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, mediadomain.ErrMediaNotFound) {
+				c.String(http.StatusNotFound, "file not found")
+				return
+			}
+			logAdminUIError(c, "get media", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminMediaItem(c, cfg, detail)
+	})
+
+	admin.POST("/media/:id", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/media", "invalid file", err)
+			return
+		}
+		input := mediadomain.TextInput{AltText: c.PostForm("alt_text"), Caption: c.PostForm("caption")}
+		if _, err := svc.UpdateMedia(c.Request.Context(), id, input); err != nil {
+			redirectWithError(c, mediaPath(id), mediaErrorMessage(err, "failed to update file"), err)
+			return
+		}
+		redirectWithSuccess(c, mediaPath(id), "file updated")
+	})
+
+	admin.POST("/media/:id/delete", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/media", "invalid file", err)
+			return
+		}
+		if err := svc.DeleteMedia(c.Request.Context(), id); err != nil {
+			redirectWithError(c, mediaPath(id), mediaErrorMessage(err, "failed to delete file"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/media", "file deleted")
+	})
+}
+
+func mediaPath(id int64) string {
+	return "/admin/ui/media/" + strconv.FormatInt(id, 10)
+}
+
+// uploadMediaFile adds an uploaded form file to the media library; input supplies the
+// text and uploader.
+func uploadMediaFile(c *gin.Context, svc *adminuisvc.Service, file *multipart.FileHeader, input mediadomain.UploadInput) (mediadomain.Media, error) {
+	body, err := file.Open()
+	if err != nil {
+		return mediadomain.Media{}, err
+	}
+	defer body.Close()
+	input.Filename, input.Size, input.Body = file.Filename, file.Size, body
+	return svc.UploadMedia(c.Request.Context(), input)
+}
+
+// mediaErrorMessage turns media validation failures and refused deletes into a flash
+// message; other errors fall back to the generic message.
+func mediaErrorMessage(err error, fallback string) string {
+	var inUse *mediadomain.InUseError
+	if errors.As(err, &inUse) {
+		names := make([]string, 0, len(inUse.Usage))
+		for _, u := range inUse.Usage {
+			names = append(names, u.Kind+" "+u.Title)
+		}
+		return "file is still used by " + strings.Join(names, ", ")
+	}
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return fallback
+	}
+	parts := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,130 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// registerMenuUIRoutes mounts the SSR menu editor under the already guarded admin group.
+// Drag-reorder in the editor talks to the JSON endpoint PUT /admin/menus/:name/order.
+func registerMenuUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/menus", func(c *gin.Context) {
+		menus, err := svc.ListMenus(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list menus", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminMenusPage(c, cfg, menus)
+	})
+
+	admin.POST("/menus", func(c *gin.Context) {
+		input := menudomain.MenuInput{Name: c.PostForm("name"), Label: c.PostForm("label")}
+		menu, err := svc.CreateMenu(c.Request.Context(), input)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/menus", menuErrorMessage(err, "failed to create menu"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/menus/"+menu.Name, "menu created")
+	})
+
+	admin.GET("/menus/:name", func(c *gin.Context) {
+		result, err := svc.GetMenu(c.Request.Context(), c.Param("name"))
+		if err != nil {
+			if errors.Is(err, menudomain.ErrMenuNotFound) {
+				c.String(http.StatusNotFound, "menu not found")
+				return
+			}
+			logAdminUIError(c, "get menu", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminMenuEditor(c, cfg, result)
+	})
+
+	admin.POST("/menus/:name/delete", func(c *gin.Context) {
+		if err := svc.DeleteMenu(c.Request.Context(), c.Param("name")); err != nil {
+			redirectWithError(c, "/admin/ui/menus", "failed to delete menu", err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/menus", "menu deleted")
+	})
+
+	admin.POST("/menus/:name/items", func(c *gin.Context) {
+		editorPath := "/admin/ui/menus/" + c.Param("name")
+		if _, err := svc.CreateMenuItem(c.Request.Context(), c.Param("name"), menuItemForm(c)); err != nil {
+			redirectWithError(c, editorPath, menuErrorMessage(err, "failed to add menu item"), err)
+			return
+		}
+		redirectWithSuccess(c, editorPath, "menu item added")
+	})
+
+	admin.POST("/menus/:name/items/:id", func(c *gin.Context) {
+		editorPath := "/admin/ui/menus/" + c.Param("name")
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, editorPath, "invalid menu item", err)
+			return
+		}
+		if _, err := svc.UpdateMenuItem(c.Request.Context(), c.Param("name"), id, menuItemForm(c)); err != nil {
+			redirectWithError(c, editorPath, menuErrorMessage(err, "failed to update menu item"), err)
+			return
+		}
+		redirectWithSuccess(c, editorPath, "menu item updated")
+	})
+
+	admin.POST("/menus/:name/items/:id/delete", func(c *gin.Context) {
+		editorPath := "/admin/ui/menus/" + c.Param("name")
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, editorPath, "invalid menu item", err)
+			return
+		}
+		if err := svc.DeleteMenuItem(c.Request.Context(), c.Param("name"), id); err != nil {
+			redirectWithError(c, editorPath, "failed to delete menu item", err)
+			return
+		}
+		redirectWithSuccess(c, editorPath, "menu item deleted")
+	})
+}
+
+// menuItemForm reads the shared add/edit item form. A blank parent keeps the item at
+// the top level.
+func menuItemForm(c *gin.Context) menudomain.ItemInput {
+	input := menudomain.ItemInput{
+		Label:      c.PostForm("label"),
+		TargetType: c.PostForm("target_type"),
+		Target:     c.PostForm("target"),
+		URL:        c.PostForm("url"),
+		Position:   formPosition(c),
+	}
+	if raw := strings.TrimSpace(c.PostForm("parent_id")); raw != "" {
+		if id, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			input.ParentID = &id
+		}
+	}
+	return input
+}
+
+// menuErrorMessage is the menu counterpart of postErrorMessage.
+func menuErrorMessage(err error, fallback string) string {
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return fallback
+	}
+	parts := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
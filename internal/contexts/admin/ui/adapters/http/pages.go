@@ -0,0 +1,119 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// registerPageUIRoutes mounts the SSR page editor under the already guarded admin group.
+func registerPageUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/pages", func(c *gin.Context) {
+		pages, err := svc.ListPages(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list pages", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminPagesPage(c, cfg, pages)
+	})
+
+	admin.GET("/pages/new", func(c *gin.Context) {
+		pages, err := svc.ListPages(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list pages", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminPageFormNew(c, cfg, pages)
+	})
+
+	admin.POST("/pages/new", func(c *gin.Context) {
+		input := pagedomain.CreatePageInput{
+			Title:      c.PostForm("title"),
+			Slug:       c.PostForm("slug"),
+			Summary:    c.PostForm("summary"),
+			ContentMD:  c.PostForm("content_md"),
+			Template:   c.PostForm("template"),
+			Status:     c.DefaultPostForm("status", pagedomain.StatusDraft),
+			ParentSlug: c.PostForm("parent"),
+			Position:   formPosition(c),
+		}
+		if _, err := svc.CreatePage(c.Request.Context(), input); err != nil {
+			redirectWithError(c, "/admin/ui/pages/new", pageErrorMessage(err, "failed to create page"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/pages", "page created")
+	})
+
+	admin.GET("/pages/:slug/edit", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		result, err := svc.GetPage(ctx, c.Param("slug"))
+		if err != nil {
+			c.String(http.StatusNotFound, "page not found")
+			return
+		}
+		pages, err := svc.ListPages(ctx)
+		if err != nil {
+			logAdminUIError(c, "list pages", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminPageFormEdit(c, cfg, result, pages)
+	})
+
+	admin.POST("/pages/:slug", func(c *gin.Context) {
+		input := pagedomain.UpdatePageInput{
+			Slug:       c.Param("slug"),
+			Title:      c.PostForm("title"),
+			Summary:    c.PostForm("summary"),
+			ContentMD:  c.PostForm("content_md"),
+			Template:   c.PostForm("template"),
+			Status:     c.DefaultPostForm("status", pagedomain.StatusDraft),
+			ParentSlug: c.PostForm("parent"),
+			Position:   formPosition(c),
+		}
+		editPath := "/admin/ui/pages/" + input.Slug + "/edit"
+		if _, err := svc.UpdatePage(c.Request.Context(), input); err != nil {
+			redirectWithError(c, editPath, pageErrorMessage(err, "failed to update page"), err)
+			return
+		}
+		redirectWithSuccess(c, editPath, "page updated")
+	})
+
+	admin.POST("/pages/:slug/delete", func(c *gin.Context) {
+		if err := svc.DeletePage(c.Request.Context(), c.Param("slug")); err != nil {
+			redirectWithError(c, "/admin/ui/pages", "failed to delete page", err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/pages", "page deleted")
+	})
+}
+
+// formPosition reads the optional ordering input; blank or malformed values mean 0.
+func formPosition(c *gin.Context) int32 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(c.PostForm("position")), 10, 32)
+	return int32(n)
+}
+
+// pageErrorMessage is the page counterpart of postErrorMessage.
+func pageErrorMessage(err error, fallback string) string {
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return fallback
+	}
+	parts := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -14,13 +14,14 @@ import (
 	authsession "proto-gin-web/internal/contexts/admin/auth/session"
 	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
 	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/ctxkeys"
 	platformview "proto-gin-web/internal/platform/http/view"
 )
 
 func registerProfileRoutes(group *gin.RouterGroup, cfg config.Config, adminSvc adminusecase.AdminService, sessionMgr *authsession.Manager) {
 	group.GET("/profile", func(c *gin.Context) {
 		isForm := platformview.WantsHTMLResponse(c)
-		profile, ok := adminProfileFromContext(c)
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
 		if !ok {
 			if isForm {
 				c.Redirect(http.StatusFound, "/admin/login?error="+url.QueryEscape("please login first"))
@@ -35,7 +36,7 @@ func registerProfileRoutes(group *gin.RouterGroup, cfg config.Config, adminSvc a
 
 	group.POST("/profile", func(c *gin.Context) {
 		isForm := platformview.WantsHTMLResponse(c)
-		sessionProfile, ok := adminProfileFromContext(c)
+		sessionProfile, ok := ctxkeys.AdminProfileFromContext(c)
 		if !ok {
 			if isForm {
 				c.Redirect(http.StatusFound, "/admin/login?error="+url.QueryEscape("please login first"))
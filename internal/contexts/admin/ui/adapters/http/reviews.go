@@ -0,0 +1,100 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+)
+
+// registerReviewUIRoutes mounts the review panel actions of the post editor and the
+// review queue page under the already guarded admin group.
+func registerReviewUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/reviews", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			handleAdminProfileError(c, errMissingAdminEmail)
+			return
+		}
+		queue, err := svc.ReviewQueue(c.Request.Context(), profile.ID)
+		if err != nil {
+			if errors.Is(err, postdomain.ErrWorkflowForbidden) {
+				c.String(http.StatusForbidden, "your role has no review queue")
+				return
+			}
+			logAdminUIError(c, "review queue", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminReviewQueue(c, cfg, queue)
+	})
+
+	admin.POST("/posts/:slug/transition", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			handleAdminProfileError(c, errMissingAdminEmail)
+			return
+		}
+		back := "/admin/ui/posts/" + c.Param("slug") + "/edit"
+		post, err := svc.TransitionPost(c.Request.Context(), postdomain.TransitionInput{
+			Slug:    c.Param("slug"),
+			ActorID: profile.ID,
+			Status:  c.PostForm("status"),
+			Comment: c.PostForm("comment"),
+		})
+		if err != nil {
+			redirectWithError(c, back, postErrorMessage(err, "failed to change status"), err)
+			return
+		}
+		redirectWithSuccess(c, back, "status changed to "+post.Status)
+	})
+
+	admin.POST("/posts/:slug/reviewer", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			handleAdminProfileError(c, errMissingAdminEmail)
+			return
+		}
+		back := "/admin/ui/posts/" + c.Param("slug") + "/edit"
+		var reviewerID int64
+		if raw := strings.TrimSpace(c.PostForm("reviewer_id")); raw != "" {
+			id, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				redirectWithError(c, back, "invalid reviewer", err)
+				return
+			}
+			reviewerID = id
+		}
+		if err := svc.AssignReviewer(c.Request.Context(), c.Param("slug"), profile.ID, reviewerID); err != nil {
+			redirectWithError(c, back, postErrorMessage(err, "failed to assign reviewer"), err)
+			return
+		}
+		if reviewerID == 0 {
+			redirectWithSuccess(c, back, "reviewer unassigned")
+			return
+		}
+		redirectWithSuccess(c, back, "reviewer assigned")
+	})
+
+	admin.POST("/posts/:slug/comments", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			handleAdminProfileError(c, errMissingAdminEmail)
+			return
+		}
+		back := "/admin/ui/posts/" + c.Param("slug") + "/edit"
+		if err := svc.CommentOnPost(c.Request.Context(), c.Param("slug"), profile.ID, c.PostForm("body")); err != nil {
+			redirectWithError(c, back, postErrorMessage(err, "failed to add comment"), err)
+			return
+		}
+		redirectWithSuccess(c, back, "comment added")
+	})
+}
@@ -15,6 +15,7 @@ func RegisterRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Admi
 	uiGroup := r.Group("/admin", sessionGuard)
 	registerDashboardRoutes(uiGroup, cfg)
 	registerProfileRoutes(uiGroup, cfg, adminSvc, sessionMgr)
+	registerUserRoutes(uiGroup, adminSvc)
 }
 
 
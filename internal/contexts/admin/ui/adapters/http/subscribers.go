@@ -0,0 +1,32 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	"proto-gin-web/internal/platform/config"
+)
+
+// registerSubscriberUIRoutes mounts the newsletter subscriber list under the already
+// guarded admin group. The CSV export is served by the admin API.
+func registerSubscriberUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/subscribers", func(c *gin.Context) {
+		status := c.Query("status")
+		subs, err := svc.ListSubscribers(c.Request.Context(), status)
+		if err != nil {
+			if errors.Is(err, newsletterdomain.ErrStatusInvalid) {
+				c.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			logAdminUIError(c, "list subscribers", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminSubscribersPage(c, cfg, status, subs)
+	})
+}
@@ -0,0 +1,122 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/config"
+)
+
+// registerTagUIRoutes mounts the tag list and edit form, and the cleanup tools that
+// merge look-alike tags and manage the aliases of merged tags, under the already
+// guarded admin group.
+func registerTagUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/tags", func(c *gin.Context) {
+		tags, err := svc.ListTags(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list tags", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		groups, err := svc.ListDuplicateTags(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list duplicate tags", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		aliases, err := svc.ListTagAliases(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list tag aliases", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminTagsPage(c, cfg, tags, groups, aliases)
+	})
+
+	admin.GET("/tags/:slug/edit", func(c *gin.Context) {
+		tag, err := svc.GetTag(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			redirectWithError(c, "/admin/ui/tags", taxonomyErrorMessage(err, "failed to load tag"), err)
+			return
+		}
+		adminview.AdminTagForm(c, cfg, tag.Tag)
+	})
+
+	admin.POST("/tags/:slug/edit", func(c *gin.Context) {
+		slug := c.Param("slug")
+		tag, err := svc.UpdateTag(c.Request.Context(), slug, taxdomain.UpdateTagInput{
+			Name:    c.PostForm("name"),
+			Slug:    c.PostForm("slug"),
+			Details: formTaxonomyDetails(c),
+		})
+		if err != nil {
+			redirectWithError(c, "/admin/ui/tags/"+slug+"/edit", taxonomyErrorMessage(err, "failed to save tag"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/tags/"+tag.Slug+"/edit", "tag saved")
+	})
+
+	admin.POST("/tags/merge", func(c *gin.Context) {
+		tag, err := svc.MergeTags(c.Request.Context(), c.PostForm("target"), c.PostFormArray("tags"))
+		if err != nil {
+			redirectWithError(c, "/admin/ui/tags", taxonomyErrorMessage(err, "failed to merge tags"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/tags", "tags merged into "+tag.Name)
+	})
+
+	admin.POST("/tags/aliases/:slug/delete", func(c *gin.Context) {
+		if err := svc.DeleteTagAlias(c.Request.Context(), c.Param("slug")); err != nil {
+			redirectWithError(c, "/admin/ui/tags", taxonomyErrorMessage(err, "failed to delete alias"), err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/tags", "alias "+c.Param("slug")+" deleted")
+	})
+}
+
+// formTaxonomyDetails reads the landing page fields shared by the category and tag
+// forms. Every field is posted, so blank inputs clear the stored value.
+func formTaxonomyDetails(c *gin.Context) taxdomain.DetailsPatch {
+	description := c.PostForm("description_md")
+	cover := c.PostForm("cover_url")
+	metaTitle := c.PostForm("meta_title")
+	metaDescription := c.PostForm("meta_description")
+	position := formPosition(c)
+	return taxdomain.DetailsPatch{
+		DescriptionMD:   &description,
+		CoverURL:        &cover,
+		MetaTitle:       &metaTitle,
+		MetaDescription: &metaDescription,
+		Position:        &position,
+	}
+}
+
+// taxonomyErrorMessage is the category and tag counterpart of postErrorMessage.
+func taxonomyErrorMessage(err error, fallback string) string {
+	switch {
+	case errors.Is(err, taxdomain.ErrMergeSourceRequired):
+		return "check at least one tag besides the target"
+	case errors.Is(err, taxdomain.ErrTagNotFound):
+		return "tag not found; reload the page"
+	case errors.Is(err, taxdomain.ErrCategoryNotFound):
+		return "category not found; reload the page"
+	case errors.Is(err, taxdomain.ErrAliasNotFound):
+		return "alias not found"
+	case errors.Is(err, taxdomain.ErrNameRequired), errors.Is(err, taxdomain.ErrSlugRequired),
+		errors.Is(err, taxdomain.ErrParentNotFound), errors.Is(err, taxdomain.ErrParentCycle),
+		errors.Is(err, taxdomain.ErrCoverInvalid):
+		return strings.TrimPrefix(err.Error(), "taxonomy: ")
+	case errors.Is(err, taxdomain.ErrNameTaken):
+		return "name already in use"
+	case errors.Is(err, taxdomain.ErrSlugTaken):
+		return "slug already in use"
+	default:
+		return fallback
+	}
+}
@@ -2,15 +2,10 @@
 
 import (
 	"errors"
-	"fmt"
 	"log/slog"
-	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -19,7 +14,11 @@ import (
 	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
 	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
 	adminusecase "proto-gin-web/internal/contexts/admin/auth/usecase"
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+	"proto-gin-web/internal/platform/validation"
 )
 
 // RegisterUIRoutes mounts legacy SSR pages that still live inside the admin context.
@@ -43,17 +42,34 @@ func RegisterUIRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Ad
 		})
 
 		admin.GET("/posts/new", func(c *gin.Context) {
-			adminview.AdminPostFormNew(c, cfg)
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
+			fields, err := svc.NewPostFields(c.Request.Context())
+			if err != nil {
+				logAdminUIError(c, "list custom fields", err)
+				c.String(http.StatusInternalServerError, "internal server error")
+				return
+			}
+			role, err := svc.Role(c.Request.Context(), profile.ID)
+			if err != nil {
+				logAdminUIError(c, "resolve role", err)
+				c.String(http.StatusInternalServerError, "internal server error")
+				return
+			}
+			adminview.AdminPostFormNew(c, cfg, fields, postdomain.NextStatuses(role, ""))
 		})
 
 		admin.POST("/posts/new", func(c *gin.Context) {
-			profile, ok := adminProfileFromContext(c)
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
 			if !ok {
 				handleAdminProfileError(c, errMissingAdminEmail)
 				return
 			}
 
-			coverURL, err := resolveCoverInput(c)
+			coverURL, err := resolveCoverInput(c, svc, profile.ID)
 			if err != nil {
 				redirectWithError(c, "/admin/ui/posts/new", err.Error(), err)
 				return
@@ -66,6 +82,7 @@ func RegisterUIRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Ad
 			status := c.DefaultPostForm("status", "draft")
 
 			params := adminuisvc.CreatePostParams{
+				ActorID:   profile.ID,
 				Title:     title,
 				Slug:      slug,
 				Summary:   summary,
@@ -73,43 +90,92 @@ func RegisterUIRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Ad
 				CoverURL:  coverURL,
 				Status:    status,
 				AuthorID:  profile.ID,
+				// custom field inputs are named field[<key>]
+				CustomFields: c.PostFormMap("field"),
+				UnpublishAt:  c.PostForm("unpublish_at"),
+				ExpiryMode:   c.PostForm("expiry_mode"),
 			}
-			if _, err := svc.CreatePost(c.Request.Context(), params); err != nil {
-				redirectWithError(c, "/admin/ui/posts/new", "failed to create post", err)
+			post, err := svc.CreatePost(c.Request.Context(), params)
+			if err != nil {
+				redirectWithError(c, "/admin/ui/posts/new", postErrorMessage(err, "failed to create post"), err)
 				return
 			}
-			redirectWithSuccess(c, "/admin/ui/posts", "post created")
+			redirectWithSuccess(c, "/admin/ui/posts", savedPostMessage(c, svc, post.Slug, "post created"))
 		})
 
 		admin.GET("/posts/:slug/edit", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
 			slug := c.Param("slug")
 			result, err := svc.GetPost(c.Request.Context(), slug)
 			if err != nil {
 				c.String(http.StatusNotFound, "post not found")
 				return
 			}
-			adminview.AdminPostFormEdit(c, cfg, result)
+			state, err := svc.ReviewState(c.Request.Context(), slug, profile.ID)
+			if err != nil {
+				logAdminUIError(c, "load review", err)
+				c.String(http.StatusInternalServerError, "internal server error")
+				return
+			}
+			// the lock only warns, so the editor still opens when it cannot be read
+			lock, err := svc.PostLock(c.Request.Context(), slug, profile.ID)
+			if err != nil {
+				logAdminUIError(c, "load edit lock", err)
+			}
+			var pending *postdomain.Autosave
+			autosave, err := svc.PendingAutosave(c.Request.Context(), slug, profile.ID)
+			switch {
+			case err == nil:
+				pending = &autosave
+			case !errors.Is(err, postdomain.ErrAutosaveNotFound):
+				logAdminUIError(c, "load autosave", err)
+			}
+			// broken links only warn as well
+			links, err := svc.PostLinkFindings(c.Request.Context(), slug)
+			if err != nil {
+				logAdminUIError(c, "load link findings", err)
+			}
+			// and tag suggestions are only a hint
+			hints, err := svc.SuggestTags(c.Request.Context(), result)
+			if err != nil {
+				logAdminUIError(c, "suggest tags", err)
+			}
+			adminview.AdminPostFormEdit(c, cfg, result, state, lock, pending, links, hints)
 		})
 
 		admin.POST("/posts/:slug", func(c *gin.Context) {
-			coverURL, err := resolveCoverInput(c)
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
+			coverURL, err := resolveCoverInput(c, svc, profile.ID)
 			if err != nil {
 				redirectWithError(c, "/admin/ui/posts/"+c.Param("slug")+"/edit", err.Error(), err)
 				return
 			}
 			params := adminuisvc.UpdatePostParams{
-				Slug:      c.Param("slug"),
-				Title:     c.PostForm("title"),
-				Summary:   c.PostForm("summary"),
-				ContentMD: c.PostForm("content_md"),
-				CoverURL:  coverURL,
-				Status:    c.DefaultPostForm("status", "draft"),
+				ActorID:      profile.ID,
+				Slug:         c.Param("slug"),
+				Title:        c.PostForm("title"),
+				Summary:      c.PostForm("summary"),
+				ContentMD:    c.PostForm("content_md"),
+				CoverURL:     coverURL,
+				Status:       c.DefaultPostForm("status", "draft"),
+				CustomFields: c.PostFormMap("field"),
+				UnpublishAt:  c.PostForm("unpublish_at"),
+				ExpiryMode:   c.PostForm("expiry_mode"),
 			}
-			if _, err := svc.UpdatePost(c.Request.Context(), params); err != nil {
-				redirectWithError(c, "/admin/ui/posts/"+params.Slug+"/edit", "failed to update post", err)
+			post, err := svc.UpdatePost(c.Request.Context(), params)
+			if err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+params.Slug+"/edit", postErrorMessage(err, "failed to update post"), err)
 				return
 			}
-			redirectWithSuccess(c, "/admin/ui/posts/"+params.Slug+"/edit", "post updated")
+			redirectWithSuccess(c, "/admin/ui/posts/"+params.Slug+"/edit", savedPostMessage(c, svc, post.Slug, "post updated"))
 		})
 
 		admin.POST("/posts/:slug/delete", func(c *gin.Context) {
@@ -121,56 +187,91 @@ func RegisterUIRoutes(r *gin.Engine, cfg config.Config, adminSvc adminusecase.Ad
 		})
 
 		admin.POST("/posts/:slug/categories/add", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
 			slug := c.Param("slug")
 			cat := c.PostForm("category_slug")
-			if err := svc.AddCategory(c.Request.Context(), slug, cat); err != nil {
-				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", "failed to add category", err)
+			if err := svc.AddCategory(c.Request.Context(), slug, profile.ID, cat); err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", postErrorMessage(err, "failed to add category"), err)
 				return
 			}
 			redirectWithSuccess(c, "/admin/ui/posts/"+slug+"/edit", "category added")
 		})
 		admin.POST("/posts/:slug/categories/remove", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
 			slug := c.Param("slug")
 			cat := c.PostForm("category_slug")
-			if err := svc.RemoveCategory(c.Request.Context(), slug, cat); err != nil {
-				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", "failed to remove category", err)
+			if err := svc.RemoveCategory(c.Request.Context(), slug, profile.ID, cat); err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", postErrorMessage(err, "failed to remove category"), err)
 				return
 			}
 			redirectWithSuccess(c, "/admin/ui/posts/"+slug+"/edit", "category removed")
 		})
 
 		admin.POST("/posts/:slug/tags/add", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
 			slug := c.Param("slug")
 			tag := c.PostForm("tag_slug")
-			if err := svc.AddTag(c.Request.Context(), slug, tag); err != nil {
-				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", "failed to add tag", err)
+			if err := svc.AddTag(c.Request.Context(), slug, profile.ID, tag); err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", postErrorMessage(err, "failed to add tag"), err)
 				return
 			}
 			redirectWithSuccess(c, "/admin/ui/posts/"+slug+"/edit", "tag added")
 		})
+		admin.POST("/posts/:slug/tags/create", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
+			slug := c.Param("slug")
+			tag, err := svc.CreateTagForPost(c.Request.Context(), slug, profile.ID, c.PostForm("tag_name"))
+			if err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", taxonomyErrorMessage(err, "failed to create tag"), err)
+				return
+			}
+			redirectWithSuccess(c, "/admin/ui/posts/"+slug+"/edit", "tag "+tag.Name+" created and added")
+		})
 		admin.POST("/posts/:slug/tags/remove", func(c *gin.Context) {
+			profile, ok := ctxkeys.AdminProfileFromContext(c)
+			if !ok {
+				handleAdminProfileError(c, errMissingAdminEmail)
+				return
+			}
 			slug := c.Param("slug")
 			tag := c.PostForm("tag_slug")
-			if err := svc.RemoveTag(c.Request.Context(), slug, tag); err != nil {
-				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", "failed to remove tag", err)
+			if err := svc.RemoveTag(c.Request.Context(), slug, profile.ID, tag); err != nil {
+				redirectWithError(c, "/admin/ui/posts/"+slug+"/edit", postErrorMessage(err, "failed to remove tag"), err)
 				return
 			}
 			redirectWithSuccess(c, "/admin/ui/posts/"+slug+"/edit", "tag removed")
 		})
+
+		registerPageUIRoutes(admin, cfg, svc)
+		registerMenuUIRoutes(admin, cfg, svc)
+		registerReviewUIRoutes(admin, cfg, svc)
+		registerLinkUIRoutes(admin, cfg, svc)
+		registerWebhookUIRoutes(admin, cfg, svc)
+		registerSubscriberUIRoutes(admin, cfg, svc)
+		registerCategoryUIRoutes(admin, cfg, svc)
+		registerTagUIRoutes(admin, cfg, svc)
+		registerMediaUIRoutes(admin, cfg, svc)
 	}
 }
 
 var errMissingAdminEmail = errors.New("admin session missing email")
 
-func adminProfileFromContext(c *gin.Context) (authdomain.Admin, bool) {
-	if v, ok := c.Get("admin_profile"); ok {
-		if profile, ok := v.(authdomain.Admin); ok {
-			return profile, true
-		}
-	}
-	return authdomain.Admin{}, false
-}
-
 func handleAdminProfileError(c *gin.Context, err error) {
 	status := http.StatusInternalServerError
 	message := "failed to resolve admin session"
@@ -185,6 +286,23 @@ func handleAdminProfileError(c *gin.Context, err error) {
 	c.String(status, message)
 }
 
+// postErrorMessage turns post validation failures into a flash message listing each
+// invalid field; other errors fall back to the generic message.
+func postErrorMessage(err error, fallback string) string {
+	if errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		return "action not permitted for your role"
+	}
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return fallback
+	}
+	parts := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
 func redirectWithError(c *gin.Context, path, message string, err error) {
 	logAdminUIError(c, message, err)
 	c.Redirect(http.StatusFound, addFlashQuery(path, "error", message))
@@ -217,26 +335,16 @@ func logAdminUIError(c *gin.Context, context string, err error) {
 		slog.Any("err", err))
 }
 
-const (
-	coverUploadDir      = "web/static/uploads"
-	maxCoverUploadBytes = 5 << 20
-)
-
-var allowedCoverExt = map[string]bool{
-	".jpg":  true,
-	".jpeg": true,
-	".png":  true,
-	".gif":  true,
-	".webp": true,
-}
-
-func resolveCoverInput(c *gin.Context) (string, error) {
+// resolveCoverInput returns the cover of a post form: an uploaded cover_file is added to
+// the media library and its URL used, otherwise the typed cover_url.
+func resolveCoverInput(c *gin.Context, svc *adminuisvc.Service, uploaderID int64) (string, error) {
 	file, err := c.FormFile("cover_file")
 	if err == nil && file != nil && file.Size > 0 {
-		if file.Size > maxCoverUploadBytes {
-			return "", fmt.Errorf("cover file exceeds %d MB", maxCoverUploadBytes>>20)
+		media, err := uploadMediaFile(c, svc, file, mediadomain.UploadInput{UploaderID: uploaderID})
+		if err != nil {
+			return "", errors.New(mediaErrorMessage(err, "failed to save cover file"))
 		}
-		return saveCoverUpload(c, file)
+		return media.URL, nil
 	}
 	if err != nil && !errors.Is(err, http.ErrMissingFile) {
 		logAdminUIError(c, "read cover upload", err)
@@ -245,24 +353,6 @@ func resolveCoverInput(c *gin.Context) (string, error) {
 	return strings.TrimSpace(c.PostForm("cover_url")), nil
 }
 
-func saveCoverUpload(c *gin.Context, file *multipart.FileHeader) (string, error) {
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !allowedCoverExt[ext] {
-		return "", fmt.Errorf("unsupported cover format: %s", ext)
-	}
-	if err := os.MkdirAll(coverUploadDir, 0o755); err != nil {
-		logAdminUIError(c, "create cover upload dir", err)
-		return "", errors.New("failed to save cover file")
-	}
-	filename := fmt.Sprintf("%d%s", time.Now().UnixNano(), ext)
-	destPath := filepath.Join(coverUploadDir, filename)
-	if err := c.SaveUploadedFile(file, destPath); err != nil {
-		logAdminUIError(c, "save cover upload", err)
-		return "", errors.New("failed to save cover file")
-	}
-	return "/static/uploads/" + filename, nil
-}
-
 
 
 
@@ -0,0 +1,64 @@
+﻿package adminuihttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	authdomain "proto-gin-web/internal/contexts/admin/auth/domain"
+	adminusecase "proto-gin-web/internal/contexts/admin/auth/usecase"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+func registerUserRoutes(group *gin.RouterGroup, adminSvc adminusecase.AdminService) {
+	group.PUT("/users/:id/role", func(c *gin.Context) {
+		profile, ok := ctxkeys.AdminProfileFromContext(c)
+		if !ok {
+			responder.JSONError(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || userID <= 0 {
+			responder.JSONError(c, http.StatusBadRequest, "invalid user id")
+			return
+		}
+		var req struct {
+			Role string `json:"role" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		updated, err := adminSvc.SetRole(c.Request.Context(), profile.ID, userID, req.Role)
+		if err != nil {
+			switch {
+			case errors.Is(err, authdomain.ErrAdminRoleForbidden):
+				responder.JSONError(c, http.StatusForbidden, "only admins can assign roles")
+			case errors.Is(err, authdomain.ErrAdminOwnRole):
+				responder.JSONError(c, http.StatusForbidden, "cannot change your own role")
+			case errors.Is(err, authdomain.ErrAdminRoleNotFound):
+				responder.JSONError(c, http.StatusBadRequest, "unknown role")
+			case errors.Is(err, authdomain.ErrAdminNotFound):
+				responder.JSONError(c, http.StatusNotFound, "account not found")
+			default:
+				slog.Error("admin role update failed",
+					slog.String("user", profile.Email),
+					slog.Int64("target", userID),
+					slog.Any("err", err))
+				responder.JSONError(c, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		slog.Info("admin role updated",
+			slog.String("user", profile.Email),
+			slog.Int64("target", updated.ID),
+			slog.String("role", req.Role))
+		responder.JSONSuccess(c, http.StatusOK, updated)
+	})
+}
@@ -0,0 +1,149 @@
+package adminuihttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	adminview "proto-gin-web/internal/contexts/admin/ui/adapters/view"
+	adminuisvc "proto-gin-web/internal/contexts/admin/ui/usecase"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// registerWebhookUIRoutes mounts the webhook subscription pages and the delivery log
+// under the already guarded admin group.
+func registerWebhookUIRoutes(admin *gin.RouterGroup, cfg config.Config, svc *adminuisvc.Service) {
+	admin.GET("/webhooks", func(c *gin.Context) {
+		subs, err := svc.ListWebhooks(c.Request.Context())
+		if err != nil {
+			logAdminUIError(c, "list webhooks", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminWebhooksPage(c, cfg, subs)
+	})
+
+	admin.POST("/webhooks", func(c *gin.Context) {
+		sub, err := svc.CreateWebhook(c.Request.Context(), webhookForm(c))
+		if err != nil {
+			redirectWithError(c, "/admin/ui/webhooks", webhookErrorMessage(err, "failed to create webhook"), err)
+			return
+		}
+		redirectWithSuccess(c, webhookPath(sub.ID), "webhook created")
+	})
+
+	admin.GET("/webhooks/:id", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.String(http.StatusNotFound, "webhook not found")
+			return
+		}
+		sub, err := svc.GetWebhook(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, webhookdomain.ErrSubscriptionNotFound) {
+				c.String(http.StatusNotFound, "webhook not found")
+				return
+			}
+			logAdminUIError(c, "get webhook", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		deliveries, err := svc.WebhookDeliveries(c.Request.Context(), id)
+		if err != nil {
+			logAdminUIError(c, "list webhook deliveries", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminWebhookEditor(c, cfg, sub, deliveries)
+	})
+
+	admin.POST("/webhooks/:id", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/webhooks", "invalid webhook", err)
+			return
+		}
+		if _, err := svc.UpdateWebhook(c.Request.Context(), id, webhookForm(c)); err != nil {
+			redirectWithError(c, webhookPath(id), webhookErrorMessage(err, "failed to update webhook"), err)
+			return
+		}
+		redirectWithSuccess(c, webhookPath(id), "webhook updated")
+	})
+
+	admin.POST("/webhooks/:id/delete", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/webhooks", "invalid webhook", err)
+			return
+		}
+		if err := svc.DeleteWebhook(c.Request.Context(), id); err != nil {
+			redirectWithError(c, "/admin/ui/webhooks", "failed to delete webhook", err)
+			return
+		}
+		redirectWithSuccess(c, "/admin/ui/webhooks", "webhook deleted")
+	})
+
+	admin.GET("/webhook-deliveries/:id", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.String(http.StatusNotFound, "delivery not found")
+			return
+		}
+		detail, err := svc.WebhookDelivery(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, webhookdomain.ErrDeliveryNotFound) {
+				c.String(http.StatusNotFound, "delivery not found")
+				return
+			}
+			logAdminUIError(c, "get webhook delivery", err)
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		adminview.AdminWebhookDelivery(c, cfg, detail)
+	})
+
+	admin.POST("/webhook-deliveries/:id/redeliver", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/webhooks", "invalid delivery", err)
+			return
+		}
+		delivery, err := svc.RedeliverWebhook(c.Request.Context(), id)
+		if err != nil {
+			redirectWithError(c, "/admin/ui/webhook-deliveries/"+c.Param("id"), "failed to redeliver", err)
+			return
+		}
+		redirectWithSuccess(c, webhookPath(delivery.SubscriptionID), "delivery #"+c.Param("id")+" queued for redelivery")
+	})
+}
+
+func webhookPath(id int64) string {
+	return "/admin/ui/webhooks/" + strconv.FormatInt(id, 10)
+}
+
+func webhookForm(c *gin.Context) webhookdomain.SubscriptionInput {
+	return webhookdomain.SubscriptionInput{
+		URL:    c.PostForm("url"),
+		Secret: c.PostForm("secret"),
+		Events: c.PostFormArray("events"),
+		Active: c.PostForm("active") != "",
+	}
+}
+
+// webhookErrorMessage is the webhook counterpart of postErrorMessage.
+func webhookErrorMessage(err error, fallback string) string {
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return fallback
+	}
+	parts := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -2,10 +2,20 @@
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
 	"proto-gin-web/internal/platform/config"
 	platformview "proto-gin-web/internal/platform/http/view"
 )
@@ -24,8 +34,9 @@ func AdminPostsPage(c *gin.Context, cfg config.Config, posts []postdomain.Post)
 	}))
 }
 
-// AdminPostFormNew renders the new post form.
-func AdminPostFormNew(c *gin.Context, cfg config.Config) {
+// AdminPostFormNew renders the new post form. statuses lists the statuses the signed-in
+// user may create a post in.
+func AdminPostFormNew(c *gin.Context, cfg config.Config, fields []postdomain.FieldValue, statuses []string) {
 	platformview.RenderHTML(c, http.StatusOK, "admin_post_form.tmpl", platformview.WithAdminContext(c, gin.H{
 		"Title":           "Admin · New Post · " + cfg.SiteName,
 		"Env":             cfg.Env,
@@ -33,13 +44,17 @@ func AdminPostFormNew(c *gin.Context, cfg config.Config) {
 		"SiteName":        cfg.SiteName,
 		"SiteDescription": cfg.SiteDescription,
 		"IsNew":           true,
+		"Fields":          fields,
+		"Statuses":        statuses,
 		"Error":           c.Query("error"),
 		"Success":         c.Query("success"),
 	}))
 }
 
-// AdminPostFormEdit renders the edit post form.
-func AdminPostFormEdit(c *gin.Context, cfg config.Config, result postdomain.PostWithRelations) {
+// AdminPostFormEdit renders the edit post form with its review panel, edit lock banner,
+// the broken links found by the last link check, the suggested tags and, when pending
+// is not nil, the offer to restore an unsaved autosave.
+func AdminPostFormEdit(c *gin.Context, cfg config.Config, result postdomain.PostWithRelations, state postdomain.ReviewState, lock postdomain.LockState, pending *postdomain.Autosave, links []postdomain.LinkFinding, hints postdomain.TagSuggestions) {
 	platformview.RenderHTML(c, http.StatusOK, "admin_post_form.tmpl", platformview.WithAdminContext(c, gin.H{
 		"Title":           "Admin · Edit Post · " + result.Post.Title + " · " + cfg.SiteName,
 		"Env":             cfg.Env,
@@ -50,9 +65,622 @@ func AdminPostFormEdit(c *gin.Context, cfg config.Config, result postdomain.Post
 		"Post":            result.Post,
 		"Categories":      result.Categories,
 		"Tags":            result.Tags,
+		"Fields":          result.Fields,
+		"Statuses":        append([]string{result.Post.Status}, state.Transitions...),
+		"Review":          reviewPanel(state),
+		"Lock":            editLockBanner(result.Post.Slug, lock),
+		"Autosave":        autosaveBanner(result.Post.Slug, pending),
+		"BrokenLinks":     brokenLinkRows(links),
+		"TagSuggestions":  hints,
 		"Error":           c.Query("error"),
 		"Success":         c.Query("success"),
 	}))
 }
 
+// EditLockBanner drives the "being edited by" banner and the lock heartbeat script.
+type EditLockBanner struct {
+	URL         string
+	HolderName  string
+	OtherHolder bool
+	HeartbeatMS int64
+}
+
+func editLockBanner(slug string, lock postdomain.LockState) EditLockBanner {
+	banner := EditLockBanner{
+		URL:         "/admin/posts/" + slug + "/lock",
+		HeartbeatMS: postdomain.EditLockHeartbeat.Milliseconds(),
+	}
+	if lock.Locked && !lock.HeldByYou {
+		banner.OtherHolder = true
+		banner.HolderName = lock.Lock.HolderName
+	}
+	return banner
+}
+
+// AutosaveBanner drives the "restore unsaved changes" banner and the autosave script.
+type AutosaveBanner struct {
+	URL        string
+	IntervalMS int64
+	Pending    bool
+	SavedAt    time.Time
+}
+
+func autosaveBanner(slug string, pending *postdomain.Autosave) AutosaveBanner {
+	banner := AutosaveBanner{
+		URL:        "/admin/posts/" + slug + "/autosave",
+		IntervalMS: postdomain.AutosaveInterval.Milliseconds(),
+	}
+	if pending != nil {
+		banner.Pending = true
+		banner.SavedAt = pending.SavedAt
+	}
+	return banner
+}
+
+// ReviewerOption is an entry of the reviewer picker.
+type ReviewerOption struct {
+	ID       int64
+	Label    string
+	Selected bool
+}
+
+// ReviewPanel carries the workflow controls shown under the post form.
+type ReviewPanel struct {
+	Role         string
+	Status       string
+	ReviewerName string
+	Transitions  []string
+	Reviewers    []ReviewerOption
+	Comments     []postdomain.ReviewComment
+}
+
+func reviewPanel(state postdomain.ReviewState) ReviewPanel {
+	panel := ReviewPanel{
+		Role:         state.Role,
+		Status:       state.Review.Status,
+		ReviewerName: state.Review.ReviewerName,
+		Transitions:  state.Transitions,
+		Comments:     state.Comments,
+	}
+	for _, r := range state.Reviewers {
+		label := r.DisplayName
+		if label == "" {
+			label = r.Email
+		}
+		panel.Reviewers = append(panel.Reviewers, ReviewerOption{
+			ID:       r.ID,
+			Label:    label + " (" + r.Role + ")",
+			Selected: state.Review.ReviewerID != nil && *state.Review.ReviewerID == r.ID,
+		})
+	}
+	return panel
+}
+
+// AdminReviewQueue renders the posts waiting on the signed-in user.
+func AdminReviewQueue(c *gin.Context, cfg config.Config, queue postdomain.ReviewQueue) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_reviews.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Review Queue · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Queue":           queue,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminLinkReport renders the broken links found in every post.
+func AdminLinkReport(c *gin.Context, cfg config.Config, findings []postdomain.LinkFinding) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_link_report.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Broken Links · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"BrokenLinks":     brokenLinkRows(findings),
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// BrokenLinkRow is a link check finding spelled out for the admin pages.
+type BrokenLinkRow struct {
+	PostSlug  string
+	PostTitle string
+	URL       string
+	Problem   string
+	CheckedAt time.Time
+}
+
+var linkProblems = map[string]string{
+	postdomain.LinkPost:     "no post with this slug",
+	postdomain.LinkCategory: "no category with this slug",
+	postdomain.LinkTag:      "no tag with this slug",
+	postdomain.LinkUpload:   "file missing from uploads",
+}
+
+func brokenLinkRows(findings []postdomain.LinkFinding) []BrokenLinkRow {
+	rows := make([]BrokenLinkRow, len(findings))
+	for i, f := range findings {
+		rows[i] = BrokenLinkRow{
+			PostSlug:  f.PostSlug,
+			PostTitle: f.PostTitle,
+			URL:       f.URL,
+			Problem:   linkProblems[f.Kind],
+			CheckedAt: f.CheckedAt,
+		}
+	}
+	return rows
+}
+
+// AdminPagesPage renders the admin pages list.
+func AdminPagesPage(c *gin.Context, cfg config.Config, pages []pagedomain.Page) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_pages.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Pages · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Pages":           pages,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminPageFormNew renders the new page form. pages feeds the parent picker.
+func AdminPageFormNew(c *gin.Context, cfg config.Config, pages []pagedomain.Page) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_page_form.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · New Page · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"IsNew":           true,
+		"Parents":         pages,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminPageFormEdit renders the edit page form. The page itself is left out of the
+// parent picker; descendants stay listed and are rejected on save.
+func AdminPageFormEdit(c *gin.Context, cfg config.Config, result pagedomain.PageWithRelations, pages []pagedomain.Page) {
+	parents := make([]pagedomain.Page, 0, len(pages))
+	for _, p := range pages {
+		if p.ID != result.Page.ID {
+			parents = append(parents, p)
+		}
+	}
+	parentSlug := ""
+	if n := len(result.Ancestors); n > 0 {
+		parentSlug = result.Ancestors[n-1].Slug
+	}
+	platformview.RenderHTML(c, http.StatusOK, "admin_page_form.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Edit Page · " + result.Page.Title + " · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"IsNew":           false,
+		"Page":            result.Page,
+		"PagePath":        result.Path(),
+		"ParentSlug":      parentSlug,
+		"Parents":         parents,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminMenusPage renders the admin menu list.
+func AdminMenusPage(c *gin.Context, cfg config.Config, menus []menudomain.Menu) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_menus.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Menus · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Menus":           menus,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// MenuOption is a select option of the menu item form.
+type MenuOption struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// MenuItemForm carries the values of an add or edit item form.
+type MenuItemForm struct {
+	Action      string
+	IsNew       bool
+	Label       string
+	Target      string
+	URL         string
+	Position    int32
+	TargetTypes []MenuOption
+	Parents     []MenuOption
+}
+
+// MenuEditorList is one level of the editor tree; the drag-reorder script saves the
+// order of a list under its ParentID.
+type MenuEditorList struct {
+	ParentID string
+	OrderURL string
+	Items    []MenuEditorItem
+}
+
+// MenuEditorItem is an editor row with its edit form and nested items.
+type MenuEditorItem struct {
+	ID           int64
+	Label        string
+	TargetType   string
+	TargetSlug   string
+	Href         string
+	Form         MenuItemForm
+	DeleteAction string
+	Children     MenuEditorList
+}
+
+var menuTargetTypes = []string{menudomain.TargetURL, menudomain.TargetPage, menudomain.TargetPost, menudomain.TargetCategory, menudomain.TargetTag}
+
+// AdminMenuEditor renders the item tree of a menu with add, edit and drag-reorder controls.
+func AdminMenuEditor(c *gin.Context, cfg config.Config, result menudomain.MenuWithItems) {
+	base := "/admin/ui/menus/" + result.Menu.Name
+	parents := menuParents(result.Items, 0)
+	platformview.RenderHTML(c, http.StatusOK, "admin_menu_editor.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Menu · " + result.Menu.Label + " · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"MenuName":        result.Menu.Name,
+		"MenuLabel":       result.Menu.Label,
+		"Tree":            menuEditorList(result.Menu.Name, nil, result.Items, parents),
+		"NewItem": MenuItemForm{
+			Action:      base + "/items",
+			IsNew:       true,
+			TargetTypes: menuOptions(menuTargetTypes, menudomain.TargetURL),
+			Parents:     menuParentOptions(parents, 0, 0),
+		},
+		"Error":   c.Query("error"),
+		"Success": c.Query("success"),
+	}))
+}
+
+type menuParent struct {
+	ID    int64
+	Label string
+}
+
+// menuParents flattens the tree into parent picker entries, indented by depth.
+func menuParents(nodes []menudomain.ItemNode, depth int) []menuParent {
+	var out []menuParent
+	for _, node := range nodes {
+		out = append(out, menuParent{ID: node.ID, Label: strings.Repeat("— ", depth) + node.Label})
+		out = append(out, menuParents(node.Children, depth+1)...)
+	}
+	return out
+}
+
+// menuParentOptions lists every parent except the item itself; selected is the
+// current parent id, zero for top-level items.
+func menuParentOptions(parents []menuParent, self, selected int64) []MenuOption {
+	out := make([]MenuOption, 0, len(parents))
+	for _, p := range parents {
+		if p.ID == self {
+			continue
+		}
+		out = append(out, MenuOption{Value: strconv.FormatInt(p.ID, 10), Label: p.Label, Selected: p.ID == selected})
+	}
+	return out
+}
+
+func menuOptions(values []string, selected string) []MenuOption {
+	out := make([]MenuOption, len(values))
+	for i, v := range values {
+		out[i] = MenuOption{Value: v, Label: v, Selected: v == selected}
+	}
+	return out
+}
+
+func menuEditorList(menuName string, parentID *int64, nodes []menudomain.ItemNode, parents []menuParent) MenuEditorList {
+	base := "/admin/ui/menus/" + menuName
+	list := MenuEditorList{OrderURL: "/admin/menus/" + menuName + "/order"}
+	if parentID != nil {
+		list.ParentID = strconv.FormatInt(*parentID, 10)
+	}
+	for _, node := range nodes {
+		var currentParent int64
+		if node.ParentID != nil {
+			currentParent = *node.ParentID
+		}
+		itemPath := base + "/items/" + strconv.FormatInt(node.ID, 10)
+		id := node.ID
+		list.Items = append(list.Items, MenuEditorItem{
+			ID:         node.ID,
+			Label:      node.Label,
+			TargetType: node.TargetType,
+			TargetSlug: node.TargetSlug,
+			Href:       node.Href,
+			Form: MenuItemForm{
+				Action:      itemPath,
+				Label:       node.Label,
+				Target:      node.TargetSlug,
+				URL:         node.URL,
+				Position:    node.Position,
+				TargetTypes: menuOptions(menuTargetTypes, node.TargetType),
+				Parents:     menuParentOptions(parents, node.ID, currentParent),
+			},
+			DeleteAction: itemPath + "/delete",
+			Children:     menuEditorList(menuName, &id, node.Children, parents),
+		})
+	}
+	return list
+}
 
+// AdminWebhooksPage renders the webhook subscriptions with a form for a new one.
+func AdminWebhooksPage(c *gin.Context, cfg config.Config, subs []webhookdomain.Subscription) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_webhooks.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Webhooks · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Webhooks":        subs,
+		"EventOptions":    webhookEventOptions(webhookdomain.EventTypes),
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminWebhookEditor renders a webhook subscription form with its recent deliveries.
+func AdminWebhookEditor(c *gin.Context, cfg config.Config, sub webhookdomain.Subscription, deliveries []webhookdomain.Delivery) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_webhook_editor.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Webhook · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Webhook":         sub,
+		"EventOptions":    webhookEventOptions(sub.Events),
+		"Deliveries":      deliveries,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminWebhookDelivery renders a delivery with its payload and attempt history.
+func AdminWebhookDelivery(c *gin.Context, cfg config.Config, detail webhookdomain.DeliveryDetail) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_webhook_delivery.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Webhook Delivery · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Delivery":        detail.Delivery,
+		"Payload":         string(detail.Delivery.Payload),
+		"Attempts":        detail.Attempts,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminMediaPage renders a page of the media library with its search and upload forms.
+func AdminMediaPage(c *gin.Context, cfg config.Config, media []mediadomain.Media, query string, page int, hasNext bool) {
+	rows := make([]MediaRow, len(media))
+	for i, m := range media {
+		rows[i] = mediaRow(m)
+	}
+	pager := gin.H{"Page": page}
+	if page > 1 {
+		pager["Prev"] = mediaPageURL(query, page-1)
+	}
+	if hasNext {
+		pager["Next"] = mediaPageURL(query, page+1)
+	}
+	platformview.RenderHTML(c, http.StatusOK, "admin_media.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Media · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Media":           rows,
+		"Query":           query,
+		"Pager":           pager,
+		"MaxUploadMB":     mediadomain.MaxUploadBytes >> 20,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminMediaItem renders a media library file with its text form and the content that
+// references it.
+func AdminMediaItem(c *gin.Context, cfg config.Config, detail mediadomain.MediaDetail) {
+	usage := make([]MediaUsageRow, len(detail.Usage))
+	for i, u := range detail.Usage {
+		usage[i] = MediaUsageRow{Usage: u}
+		switch u.Kind {
+		case mediadomain.UsagePost:
+			usage[i].EditPath = "/admin/ui/posts/" + u.Slug + "/edit"
+		case mediadomain.UsagePage:
+			usage[i].EditPath = "/admin/ui/pages/" + u.Slug + "/edit"
+		case mediadomain.UsageCategory:
+			usage[i].EditPath = "/admin/ui/categories/" + u.Slug + "/edit"
+		case mediadomain.UsageTag:
+			usage[i].EditPath = "/admin/ui/tags/" + u.Slug + "/edit"
+		}
+	}
+	platformview.RenderHTML(c, http.StatusOK, "admin_media_item.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Media · " + detail.OriginalName + " · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Media":           mediaRow(detail.Media),
+		"Usage":           usage,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// MediaRow is a media library file with its size spelled out for display.
+type MediaRow struct {
+	mediadomain.Media
+	Size string
+}
+
+// MediaUsageRow is a reference to a file, linked to the editor of the referencing item.
+type MediaUsageRow struct {
+	mediadomain.Usage
+	EditPath string
+}
+
+func mediaRow(m mediadomain.Media) MediaRow {
+	return MediaRow{Media: m, Size: formatBytes(m.SizeBytes)}
+}
+
+func mediaPageURL(query string, page int) string {
+	values := url.Values{}
+	if query != "" {
+		values.Set("q", query)
+	}
+	values.Set("page", strconv.Itoa(page))
+	return "/admin/ui/media?" + values.Encode()
+}
+
+// formatBytes renders n as B, KB or MB with one decimal.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + " MB"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + " KB"
+	default:
+		return strconv.FormatInt(n, 10) + " B"
+	}
+}
+
+// WebhookEventOption is an event checkbox of the webhook form.
+type WebhookEventOption struct {
+	Value   string
+	Checked bool
+}
+
+func webhookEventOptions(checked []string) []WebhookEventOption {
+	out := make([]WebhookEventOption, len(webhookdomain.EventTypes))
+	for i, event := range webhookdomain.EventTypes {
+		out[i] = WebhookEventOption{Value: event}
+		for _, c := range checked {
+			if c == event {
+				out[i].Checked = true
+			}
+		}
+	}
+	return out
+}
+
+// AdminTagsPage renders groups of look-alike tags, each with a merge form, and the
+// aliases left by earlier merges.
+func AdminTagsPage(c *gin.Context, cfg config.Config, tags []taxdomain.TagWithCount, groups []taxdomain.DuplicateTagGroup, aliases []taxdomain.TagAlias) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_tags.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Tags · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Tags":            tags,
+		"Groups":          groups,
+		"Aliases":         aliases,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminCategoriesPage renders the category list in display order.
+func AdminCategoriesPage(c *gin.Context, cfg config.Config, categories []taxdomain.CategoryWithCount) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_categories.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Categories · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Categories":      categories,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminCategoryForm renders the category edit form. The category itself is left out
+// of the parent picker; descendants stay listed and are rejected on save.
+func AdminCategoryForm(c *gin.Context, cfg config.Config, category taxdomain.Category, categories []taxdomain.CategoryWithCount) {
+	parents := make([]taxdomain.Category, 0, len(categories))
+	parentSlug := ""
+	for _, other := range categories {
+		if other.ID == category.ID {
+			continue
+		}
+		parents = append(parents, other.Category)
+		if category.ParentID != nil && other.ID == *category.ParentID {
+			parentSlug = other.Slug
+		}
+	}
+	platformview.RenderHTML(c, http.StatusOK, "admin_taxonomy_form.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Edit Category · " + category.Name + " · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Kind":            "Category",
+		"Noun":            "category",
+		"Action":          "/admin/ui/categories/" + category.Slug + "/edit",
+		"BackURL":         "/admin/ui/categories",
+		"PublicURL":       "/categories/" + category.Slug,
+		"Name":            category.Name,
+		"Slug":            category.Slug,
+		"Details":         category.Details,
+		"IsCategory":      true,
+		"Parents":         parents,
+		"ParentSlug":      parentSlug,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminTagForm renders the tag edit form.
+func AdminTagForm(c *gin.Context, cfg config.Config, tag taxdomain.Tag) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_taxonomy_form.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Edit Tag · " + tag.Name + " · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Kind":            "Tag",
+		"Noun":            "tag",
+		"Action":          "/admin/ui/tags/" + tag.Slug + "/edit",
+		"BackURL":         "/admin/ui/tags",
+		"PublicURL":       "/tags/" + tag.Slug,
+		"Name":            tag.Name,
+		"Slug":            tag.Slug,
+		"Details":         tag.Details,
+		"Error":           c.Query("error"),
+		"Success":         c.Query("success"),
+	}))
+}
+
+// AdminSubscribersPage renders the newsletter subscribers with the status filter in use.
+func AdminSubscribersPage(c *gin.Context, cfg config.Config, status string, subs []newsletterdomain.Subscriber) {
+	platformview.RenderHTML(c, http.StatusOK, "admin_subscribers.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           "Admin · Subscribers · " + cfg.SiteName,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Subscribers":     subs,
+		"Status":          status,
+		"Statuses":        []string{newsletterdomain.StatusPending, newsletterdomain.StatusConfirmed, newsletterdomain.StatusUnsubscribed},
+	}))
+}
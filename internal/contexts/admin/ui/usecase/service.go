@@ -4,19 +4,45 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	webhookusecase "proto-gin-web/internal/contexts/admin/webhook/usecase"
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	menuusecase "proto-gin-web/internal/contexts/blog/menu/usecase"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	newsletterusecase "proto-gin-web/internal/contexts/blog/newsletter/usecase"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
+	"proto-gin-web/internal/platform/validation"
 )
 
-// Service wraps post operations used by the admin UI forms.
+// Service wraps post, review, lock, autosave, link check, tag suggestion, page, menu, webhook, newsletter, taxonomy and media operations used by the admin UI forms.
 type Service struct {
-	posts postusecase.PostService
+	posts      postusecase.PostService
+	reviews    postusecase.ReviewService
+	locks      postusecase.LockService
+	autosaves  postusecase.AutosaveService
+	links      postusecase.LinkCheckService
+	fields     postusecase.CustomFieldService
+	pages      pageusecase.PageService
+	menus      menuusecase.MenuService
+	webhooks   webhookusecase.WebhookService
+	newsletter newsletterusecase.NewsletterService
+	taxonomy   taxonomyusecase.TaxonomyService
+	tagHints   postusecase.TagSuggestionService
+	media      mediausecase.MediaService
 }
 
 // NewService creates an admin UI helper service.
-func NewService(posts postusecase.PostService) *Service {
-	return &Service{posts: posts}
+func NewService(posts postusecase.PostService, reviews postusecase.ReviewService, locks postusecase.LockService, autosaves postusecase.AutosaveService, links postusecase.LinkCheckService, fields postusecase.CustomFieldService, pages pageusecase.PageService, menus menuusecase.MenuService, webhooks webhookusecase.WebhookService, newsletter newsletterusecase.NewsletterService, taxonomy taxonomyusecase.TaxonomyService, tagHints postusecase.TagSuggestionService, media mediausecase.MediaService) *Service {
+	return &Service{posts: posts, reviews: reviews, locks: locks, autosaves: autosaves, links: links, fields: fields, pages: pages, menus: menus, webhooks: webhooks, newsletter: newsletter, taxonomy: taxonomy, tagHints: tagHints, media: media}
 }
 
 // ListPosts lists published posts for the UI with optional limit.
@@ -32,26 +58,52 @@ func (s *Service) GetPost(ctx context.Context, slug string) (postdomain.PostWith
 	return s.posts.GetBySlug(ctx, strings.TrimSpace(slug))
 }
 
-// CreatePostParams captures the form fields required to create a post.
+// NewPostFields lists the custom fields shown on the new post form. A new post has no
+// categories yet, so only global fields apply.
+func (s *Service) NewPostFields(ctx context.Context) ([]postdomain.FieldValue, error) {
+	defs, err := s.fields.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return postdomain.BuildFieldValues(defs, nil, nil), nil
+}
+
+// formDateTimeLayout is the value format of datetime-local inputs.
+const formDateTimeLayout = "2006-01-02T15:04"
+
+// CreatePostParams captures the form fields required to create a post. UnpublishAt is
+// the raw datetime-local value, read in the server's time zone.
 type CreatePostParams struct {
-	Title     string
-	Slug      string
-	Summary   string
-	ContentMD string
-	CoverURL  string
-	Status    string
-	AuthorID  int64
+	ActorID      int64
+	Title        string
+	Slug         string
+	Summary      string
+	ContentMD    string
+	CoverURL     string
+	Status       string
+	AuthorID     int64
+	CustomFields map[string]string
+	UnpublishAt  string
+	ExpiryMode   string
 }
 
 // CreatePost creates a post from admin form params.
 func (s *Service) CreatePost(ctx context.Context, params CreatePostParams) (postdomain.Post, error) {
+	unpublishAt, err := formUnpublishAt(params.UnpublishAt)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
 	input := postdomain.CreatePostInput{
-		Title:     strings.TrimSpace(params.Title),
-		Slug:      strings.TrimSpace(params.Slug),
-		Summary:   strings.TrimSpace(params.Summary),
-		ContentMD: params.ContentMD,
-		Status:    strings.TrimSpace(params.Status),
-		AuthorID:  params.AuthorID,
+		ActorID:      params.ActorID,
+		Title:        strings.TrimSpace(params.Title),
+		Slug:         strings.TrimSpace(params.Slug),
+		Summary:      strings.TrimSpace(params.Summary),
+		ContentMD:    params.ContentMD,
+		Status:       strings.TrimSpace(params.Status),
+		AuthorID:     params.AuthorID,
+		CustomFields: formFieldValues(params.CustomFields),
+		UnpublishAt:  unpublishAt,
+		ExpiryMode:   strings.TrimSpace(params.ExpiryMode),
 	}
 	if trimmed := strings.TrimSpace(params.CoverURL); trimmed != "" {
 		input.CoverURL = &trimmed
@@ -59,29 +111,105 @@ func (s *Service) CreatePost(ctx context.Context, params CreatePostParams) (post
 	return s.posts.Create(ctx, input)
 }
 
-// UpdatePostParams captures editable post fields.
+// UpdatePostParams captures editable post fields. The form always renders every
+// custom field in scope, so CustomFields replaces the stored values, and a blank
+// UnpublishAt clears the unpublish date.
 type UpdatePostParams struct {
-	Slug      string
-	Title     string
-	Summary   string
-	ContentMD string
-	CoverURL  string
-	Status    string
+	ActorID      int64
+	Slug         string
+	Title        string
+	Summary      string
+	ContentMD    string
+	CoverURL     string
+	Status       string
+	CustomFields map[string]string
+	UnpublishAt  string
+	ExpiryMode   string
 }
 
-// UpdatePost updates a post identified by slug.
+// UpdatePost updates a post identified by slug and discards the editor's autosave of it.
 func (s *Service) UpdatePost(ctx context.Context, params UpdatePostParams) (postdomain.Post, error) {
+	unpublishAt, err := formUnpublishAt(params.UnpublishAt)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
 	input := postdomain.UpdatePostInput{
-		Slug:      strings.TrimSpace(params.Slug),
-		Title:     strings.TrimSpace(params.Title),
-		Summary:   strings.TrimSpace(params.Summary),
-		ContentMD: params.ContentMD,
-		Status:    strings.TrimSpace(params.Status),
+		ActorID:      params.ActorID,
+		Slug:         strings.TrimSpace(params.Slug),
+		Title:        strings.TrimSpace(params.Title),
+		Summary:      strings.TrimSpace(params.Summary),
+		ContentMD:    params.ContentMD,
+		Status:       strings.TrimSpace(params.Status),
+		CustomFields: formFieldValues(params.CustomFields),
+		UnpublishAt:  unpublishAt,
+		ExpiryMode:   strings.TrimSpace(params.ExpiryMode),
 	}
 	if trimmed := strings.TrimSpace(params.CoverURL); trimmed != "" {
 		input.CoverURL = &trimmed
 	}
-	return s.posts.Update(ctx, input)
+	post, err := s.posts.Update(ctx, input)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	s.autosaves.DiscardSaved(ctx, post.Slug, params.ActorID)
+	return post, nil
+}
+
+// Role returns the editorial role of the signed-in user, "" when they have none.
+func (s *Service) Role(ctx context.Context, actorID int64) (string, error) {
+	return s.reviews.Role(ctx, actorID)
+}
+
+// ReviewState loads the workflow state of a post for the review panel.
+func (s *Service) ReviewState(ctx context.Context, slug string, actorID int64) (postdomain.ReviewState, error) {
+	return s.reviews.State(ctx, slug, actorID)
+}
+
+// TransitionPost moves a post to another workflow status.
+func (s *Service) TransitionPost(ctx context.Context, input postdomain.TransitionInput) (postdomain.Post, error) {
+	return s.reviews.Transition(ctx, input)
+}
+
+// AssignReviewer assigns a reviewer to a post; zero clears the assignment.
+func (s *Service) AssignReviewer(ctx context.Context, slug string, actorID, reviewerID int64) error {
+	_, err := s.reviews.AssignReviewer(ctx, slug, actorID, reviewerID)
+	return err
+}
+
+// CommentOnPost adds a review comment to a post.
+func (s *Service) CommentOnPost(ctx context.Context, slug string, actorID int64, body string) error {
+	_, err := s.reviews.Comment(ctx, slug, actorID, body)
+	return err
+}
+
+// ReviewQueue lists the posts waiting on the signed-in user.
+func (s *Service) ReviewQueue(ctx context.Context, actorID int64) (postdomain.ReviewQueue, error) {
+	return s.reviews.Queue(ctx, actorID)
+}
+
+// PostLock returns the edit lock of a post so the edit page can warn about other editors.
+func (s *Service) PostLock(ctx context.Context, slug string, actorID int64) (postdomain.LockState, error) {
+	return s.locks.State(ctx, slug, actorID)
+}
+
+// PendingAutosave returns the editor's unsaved working copy of a post, if newer than the post.
+func (s *Service) PendingAutosave(ctx context.Context, slug string, actorID int64) (postdomain.Autosave, error) {
+	return s.autosaves.Pending(ctx, slug, actorID)
+}
+
+// CheckPostLinks re-checks the internal links and uploads referenced by a saved post.
+func (s *Service) CheckPostLinks(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	return s.links.CheckPost(ctx, slug)
+}
+
+// PostLinkFindings lists the broken links found in a post by its last check.
+func (s *Service) PostLinkFindings(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	return s.links.PostFindings(ctx, slug)
+}
+
+// LinkReport lists the broken links found in every post.
+func (s *Service) LinkReport(ctx context.Context) ([]postdomain.LinkFinding, error) {
+	return s.links.Report(ctx)
 }
 
 // DeletePost removes a post by slug.
@@ -93,24 +221,254 @@ func (s *Service) DeletePost(ctx context.Context, slug string) error {
 }
 
 // AddCategory assigns a category to a post.
-func (s *Service) AddCategory(ctx context.Context, slug, categorySlug string) error {
-	return s.posts.AddCategory(ctx, slug, categorySlug)
+func (s *Service) AddCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
+	return s.posts.AddCategory(ctx, slug, actorID, categorySlug)
 }
 
 // RemoveCategory removes a category from a post.
-func (s *Service) RemoveCategory(ctx context.Context, slug, categorySlug string) error {
-	return s.posts.RemoveCategory(ctx, slug, categorySlug)
+func (s *Service) RemoveCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
+	return s.posts.RemoveCategory(ctx, slug, actorID, categorySlug)
 }
 
 // AddTag assigns a tag to a post.
-func (s *Service) AddTag(ctx context.Context, slug, tagSlug string) error {
-	return s.posts.AddTag(ctx, slug, tagSlug)
+func (s *Service) AddTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
+	return s.posts.AddTag(ctx, slug, actorID, tagSlug)
+}
+
+// SuggestTags suggests tags for a saved post, leaving out the tags it already carries.
+func (s *Service) SuggestTags(ctx context.Context, post postdomain.PostWithRelations) (postdomain.TagSuggestions, error) {
+	current := make([]string, len(post.Tags))
+	for i, t := range post.Tags {
+		current[i] = t.Slug
+	}
+	return s.tagHints.Suggest(ctx, postdomain.SuggestTagsInput{Title: post.Post.Title, ContentMD: post.Post.ContentMD, Tags: current})
+}
+
+// CreateTagForPost creates a tag named name and assigns it to the post.
+func (s *Service) CreateTagForPost(ctx context.Context, slug string, actorID int64, name string) (taxdomain.Tag, error) {
+	tag, err := s.taxonomy.CreateTag(ctx, taxdomain.CreateTagInput{Name: name})
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	return tag, s.posts.AddTag(ctx, slug, actorID, tag.Slug)
 }
 
 // RemoveTag removes a tag from a post.
-func (s *Service) RemoveTag(ctx context.Context, slug, tagSlug string) error {
-	return s.posts.RemoveTag(ctx, slug, tagSlug)
+func (s *Service) RemoveTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
+	return s.posts.RemoveTag(ctx, slug, actorID, tagSlug)
 }
 
+// ListPages lists every page, drafts included.
+func (s *Service) ListPages(ctx context.Context) ([]pagedomain.Page, error) {
+	return s.pages.List(ctx)
+}
 
+// GetPage fetches a page and its ancestors by slug.
+func (s *Service) GetPage(ctx context.Context, slug string) (pagedomain.PageWithRelations, error) {
+	return s.pages.Get(ctx, strings.TrimSpace(slug))
+}
+
+// CreatePage creates a page from admin form input.
+func (s *Service) CreatePage(ctx context.Context, input pagedomain.CreatePageInput) (pagedomain.Page, error) {
+	return s.pages.Create(ctx, input)
+}
+
+// UpdatePage updates a page identified by input.Slug.
+func (s *Service) UpdatePage(ctx context.Context, input pagedomain.UpdatePageInput) (pagedomain.Page, error) {
+	return s.pages.Update(ctx, input)
+}
+
+// DeletePage removes a page by slug.
+func (s *Service) DeletePage(ctx context.Context, slug string) error {
+	if strings.TrimSpace(slug) == "" {
+		return errors.New("adminui: slug is required")
+	}
+	return s.pages.Delete(ctx, strings.TrimSpace(slug))
+}
+
+// ListMenus lists every navigation menu.
+func (s *Service) ListMenus(ctx context.Context) ([]menudomain.Menu, error) {
+	return s.menus.ListMenus(ctx)
+}
+
+// GetMenu fetches a menu with its item tree.
+func (s *Service) GetMenu(ctx context.Context, name string) (menudomain.MenuWithItems, error) {
+	return s.menus.GetMenu(ctx, name)
+}
+
+// CreateMenu creates a menu from admin form input.
+func (s *Service) CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error) {
+	return s.menus.CreateMenu(ctx, input)
+}
+
+// DeleteMenu removes a menu and its items.
+func (s *Service) DeleteMenu(ctx context.Context, name string) error {
+	return s.menus.DeleteMenu(ctx, name)
+}
+
+// CreateMenuItem adds an item to the named menu.
+func (s *Service) CreateMenuItem(ctx context.Context, menuName string, input menudomain.ItemInput) (menudomain.Item, error) {
+	return s.menus.CreateItem(ctx, menuName, input)
+}
+
+// UpdateMenuItem replaces an item of the named menu.
+func (s *Service) UpdateMenuItem(ctx context.Context, menuName string, id int64, input menudomain.ItemInput) (menudomain.Item, error) {
+	return s.menus.UpdateItem(ctx, menuName, id, input)
+}
 
+// DeleteMenuItem removes an item and its nested items.
+func (s *Service) DeleteMenuItem(ctx context.Context, menuName string, id int64) error {
+	return s.menus.DeleteItem(ctx, menuName, id)
+}
+
+// formUnpublishAt parses the unpublish date of the post form; blank means none.
+func formUnpublishAt(raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(formDateTimeLayout, raw, time.Local)
+	if err != nil {
+		v := &validation.Error{}
+		v.Add("unpublish_at", postdomain.ErrUnpublishAtInvalid)
+		return nil, v
+	}
+	return &t, nil
+}
+
+// formFieldValues widens form strings to the untyped values the post use case
+// coerces; blank inputs are dropped by that coercion.
+func formFieldValues(form map[string]string) map[string]any {
+	out := make(map[string]any, len(form))
+	for k, v := range form {
+		out[k] = v
+	}
+	return out
+}
+
+// ListWebhooks lists every webhook subscription.
+func (s *Service) ListWebhooks(ctx context.Context) ([]webhookdomain.Subscription, error) {
+	return s.webhooks.ListSubscriptions(ctx)
+}
+
+// GetWebhook fetches a webhook subscription.
+func (s *Service) GetWebhook(ctx context.Context, id int64) (webhookdomain.Subscription, error) {
+	return s.webhooks.GetSubscription(ctx, id)
+}
+
+// CreateWebhook creates a webhook subscription.
+func (s *Service) CreateWebhook(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	return s.webhooks.CreateSubscription(ctx, input)
+}
+
+// UpdateWebhook updates a webhook subscription.
+func (s *Service) UpdateWebhook(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	return s.webhooks.UpdateSubscription(ctx, id, input)
+}
+
+// DeleteWebhook removes a webhook subscription and its deliveries.
+func (s *Service) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.webhooks.DeleteSubscription(ctx, id)
+}
+
+// WebhookDeliveries lists the recent deliveries of a webhook subscription.
+func (s *Service) WebhookDeliveries(ctx context.Context, id int64) ([]webhookdomain.Delivery, error) {
+	return s.webhooks.Deliveries(ctx, id)
+}
+
+// WebhookDelivery fetches a delivery with its attempts.
+func (s *Service) WebhookDelivery(ctx context.Context, id int64) (webhookdomain.DeliveryDetail, error) {
+	return s.webhooks.Delivery(ctx, id)
+}
+
+// RedeliverWebhook queues a delivery to be sent again.
+func (s *Service) RedeliverWebhook(ctx context.Context, id int64) (webhookdomain.Delivery, error) {
+	return s.webhooks.Redeliver(ctx, id)
+}
+
+// UploadMedia stores a file in the media library, reusing an identical one.
+func (s *Service) UploadMedia(ctx context.Context, input mediadomain.UploadInput) (mediadomain.Media, error) {
+	return s.media.Upload(ctx, input)
+}
+
+// ListMedia lists the media library page, optionally searched.
+func (s *Service) ListMedia(ctx context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error) {
+	return s.media.List(ctx, opts)
+}
+
+// GetMedia fetches a file with the content that references it.
+func (s *Service) GetMedia(ctx context.Context, id int64) (mediadomain.MediaDetail, error) {
+	return s.media.Get(ctx, id)
+}
+
+// UpdateMedia saves the alt text and caption form of a file.
+func (s *Service) UpdateMedia(ctx context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error) {
+	return s.media.UpdateText(ctx, id, input)
+}
+
+// DeleteMedia removes a file no content references any more.
+func (s *Service) DeleteMedia(ctx context.Context, id int64) error {
+	return s.media.Delete(ctx, id)
+}
+
+// ListCategories lists every category in display order.
+func (s *Service) ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error) {
+	return s.taxonomy.ListCategories(ctx)
+}
+
+// GetCategory fetches a category by slug.
+func (s *Service) GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error) {
+	return s.taxonomy.GetCategory(ctx, slug)
+}
+
+// UpdateCategory saves the category edit form.
+func (s *Service) UpdateCategory(ctx context.Context, slug string, input taxdomain.UpdateCategoryInput) (taxdomain.Category, error) {
+	return s.taxonomy.UpdateCategory(ctx, slug, input)
+}
+
+// ListTags lists every tag in display order.
+func (s *Service) ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error) {
+	return s.taxonomy.ListTags(ctx)
+}
+
+// GetTag fetches a tag by slug.
+func (s *Service) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	return s.taxonomy.GetTag(ctx, slug)
+}
+
+// UpdateTag saves the tag edit form.
+func (s *Service) UpdateTag(ctx context.Context, slug string, input taxdomain.UpdateTagInput) (taxdomain.Tag, error) {
+	return s.taxonomy.UpdateTag(ctx, slug, input)
+}
+
+// ListDuplicateTags groups tags whose names look alike.
+func (s *Service) ListDuplicateTags(ctx context.Context) ([]taxdomain.DuplicateTagGroup, error) {
+	return s.taxonomy.ListDuplicateTags(ctx)
+}
+
+// MergeTags folds the checked tags of a duplicate group into target. The target may
+// be among the checked tags; it is left out of the sources.
+func (s *Service) MergeTags(ctx context.Context, target string, checked []string) (taxdomain.Tag, error) {
+	sources := make([]string, 0, len(checked))
+	for _, slug := range checked {
+		if slug != target {
+			sources = append(sources, slug)
+		}
+	}
+	return s.taxonomy.MergeTags(ctx, target, sources)
+}
+
+// ListTagAliases lists the slugs left behind by merged tags.
+func (s *Service) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	return s.taxonomy.ListTagAliases(ctx)
+}
+
+// DeleteTagAlias removes a merged tag's alias.
+func (s *Service) DeleteTagAlias(ctx context.Context, slug string) error {
+	return s.taxonomy.DeleteTagAlias(ctx, slug)
+}
+
+// ListSubscribers lists newsletter subscribers, optionally only those with status.
+func (s *Service) ListSubscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error) {
+	return s.newsletter.Subscribers(ctx, status)
+}
@@ -0,0 +1,43 @@
+package webhookdomain
+
+import postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+
+// Lifecycle event types sent to subscribers, named after what readers of the site see:
+// a post appears, changes, disappears or is removed for good.
+const (
+	EventPostPublished   = "post.published"
+	EventPostUpdated     = "post.updated"
+	EventPostUnpublished = "post.unpublished"
+	EventPostDeleted     = "post.deleted"
+)
+
+// EventTypes lists every lifecycle event type in a stable order.
+var EventTypes = []string{EventPostPublished, EventPostUpdated, EventPostUnpublished, EventPostDeleted}
+
+// IsValidEventType reports whether t is a known lifecycle event type.
+func IsValidEventType(t string) bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// LifecycleEvent returns the event a write from one status to another produces, or ""
+// when the post was not visible before or after it. An empty to means the post was
+// deleted.
+func LifecycleEvent(from, to string) string {
+	wasLive := from == postdomain.StatusPublished
+	switch {
+	case to == "" && wasLive:
+		return EventPostDeleted
+	case to == postdomain.StatusPublished && wasLive:
+		return EventPostUpdated
+	case to == postdomain.StatusPublished:
+		return EventPostPublished
+	case wasLive:
+		return EventPostUnpublished
+	}
+	return ""
+}
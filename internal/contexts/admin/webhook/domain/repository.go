@@ -0,0 +1,30 @@
+package webhookdomain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookRepository abstracts persistence of subscriptions and the delivery queue.
+type WebhookRepository interface {
+	ListSubscriptions(ctx context.Context) ([]Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (Subscription, error)
+	CreateSubscription(ctx context.Context, input SubscriptionInput) (Subscription, error)
+	UpdateSubscription(ctx context.Context, id int64, input SubscriptionInput) (Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	// EnqueueDeliveries queues payload for every active subscription to event and
+	// returns how many deliveries were queued.
+	EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries of active subscriptions
+	// due at now and pushes their next attempt back by lease, so concurrent workers skip
+	// them and a crashed worker's claims are retried once the lease runs out.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int32, lease time.Duration) ([]QueuedDelivery, error)
+	// RecordAttempt stores an attempt and updates its delivery in one statement.
+	RecordAttempt(ctx context.Context, record AttemptRecord) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int32) ([]Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (Delivery, error)
+	ListAttempts(ctx context.Context, deliveryID int64) ([]Attempt, error)
+	// RequeueDelivery makes a delivery pending and due at now with a fresh attempt
+	// budget, keeping its attempt history.
+	RequeueDelivery(ctx context.Context, id int64, now time.Time) (Delivery, error)
+}
@@ -0,0 +1,118 @@
+package webhookdomain
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Field length limits.
+const (
+	MaxURLLength    = 2048
+	MinSecretLength = 16
+	MaxSecretLength = 200
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook: subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook: delivery not found")
+
+	ErrURLRequired    = errors.New("url is required")
+	ErrURLInvalid     = errors.New("url must be an absolute http(s) URL")
+	ErrURLTooLong     = errors.New("url must be at most " + strconv.Itoa(MaxURLLength) + " characters")
+	ErrURLForbidden   = errors.New("url must not point to a loopback, private or link-local address")
+	ErrURLUnresolved  = errors.New("url host could not be resolved")
+	ErrSecretTooShort = errors.New("secret must be at least " + strconv.Itoa(MinSecretLength) + " characters")
+	ErrSecretTooLong  = errors.New("secret must be at most " + strconv.Itoa(MaxSecretLength) + " characters")
+	ErrEventsRequired = errors.New("events must list at least one event")
+	ErrEventInvalid   = errors.New("events must be among " + strings.Join(EventTypes, ", "))
+)
+
+// ValidateSubscription checks a subscription. An empty secret is accepted; the caller
+// generates or keeps one.
+func ValidateSubscription(input SubscriptionInput) *validation.Error {
+	v := &validation.Error{}
+	switch {
+	case input.URL == "":
+		v.Add("url", ErrURLRequired)
+	case len(input.URL) > MaxURLLength:
+		v.Add("url", ErrURLTooLong)
+	case !isAllowedURL(input.URL):
+		v.Add("url", ErrURLInvalid)
+	case isForbiddenHost(URLHost(input.URL)):
+		v.Add("url", ErrURLForbidden)
+	}
+	switch {
+	case input.Secret == "":
+	case len(input.Secret) < MinSecretLength:
+		v.Add("secret", ErrSecretTooShort)
+	case len(input.Secret) > MaxSecretLength:
+		v.Add("secret", ErrSecretTooLong)
+	}
+	if len(input.Events) == 0 {
+		v.Add("events", ErrEventsRequired)
+	}
+	for _, event := range input.Events {
+		if !IsValidEventType(event) {
+			v.Add("events", ErrEventInvalid)
+			break
+		}
+	}
+	return v
+}
+
+func isAllowedURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// URLHost returns the host of raw without port or IPv6 brackets, or "" when raw does
+// not parse.
+func URLHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// IsForbiddenAddr reports whether deliveries must not reach addr: loopback, private,
+// link-local (which includes the 169.254.169.254 cloud metadata endpoint),
+// carrier-grade NAT, unspecified and multicast addresses all belong to the server's
+// own network rather than to a subscriber.
+func IsForbiddenAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		thisNetwork.Contains(addr) ||
+		sharedAddressSpace.Contains(addr)
+}
+
+var (
+	thisNetwork        = netip.MustParsePrefix("0.0.0.0/8")
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+)
+
+// isForbiddenHost catches the hosts that need no lookup: localhost names and IP
+// literals. Other names are resolved by the caller.
+func isForbiddenHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && IsForbiddenAddr(addr)
+}
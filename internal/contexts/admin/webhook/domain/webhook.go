@@ -0,0 +1,108 @@
+package webhookdomain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Delivery statuses. Pending deliveries wait in the queue for their next attempt;
+// failed ones ran out of attempts and only leave the queue through a manual redelivery.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Subscription sends the selected lifecycle events to an endpoint, signed with secret.
+type Subscription struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SubscriptionInput carries the editable fields of a subscription. An empty secret
+// generates one on create and keeps the stored one on update.
+type SubscriptionInput struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+// Delivery is one event queued for one subscription.
+type Delivery struct {
+	ID              int64           `json:"id"`
+	SubscriptionID  int64           `json:"subscription_id"`
+	SubscriptionURL string          `json:"subscription_url"`
+	Event           string          `json:"event"`
+	Payload         json.RawMessage `json:"payload"`
+	Status          string          `json:"status"`
+	Attempts        int32           `json:"attempts"`
+	NextAttemptAt   *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode  int32           `json:"last_status_code"`
+	LastError       string          `json:"last_error"`
+	CreatedAt       time.Time       `json:"created_at"`
+	DeliveredAt     *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// QueuedDelivery is a due delivery claimed for sending, with the secret to sign it.
+type QueuedDelivery struct {
+	Delivery
+	Secret string
+}
+
+// Attempt records one HTTP request made for a delivery. StatusCode is 0 when no
+// response came back.
+type Attempt struct {
+	ID          int64     `json:"id"`
+	DeliveryID  int64     `json:"delivery_id"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int32     `json:"status_code"`
+	Error       string    `json:"error"`
+	DurationMS  int64     `json:"duration_ms"`
+}
+
+// AttemptRecord stores an attempt and moves its delivery to Status. NextAttemptAt is
+// set when the delivery stays pending.
+type AttemptRecord struct {
+	DeliveryID    int64
+	AttemptedAt   time.Time
+	StatusCode    int32
+	Error         string
+	Duration      time.Duration
+	Status        string
+	NextAttemptAt *time.Time
+}
+
+// DeliveryDetail is a delivery with its attempt history, newest first.
+type DeliveryDetail struct {
+	Delivery Delivery  `json:"delivery"`
+	Attempts []Attempt `json:"attempts"`
+}
+
+// Sign returns the signature header value for body sent at timestamp: the hex
+// HMAC-SHA256 of "<unix timestamp>.<body>" keyed with secret. Receivers recompute it
+// and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
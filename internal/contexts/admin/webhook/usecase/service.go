@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/outbox"
+)
+
+const (
+	// MaxAttempts is how many times a delivery is tried before it is marked failed.
+	MaxAttempts = 10
+
+	retryBase      = 30 * time.Second
+	retryCap       = 6 * time.Hour
+	requestTimeout = 10 * time.Second
+	claimBatch     = 10
+	// claimLease outlasts a batch of requests that all time out.
+	claimLease        = 5 * time.Minute
+	deliveryListLimit = 50
+	maxErrorLength    = 500
+
+	// OutboxHandlerName is the name the outbox relay keeps the webhook offset under.
+	OutboxHandlerName = "webhooks"
+)
+
+// WebhookService manages webhook subscriptions and their delivery log.
+type WebhookService interface {
+	ListSubscriptions(ctx context.Context) ([]webhookdomain.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (webhookdomain.Subscription, error)
+	CreateSubscription(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error)
+	UpdateSubscription(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	Deliveries(ctx context.Context, subscriptionID int64) ([]webhookdomain.Delivery, error)
+	Delivery(ctx context.Context, id int64) (webhookdomain.DeliveryDetail, error)
+	Redeliver(ctx context.Context, id int64) (webhookdomain.Delivery, error)
+}
+
+// Service implements WebhookService and turns post events from the outbox into
+// lifecycle events on the delivery queue. Queueing only stores deliveries; DeliverDue
+// sends them from a background job, retrying failures with exponential backoff.
+type Service struct {
+	repo     webhookdomain.WebhookRepository
+	baseURL  string
+	client   *http.Client
+	lookupIP func(ctx context.Context, host string) ([]netip.Addr, error)
+	now      func() time.Time
+}
+
+var _ WebhookService = (*Service)(nil)
+
+// NewService wires a webhook repository into a use case implementation. baseURL builds
+// the public post links in payloads; a nil client uses one with a short timeout that
+// refuses to connect to loopback, private and link-local addresses.
+func NewService(repo webhookdomain.WebhookRepository, baseURL string, client *http.Client) *Service {
+	if client == nil {
+		client = newGuardedClient()
+	}
+	return &Service{
+		repo:     repo,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		client:   client,
+		lookupIP: lookupIP,
+		now:      time.Now,
+	}
+}
+
+// newGuardedClient returns a client that checks every address it dials, after DNS
+// resolution and on redirects, so a host that resolves differently at delivery time
+// than when it was saved still cannot reach the server's own network. It ignores
+// proxy settings, which would otherwise be the only address checked.
+func newGuardedClient() *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: refuseForbiddenAddr}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
+
+// errForbiddenAddr is the delivery error for a dial to a forbidden address.
+var errForbiddenAddr = errors.New("webhook: endpoint resolves to a forbidden address")
+
+func refuseForbiddenAddr(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || webhookdomain.IsForbiddenAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errForbiddenAddr, address)
+	}
+	return nil
+}
+
+func lookupIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]webhookdomain.Subscription, error) {
+	return s.repo.ListSubscriptions(ctx)
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id int64) (webhookdomain.Subscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+// CreateSubscription validates and stores a subscription, generating a secret when
+// none is given.
+func (s *Service) CreateSubscription(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	input = normalizeInput(input)
+	if err := s.validate(ctx, input); err != nil {
+		return webhookdomain.Subscription{}, err
+	}
+	if input.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return webhookdomain.Subscription{}, err
+		}
+		input.Secret = secret
+	}
+	return s.repo.CreateSubscription(ctx, input)
+}
+
+// UpdateSubscription replaces the editable fields of a subscription. An empty secret
+// keeps the stored one.
+func (s *Service) UpdateSubscription(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	input = normalizeInput(input)
+	if err := s.validate(ctx, input); err != nil {
+		return webhookdomain.Subscription{}, err
+	}
+	if input.Secret == "" {
+		current, err := s.repo.GetSubscription(ctx, id)
+		if err != nil {
+			return webhookdomain.Subscription{}, err
+		}
+		input.Secret = current.Secret
+	}
+	return s.repo.UpdateSubscription(ctx, id, input)
+}
+
+// validate checks input and, when the URL is otherwise valid, resolves its host and
+// rejects it if any address it resolves to is forbidden.
+func (s *Service) validate(ctx context.Context, input webhookdomain.SubscriptionInput) error {
+	v := webhookdomain.ValidateSubscription(input)
+	if !v.Has("url") {
+		addrs, err := s.lookupIP(ctx, webhookdomain.URLHost(input.URL))
+		switch {
+		case err != nil || len(addrs) == 0:
+			v.Add("url", webhookdomain.ErrURLUnresolved)
+		case slices.ContainsFunc(addrs, webhookdomain.IsForbiddenAddr):
+			v.Add("url", webhookdomain.ErrURLForbidden)
+		}
+	}
+	return v.Err()
+}
+
+// DeleteSubscription removes a subscription along with its queued and logged
+// deliveries.
+func (s *Service) DeleteSubscription(ctx context.Context, id int64) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// Deliveries returns the most recent deliveries of a subscription, newest first.
+func (s *Service) Deliveries(ctx context.Context, subscriptionID int64) ([]webhookdomain.Delivery, error) {
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, subscriptionID, deliveryListLimit)
+}
+
+// Delivery returns a delivery with every attempt made for it.
+func (s *Service) Delivery(ctx context.Context, id int64) (webhookdomain.DeliveryDetail, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return webhookdomain.DeliveryDetail{}, err
+	}
+	attempts, err := s.repo.ListAttempts(ctx, id)
+	if err != nil {
+		return webhookdomain.DeliveryDetail{}, err
+	}
+	return webhookdomain.DeliveryDetail{Delivery: delivery, Attempts: attempts}, nil
+}
+
+// Redeliver queues a delivery again with a fresh attempt budget, whatever its status.
+// The next DeliverDue run sends it, unless its subscription is inactive.
+func (s *Service) Redeliver(ctx context.Context, id int64) (webhookdomain.Delivery, error) {
+	return s.repo.RequeueDelivery(ctx, id, s.now().UTC())
+}
+
+type eventPayload struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       eventData `json:"data"`
+}
+
+type eventData struct {
+	Post postdomain.Post `json:"post"`
+	URL  string          `json:"url"`
+}
+
+// OutboxHandler returns the relay handler that queues deliveries for post events.
+func (s *Service) OutboxHandler() outbox.Handler {
+	return outbox.Handler{Name: OutboxHandlerName, Types: postdomain.EventTypes, Handle: s.HandleEvent}
+}
+
+// HandleEvent queues the lifecycle event of a post outbox message for every active
+// subscription to it. Writes that readers cannot see, such as draft edits, queue
+// nothing. It runs in the relay transaction, so a message is queued once even when
+// the relay retries it.
+func (s *Service) HandleEvent(ctx context.Context, msg outbox.Message) error {
+	var event postdomain.Event
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("decode %s payload: %w", msg.Type, err)
+	}
+	to := event.Post.Status
+	if msg.Type == postdomain.EventPostDeleted {
+		to = ""
+	}
+	lifecycle := webhookdomain.LifecycleEvent(event.PreviousStatus, to)
+	if lifecycle == "" {
+		return nil
+	}
+	payload, err := json.Marshal(eventPayload{
+		Event:      lifecycle,
+		OccurredAt: msg.OccurredAt,
+		Data: eventData{
+			Post: event.Post,
+			URL:  s.baseURL + "/posts/" + event.Post.Slug,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.EnqueueDeliveries(ctx, lifecycle, payload)
+	return err
+}
+
+// DeliverDue sends every due delivery and returns how many reached their endpoint.
+// Endpoint failures are recorded on the delivery, not returned; the error only
+// reports deliveries whose outcome could not be stored.
+func (s *Service) DeliverDue(ctx context.Context) (int, error) {
+	delivered := 0
+	var errs []error
+	for {
+		batch, err := s.repo.ClaimDueDeliveries(ctx, s.now().UTC(), claimBatch, claimLease)
+		if err != nil {
+			return delivered, errors.Join(append(errs, err)...)
+		}
+		for _, d := range batch {
+			record := s.send(ctx, d)
+			if record.Status == webhookdomain.DeliveryDelivered {
+				delivered++
+			}
+			if err := s.repo.RecordAttempt(ctx, record); err != nil {
+				errs = append(errs, fmt.Errorf("delivery %d: %w", d.ID, err))
+			}
+		}
+		if len(batch) < claimBatch || ctx.Err() != nil {
+			return delivered, errors.Join(errs...)
+		}
+	}
+}
+
+// send posts a delivery to its endpoint and returns the outcome to record.
+func (s *Service) send(ctx context.Context, d webhookdomain.QueuedDelivery) webhookdomain.AttemptRecord {
+	started := s.now().UTC()
+	record := webhookdomain.AttemptRecord{DeliveryID: d.ID, AttemptedAt: started}
+
+	statusCode, err := s.post(ctx, d, started)
+	record.StatusCode = int32(statusCode)
+	record.Duration = s.now().Sub(started)
+	if err == nil {
+		record.Status = webhookdomain.DeliveryDelivered
+		return record
+	}
+	record.Error = truncate(err.Error(), maxErrorLength)
+	attempts := d.Attempts + 1
+	if attempts >= MaxAttempts {
+		record.Status = webhookdomain.DeliveryFailed
+		return record
+	}
+	next := started.Add(backoff(attempts))
+	record.Status = webhookdomain.DeliveryPending
+	record.NextAttemptAt = &next
+	return record
+}
+
+func (s *Service) post(ctx context.Context, d webhookdomain.QueuedDelivery, at time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.SubscriptionURL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookdomain.HeaderEvent, d.Event)
+	req.Header.Set(webhookdomain.HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(webhookdomain.HeaderTimestamp, strconv.FormatInt(at.Unix(), 10))
+	req.Header.Set(webhookdomain.HeaderSignature, webhookdomain.Sign(d.Secret, at, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.New("unexpected status " + resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait after the given number of failed attempts: 30s, 1m, 2m
+// and so on, capped at six hours.
+func backoff(attempts int32) time.Duration {
+	wait := retryBase
+	for i := int32(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= retryCap {
+			return retryCap
+		}
+	}
+	return wait
+}
+
+// normalizeInput trims the input and keeps each known event once, in EventTypes order,
+// so unknown ones are still reported by validation.
+func normalizeInput(input webhookdomain.SubscriptionInput) webhookdomain.SubscriptionInput {
+	input.URL = strings.TrimSpace(input.URL)
+	input.Secret = strings.TrimSpace(input.Secret)
+	seen := make(map[string]bool, len(input.Events))
+	var unknown []string
+	for _, event := range input.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		switch {
+		case event == "" || seen[event]:
+		case webhookdomain.IsValidEventType(event):
+			seen[event] = true
+		default:
+			seen[event] = true
+			unknown = append(unknown, event)
+		}
+	}
+	events := make([]string, 0, len(seen))
+	for _, event := range webhookdomain.EventTypes {
+		if seen[event] {
+			events = append(events, event)
+		}
+	}
+	input.Events = append(events, unknown...)
+	return input
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/validation"
+)
+
+func TestCreateSubscriptionNormalizesAndGeneratesSecret(t *testing.T) {
+	repo := &fakeWebhookRepo{}
+	svc := NewService(repo, "https://blog.example.com/", nil)
+	svc.lookupIP = func(context.Context, string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+	}
+
+	sub, err := svc.CreateSubscription(context.Background(), webhookdomain.SubscriptionInput{
+		URL:    " https://hooks.example.com/blog ",
+		Events: []string{" POST.DELETED", "post.published", "post.deleted", ""},
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.URL != "https://hooks.example.com/blog" {
+		t.Fatalf("expected trimmed url, got %q", sub.URL)
+	}
+	if len(sub.Events) != 2 || sub.Events[0] != webhookdomain.EventPostPublished || sub.Events[1] != webhookdomain.EventPostDeleted {
+		t.Fatalf("expected deduplicated events in order, got %v", sub.Events)
+	}
+	if len(sub.Secret) < webhookdomain.MinSecretLength {
+		t.Fatalf("expected a generated secret, got %q", sub.Secret)
+	}
+}
+
+func TestCreateSubscriptionValidates(t *testing.T) {
+	svc := NewService(&fakeWebhookRepo{}, "https://blog.example.com/", nil)
+	svc.lookupIP = func(context.Context, string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+	}
+
+	_, err := svc.CreateSubscription(context.Background(), webhookdomain.SubscriptionInput{
+		URL:    "ftp://hooks.example.com",
+		Secret: "short",
+		Events: []string{"post.published", "post.renamed"},
+	})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, want := range []error{webhookdomain.ErrURLInvalid, webhookdomain.ErrSecretTooShort, webhookdomain.ErrEventInvalid} {
+		if !errors.Is(err, want) {
+			t.Errorf("expected %v in %v", want, err)
+		}
+	}
+
+	_, err = svc.CreateSubscription(context.Background(), webhookdomain.SubscriptionInput{URL: "https://hooks.example.com"})
+	if !errors.Is(err, webhookdomain.ErrEventsRequired) {
+		t.Fatalf("expected ErrEventsRequired, got %v", err)
+	}
+}
+
+func TestCreateSubscriptionRejectsInternalTargets(t *testing.T) {
+	cases := []struct {
+		url  string
+		addr string
+		want error
+	}{
+		{"http://127.0.0.1:8080/hook", "", webhookdomain.ErrURLForbidden},
+		{"http://localhost/hook", "", webhookdomain.ErrURLForbidden},
+		{"http://[::1]/hook", "", webhookdomain.ErrURLForbidden},
+		{"http://169.254.169.254/latest/meta-data/", "", webhookdomain.ErrURLForbidden},
+		{"https://10.0.0.8/hook", "", webhookdomain.ErrURLForbidden},
+		{"https://intranet.example.com/hook", "192.168.1.20", webhookdomain.ErrURLForbidden},
+		{"https://metadata.example.com/hook", "169.254.169.254", webhookdomain.ErrURLForbidden},
+		{"https://mapped.example.com/hook", "::ffff:127.0.0.1", webhookdomain.ErrURLForbidden},
+		{"https://gone.example.com/hook", "", webhookdomain.ErrURLUnresolved},
+	}
+	for _, tc := range cases {
+		t.Run(tc.url, func(t *testing.T) {
+			svc := NewService(&fakeWebhookRepo{}, "https://blog.example.com/", nil)
+			svc.lookupIP = func(ctx context.Context, host string) ([]netip.Addr, error) {
+				if tc.addr == "" {
+					return nil, errors.New("no such host")
+				}
+				return []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr(tc.addr)}, nil
+			}
+
+			_, err := svc.CreateSubscription(context.Background(), webhookdomain.SubscriptionInput{
+				URL:    tc.url,
+				Events: []string{webhookdomain.EventPostPublished},
+			})
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestUpdateSubscriptionKeepsSecret(t *testing.T) {
+	repo := &fakeWebhookRepo{subs: map[int64]webhookdomain.Subscription{
+		4: {ID: 4, URL: "https://old.example.com", Secret: "0123456789abcdef-old"},
+	}}
+	svc := NewService(repo, "https://blog.example.com/", nil)
+	svc.lookupIP = func(context.Context, string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+	}
+
+	sub, err := svc.UpdateSubscription(context.Background(), 4, webhookdomain.SubscriptionInput{
+		URL:    "https://new.example.com",
+		Events: []string{webhookdomain.EventPostUpdated},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.Secret != "0123456789abcdef-old" || sub.URL != "https://new.example.com" {
+		t.Fatalf("expected the stored secret to be kept, got %+v", sub)
+	}
+}
+
+func TestHandleEventQueuesPayload(t *testing.T) {
+	repo := &fakeWebhookRepo{}
+	svc := NewService(repo, "https://blog.example.com/", nil)
+	msg := postMessage(t, postdomain.EventPostPublished, postdomain.Event{
+		Post:           postdomain.Post{ID: 9, Slug: "hello", Title: "Hello", Status: postdomain.StatusPublished},
+		PreviousStatus: postdomain.StatusDraft,
+	})
+	msg.OccurredAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	err := svc.HandleEvent(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.enqueuedEvent != webhookdomain.EventPostPublished {
+		t.Fatalf("expected the lifecycle event to be queued, got %q", repo.enqueuedEvent)
+	}
+	var payload struct {
+		Event      string    `json:"event"`
+		OccurredAt time.Time `json:"occurred_at"`
+		Data       struct {
+			Post postdomain.Post `json:"post"`
+			URL  string          `json:"url"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(repo.enqueuedPayload, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Event != webhookdomain.EventPostPublished || payload.Data.Post.ID != 9 || payload.Data.URL != "https://blog.example.com/posts/hello" {
+		t.Fatalf("unexpected payload: %s", repo.enqueuedPayload)
+	}
+	if !payload.OccurredAt.Equal(msg.OccurredAt) {
+		t.Fatalf("expected the outbox time, got %v", payload.OccurredAt)
+	}
+}
+
+func TestHandleEventMapsToLifecycleEvents(t *testing.T) {
+	cases := []struct {
+		name     string
+		typ      string
+		previous string
+		status   string
+		want     string
+	}{
+		{"new draft", postdomain.EventPostUpdated, "", postdomain.StatusDraft, ""},
+		{"draft edit", postdomain.EventPostUpdated, postdomain.StatusDraft, postdomain.StatusDraft, ""},
+		{"live edit", postdomain.EventPostUpdated, postdomain.StatusPublished, postdomain.StatusPublished, webhookdomain.EventPostUpdated},
+		{"archived", postdomain.EventPostUpdated, postdomain.StatusPublished, postdomain.StatusArchived, webhookdomain.EventPostUnpublished},
+		{"live delete", postdomain.EventPostDeleted, postdomain.StatusPublished, postdomain.StatusPublished, webhookdomain.EventPostDeleted},
+		{"draft delete", postdomain.EventPostDeleted, postdomain.StatusDraft, postdomain.StatusDraft, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeWebhookRepo{}
+			svc := NewService(repo, "https://blog.example.com/", nil)
+			err := svc.HandleEvent(context.Background(), postMessage(t, tc.typ, postdomain.Event{
+				Post:           postdomain.Post{Slug: "hello", Status: tc.status},
+				PreviousStatus: tc.previous,
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.enqueuedEvent != tc.want {
+				t.Fatalf("expected %q to be queued, got %q", tc.want, repo.enqueuedEvent)
+			}
+		})
+	}
+}
+
+func postMessage(t *testing.T, eventType string, event postdomain.Event) outbox.Message {
+	t.Helper()
+	msg, err := outbox.NewMessage(eventType, event.Post.Slug, event)
+	if err != nil {
+		t.Fatalf("new message: %v", err)
+	}
+	return msg
+}
+
+func TestDeliverDueSignsRequests(t *testing.T) {
+	body := []byte(`{"event":"post.published"}`)
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{queue: []webhookdomain.QueuedDelivery{{
+		Delivery: webhookdomain.Delivery{ID: 12, SubscriptionURL: server.URL, Event: webhookdomain.EventPostPublished, Payload: body},
+		Secret:   "0123456789abcdef",
+	}}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(repo, "https://blog.example.com/", server.Client())
+	svc.now = func() time.Time { return now }
+
+	delivered, err := svc.DeliverDue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("expected one delivery, got %d", delivered)
+	}
+	if got.Header.Get(webhookdomain.HeaderEvent) != webhookdomain.EventPostPublished || got.Header.Get(webhookdomain.HeaderDelivery) != "12" {
+		t.Fatalf("unexpected headers: %v", got.Header)
+	}
+	if got.Header.Get(webhookdomain.HeaderTimestamp) != strconv.FormatInt(now.Unix(), 10) {
+		t.Fatalf("unexpected timestamp: %q", got.Header.Get(webhookdomain.HeaderTimestamp))
+	}
+	if got.Header.Get(webhookdomain.HeaderSignature) != webhookdomain.Sign("0123456789abcdef", now, gotBody) {
+		t.Fatalf("signature does not match body")
+	}
+	record := repo.records[0]
+	if record.Status != webhookdomain.DeliveryDelivered || record.StatusCode != http.StatusNoContent || record.NextAttemptAt != nil {
+		t.Fatalf("unexpected attempt record: %+v", record)
+	}
+}
+
+func TestDeliverDueRetriesWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{queue: []webhookdomain.QueuedDelivery{
+		{Delivery: webhookdomain.Delivery{ID: 1, SubscriptionURL: server.URL, Attempts: 0}},
+		{Delivery: webhookdomain.Delivery{ID: 2, SubscriptionURL: server.URL, Attempts: 3}},
+		{Delivery: webhookdomain.Delivery{ID: 3, SubscriptionURL: server.URL, Attempts: MaxAttempts - 1}},
+	}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(repo, "https://blog.example.com/", server.Client())
+	svc.now = func() time.Time { return now }
+
+	delivered, err := svc.DeliverDue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivered != 0 || len(repo.records) != 3 {
+		t.Fatalf("expected three failed attempts, got %d delivered / %+v", delivered, repo.records)
+	}
+	for i, wait := range []time.Duration{30 * time.Second, 4 * time.Minute} {
+		record := repo.records[i]
+		if record.Status != webhookdomain.DeliveryPending || record.StatusCode != http.StatusBadGateway || record.Error == "" {
+			t.Fatalf("unexpected attempt record: %+v", record)
+		}
+		if record.NextAttemptAt == nil || !record.NextAttemptAt.Equal(now.Add(wait)) {
+			t.Fatalf("expected a retry after %s, got %v", wait, record.NextAttemptAt)
+		}
+	}
+	if last := repo.records[2]; last.Status != webhookdomain.DeliveryFailed || last.NextAttemptAt != nil {
+		t.Fatalf("expected the last attempt to fail the delivery, got %+v", last)
+	}
+}
+
+func TestDeliverDueRecordsUnreachableEndpoints(t *testing.T) {
+	repo := &fakeWebhookRepo{queue: []webhookdomain.QueuedDelivery{
+		{Delivery: webhookdomain.Delivery{ID: 1, SubscriptionURL: "http://127.0.0.1:1/hook"}},
+	}}
+	svc := NewService(repo, "https://blog.example.com/", &http.Client{})
+
+	if _, err := svc.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("endpoint failures should not be returned, got %v", err)
+	}
+	if record := repo.records[0]; record.StatusCode != 0 || record.Error == "" || record.Status != webhookdomain.DeliveryPending {
+		t.Fatalf("unexpected attempt record: %+v", record)
+	}
+}
+
+func TestDeliverDueRefusesForbiddenAddresses(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{queue: []webhookdomain.QueuedDelivery{
+		{Delivery: webhookdomain.Delivery{ID: 1, SubscriptionURL: server.URL}},
+	}}
+	svc := NewService(repo, "https://blog.example.com/", nil)
+
+	if _, err := svc.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("endpoint failures should not be returned, got %v", err)
+	}
+	if hit {
+		t.Fatal("expected the default client not to reach a loopback endpoint")
+	}
+	if record := repo.records[0]; record.Status != webhookdomain.DeliveryPending || !strings.Contains(record.Error, "forbidden address") {
+		t.Fatalf("unexpected attempt record: %+v", record)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	if got := backoff(1); got != 30*time.Second {
+		t.Fatalf("expected 30s after the first failure, got %s", got)
+	}
+	if got := backoff(40); got != retryCap {
+		t.Fatalf("expected the cap, got %s", got)
+	}
+}
+
+type fakeWebhookRepo struct {
+	subs            map[int64]webhookdomain.Subscription
+	nextID          int64
+	enqueuedEvent   string
+	enqueuedPayload []byte
+	queue           []webhookdomain.QueuedDelivery
+	records         []webhookdomain.AttemptRecord
+}
+
+func (f *fakeWebhookRepo) ListSubscriptions(context.Context) ([]webhookdomain.Subscription, error) {
+	var out []webhookdomain.Subscription
+	for _, sub := range f.subs {
+		out = append(out, sub)
+	}
+	return out, nil
+}
+
+func (f *fakeWebhookRepo) GetSubscription(ctx context.Context, id int64) (webhookdomain.Subscription, error) {
+	sub, ok := f.subs[id]
+	if !ok {
+		return webhookdomain.Subscription{}, webhookdomain.ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+func (f *fakeWebhookRepo) CreateSubscription(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	f.nextID++
+	return f.UpdateSubscription(ctx, f.nextID, input)
+}
+
+func (f *fakeWebhookRepo) UpdateSubscription(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	if f.subs == nil {
+		f.subs = map[int64]webhookdomain.Subscription{}
+	}
+	sub := webhookdomain.Subscription{ID: id, URL: input.URL, Secret: input.Secret, Events: input.Events, Active: input.Active}
+	f.subs[id] = sub
+	return sub, nil
+}
+
+func (f *fakeWebhookRepo) DeleteSubscription(ctx context.Context, id int64) error {
+	delete(f.subs, id)
+	return nil
+}
+
+func (f *fakeWebhookRepo) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error) {
+	f.enqueuedEvent = event
+	f.enqueuedPayload = payload
+	return 1, nil
+}
+
+// ClaimDueDeliveries hands out the whole queue once.
+func (f *fakeWebhookRepo) ClaimDueDeliveries(context.Context, time.Time, int32, time.Duration) ([]webhookdomain.QueuedDelivery, error) {
+	batch := f.queue
+	f.queue = nil
+	return batch, nil
+}
+
+func (f *fakeWebhookRepo) RecordAttempt(ctx context.Context, record webhookdomain.AttemptRecord) error {
+	f.records = append(f.records, record)
+	return nil
+}
+
+func (f *fakeWebhookRepo) ListDeliveries(context.Context, int64, int32) ([]webhookdomain.Delivery, error) {
+	return nil, nil
+}
+
+func (f *fakeWebhookRepo) GetDelivery(context.Context, int64) (webhookdomain.Delivery, error) {
+	return webhookdomain.Delivery{}, webhookdomain.ErrDeliveryNotFound
+}
+
+func (f *fakeWebhookRepo) ListAttempts(context.Context, int64) ([]webhookdomain.Attempt, error) {
+	return nil, nil
+}
+
+func (f *fakeWebhookRepo) RequeueDelivery(context.Context, int64, time.Time) (webhookdomain.Delivery, error) {
+	return webhookdomain.Delivery{}, webhookdomain.ErrDeliveryNotFound
+}
@@ -0,0 +1,31 @@
+package public
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	menuusecase "proto-gin-web/internal/contexts/blog/menu/usecase"
+	"proto-gin-web/internal/platform/http/ctxkeys"
+)
+
+// Middleware exposes a menu resolver to templates. Menus are only resolved when a
+// layout asks for one, so JSON and redirect responses cost nothing.
+func Middleware(menuSvc menuusecase.MenuService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ctxkeys.MenuLoader, func(name string) []menudomain.Link {
+			links, err := menuSvc.Resolve(c.Request.Context(), name)
+			if err != nil {
+				// A broken menu should not take the page down with it.
+				slog.Default().Error("menu: resolve",
+					slog.String("menu", name),
+					slog.String("path", c.FullPath()),
+					slog.Any("err", err))
+				return nil
+			}
+			return links
+		})
+		c.Next()
+	}
+}
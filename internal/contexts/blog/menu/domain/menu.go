@@ -0,0 +1,117 @@
+package menudomain
+
+import "time"
+
+// Item target types. Internal targets are stored by id so renamed or moved content
+// keeps its menu entries; url targets carry their link verbatim.
+const (
+	TargetPost     = "post"
+	TargetPage     = "page"
+	TargetCategory = "category"
+	TargetTag      = "tag"
+	TargetURL      = "url"
+)
+
+// Menu is a named navigation slot such as "header" or "footer".
+type Menu struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Item is a single menu entry. The target fields after Position are resolved from the
+// target tables when the item is read.
+type Item struct {
+	ID         int64  `json:"id"`
+	MenuID     int64  `json:"menu_id"`
+	ParentID   *int64 `json:"parent_id,omitempty"`
+	Label      string `json:"label"`
+	TargetType string `json:"target_type"`
+	TargetID   *int64 `json:"target_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Position   int32  `json:"position"`
+
+	// TargetSlug is the current slug of an internal target, empty once it is deleted.
+	TargetSlug string `json:"target_slug,omitempty"`
+	// TargetPath is the public path of a page target, including its parents.
+	TargetPath string `json:"-"`
+	// TargetLive reports whether the target is publicly visible (published posts and pages).
+	TargetLive bool `json:"target_live"`
+}
+
+// Href returns the public link for the item, or "" when its target is gone or hidden.
+func (i Item) Href() string {
+	if i.TargetType == TargetURL {
+		return i.URL
+	}
+	if !i.TargetLive || i.TargetSlug == "" {
+		return ""
+	}
+	switch i.TargetType {
+	case TargetPost:
+		return "/posts/" + i.TargetSlug
+	case TargetPage:
+		return i.TargetPath
+	case TargetCategory:
+		return "/categories/" + i.TargetSlug
+	case TargetTag:
+		return "/tags/" + i.TargetSlug
+	}
+	return ""
+}
+
+// ItemNode is an item with its nested children, used by the admin editor.
+type ItemNode struct {
+	Item
+	Href     string     `json:"href"`
+	Children []ItemNode `json:"children"`
+}
+
+// MenuWithItems bundles a menu with its item tree.
+type MenuWithItems struct {
+	Menu  Menu       `json:"menu"`
+	Items []ItemNode `json:"items"`
+}
+
+// Link is a resolved, renderable menu entry.
+type Link struct {
+	Label    string `json:"label"`
+	URL      string `json:"url"`
+	Children []Link `json:"children,omitempty"`
+}
+
+// MenuInput describes a new menu.
+type MenuInput struct {
+	Name  string
+	Label string
+}
+
+// ItemInput describes a menu item as submitted by admins. Target holds the slug of an
+// internal target; URL is used when TargetType is "url".
+type ItemInput struct {
+	Label      string
+	TargetType string
+	Target     string
+	URL        string
+	ParentID   *int64
+	Position   int32
+}
+
+// ItemRecord is the shape the repository writes; the target is already resolved to an id.
+type ItemRecord struct {
+	MenuID     int64
+	ParentID   *int64
+	Label      string
+	TargetType string
+	TargetID   *int64
+	URL        string
+	Position   int32
+}
+
+// ItemPosition places an item during a reorder.
+type ItemPosition struct {
+	ID       int64  `json:"id"`
+	ParentID *int64 `json:"parent_id"`
+	Position int32  `json:"position"`
+}
@@ -0,0 +1,28 @@
+package menudomain
+
+import "context"
+
+// MenuRepository abstracts persistence of menus and their items.
+type MenuRepository interface {
+	ListMenus(ctx context.Context) ([]Menu, error)
+	GetMenu(ctx context.Context, name string) (Menu, error)
+	CreateMenu(ctx context.Context, input MenuInput) (Menu, error)
+	DeleteMenu(ctx context.Context, name string) error
+	// ListItems returns every item of the menu with targets resolved, parents before
+	// children and siblings in position order.
+	ListItems(ctx context.Context, menuID int64) ([]Item, error)
+	// ResolveTarget returns the id of the post, page, category or tag with slug.
+	ResolveTarget(ctx context.Context, targetType, slug string) (int64, error)
+	CreateItem(ctx context.Context, record ItemRecord) (Item, error)
+	UpdateItem(ctx context.Context, id int64, record ItemRecord) (Item, error)
+	DeleteItem(ctx context.Context, menuID, id int64) error
+	ReorderItems(ctx context.Context, menuID int64, order []ItemPosition) error
+}
+
+// MenuCache stores resolved menus between renders. Implementations may evict entries
+// at any time; callers fall back to the repository on a miss.
+type MenuCache interface {
+	Get(ctx context.Context, name string) ([]Link, bool, error)
+	Set(ctx context.Context, name string, links []Link) error
+	Invalidate(ctx context.Context, name string) error
+}
@@ -0,0 +1,112 @@
+package menudomain
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Field length limits, counted in characters.
+const (
+	MaxNameLength  = 64
+	MaxLabelLength = 100
+	MaxURLLength   = 2048
+)
+
+var (
+	ErrMenuNotFound   = errors.New("menu: not found")
+	ErrItemNotFound   = errors.New("menu: item not found")
+	ErrTargetNotFound = errors.New("menu: target not found")
+
+	ErrNameRequired       = errors.New("name is required")
+	ErrNameInvalid        = errors.New("name may only contain lowercase letters, digits, hyphens and underscores")
+	ErrNameTooLong        = errors.New("name must be at most " + strconv.Itoa(MaxNameLength) + " characters")
+	ErrNameTaken          = errors.New("name is already in use")
+	ErrLabelRequired      = errors.New("label is required")
+	ErrLabelTooLong       = errors.New("label must be at most " + strconv.Itoa(MaxLabelLength) + " characters")
+	ErrTargetTypeInvalid  = errors.New("target_type must be one of post, page, category, tag, url")
+	ErrTargetRequired     = errors.New("target slug is required")
+	ErrTargetMissing      = errors.New("target does not exist")
+	ErrURLRequired        = errors.New("url is required")
+	ErrURLInvalid         = errors.New("url must be an absolute http(s) URL or a path starting with /")
+	ErrURLTooLong         = errors.New("url must be at most " + strconv.Itoa(MaxURLLength) + " characters")
+	ErrParentNotFound     = errors.New("parent item does not exist in this menu")
+	ErrParentCycle        = errors.New("an item cannot be nested under itself or its children")
+	ErrOrderUnknownItem   = errors.New("order lists an item that is not in this menu")
+	ErrOrderDuplicateItem = errors.New("order lists an item more than once")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(?:[-_][a-z0-9]+)*$`)
+
+// IsValidTargetType reports whether t is a known item target type.
+func IsValidTargetType(t string) bool {
+	switch t {
+	case TargetPost, TargetPage, TargetCategory, TargetTag, TargetURL:
+		return true
+	}
+	return false
+}
+
+// ValidateMenu checks a new menu.
+func ValidateMenu(input MenuInput) *validation.Error {
+	v := &validation.Error{}
+	switch {
+	case input.Name == "":
+		v.Add("name", ErrNameRequired)
+	case len(input.Name) > MaxNameLength:
+		v.Add("name", ErrNameTooLong)
+	case !namePattern.MatchString(input.Name):
+		v.Add("name", ErrNameInvalid)
+	}
+	validateLabel(v, input.Label)
+	return v
+}
+
+// ValidateItem checks the attributes of an item that do not need the repository.
+func ValidateItem(input ItemInput) *validation.Error {
+	v := &validation.Error{}
+	validateLabel(v, input.Label)
+	switch {
+	case !IsValidTargetType(input.TargetType):
+		v.Add("target_type", ErrTargetTypeInvalid)
+	case input.TargetType == TargetURL:
+		switch {
+		case input.URL == "":
+			v.Add("url", ErrURLRequired)
+		case len(input.URL) > MaxURLLength:
+			v.Add("url", ErrURLTooLong)
+		case !isAllowedURL(input.URL):
+			v.Add("url", ErrURLInvalid)
+		}
+	case input.Target == "":
+		v.Add("target", ErrTargetRequired)
+	}
+	return v
+}
+
+func validateLabel(v *validation.Error, label string) {
+	switch {
+	case label == "":
+		v.Add("label", ErrLabelRequired)
+	case utf8.RuneCountInString(label) > MaxLabelLength:
+		v.Add("label", ErrLabelTooLong)
+	}
+}
+
+// isAllowedURL accepts absolute http(s) URLs and site-relative paths; protocol-relative
+// and script URLs are rejected.
+func isAllowedURL(raw string) bool {
+	if strings.HasPrefix(raw, "/") {
+		return !strings.HasPrefix(raw, "//") && !strings.HasPrefix(raw, "/\\")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
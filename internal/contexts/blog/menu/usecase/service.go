@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// OutboxHandlerName is the name the outbox relay keeps the menu cache offset under.
+const OutboxHandlerName = "menu-cache"
+
+// MenuService exposes application-facing operations around navigation menus.
+type MenuService interface {
+	ListMenus(ctx context.Context) ([]menudomain.Menu, error)
+	GetMenu(ctx context.Context, name string) (menudomain.MenuWithItems, error)
+	CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error)
+	DeleteMenu(ctx context.Context, name string) error
+	CreateItem(ctx context.Context, menuName string, input menudomain.ItemInput) (menudomain.Item, error)
+	UpdateItem(ctx context.Context, menuName string, id int64, input menudomain.ItemInput) (menudomain.Item, error)
+	DeleteItem(ctx context.Context, menuName string, id int64) error
+	ReorderItems(ctx context.Context, menuName string, order []menudomain.ItemPosition) error
+	Resolve(ctx context.Context, name string) ([]menudomain.Link, error)
+}
+
+// Service implements MenuService using a repository and a resolved-menu cache.
+//
+// Cache failures never fail a request: reads fall back to the repository and a missed
+// invalidation heals once the cache entry expires. Menu edits invalidate their menu
+// directly; post, page and taxonomy changes reach the cache through OutboxHandler, so
+// links follow renamed, unpublished and deleted targets.
+type Service struct {
+	repo  menudomain.MenuRepository
+	cache menudomain.MenuCache
+}
+
+var _ MenuService = (*Service)(nil)
+
+// NewService wires a menu repository and cache into a use case implementation.
+func NewService(repo menudomain.MenuRepository, cache menudomain.MenuCache) *Service {
+	return &Service{repo: repo, cache: cache}
+}
+
+func (s *Service) ListMenus(ctx context.Context) ([]menudomain.Menu, error) {
+	return s.repo.ListMenus(ctx)
+}
+
+// GetMenu returns a menu with its full item tree, including items whose target is
+// gone, so admins can fix them.
+func (s *Service) GetMenu(ctx context.Context, name string) (menudomain.MenuWithItems, error) {
+	menu, err := s.repo.GetMenu(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return menudomain.MenuWithItems{}, err
+	}
+	items, err := s.repo.ListItems(ctx, menu.ID)
+	if err != nil {
+		return menudomain.MenuWithItems{}, err
+	}
+	return menudomain.MenuWithItems{Menu: menu, Items: buildTree(items)}, nil
+}
+
+func (s *Service) CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error) {
+	input.Name = strings.ToLower(strings.TrimSpace(input.Name))
+	input.Label = strings.TrimSpace(input.Label)
+	v := menudomain.ValidateMenu(input)
+	if err := v.Err(); err != nil {
+		return menudomain.Menu{}, err
+	}
+	menu, err := s.repo.CreateMenu(ctx, input)
+	if errors.Is(err, menudomain.ErrNameTaken) {
+		v.Add("name", menudomain.ErrNameTaken)
+		return menudomain.Menu{}, v
+	}
+	return menu, err
+}
+
+func (s *Service) DeleteMenu(ctx context.Context, name string) error {
+	name = strings.TrimSpace(name)
+	if err := s.repo.DeleteMenu(ctx, name); err != nil {
+		return err
+	}
+	s.invalidate(ctx, name)
+	return nil
+}
+
+func (s *Service) CreateItem(ctx context.Context, menuName string, input menudomain.ItemInput) (menudomain.Item, error) {
+	menu, err := s.repo.GetMenu(ctx, strings.TrimSpace(menuName))
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	record, err := s.itemRecord(ctx, menu, 0, input)
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	item, err := s.repo.CreateItem(ctx, record)
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	s.invalidate(ctx, menu.Name)
+	return item, nil
+}
+
+func (s *Service) UpdateItem(ctx context.Context, menuName string, id int64, input menudomain.ItemInput) (menudomain.Item, error) {
+	menu, err := s.repo.GetMenu(ctx, strings.TrimSpace(menuName))
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	record, err := s.itemRecord(ctx, menu, id, input)
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	item, err := s.repo.UpdateItem(ctx, id, record)
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	s.invalidate(ctx, menu.Name)
+	return item, nil
+}
+
+// DeleteItem removes an item together with its nested items.
+func (s *Service) DeleteItem(ctx context.Context, menuName string, id int64) error {
+	menu, err := s.repo.GetMenu(ctx, strings.TrimSpace(menuName))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteItem(ctx, menu.ID, id); err != nil {
+		return err
+	}
+	s.invalidate(ctx, menu.Name)
+	return nil
+}
+
+// ReorderItems moves the listed items to new parents and positions in one step. Items
+// left out keep their place; the resulting tree must stay acyclic.
+func (s *Service) ReorderItems(ctx context.Context, menuName string, order []menudomain.ItemPosition) error {
+	menu, err := s.repo.GetMenu(ctx, strings.TrimSpace(menuName))
+	if err != nil {
+		return err
+	}
+	items, err := s.repo.ListItems(ctx, menu.ID)
+	if err != nil {
+		return err
+	}
+	parents := make(map[int64]*int64, len(items))
+	for _, item := range items {
+		parents[item.ID] = item.ParentID
+	}
+	v := &validation.Error{}
+	seen := make(map[int64]bool, len(order))
+	for _, pos := range order {
+		if _, ok := parents[pos.ID]; !ok {
+			v.Add("items", menudomain.ErrOrderUnknownItem)
+			return v
+		}
+		if seen[pos.ID] {
+			v.Add("items", menudomain.ErrOrderDuplicateItem)
+			return v
+		}
+		seen[pos.ID] = true
+		if pos.ParentID != nil {
+			if _, ok := parents[*pos.ParentID]; !ok {
+				v.Add("items", menudomain.ErrParentNotFound)
+				return v
+			}
+		}
+		parents[pos.ID] = pos.ParentID
+	}
+	for id := range parents {
+		if hasCycle(parents, id) {
+			v.Add("items", menudomain.ErrParentCycle)
+			return v
+		}
+	}
+	if err := s.repo.ReorderItems(ctx, menu.ID, order); err != nil {
+		return err
+	}
+	s.invalidate(ctx, menu.Name)
+	return nil
+}
+
+// Resolve returns the renderable links of a menu. Items whose target is deleted or not
+// published are dropped together with their children. An unknown menu resolves to nil
+// so layouts can reference menus that have not been created yet.
+func (s *Service) Resolve(ctx context.Context, name string) ([]menudomain.Link, error) {
+	if links, ok, err := s.cache.Get(ctx, name); err == nil && ok {
+		return links, nil
+	}
+	menu, err := s.repo.GetMenu(ctx, name)
+	if errors.Is(err, menudomain.ErrMenuNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListItems(ctx, menu.ID)
+	if err != nil {
+		return nil, err
+	}
+	links := buildLinks(buildTree(items))
+	_ = s.cache.Set(ctx, name, links)
+	return links, nil
+}
+
+// OutboxHandler returns the relay handler that drops cached menus when content they
+// may link to changes.
+func (s *Service) OutboxHandler() outbox.Handler {
+	types := append([]string{pagedomain.EventPageChanged, taxdomain.EventTaxonomyChanged}, postdomain.EventTypes...)
+	return outbox.Handler{Name: OutboxHandlerName, Types: types, Handle: s.HandleEvent}
+}
+
+// HandleEvent invalidates every cached menu. Menus are few and cheap to resolve, so
+// this does not look for the menus that actually link to the changed content.
+func (s *Service) HandleEvent(ctx context.Context, msg outbox.Message) error {
+	menus, err := s.repo.ListMenus(ctx)
+	if err != nil {
+		return err
+	}
+	for _, menu := range menus {
+		s.invalidate(ctx, menu.Name)
+	}
+	return nil
+}
+
+// itemRecord validates input for the item identified by selfID (zero for new items)
+// and resolves its target and parent.
+func (s *Service) itemRecord(ctx context.Context, menu menudomain.Menu, selfID int64, input menudomain.ItemInput) (menudomain.ItemRecord, error) {
+	input.Label = strings.TrimSpace(input.Label)
+	input.TargetType = strings.ToLower(strings.TrimSpace(input.TargetType))
+	input.Target = strings.TrimSpace(input.Target)
+	input.URL = strings.TrimSpace(input.URL)
+
+	v := menudomain.ValidateItem(input)
+	record := menudomain.ItemRecord{
+		MenuID:     menu.ID,
+		ParentID:   input.ParentID,
+		Label:      input.Label,
+		TargetType: input.TargetType,
+		Position:   input.Position,
+	}
+	if input.TargetType == menudomain.TargetURL {
+		record.URL = input.URL
+	} else if !v.Has("target_type") && !v.Has("target") {
+		id, err := s.repo.ResolveTarget(ctx, input.TargetType, input.Target)
+		switch {
+		case errors.Is(err, menudomain.ErrTargetNotFound):
+			v.Add("target", menudomain.ErrTargetMissing)
+		case err != nil:
+			return menudomain.ItemRecord{}, err
+		default:
+			record.TargetID = &id
+		}
+	}
+
+	if input.ParentID != nil {
+		items, err := s.repo.ListItems(ctx, menu.ID)
+		if err != nil {
+			return menudomain.ItemRecord{}, err
+		}
+		parents := make(map[int64]*int64, len(items))
+		for _, item := range items {
+			parents[item.ID] = item.ParentID
+		}
+		if _, ok := parents[*input.ParentID]; !ok {
+			v.Add("parent_id", menudomain.ErrParentNotFound)
+		} else if selfID != 0 {
+			parents[selfID] = input.ParentID
+			if hasCycle(parents, selfID) {
+				v.Add("parent_id", menudomain.ErrParentCycle)
+			}
+		}
+	}
+	if err := v.Err(); err != nil {
+		return menudomain.ItemRecord{}, err
+	}
+	return record, nil
+}
+
+func (s *Service) invalidate(ctx context.Context, name string) {
+	_ = s.cache.Invalidate(ctx, name)
+}
+
+// hasCycle reports whether following parent links from id returns to id.
+func hasCycle(parents map[int64]*int64, id int64) bool {
+	steps := 0
+	for p := parents[id]; p != nil; p = parents[*p] {
+		if *p == id || steps > len(parents) {
+			return true
+		}
+		steps++
+	}
+	return false
+}
+
+// buildTree nests items under their parents. items must list siblings in position
+// order; items whose parent is missing are promoted to the top level.
+func buildTree(items []menudomain.Item) []menudomain.ItemNode {
+	children := make(map[int64][]menudomain.Item, len(items))
+	known := make(map[int64]bool, len(items))
+	for _, item := range items {
+		known[item.ID] = true
+	}
+	var roots []menudomain.Item
+	for _, item := range items {
+		if item.ParentID != nil && known[*item.ParentID] {
+			children[*item.ParentID] = append(children[*item.ParentID], item)
+			continue
+		}
+		roots = append(roots, item)
+	}
+	var build func([]menudomain.Item) []menudomain.ItemNode
+	build = func(level []menudomain.Item) []menudomain.ItemNode {
+		nodes := make([]menudomain.ItemNode, 0, len(level))
+		for _, item := range level {
+			nodes = append(nodes, menudomain.ItemNode{
+				Item:     item,
+				Href:     item.Href(),
+				Children: build(children[item.ID]),
+			})
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+func buildLinks(nodes []menudomain.ItemNode) []menudomain.Link {
+	var links []menudomain.Link
+	for _, node := range nodes {
+		if node.Href == "" {
+			continue
+		}
+		links = append(links, menudomain.Link{
+			Label:    node.Label,
+			URL:      node.Href,
+			Children: buildLinks(node.Children),
+		})
+	}
+	return links
+}
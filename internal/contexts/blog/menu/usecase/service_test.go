@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/validation"
+)
+
+func TestServiceCreateItemResolvesTarget(t *testing.T) {
+	repo := newFakeMenuRepo()
+	cache := newFakeMenuCache()
+	svc := NewService(repo, cache)
+	cache.links["header"] = []menudomain.Link{{Label: "stale", URL: "/stale"}}
+
+	item, err := svc.CreateItem(context.Background(), "header", menudomain.ItemInput{Label: " About ", TargetType: "Page", Target: "team"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Label != "About" || item.TargetType != menudomain.TargetPage || item.TargetID == nil || *item.TargetID != 20 {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+	if _, ok := cache.links["header"]; ok {
+		t.Fatalf("expected header cache to be invalidated")
+	}
+}
+
+func TestServiceCreateItemRejectsInvalidInput(t *testing.T) {
+	svc := NewService(newFakeMenuRepo(), newFakeMenuCache())
+	missing := int64(99)
+
+	_, err := svc.CreateItem(context.Background(), "header", menudomain.ItemInput{TargetType: menudomain.TargetPost, Target: "nope", ParentID: &missing})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, field := range []string{"label", "target", "parent_id"} {
+		if !verr.Has(field) {
+			t.Fatalf("expected %s error, got %+v", field, verr.Fields)
+		}
+	}
+	if !errors.Is(err, menudomain.ErrTargetMissing) {
+		t.Fatalf("expected missing target sentinel, got %v", err)
+	}
+
+	_, err = svc.CreateItem(context.Background(), "header", menudomain.ItemInput{Label: "Evil", TargetType: menudomain.TargetURL, URL: "javascript:alert(1)"})
+	if !errors.Is(err, menudomain.ErrURLInvalid) {
+		t.Fatalf("expected invalid url error, got %v", err)
+	}
+
+	_, err = svc.CreateItem(context.Background(), "sidebar", menudomain.ItemInput{Label: "Home", TargetType: menudomain.TargetURL, URL: "/"})
+	if !errors.Is(err, menudomain.ErrMenuNotFound) {
+		t.Fatalf("expected menu not found, got %v", err)
+	}
+}
+
+func TestServiceUpdateItemRejectsCycles(t *testing.T) {
+	repo := newFakeMenuRepo()
+	svc := NewService(repo, newFakeMenuCache())
+	ctx := context.Background()
+	parent := repo.addItem(menudomain.Item{Label: "About", TargetType: menudomain.TargetURL, URL: "/about"})
+	child := repo.addItem(menudomain.Item{Label: "Team", TargetType: menudomain.TargetURL, URL: "/team", ParentID: &parent})
+
+	_, err := svc.UpdateItem(ctx, "header", parent, menudomain.ItemInput{Label: "About", TargetType: menudomain.TargetURL, URL: "/about", ParentID: &child})
+	if !errors.Is(err, menudomain.ErrParentCycle) {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	_, err = svc.UpdateItem(ctx, "header", parent, menudomain.ItemInput{Label: "About", TargetType: menudomain.TargetURL, URL: "/about", ParentID: &parent})
+	if !errors.Is(err, menudomain.ErrParentCycle) {
+		t.Fatalf("expected self parent error, got %v", err)
+	}
+}
+
+func TestServiceReorderItems(t *testing.T) {
+	repo := newFakeMenuRepo()
+	cache := newFakeMenuCache()
+	svc := NewService(repo, cache)
+	ctx := context.Background()
+	a := repo.addItem(menudomain.Item{Label: "A", TargetType: menudomain.TargetURL, URL: "/a"})
+	b := repo.addItem(menudomain.Item{Label: "B", TargetType: menudomain.TargetURL, URL: "/b", Position: 1})
+
+	cases := []struct {
+		name  string
+		order []menudomain.ItemPosition
+		want  error
+	}{
+		{"unknown item", []menudomain.ItemPosition{{ID: 42}}, menudomain.ErrOrderUnknownItem},
+		{"duplicate item", []menudomain.ItemPosition{{ID: a}, {ID: a, Position: 1}}, menudomain.ErrOrderDuplicateItem},
+		{"cycle", []menudomain.ItemPosition{{ID: a, ParentID: &b}, {ID: b, ParentID: &a}}, menudomain.ErrParentCycle},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := svc.ReorderItems(ctx, "header", tc.order); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	cache.links["header"] = []menudomain.Link{{Label: "stale", URL: "/stale"}}
+	if err := svc.ReorderItems(ctx, "header", []menudomain.ItemPosition{{ID: b, Position: 0}, {ID: a, Position: 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	links, err := svc.Resolve(ctx, "header")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 || links[0].Label != "B" || links[1].Label != "A" {
+		t.Fatalf("expected reordered links, got %+v", links)
+	}
+}
+
+func TestServiceResolveDropsHiddenTargets(t *testing.T) {
+	repo := newFakeMenuRepo()
+	cache := newFakeMenuCache()
+	svc := NewService(repo, cache)
+	ctx := context.Background()
+	about := repo.addItem(menudomain.Item{Label: "About", TargetType: menudomain.TargetPage, TargetID: ptr(20)})
+	repo.addItem(menudomain.Item{Label: "Jobs", TargetType: menudomain.TargetPage, TargetID: ptr(21), ParentID: &about, Position: 1})
+	draft := repo.addItem(menudomain.Item{Label: "Draft", TargetType: menudomain.TargetPost, TargetID: ptr(11), Position: 2})
+	repo.addItem(menudomain.Item{Label: "Under draft", TargetType: menudomain.TargetURL, URL: "/x", ParentID: &draft})
+	repo.addItem(menudomain.Item{Label: "Go", TargetType: menudomain.TargetTag, TargetID: ptr(30), Position: 3})
+
+	links, err := svc.Resolve(ctx, "header")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []menudomain.Link{
+		{Label: "About", URL: "/p/about/team"},
+		{Label: "Go", URL: "/tags/go"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("expected %d links, got %+v", len(want), links)
+	}
+	for i := range want {
+		if links[i].Label != want[i].Label || links[i].URL != want[i].URL || len(links[i].Children) != 0 {
+			t.Fatalf("link %d: expected %+v, got %+v", i, want[i], links[i])
+		}
+	}
+	if cached := cache.links["header"]; len(cached) != 2 {
+		t.Fatalf("expected resolved menu to be cached, got %+v", cached)
+	}
+
+	repo.listCalls = 0
+	if _, err := svc.Resolve(ctx, "header"); err != nil || repo.listCalls != 0 {
+		t.Fatalf("expected cache hit, got %d repository reads, %v", repo.listCalls, err)
+	}
+}
+
+func TestServiceResolveUnknownMenu(t *testing.T) {
+	svc := NewService(newFakeMenuRepo(), newFakeMenuCache())
+	links, err := svc.Resolve(context.Background(), "sidebar")
+	if err != nil || links != nil {
+		t.Fatalf("expected nil links, got %+v, %v", links, err)
+	}
+}
+
+func TestServiceResolveIgnoresCacheFailures(t *testing.T) {
+	repo := newFakeMenuRepo()
+	cache := newFakeMenuCache()
+	cache.err = errors.New("redis down")
+	svc := NewService(repo, cache)
+	repo.addItem(menudomain.Item{Label: "Home", TargetType: menudomain.TargetURL, URL: "/"})
+
+	links, err := svc.Resolve(context.Background(), "header")
+	if err != nil || len(links) != 1 {
+		t.Fatalf("expected links from repository, got %+v, %v", links, err)
+	}
+}
+
+func TestServiceHandleEventRefreshesRenamedTargets(t *testing.T) {
+	repo := newFakeMenuRepo()
+	repo.menus["footer"] = menudomain.Menu{ID: 2, Name: "footer"}
+	cache := newFakeMenuCache()
+	cache.links["footer"] = []menudomain.Link{{Label: "Go", URL: "/tags/go"}}
+	svc := NewService(repo, cache)
+	repo.addItem(menudomain.Item{Label: "Hello", TargetType: menudomain.TargetPost, TargetID: ptr(10)})
+
+	if _, err := svc.Resolve(context.Background(), "header"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo.targets[menudomain.TargetPost][10] = fakeTarget{slug: "hello-world", live: true}
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: postdomain.EventPostUpdated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cache.links["footer"]; ok {
+		t.Fatal("expected every menu to be invalidated")
+	}
+	links, err := svc.Resolve(context.Background(), "header")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "/posts/hello-world" {
+		t.Fatalf("expected the renamed slug, got %+v", links)
+	}
+}
+
+func ptr(v int64) *int64 { return &v }
+
+type fakeTarget struct {
+	slug string
+	path string
+	live bool
+}
+
+type fakeMenuRepo struct {
+	menus     map[string]menudomain.Menu
+	items     []menudomain.Item
+	targets   map[string]map[int64]fakeTarget
+	nextID    int64
+	listCalls int
+}
+
+func newFakeMenuRepo() *fakeMenuRepo {
+	return &fakeMenuRepo{
+		menus: map[string]menudomain.Menu{"header": {ID: 1, Name: "header", Label: "Header"}},
+		targets: map[string]map[int64]fakeTarget{
+			menudomain.TargetPost: {10: {slug: "hello", live: true}, 11: {slug: "draft"}},
+			menudomain.TargetPage: {20: {slug: "team", path: "/p/about/team", live: true}},
+			menudomain.TargetTag:  {30: {slug: "go", live: true}},
+		},
+		nextID: 100,
+	}
+}
+
+func (r *fakeMenuRepo) addItem(item menudomain.Item) int64 {
+	r.nextID++
+	item.ID = r.nextID
+	item.MenuID = r.menus["header"].ID
+	r.items = append(r.items, item)
+	return item.ID
+}
+
+func (r *fakeMenuRepo) resolved(item menudomain.Item) menudomain.Item {
+	if item.TargetID != nil {
+		if target, ok := r.targets[item.TargetType][*item.TargetID]; ok {
+			item.TargetSlug = target.slug
+			item.TargetPath = target.path
+			item.TargetLive = target.live
+		}
+	}
+	return item
+}
+
+func (r *fakeMenuRepo) ListMenus(ctx context.Context) ([]menudomain.Menu, error) {
+	out := make([]menudomain.Menu, 0, len(r.menus))
+	for _, m := range r.menus {
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *fakeMenuRepo) GetMenu(ctx context.Context, name string) (menudomain.Menu, error) {
+	m, ok := r.menus[name]
+	if !ok {
+		return menudomain.Menu{}, menudomain.ErrMenuNotFound
+	}
+	return m, nil
+}
+
+func (r *fakeMenuRepo) CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error) {
+	if _, ok := r.menus[input.Name]; ok {
+		return menudomain.Menu{}, menudomain.ErrNameTaken
+	}
+	r.nextID++
+	m := menudomain.Menu{ID: r.nextID, Name: input.Name, Label: input.Label}
+	r.menus[input.Name] = m
+	return m, nil
+}
+
+func (r *fakeMenuRepo) DeleteMenu(ctx context.Context, name string) error {
+	if _, ok := r.menus[name]; !ok {
+		return menudomain.ErrMenuNotFound
+	}
+	delete(r.menus, name)
+	return nil
+}
+
+func (r *fakeMenuRepo) ListItems(ctx context.Context, menuID int64) ([]menudomain.Item, error) {
+	r.listCalls++
+	var out []menudomain.Item
+	for _, item := range r.items {
+		if item.MenuID == menuID {
+			out = append(out, r.resolved(item))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Position < out[j].Position })
+	return out, nil
+}
+
+func (r *fakeMenuRepo) ResolveTarget(ctx context.Context, targetType, slug string) (int64, error) {
+	for id, target := range r.targets[targetType] {
+		if target.slug == slug {
+			return id, nil
+		}
+	}
+	return 0, menudomain.ErrTargetNotFound
+}
+
+func (r *fakeMenuRepo) CreateItem(ctx context.Context, record menudomain.ItemRecord) (menudomain.Item, error) {
+	r.addItem(menudomain.Item{
+		ParentID:   record.ParentID,
+		Label:      record.Label,
+		TargetType: record.TargetType,
+		TargetID:   record.TargetID,
+		URL:        record.URL,
+		Position:   record.Position,
+	})
+	return r.resolved(r.items[len(r.items)-1]), nil
+}
+
+func (r *fakeMenuRepo) UpdateItem(ctx context.Context, id int64, record menudomain.ItemRecord) (menudomain.Item, error) {
+	for i, item := range r.items {
+		if item.ID == id && item.MenuID == record.MenuID {
+			r.items[i] = menudomain.Item{
+				ID:         id,
+				MenuID:     record.MenuID,
+				ParentID:   record.ParentID,
+				Label:      record.Label,
+				TargetType: record.TargetType,
+				TargetID:   record.TargetID,
+				URL:        record.URL,
+				Position:   record.Position,
+			}
+			return r.resolved(r.items[i]), nil
+		}
+	}
+	return menudomain.Item{}, menudomain.ErrItemNotFound
+}
+
+func (r *fakeMenuRepo) DeleteItem(ctx context.Context, menuID, id int64) error {
+	for i, item := range r.items {
+		if item.ID == id && item.MenuID == menuID {
+			r.items = append(r.items[:i], r.items[i+1:]...)
+			return nil
+		}
+	}
+	return menudomain.ErrItemNotFound
+}
+
+func (r *fakeMenuRepo) ReorderItems(ctx context.Context, menuID int64, order []menudomain.ItemPosition) error {
+	for _, pos := range order {
+		for i := range r.items {
+			if r.items[i].ID == pos.ID {
+				r.items[i].ParentID = pos.ParentID
+				r.items[i].Position = pos.Position
+			}
+		}
+	}
+	return nil
+}
+
+type fakeMenuCache struct {
+	links map[string][]menudomain.Link
+	err   error
+}
+
+func newFakeMenuCache() *fakeMenuCache {
+	return &fakeMenuCache{links: map[string][]menudomain.Link{}}
+}
+
+func (c *fakeMenuCache) Get(ctx context.Context, name string) ([]menudomain.Link, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	links, ok := c.links[name]
+	return links, ok, nil
+}
+
+func (c *fakeMenuCache) Set(ctx context.Context, name string, links []menudomain.Link) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.links[name] = links
+	return nil
+}
+
+func (c *fakeMenuCache) Invalidate(ctx context.Context, name string) error {
+	if c.err != nil {
+		return c.err
+	}
+	delete(c.links, name)
+	return nil
+}
@@ -0,0 +1,110 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	newsletterview "proto-gin-web/internal/contexts/blog/newsletter/adapters/view"
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	newsletterusecase "proto-gin-web/internal/contexts/blog/newsletter/usecase"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// RegisterRoutes wires the subscribe endpoint and the pages linked from emails.
+func RegisterRoutes(r *gin.Engine, cfg config.Config, svc newsletterusecase.NewsletterService, subscribeLimiter gin.HandlerFunc) {
+	r.POST("/api/subscribe", subscribeLimiter, subscribeHandler(svc))
+
+	r.GET("/newsletter/confirm", func(c *gin.Context) {
+		if _, err := svc.Confirm(c.Request.Context(), c.Query("token")); err != nil {
+			renderLinkError(c, cfg, err)
+			return
+		}
+		newsletterview.PublicNewsletterPage(c, cfg, http.StatusOK, newsletterview.NewsletterPage{
+			Title:   "Subscription confirmed",
+			Message: "Thanks! New posts will arrive in your inbox.",
+		})
+	})
+
+	// The link in an email only asks; unsubscribing takes a POST so link scanners that
+	// follow every URL in a message do not unsubscribe the reader.
+	r.GET("/newsletter/unsubscribe", func(c *gin.Context) {
+		newsletterview.PublicNewsletterPage(c, cfg, http.StatusOK, newsletterview.NewsletterPage{
+			Title:   "Unsubscribe",
+			Message: "Stop receiving new posts from " + cfg.SiteName + " by email?",
+			Action:  c.Request.URL.RequestURI(),
+			Button:  "Unsubscribe",
+		})
+	})
+
+	r.POST("/newsletter/unsubscribe", func(c *gin.Context) {
+		_, err := svc.Unsubscribe(c.Request.Context(), c.Query("token"))
+		// Mail clients post List-Unsubscribe=One-Click (RFC 8058) and only look at the status.
+		if strings.TrimSpace(c.PostForm("List-Unsubscribe")) == "One-Click" {
+			switch {
+			case errors.Is(err, newsletterdomain.ErrTokenInvalid):
+				c.String(http.StatusBadRequest, "invalid unsubscribe link")
+			case err != nil:
+				c.String(http.StatusInternalServerError, "internal server error")
+			default:
+				c.String(http.StatusOK, "unsubscribed")
+			}
+			return
+		}
+		if err != nil {
+			renderLinkError(c, cfg, err)
+			return
+		}
+		newsletterview.PublicNewsletterPage(c, cfg, http.StatusOK, newsletterview.NewsletterPage{
+			Title:   "Unsubscribed",
+			Message: "You will not receive any more emails from " + cfg.SiteName + ".",
+		})
+	})
+}
+
+// subscribeHandler godoc
+// @Summary      Subscribe to new posts
+// @Description  Starts a double opt-in subscription: a confirmation link is emailed to the
+// @Description  address and nothing else is sent until it is followed. The answer is the same
+// @Description  whether or not the address was already subscribed.
+// @Tags         Public
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      subscribeRequest  true  "Subscriber email"
+// @Success      202  {object}  subscribeResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      429  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /api/subscribe [post]
+func subscribeHandler(svc newsletterusecase.NewsletterService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req subscribeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responder.JSONError(c, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		if err := svc.Subscribe(c.Request.Context(), req.Email); err != nil {
+			if errors.Is(err, newsletterdomain.ErrEmailInvalid) {
+				responder.JSONError(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			responder.JSONError(c, http.StatusInternalServerError, "failed to subscribe")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusAccepted, gin.H{"status": newsletterdomain.StatusPending})
+	}
+}
+
+func renderLinkError(c *gin.Context, cfg config.Config, err error) {
+	if errors.Is(err, newsletterdomain.ErrTokenInvalid) {
+		newsletterview.PublicNewsletterPage(c, cfg, http.StatusBadRequest, newsletterview.NewsletterPage{
+			Title:   "Link not valid",
+			Message: "This link is invalid or has already been used.",
+		})
+		return
+	}
+	c.String(http.StatusInternalServerError, "internal server error")
+}
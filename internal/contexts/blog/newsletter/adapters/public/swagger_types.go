@@ -0,0 +1,20 @@
+package public
+
+// subscribeRequest documents the body accepted by /api/subscribe.
+type subscribeRequest struct {
+	Email string `json:"email" example:"reader@example.com"`
+}
+
+// subscribeResponse documents the JSON envelope returned by /api/subscribe.
+type subscribeResponse struct {
+	Ok   bool `json:"ok"`
+	Data struct {
+		Status string `json:"status" example:"pending"`
+	} `json:"data"`
+}
+
+// errorResponse documents the JSON error envelope.
+type errorResponse struct {
+	Ok    bool   `json:"ok"`
+	Error string `json:"error"`
+}
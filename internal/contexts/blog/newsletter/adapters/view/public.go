@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"proto-gin-web/internal/platform/config"
+	platformview "proto-gin-web/internal/platform/http/view"
+)
+
+// NewsletterPage describes the outcome of following a link from a newsletter email.
+// Action, when set, is where the page's button posts to.
+type NewsletterPage struct {
+	Title   string
+	Message string
+	Action  string
+	Button  string
+}
+
+// PublicNewsletterPage renders the confirmation and unsubscribe pages.
+func PublicNewsletterPage(c *gin.Context, cfg config.Config, status int, page NewsletterPage) {
+	platformview.RenderHTML(c, status, "newsletter.tmpl", platformview.WithAdminContext(c, gin.H{
+		"Title":           page.Title,
+		"Message":         page.Message,
+		"Action":          page.Action,
+		"Button":          page.Button,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+	}))
+}
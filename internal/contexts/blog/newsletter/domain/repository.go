@@ -0,0 +1,34 @@
+package newsletterdomain
+
+import (
+	"context"
+	"time"
+)
+
+// NewsletterRepository abstracts persistence of subscribers and the send queue.
+type NewsletterRepository interface {
+	GetSubscriberByEmail(ctx context.Context, email string) (Subscriber, error)
+	CreateSubscriber(ctx context.Context, email, tokenHash string) (Subscriber, error)
+	// ResetSubscriber makes a subscriber pending again with a new confirmation token.
+	ResetSubscriber(ctx context.Context, id int64, tokenHash string) (Subscriber, error)
+	// ConfirmSubscriber confirms the pending subscriber holding the token and clears it.
+	ConfirmSubscriber(ctx context.Context, tokenHash string, now time.Time) (Subscriber, error)
+	Unsubscribe(ctx context.Context, id int64, now time.Time) (Subscriber, error)
+	// ListSubscribers returns subscribers with the given status, or all when empty,
+	// newest first.
+	ListSubscribers(ctx context.Context, status string) ([]Subscriber, error)
+
+	// QueuePost queues a post for the next issue; a post is only ever queued once.
+	QueuePost(ctx context.Context, postID int64) error
+	// OpenIssue claims an unfinished issue no other sender holds, or starts one with
+	// every queued post, and holds it for lease. It reports false when there is
+	// nothing to send.
+	OpenIssue(ctx context.Context, now time.Time, lease time.Duration) (Issue, bool, error)
+	// ListIssuePosts returns the posts of an issue that are still published.
+	ListIssuePosts(ctx context.Context, postIDs []int64) ([]IssuePost, error)
+	// ListRecipients returns up to limit confirmed subscribers after the given id.
+	ListRecipients(ctx context.Context, afterID int64, limit int32) ([]Subscriber, error)
+	// AdvanceIssue records the last subscriber handled and extends the hold on the issue.
+	AdvanceIssue(ctx context.Context, issueID, cursor int64, leaseUntil time.Time) error
+	CompleteIssue(ctx context.Context, issueID int64, now time.Time) error
+}
@@ -0,0 +1,66 @@
+package newsletterdomain
+
+import (
+	"errors"
+	"time"
+)
+
+// Subscriber statuses. A subscriber stays pending until the link in the confirmation
+// email is followed; only confirmed subscribers receive newsletters.
+const (
+	StatusPending      = "pending"
+	StatusConfirmed    = "confirmed"
+	StatusUnsubscribed = "unsubscribed"
+)
+
+// Delivery modes: send each published post right away, or collect them into a daily
+// digest.
+const (
+	ModeImmediate = "immediate"
+	ModeDigest    = "digest"
+)
+
+var (
+	// ErrSubscriberNotFound indicates no subscriber matched.
+	ErrSubscriberNotFound = errors.New("newsletter: subscriber not found")
+	// ErrEmailInvalid indicates the address given to subscribe is not an email address.
+	ErrEmailInvalid = errors.New("newsletter: invalid email address")
+	// ErrTokenInvalid indicates a confirmation or unsubscribe token that is malformed,
+	// forged or already used.
+	ErrTokenInvalid = errors.New("newsletter: invalid or expired link")
+	// ErrStatusInvalid indicates an unknown status filter.
+	ErrStatusInvalid = errors.New("newsletter: status must be pending, confirmed or unsubscribed")
+)
+
+// Subscriber is a reader who asked for new posts by email.
+type Subscriber struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ConfirmedAt    *time.Time `json:"confirmed_at,omitempty"`
+	UnsubscribedAt *time.Time `json:"unsubscribed_at,omitempty"`
+}
+
+// IsValidStatus reports whether s is a known subscriber status.
+func IsValidStatus(s string) bool {
+	return s == StatusPending || s == StatusConfirmed || s == StatusUnsubscribed
+}
+
+// Issue is one newsletter send: the posts queued since the previous one, mailed to
+// every confirmed subscriber in id order. Cursor is the last subscriber handled, so an
+// interrupted send resumes where it stopped, once the hold of the sender that was
+// interrupted runs out.
+type Issue struct {
+	ID      int64
+	PostIDs []int64
+	Cursor  int64
+}
+
+// IssuePost is a post included in an issue.
+type IssuePost struct {
+	ID      int64
+	Title   string
+	Slug    string
+	Summary string
+}
@@ -0,0 +1,27 @@
+package newsletterdomain
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// MaxEmailLength bounds subscriber addresses, as RFC 5321 does.
+const MaxEmailLength = 254
+
+// NormalizeEmail trims and lowercases an address so each reader subscribes once.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsValidEmail reports whether email is a bare address with a dotted domain.
+func IsValidEmail(email string) bool {
+	if email == "" || len(email) > MaxEmailLength {
+		return false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return false
+	}
+	_, domain, _ := strings.Cut(email, "@")
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/mail"
+	"proto-gin-web/internal/platform/outbox"
+)
+
+const (
+	// OutboxHandlerName is the name the outbox relay keeps the newsletter offset under.
+	OutboxHandlerName = "newsletter"
+
+	recipientBatch = 100
+	// issueLease outlasts a few emails that all hit the SMTP timeout.
+	issueLease = 5 * time.Minute
+)
+
+// NewsletterService covers the reader-facing subscription flow and the admin list.
+type NewsletterService interface {
+	Subscribe(ctx context.Context, email string) error
+	Confirm(ctx context.Context, token string) (newsletterdomain.Subscriber, error)
+	Unsubscribe(ctx context.Context, token string) (newsletterdomain.Subscriber, error)
+	Subscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error)
+}
+
+// Config names the site in emails and holds the key unsubscribe links are signed with.
+type Config struct {
+	BaseURL  string
+	SiteName string
+	Secret   string
+}
+
+// Service implements NewsletterService with double opt-in. Published posts are queued
+// from the outbox and mailed by SendPending, which a background job runs every minute
+// or once a day for digests.
+type Service struct {
+	repo   newsletterdomain.NewsletterRepository
+	mailer mail.Mailer
+	cfg    Config
+	now    func() time.Time
+}
+
+var _ NewsletterService = (*Service)(nil)
+
+// NewService wires the newsletter repository and a mailer into a use case
+// implementation.
+func NewService(repo newsletterdomain.NewsletterRepository, mailer mail.Mailer, cfg Config) *Service {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Service{repo: repo, mailer: mailer, cfg: cfg, now: time.Now}
+}
+
+// Subscribe starts the double opt-in for email by mailing it a confirmation link. An
+// address that is already confirmed gets nothing, so the answer is the same whether
+// or not the reader was subscribed.
+func (s *Service) Subscribe(ctx context.Context, email string) error {
+	email = newsletterdomain.NormalizeEmail(email)
+	if !newsletterdomain.IsValidEmail(email) {
+		return newsletterdomain.ErrEmailInvalid
+	}
+	token, hash, err := newConfirmToken()
+	if err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetSubscriberByEmail(ctx, email)
+	switch {
+	case errors.Is(err, newsletterdomain.ErrSubscriberNotFound):
+		_, err = s.repo.CreateSubscriber(ctx, email, hash)
+	case err != nil:
+		return err
+	case current.Status == newsletterdomain.StatusConfirmed:
+		return nil
+	default:
+		_, err = s.repo.ResetSubscriber(ctx, current.ID, hash)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Confirm your subscription to " + s.cfg.SiteName,
+		Text: fmt.Sprintf("Someone, hopefully you, asked to receive new posts from %s at this address.\n\n"+
+			"Confirm your subscription:\n%s\n\nIf it was not you, ignore this email and nothing will be sent.\n",
+			s.cfg.SiteName, s.cfg.BaseURL+"/newsletter/confirm?token="+url.QueryEscape(token)),
+	})
+}
+
+// Confirm confirms the subscriber a confirmation token was sent to. Tokens work once.
+func (s *Service) Confirm(ctx context.Context, token string) (newsletterdomain.Subscriber, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return newsletterdomain.Subscriber{}, newsletterdomain.ErrTokenInvalid
+	}
+	sub, err := s.repo.ConfirmSubscriber(ctx, hashToken(token), s.now().UTC())
+	if errors.Is(err, newsletterdomain.ErrSubscriberNotFound) {
+		return newsletterdomain.Subscriber{}, newsletterdomain.ErrTokenInvalid
+	}
+	return sub, err
+}
+
+// Unsubscribe unsubscribes the reader a signed unsubscribe token was issued to.
+// Unsubscribing twice is not an error.
+func (s *Service) Unsubscribe(ctx context.Context, token string) (newsletterdomain.Subscriber, error) {
+	id, ok := s.verifyUnsubscribeToken(token)
+	if !ok {
+		return newsletterdomain.Subscriber{}, newsletterdomain.ErrTokenInvalid
+	}
+	sub, err := s.repo.Unsubscribe(ctx, id, s.now().UTC())
+	if errors.Is(err, newsletterdomain.ErrSubscriberNotFound) {
+		return newsletterdomain.Subscriber{}, newsletterdomain.ErrTokenInvalid
+	}
+	return sub, err
+}
+
+// Subscribers lists subscribers with the given status, or all of them when empty.
+func (s *Service) Subscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error) {
+	status = strings.TrimSpace(status)
+	if status != "" && !newsletterdomain.IsValidStatus(status) {
+		return nil, newsletterdomain.ErrStatusInvalid
+	}
+	return s.repo.ListSubscribers(ctx, status)
+}
+
+// OutboxHandler returns the relay handler that queues newly published posts.
+func (s *Service) OutboxHandler() outbox.Handler {
+	return outbox.Handler{Name: OutboxHandlerName, Types: []string{postdomain.EventPostPublished}, Handle: s.HandleEvent}
+}
+
+// HandleEvent queues the post of a PostPublished message for the next issue. A post
+// published again after being taken down is not sent twice.
+func (s *Service) HandleEvent(ctx context.Context, msg outbox.Message) error {
+	var event postdomain.Event
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("decode %s payload: %w", msg.Type, err)
+	}
+	return s.repo.QueuePost(ctx, event.Post.ID)
+}
+
+// SendPending mails the queued posts to every confirmed subscriber, one post on its
+// own or several as a digest, and returns how many emails were sent. Progress is kept
+// per subscriber, so an interrupted run resumes without mailing anyone twice, and an
+// issue is held by one sender at a time. A failed email is reported but not retried,
+// so one bad address cannot hold up the rest.
+func (s *Service) SendPending(ctx context.Context) (int, error) {
+	issue, ok, err := s.repo.OpenIssue(ctx, s.now().UTC(), issueLease)
+	if err != nil || !ok {
+		return 0, err
+	}
+	posts, err := s.repo.ListIssuePosts(ctx, issue.PostIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	cursor := issue.Cursor
+	for len(posts) > 0 {
+		recipients, err := s.repo.ListRecipients(ctx, cursor, recipientBatch)
+		if err != nil {
+			return sent, errors.Join(append(errs, err)...)
+		}
+		if len(recipients) == 0 {
+			break
+		}
+		for _, sub := range recipients {
+			if err := s.mailer.Send(ctx, s.issueMessage(sub, posts)); err != nil {
+				if ctx.Err() != nil {
+					return sent, errors.Join(append(errs, err)...)
+				}
+				errs = append(errs, fmt.Errorf("subscriber %d: %w", sub.ID, err))
+			} else {
+				sent++
+			}
+			cursor = sub.ID
+			if err := s.repo.AdvanceIssue(ctx, issue.ID, cursor, s.now().UTC().Add(issueLease)); err != nil {
+				return sent, errors.Join(append(errs, err)...)
+			}
+		}
+	}
+	if err := s.repo.CompleteIssue(ctx, issue.ID, s.now().UTC()); err != nil {
+		errs = append(errs, err)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// issueMessage builds the email of an issue for one subscriber, with a one-click
+// unsubscribe link in the body and the List-Unsubscribe headers (RFC 8058).
+func (s *Service) issueMessage(sub newsletterdomain.Subscriber, posts []newsletterdomain.IssuePost) mail.Message {
+	unsubscribe := s.UnsubscribeURL(sub.ID)
+	var body strings.Builder
+	subject := s.cfg.SiteName + ": " + posts[0].Title
+	if len(posts) > 1 {
+		subject = fmt.Sprintf("%s: %d new posts", s.cfg.SiteName, len(posts))
+		fmt.Fprintf(&body, "New on %s:\n\n", s.cfg.SiteName)
+	}
+	for _, p := range posts {
+		body.WriteString(p.Title + "\n")
+		if p.Summary != "" {
+			body.WriteString(p.Summary + "\n")
+		}
+		body.WriteString(s.cfg.BaseURL + "/posts/" + url.PathEscape(p.Slug) + "\n\n")
+	}
+	fmt.Fprintf(&body, "--\nYou receive this because you subscribed to %s.\nUnsubscribe: %s\n", s.cfg.SiteName, unsubscribe)
+	return mail.Message{
+		To:      sub.Email,
+		Subject: subject,
+		Text:    body.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribe + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
+
+// UnsubscribeURL returns the signed unsubscribe link of a subscriber. It never
+// expires, so old emails keep working.
+func (s *Service) UnsubscribeURL(id int64) string {
+	return s.cfg.BaseURL + "/newsletter/unsubscribe?token=" + s.unsubscribeToken(id)
+}
+
+func (s *Service) unsubscribeToken(id int64) string {
+	idPart := strconv.FormatInt(id, 10)
+	return idPart + "." + s.sign(idPart)
+}
+
+func (s *Service) verifyUnsubscribeToken(token string) (int64, bool) {
+	idPart, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, hmac.Equal([]byte(sig), []byte(s.sign(idPart)))
+}
+
+func (s *Service) sign(idPart string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	mac.Write([]byte("unsubscribe:" + idPart))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newConfirmToken returns a random confirmation token and the hash stored for it.
+func newConfirmToken() (string, string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate confirmation token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
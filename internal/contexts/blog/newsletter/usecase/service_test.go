@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/mail"
+	"proto-gin-web/internal/platform/outbox"
+)
+
+func TestSubscribeAndConfirm(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	mailer := &fakeMailer{}
+	svc := NewService(repo, mailer, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+
+	if err := svc.Subscribe(context.Background(), "not-an-email"); !errors.Is(err, newsletterdomain.ErrEmailInvalid) {
+		t.Fatalf("expected ErrEmailInvalid, got %v", err)
+	}
+	if err := svc.Subscribe(context.Background(), " Reader@Example.com "); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != "reader@example.com" {
+		t.Fatalf("expected a confirmation email, got %+v", mailer.sent)
+	}
+	token := tokenFrom(t, mailer.sent[0].Text, "https://blog.example.com/newsletter/confirm?token=")
+
+	if _, err := svc.Confirm(context.Background(), "forged"); !errors.Is(err, newsletterdomain.ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid, got %v", err)
+	}
+	sub, err := svc.Confirm(context.Background(), token)
+	if err != nil || sub.Status != newsletterdomain.StatusConfirmed {
+		t.Fatalf("confirm: %+v, %v", sub, err)
+	}
+	if _, err := svc.Confirm(context.Background(), token); !errors.Is(err, newsletterdomain.ErrTokenInvalid) {
+		t.Fatalf("a token must only work once, got %v", err)
+	}
+
+	if err := svc.Subscribe(context.Background(), "reader@example.com"); err != nil {
+		t.Fatalf("subscribe again: %v", err)
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("a confirmed reader must not be mailed again, got %d emails", len(mailer.sent))
+	}
+}
+
+func TestSubscribeAfterUnsubscribeNeedsConfirmation(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	repo.add(newsletterdomain.Subscriber{ID: 4, Email: "reader@example.com", Status: newsletterdomain.StatusUnsubscribed})
+	mailer := &fakeMailer{}
+	svc := NewService(repo, mailer, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+
+	if err := svc.Subscribe(context.Background(), "reader@example.com"); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if repo.subs[4].Status != newsletterdomain.StatusPending || len(mailer.sent) != 1 {
+		t.Fatalf("expected a pending subscriber and a confirmation email, got %+v, %d emails", repo.subs[4], len(mailer.sent))
+	}
+}
+
+func TestUnsubscribeVerifiesSignature(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	repo.add(newsletterdomain.Subscriber{ID: 7, Email: "reader@example.com", Status: newsletterdomain.StatusConfirmed})
+	svc := NewService(repo, &fakeMailer{}, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+
+	link := svc.UnsubscribeURL(7)
+	token := tokenFrom(t, link, "https://blog.example.com/newsletter/unsubscribe?token=")
+	for _, bad := range []string{"", "7", "8." + strings.SplitN(token, ".", 2)[1], token + "x"} {
+		if _, err := svc.Unsubscribe(context.Background(), bad); !errors.Is(err, newsletterdomain.ErrTokenInvalid) {
+			t.Errorf("token %q: expected ErrTokenInvalid, got %v", bad, err)
+		}
+	}
+	other := NewService(repo, &fakeMailer{}, Config{Secret: "other"})
+	if _, err := other.Unsubscribe(context.Background(), token); !errors.Is(err, newsletterdomain.ErrTokenInvalid) {
+		t.Fatalf("a token signed with another secret must fail, got %v", err)
+	}
+
+	sub, err := svc.Unsubscribe(context.Background(), token)
+	if err != nil || sub.Status != newsletterdomain.StatusUnsubscribed {
+		t.Fatalf("unsubscribe: %+v, %v", sub, err)
+	}
+}
+
+func TestSubscribersValidatesStatus(t *testing.T) {
+	svc := NewService(newFakeNewsletterRepo(), &fakeMailer{}, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+	if _, err := svc.Subscribers(context.Background(), "bounced"); !errors.Is(err, newsletterdomain.ErrStatusInvalid) {
+		t.Fatalf("expected ErrStatusInvalid, got %v", err)
+	}
+}
+
+func TestHandleEventQueuesPost(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	svc := NewService(repo, &fakeMailer{}, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+	msg, err := outbox.NewMessage(postdomain.EventPostPublished, "hello", postdomain.Event{Post: postdomain.Post{ID: 9, Slug: "hello"}})
+	if err != nil {
+		t.Fatalf("new message: %v", err)
+	}
+	if err := svc.HandleEvent(context.Background(), msg); err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if len(repo.queued) != 1 || repo.queued[0] != 9 {
+		t.Fatalf("expected post 9 to be queued, got %v", repo.queued)
+	}
+	if h := svc.OutboxHandler(); len(h.Types) != 1 || h.Types[0] != postdomain.EventPostPublished {
+		t.Fatalf("unexpected handler types %v", h.Types)
+	}
+}
+
+func TestSendPendingMailsConfirmedSubscribers(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	repo.add(newsletterdomain.Subscriber{ID: 1, Email: "a@example.com", Status: newsletterdomain.StatusConfirmed})
+	repo.add(newsletterdomain.Subscriber{ID: 2, Email: "b@example.com", Status: newsletterdomain.StatusPending})
+	repo.add(newsletterdomain.Subscriber{ID: 3, Email: "c@example.com", Status: newsletterdomain.StatusConfirmed})
+	repo.issue = &newsletterdomain.Issue{ID: 5, PostIDs: []int64{9}}
+	repo.posts = []newsletterdomain.IssuePost{{ID: 9, Title: "Hello", Slug: "hello", Summary: "Hi there"}}
+	mailer := &fakeMailer{}
+	svc := NewService(repo, mailer, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+
+	sent, err := svc.SendPending(context.Background())
+	if err != nil || sent != 2 {
+		t.Fatalf("expected two emails, got %d, %v", sent, err)
+	}
+	msg := mailer.sent[1]
+	if msg.To != "c@example.com" || msg.Subject != "Blog: Hello" || !strings.Contains(msg.Text, "https://blog.example.com/posts/hello") {
+		t.Fatalf("unexpected email %+v", msg)
+	}
+	unsubscribe := svc.UnsubscribeURL(3)
+	if msg.Headers["List-Unsubscribe"] != "<"+unsubscribe+">" || msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" || !strings.Contains(msg.Text, unsubscribe) {
+		t.Fatalf("expected one-click unsubscribe headers and link, got %+v", msg)
+	}
+	if repo.cursor != 3 || !repo.completed {
+		t.Fatalf("expected the issue to be finished, cursor=%d completed=%v", repo.cursor, repo.completed)
+	}
+	if sent, err := svc.SendPending(context.Background()); err != nil || sent != 0 {
+		t.Fatalf("expected nothing left, got %d, %v", sent, err)
+	}
+}
+
+func TestSendPendingDigestsAndSkipsFailures(t *testing.T) {
+	repo := newFakeNewsletterRepo()
+	repo.add(newsletterdomain.Subscriber{ID: 1, Email: "bad@example.com", Status: newsletterdomain.StatusConfirmed})
+	repo.add(newsletterdomain.Subscriber{ID: 2, Email: "good@example.com", Status: newsletterdomain.StatusConfirmed})
+	repo.issue = &newsletterdomain.Issue{ID: 5, PostIDs: []int64{9, 10}}
+	repo.posts = []newsletterdomain.IssuePost{{ID: 9, Title: "One", Slug: "one"}, {ID: 10, Title: "Two", Slug: "two"}}
+	mailer := &fakeMailer{fail: map[string]bool{"bad@example.com": true}}
+	svc := NewService(repo, mailer, Config{BaseURL: "https://blog.example.com/", SiteName: "Blog", Secret: "s3cret"})
+
+	sent, err := svc.SendPending(context.Background())
+	if err == nil || sent != 1 {
+		t.Fatalf("expected one email and the failure reported, got %d, %v", sent, err)
+	}
+	if msg := mailer.sent[0]; msg.Subject != "Blog: 2 new posts" || !strings.Contains(msg.Text, "/posts/one") || !strings.Contains(msg.Text, "/posts/two") {
+		t.Fatalf("unexpected digest %+v", msg)
+	}
+	if !repo.completed {
+		t.Fatal("a failed email must not hold up the issue")
+	}
+}
+
+func tokenFrom(t *testing.T, text, prefix string) string {
+	t.Helper()
+	i := strings.Index(text, prefix)
+	if i < 0 {
+		t.Fatalf("no %q in %q", prefix, text)
+	}
+	raw := strings.Fields(text[i+len(prefix):])[0]
+	token, err := url.QueryUnescape(raw)
+	if err != nil {
+		t.Fatalf("unescape %q: %v", raw, err)
+	}
+	return token
+}
+
+type fakeMailer struct {
+	sent []mail.Message
+	fail map[string]bool
+}
+
+func (f *fakeMailer) Send(ctx context.Context, msg mail.Message) error {
+	if f.fail[msg.To] {
+		return errors.New("mailbox unavailable")
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+type fakeNewsletterRepo struct {
+	subs      map[int64]newsletterdomain.Subscriber
+	tokens    map[int64]string
+	nextID    int64
+	queued    []int64
+	issue     *newsletterdomain.Issue
+	posts     []newsletterdomain.IssuePost
+	cursor    int64
+	completed bool
+}
+
+func newFakeNewsletterRepo() *fakeNewsletterRepo {
+	return &fakeNewsletterRepo{subs: map[int64]newsletterdomain.Subscriber{}, tokens: map[int64]string{}, nextID: 100}
+}
+
+func (f *fakeNewsletterRepo) add(sub newsletterdomain.Subscriber) {
+	f.subs[sub.ID] = sub
+}
+
+func (f *fakeNewsletterRepo) GetSubscriberByEmail(ctx context.Context, email string) (newsletterdomain.Subscriber, error) {
+	for _, sub := range f.subs {
+		if sub.Email == email {
+			return sub, nil
+		}
+	}
+	return newsletterdomain.Subscriber{}, newsletterdomain.ErrSubscriberNotFound
+}
+
+func (f *fakeNewsletterRepo) CreateSubscriber(ctx context.Context, email, tokenHash string) (newsletterdomain.Subscriber, error) {
+	f.nextID++
+	sub := newsletterdomain.Subscriber{ID: f.nextID, Email: email, Status: newsletterdomain.StatusPending}
+	f.subs[sub.ID] = sub
+	f.tokens[sub.ID] = tokenHash
+	return sub, nil
+}
+
+func (f *fakeNewsletterRepo) ResetSubscriber(ctx context.Context, id int64, tokenHash string) (newsletterdomain.Subscriber, error) {
+	sub := f.subs[id]
+	sub.Status = newsletterdomain.StatusPending
+	f.subs[id] = sub
+	f.tokens[id] = tokenHash
+	return sub, nil
+}
+
+func (f *fakeNewsletterRepo) ConfirmSubscriber(ctx context.Context, tokenHash string, now time.Time) (newsletterdomain.Subscriber, error) {
+	for id, hash := range f.tokens {
+		if hash == tokenHash && f.subs[id].Status == newsletterdomain.StatusPending {
+			sub := f.subs[id]
+			sub.Status = newsletterdomain.StatusConfirmed
+			sub.ConfirmedAt = &now
+			f.subs[id] = sub
+			delete(f.tokens, id)
+			return sub, nil
+		}
+	}
+	return newsletterdomain.Subscriber{}, newsletterdomain.ErrSubscriberNotFound
+}
+
+func (f *fakeNewsletterRepo) Unsubscribe(ctx context.Context, id int64, now time.Time) (newsletterdomain.Subscriber, error) {
+	sub, ok := f.subs[id]
+	if !ok {
+		return newsletterdomain.Subscriber{}, newsletterdomain.ErrSubscriberNotFound
+	}
+	sub.Status = newsletterdomain.StatusUnsubscribed
+	sub.UnsubscribedAt = &now
+	f.subs[id] = sub
+	return sub, nil
+}
+
+func (f *fakeNewsletterRepo) ListSubscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error) {
+	var out []newsletterdomain.Subscriber
+	for _, sub := range f.subs {
+		if status == "" || sub.Status == status {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeNewsletterRepo) QueuePost(ctx context.Context, postID int64) error {
+	f.queued = append(f.queued, postID)
+	return nil
+}
+
+func (f *fakeNewsletterRepo) OpenIssue(ctx context.Context, now time.Time, lease time.Duration) (newsletterdomain.Issue, bool, error) {
+	if f.issue == nil || f.completed {
+		return newsletterdomain.Issue{}, false, nil
+	}
+	return *f.issue, true, nil
+}
+
+func (f *fakeNewsletterRepo) ListIssuePosts(ctx context.Context, postIDs []int64) ([]newsletterdomain.IssuePost, error) {
+	return f.posts, nil
+}
+
+func (f *fakeNewsletterRepo) ListRecipients(ctx context.Context, afterID int64, limit int32) ([]newsletterdomain.Subscriber, error) {
+	var out []newsletterdomain.Subscriber
+	for id := afterID + 1; id <= f.nextID && len(out) < int(limit); id++ {
+		if sub, ok := f.subs[id]; ok && sub.Status == newsletterdomain.StatusConfirmed {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeNewsletterRepo) AdvanceIssue(ctx context.Context, issueID, cursor int64, leaseUntil time.Time) error {
+	f.cursor = cursor
+	return nil
+}
+
+func (f *fakeNewsletterRepo) CompleteIssue(ctx context.Context, issueID int64, now time.Time) error {
+	f.completed = true
+	return nil
+}
@@ -0,0 +1,36 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pageview "proto-gin-web/internal/contexts/blog/page/adapters/view"
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/markdown"
+)
+
+// RegisterRoutes wires the public page routes under /p.
+func RegisterRoutes(r *gin.Engine, cfg config.Config, pageSvc pageusecase.PageService) {
+	r.GET("/p/*path", func(c *gin.Context) {
+		result, err := pageSvc.GetPublished(c.Request.Context(), c.Param("path"))
+		if err != nil {
+			if errors.Is(err, pagedomain.ErrPageNotFound) {
+				c.String(http.StatusNotFound, "page not found")
+				return
+			}
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		// Pages are addressed by their last segment; send stale or partial paths to
+		// the canonical one so moved pages keep working.
+		if canonical := result.Path(); c.Request.URL.Path != canonical {
+			c.Redirect(http.StatusMovedPermanently, canonical)
+			return
+		}
+		pageview.PublicPageDetail(c, cfg, result, markdown.Render(result.Page.ContentMD))
+	})
+}
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	"proto-gin-web/internal/platform/config"
+	platformview "proto-gin-web/internal/platform/http/view"
+	"proto-gin-web/internal/platform/seo"
+)
+
+// PageLink is a titled link to another page, used for breadcrumbs and child lists.
+type PageLink struct {
+	Title   string
+	Summary string
+	Path    string
+}
+
+var templateViews = map[string]string{
+	pagedomain.TemplateDefault: "page.tmpl",
+	pagedomain.TemplateWide:    "page_wide.tmpl",
+}
+
+// PublicPageDetail renders a published page with the view matching its template.
+func PublicPageDetail(c *gin.Context, cfg config.Config, page pagedomain.PageWithRelations, content template.HTML) {
+	path := page.Path()
+	m := seo.Default(cfg.SiteName, cfg.SiteDescription, cfg.BaseURL).
+		WithPage(page.Page.Title, page.Page.Summary, cfg.BaseURL+path, "")
+
+	ancestors := make([]PageLink, len(page.Ancestors))
+	for i, a := range page.Ancestors {
+		ancestors[i] = PageLink{Title: a.Title, Summary: a.Summary, Path: a.Path(page.Ancestors[:i])}
+	}
+	chain := append(append([]pagedomain.Page{}, page.Ancestors...), page.Page)
+	children := make([]PageLink, len(page.Children))
+	for i, child := range page.Children {
+		children[i] = PageLink{Title: child.Title, Summary: child.Summary, Path: child.Path(chain)}
+	}
+
+	view, ok := templateViews[page.Page.Template]
+	if !ok {
+		view = templateViews[pagedomain.TemplateDefault]
+	}
+	platformview.RenderHTML(c, http.StatusOK, view, platformview.WithAdminContext(c, gin.H{
+		"Title":           page.Page.Title,
+		"Summary":         page.Page.Summary,
+		"ContentHTML":     content,
+		"Ancestors":       ancestors,
+		"Children":        children,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"MetaTags":        template.HTML(m.Tags()),
+	}))
+}
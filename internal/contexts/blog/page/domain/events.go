@@ -0,0 +1,12 @@
+package pagedomain
+
+// EventPageChanged is the outbox event type recorded when a page is created, updated or
+// deleted.
+const EventPageChanged = "PageChanged"
+
+// Event is the payload of EventPageChanged: the page as written, or as it was before a
+// delete.
+type Event struct {
+	Page    Page `json:"page"`
+	Deleted bool `json:"deleted,omitempty"`
+}
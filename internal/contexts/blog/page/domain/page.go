@@ -0,0 +1,77 @@
+package pagedomain
+
+import "time"
+
+// Page is a standalone content item such as About or Privacy. Unlike posts, pages are
+// kept out of listings and feeds and may be nested under a parent page.
+type Page struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Summary   string    `json:"summary"`
+	ContentMD string    `json:"content_md"`
+	Template  string    `json:"template"`
+	Status    string    `json:"status"`
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Path returns the public URL path of the page given its ancestors, root first.
+func (p Page) Path(ancestors []Page) string {
+	path := "/p"
+	for _, a := range ancestors {
+		path += "/" + a.Slug
+	}
+	return path + "/" + p.Slug
+}
+
+// PageWithRelations bundles a page with its ancestors (root first) and published children.
+type PageWithRelations struct {
+	Page      Page   `json:"page"`
+	Ancestors []Page `json:"ancestors"`
+	Children  []Page `json:"children"`
+}
+
+// Path returns the public URL path of the page.
+func (p PageWithRelations) Path() string {
+	return p.Page.Path(p.Ancestors)
+}
+
+// CreatePageInput describes a new page. An empty slug is generated from the title and
+// an empty ParentSlug creates a top-level page.
+type CreatePageInput struct {
+	Title      string
+	Slug       string
+	Summary    string
+	ContentMD  string
+	Template   string
+	Status     string
+	ParentSlug string
+	Position   int32
+}
+
+// UpdatePageInput replaces the editable fields of the page identified by Slug.
+type UpdatePageInput struct {
+	Slug       string
+	Title      string
+	Summary    string
+	ContentMD  string
+	Template   string
+	Status     string
+	ParentSlug string
+	Position   int32
+}
+
+// PageRecord is the shape the repository writes; the parent is already resolved to an id.
+type PageRecord struct {
+	Title     string
+	Slug      string
+	Summary   string
+	ContentMD string
+	Template  string
+	Status    string
+	ParentID  *int64
+	Position  int32
+}
@@ -0,0 +1,16 @@
+package pagedomain
+
+import "context"
+
+// PageRepository abstracts persistence of pages.
+type PageRepository interface {
+	ListPages(ctx context.Context) ([]Page, error)
+	ListPublishedChildren(ctx context.Context, parentID int64) ([]Page, error)
+	// ListAncestors returns the chain of parents of the page, root first.
+	ListAncestors(ctx context.Context, id int64) ([]Page, error)
+	GetPageBySlug(ctx context.Context, slug string) (Page, error)
+	CreatePage(ctx context.Context, record PageRecord) (Page, error)
+	UpdatePage(ctx context.Context, slug string, record PageRecord) (Page, error)
+	DeletePage(ctx context.Context, slug string) error
+	PageSlugExists(ctx context.Context, slug string) (bool, error)
+}
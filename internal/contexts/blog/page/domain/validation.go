@@ -0,0 +1,87 @@
+package pagedomain
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Page statuses. Pages have no archive state; unpublishing returns them to draft.
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+)
+
+// Page templates, each rendered by its own view.
+const (
+	TemplateDefault = "default"
+	TemplateWide    = "wide"
+)
+
+// Field length limits, counted in characters.
+const (
+	MaxTitleLength   = 200
+	MaxSlugLength    = 160
+	MaxSummaryLength = 500
+	MaxContentLength = 200000
+)
+
+var (
+	ErrPageNotFound = errors.New("page: not found")
+
+	ErrTitleRequired    = errors.New("title is required")
+	ErrTitleTooLong     = errors.New("title must be at most " + strconv.Itoa(MaxTitleLength) + " characters")
+	ErrSlugRequired     = errors.New("slug is required")
+	ErrSlugInvalid      = errors.New("slug may only contain lowercase letters, digits and single hyphens")
+	ErrSlugTooLong      = errors.New("slug must be at most " + strconv.Itoa(MaxSlugLength) + " characters")
+	ErrSlugTaken        = errors.New("slug is already in use")
+	ErrSummaryTooLong   = errors.New("summary must be at most " + strconv.Itoa(MaxSummaryLength) + " characters")
+	ErrContentTooLong   = errors.New("content must be at most " + strconv.Itoa(MaxContentLength) + " characters")
+	ErrStatusInvalid    = errors.New("status must be one of draft, published")
+	ErrTemplateInvalid  = errors.New("template must be one of default, wide")
+	ErrParentNotFound   = errors.New("parent page does not exist")
+	ErrParentIsSelf     = errors.New("a page cannot be its own parent")
+	ErrParentDescendant = errors.New("parent cannot be a descendant of the page")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// IsValidTemplate reports whether name is a known page template.
+func IsValidTemplate(name string) bool {
+	return name == TemplateDefault || name == TemplateWide
+}
+
+// Validate checks the attributes shared by create and update.
+func Validate(record PageRecord) *validation.Error {
+	v := &validation.Error{}
+	switch {
+	case record.Title == "":
+		v.Add("title", ErrTitleRequired)
+	case utf8.RuneCountInString(record.Title) > MaxTitleLength:
+		v.Add("title", ErrTitleTooLong)
+	}
+	switch {
+	case record.Slug == "":
+		v.Add("slug", ErrSlugRequired)
+	case len(record.Slug) > MaxSlugLength:
+		v.Add("slug", ErrSlugTooLong)
+	case !slugPattern.MatchString(record.Slug):
+		v.Add("slug", ErrSlugInvalid)
+	}
+	if utf8.RuneCountInString(record.Summary) > MaxSummaryLength {
+		v.Add("summary", ErrSummaryTooLong)
+	}
+	if utf8.RuneCountInString(record.ContentMD) > MaxContentLength {
+		v.Add("content_md", ErrContentTooLong)
+	}
+	if record.Status != StatusDraft && record.Status != StatusPublished {
+		v.Add("status", ErrStatusInvalid)
+	}
+	if !IsValidTemplate(record.Template) {
+		v.Add("template", ErrTemplateInvalid)
+	}
+	return v
+}
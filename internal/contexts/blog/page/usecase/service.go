@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/slug"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// PageService exposes application-facing operations around static pages.
+type PageService interface {
+	List(ctx context.Context) ([]pagedomain.Page, error)
+	PublishedPaths(ctx context.Context) ([]string, error)
+	GetPublished(ctx context.Context, path string) (pagedomain.PageWithRelations, error)
+	Get(ctx context.Context, slug string) (pagedomain.PageWithRelations, error)
+	Create(ctx context.Context, input pagedomain.CreatePageInput) (pagedomain.Page, error)
+	Update(ctx context.Context, input pagedomain.UpdatePageInput) (pagedomain.Page, error)
+	Delete(ctx context.Context, slug string) error
+}
+
+// Service implements PageService using a repository abstraction.
+//
+// Every write records a page event in the outbox, in the same transaction as the
+// write. A nil outbox stores writes without events.
+type Service struct {
+	repo   pagedomain.PageRepository
+	outbox outbox.Writer
+}
+
+var _ PageService = (*Service)(nil)
+
+// NewService wires a page repository and the event outbox into a use case
+// implementation.
+func NewService(repo pagedomain.PageRepository, events outbox.Writer) *Service {
+	return &Service{repo: repo, outbox: events}
+}
+
+// List returns every page, drafts included, for the admin.
+func (s *Service) List(ctx context.Context) ([]pagedomain.Page, error) {
+	return s.repo.ListPages(ctx)
+}
+
+// PublishedPaths returns the public URL path of every published page, for the sitemap.
+func (s *Service) PublishedPaths(ctx context.Context) ([]string, error) {
+	pages, err := s.repo.ListPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]pagedomain.Page, len(pages))
+	for _, p := range pages {
+		byID[p.ID] = p
+	}
+	var paths []string
+	for _, p := range pages {
+		if p.Status != pagedomain.StatusPublished {
+			continue
+		}
+		paths = append(paths, p.Path(ancestorsOf(p, byID)))
+	}
+	return paths, nil
+}
+
+// GetPublished resolves a public path such as "about/team" to a published page. Only
+// the last segment identifies the page; callers compare the canonical Path to redirect
+// stale parent segments.
+func (s *Service) GetPublished(ctx context.Context, path string) (pagedomain.PageWithRelations, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	slug := segments[len(segments)-1]
+	if slug == "" {
+		return pagedomain.PageWithRelations{}, pagedomain.ErrPageNotFound
+	}
+	result, err := s.Get(ctx, slug)
+	if err != nil {
+		return pagedomain.PageWithRelations{}, err
+	}
+	if result.Page.Status != pagedomain.StatusPublished {
+		return pagedomain.PageWithRelations{}, pagedomain.ErrPageNotFound
+	}
+	return result, nil
+}
+
+// Get loads a page with its ancestors and published children regardless of status.
+func (s *Service) Get(ctx context.Context, slug string) (pagedomain.PageWithRelations, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return pagedomain.PageWithRelations{}, pagedomain.ErrPageNotFound
+	}
+	page, err := s.repo.GetPageBySlug(ctx, slug)
+	if err != nil {
+		return pagedomain.PageWithRelations{}, err
+	}
+	ancestors, err := s.repo.ListAncestors(ctx, page.ID)
+	if err != nil {
+		return pagedomain.PageWithRelations{}, err
+	}
+	children, err := s.repo.ListPublishedChildren(ctx, page.ID)
+	if err != nil {
+		return pagedomain.PageWithRelations{}, err
+	}
+	return pagedomain.PageWithRelations{Page: page, Ancestors: ancestors, Children: children}, nil
+}
+
+// Create validates and stores a new page. An empty slug is generated from the title,
+// an empty template defaults to "default" and an empty status to draft.
+func (s *Service) Create(ctx context.Context, input pagedomain.CreatePageInput) (pagedomain.Page, error) {
+	record := pagedomain.PageRecord{
+		Title:     strings.TrimSpace(input.Title),
+		Slug:      strings.TrimSpace(input.Slug),
+		Summary:   strings.TrimSpace(input.Summary),
+		ContentMD: input.ContentMD,
+		Template:  normalizeTemplate(input.Template),
+		Status:    normalizeStatus(input.Status),
+		Position:  input.Position,
+	}
+	generated := record.Slug == ""
+	if generated {
+		if base := slug.Make(record.Title); base != "" {
+			unique, err := slug.Unique(ctx, base, s.repo.PageSlugExists)
+			if err != nil {
+				return pagedomain.Page{}, err
+			}
+			record.Slug = unique
+		}
+	}
+
+	v := pagedomain.Validate(record)
+	if !generated && !v.Has("slug") {
+		taken, err := s.repo.PageSlugExists(ctx, record.Slug)
+		if err != nil {
+			return pagedomain.Page{}, err
+		}
+		if taken {
+			v.Add("slug", pagedomain.ErrSlugTaken)
+		}
+	}
+	parentID, err := s.resolveParent(ctx, v, 0, input.ParentSlug)
+	if err != nil {
+		return pagedomain.Page{}, err
+	}
+	record.ParentID = parentID
+	if err := v.Err(); err != nil {
+		return pagedomain.Page{}, err
+	}
+
+	page, err := s.recordPageWrite(ctx, func(ctx context.Context) (pagedomain.Page, error) {
+		return s.repo.CreatePage(ctx, record)
+	})
+	if errors.Is(err, pagedomain.ErrSlugTaken) {
+		v.Add("slug", pagedomain.ErrSlugTaken)
+		return pagedomain.Page{}, v
+	}
+	return page, err
+}
+
+// Update replaces the editable fields of a page. The slug is fixed after creation.
+func (s *Service) Update(ctx context.Context, input pagedomain.UpdatePageInput) (pagedomain.Page, error) {
+	current, err := s.repo.GetPageBySlug(ctx, strings.TrimSpace(input.Slug))
+	if err != nil {
+		return pagedomain.Page{}, err
+	}
+	record := pagedomain.PageRecord{
+		Title:     strings.TrimSpace(input.Title),
+		Slug:      current.Slug,
+		Summary:   strings.TrimSpace(input.Summary),
+		ContentMD: input.ContentMD,
+		Template:  normalizeTemplate(input.Template),
+		Status:    normalizeStatus(input.Status),
+		Position:  input.Position,
+	}
+	v := pagedomain.Validate(record)
+	parentID, err := s.resolveParent(ctx, v, current.ID, input.ParentSlug)
+	if err != nil {
+		return pagedomain.Page{}, err
+	}
+	record.ParentID = parentID
+	if err := v.Err(); err != nil {
+		return pagedomain.Page{}, err
+	}
+	return s.recordPageWrite(ctx, func(ctx context.Context) (pagedomain.Page, error) {
+		return s.repo.UpdatePage(ctx, current.Slug, record)
+	})
+}
+
+// Delete removes a page; its children move up to the top level. Its delete event
+// carries the page as it was.
+func (s *Service) Delete(ctx context.Context, slug string) error {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return pagedomain.ErrPageNotFound
+	}
+	return outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		current, err := s.repo.GetPageBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.DeletePage(ctx, slug); err != nil {
+			return nil, err
+		}
+		msg, err := outbox.NewMessage(pagedomain.EventPageChanged, current.Slug, pagedomain.Event{Page: current, Deleted: true})
+		if err != nil {
+			return nil, err
+		}
+		return []outbox.Message{msg}, nil
+	})
+}
+
+// recordPageWrite runs write and records the event of the page it returns in one
+// transaction.
+func (s *Service) recordPageWrite(ctx context.Context, write func(ctx context.Context) (pagedomain.Page, error)) (pagedomain.Page, error) {
+	var page pagedomain.Page
+	err := outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		written, err := write(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page = written
+		msg, err := outbox.NewMessage(pagedomain.EventPageChanged, written.Slug, pagedomain.Event{Page: written})
+		if err != nil {
+			return nil, err
+		}
+		return []outbox.Message{msg}, nil
+	})
+	if err != nil {
+		return pagedomain.Page{}, err
+	}
+	return page, nil
+}
+
+// resolveParent looks up parentSlug and rejects parents that would create a cycle with
+// the page identified by selfID (zero for new pages). Problems are recorded on v.
+func (s *Service) resolveParent(ctx context.Context, v *validation.Error, selfID int64, parentSlug string) (*int64, error) {
+	parentSlug = strings.TrimSpace(parentSlug)
+	if parentSlug == "" {
+		return nil, nil
+	}
+	parent, err := s.repo.GetPageBySlug(ctx, parentSlug)
+	if errors.Is(err, pagedomain.ErrPageNotFound) {
+		v.Add("parent", pagedomain.ErrParentNotFound)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if selfID != 0 {
+		if parent.ID == selfID {
+			v.Add("parent", pagedomain.ErrParentIsSelf)
+			return nil, nil
+		}
+		ancestors, err := s.repo.ListAncestors(ctx, parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range ancestors {
+			if a.ID == selfID {
+				v.Add("parent", pagedomain.ErrParentDescendant)
+				return nil, nil
+			}
+		}
+	}
+	return &parent.ID, nil
+}
+
+// ancestorsOf walks parent links in byID, root first. Links are acyclic because
+// resolveParent rejects cycles; the depth cap only guards against corrupt data.
+func ancestorsOf(p pagedomain.Page, byID map[int64]pagedomain.Page) []pagedomain.Page {
+	var chain []pagedomain.Page
+	for parentID := p.ParentID; parentID != nil && len(chain) < len(byID); {
+		parent, ok := byID[*parentID]
+		if !ok {
+			break
+		}
+		chain = append([]pagedomain.Page{parent}, chain...)
+		parentID = parent.ParentID
+	}
+	return chain
+}
+
+func normalizeTemplate(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return pagedomain.TemplateDefault
+	}
+	return name
+}
+
+func normalizeStatus(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		return pagedomain.StatusDraft
+	}
+	return status
+}
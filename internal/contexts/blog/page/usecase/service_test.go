@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+	"proto-gin-web/internal/platform/validation"
+)
+
+func TestServiceCreateGeneratesSlugAndResolvesParent(t *testing.T) {
+	repo := newFakePageRepo(pagedomain.Page{ID: 1, Slug: "about", Title: "About", Status: pagedomain.StatusPublished})
+	svc := NewService(repo, nil)
+
+	page, err := svc.Create(context.Background(), pagedomain.CreatePageInput{Title: "Our Team", ParentSlug: "about"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Slug != "our-team" || page.Template != pagedomain.TemplateDefault || page.Status != pagedomain.StatusDraft {
+		t.Fatalf("unexpected defaults: %+v", page)
+	}
+	if page.ParentID == nil || *page.ParentID != 1 {
+		t.Fatalf("expected parent 1, got %v", page.ParentID)
+	}
+}
+
+func TestServiceCreateRejectsInvalidInput(t *testing.T) {
+	repo := newFakePageRepo(pagedomain.Page{ID: 1, Slug: "about", Title: "About"})
+	svc := NewService(repo, nil)
+
+	_, err := svc.Create(context.Background(), pagedomain.CreatePageInput{
+		Title:      "About",
+		Slug:       "about",
+		Template:   "sidebar",
+		ParentSlug: "missing",
+	})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, field := range []string{"slug", "template", "parent"} {
+		if !verr.Has(field) {
+			t.Fatalf("expected %s error, got %+v", field, verr.Fields)
+		}
+	}
+	if !errors.Is(err, pagedomain.ErrSlugTaken) || !errors.Is(err, pagedomain.ErrParentNotFound) {
+		t.Fatalf("expected slug and parent sentinels, got %v", err)
+	}
+}
+
+func TestServiceUpdateRejectsCycles(t *testing.T) {
+	root, child := int64(1), int64(2)
+	repo := newFakePageRepo(
+		pagedomain.Page{ID: root, Slug: "about", Title: "About"},
+		pagedomain.Page{ID: child, Slug: "team", Title: "Team", ParentID: &root},
+		pagedomain.Page{ID: 3, Slug: "alice", Title: "Alice", ParentID: &child},
+	)
+	svc := NewService(repo, nil)
+
+	_, err := svc.Update(context.Background(), pagedomain.UpdatePageInput{Slug: "about", Title: "About", ParentSlug: "about"})
+	if !errors.Is(err, pagedomain.ErrParentIsSelf) {
+		t.Fatalf("expected self parent error, got %v", err)
+	}
+	_, err = svc.Update(context.Background(), pagedomain.UpdatePageInput{Slug: "about", Title: "About", ParentSlug: "alice"})
+	if !errors.Is(err, pagedomain.ErrParentDescendant) {
+		t.Fatalf("expected descendant parent error, got %v", err)
+	}
+	page, err := svc.Update(context.Background(), pagedomain.UpdatePageInput{Slug: "alice", Title: "Alice", ParentSlug: "about"})
+	if err != nil || page.ParentID == nil || *page.ParentID != root {
+		t.Fatalf("expected move under about, got %+v, %v", page, err)
+	}
+}
+
+func TestServiceGetPublishedHidesDrafts(t *testing.T) {
+	root := int64(1)
+	repo := newFakePageRepo(
+		pagedomain.Page{ID: root, Slug: "about", Title: "About", Status: pagedomain.StatusPublished},
+		pagedomain.Page{ID: 2, Slug: "team", Title: "Team", Status: pagedomain.StatusPublished, ParentID: &root},
+		pagedomain.Page{ID: 3, Slug: "careers", Title: "Careers", Status: pagedomain.StatusDraft, ParentID: &root},
+	)
+	svc := NewService(repo, nil)
+
+	result, err := svc.GetPublished(context.Background(), "/team")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Path(); got != "/p/about/team" {
+		t.Fatalf("expected canonical path /p/about/team, got %s", got)
+	}
+	about, err := svc.GetPublished(context.Background(), "/about/")
+	if err != nil || len(about.Children) != 1 {
+		t.Fatalf("expected one published child, got %+v, %v", about.Children, err)
+	}
+	if _, err := svc.GetPublished(context.Background(), "/about/careers"); !errors.Is(err, pagedomain.ErrPageNotFound) {
+		t.Fatalf("expected draft to be hidden, got %v", err)
+	}
+}
+
+func TestServicePublishedPaths(t *testing.T) {
+	root := int64(1)
+	repo := newFakePageRepo(
+		pagedomain.Page{ID: root, Slug: "about", Status: pagedomain.StatusDraft},
+		pagedomain.Page{ID: 2, Slug: "team", Status: pagedomain.StatusPublished, ParentID: &root},
+		pagedomain.Page{ID: 3, Slug: "privacy", Status: pagedomain.StatusPublished},
+	)
+	svc := NewService(repo, nil)
+
+	paths, err := svc.PublishedPaths(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "/p/about/team" || paths[1] != "/p/privacy" {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+}
+
+type fakePageRepo struct {
+	pages  []pagedomain.Page
+	nextID int64
+}
+
+func newFakePageRepo(pages ...pagedomain.Page) *fakePageRepo {
+	return &fakePageRepo{pages: pages, nextID: int64(len(pages)) + 1}
+}
+
+func (f *fakePageRepo) ListPages(context.Context) ([]pagedomain.Page, error) {
+	return f.pages, nil
+}
+
+func (f *fakePageRepo) ListPublishedChildren(ctx context.Context, parentID int64) ([]pagedomain.Page, error) {
+	var out []pagedomain.Page
+	for _, p := range f.pages {
+		if p.ParentID != nil && *p.ParentID == parentID && p.Status == pagedomain.StatusPublished {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePageRepo) ListAncestors(ctx context.Context, id int64) ([]pagedomain.Page, error) {
+	byID := make(map[int64]pagedomain.Page, len(f.pages))
+	for _, p := range f.pages {
+		byID[p.ID] = p
+	}
+	return ancestorsOf(byID[id], byID), nil
+}
+
+func (f *fakePageRepo) GetPageBySlug(ctx context.Context, slug string) (pagedomain.Page, error) {
+	for _, p := range f.pages {
+		if p.Slug == slug {
+			return p, nil
+		}
+	}
+	return pagedomain.Page{}, pagedomain.ErrPageNotFound
+}
+
+func (f *fakePageRepo) CreatePage(ctx context.Context, record pagedomain.PageRecord) (pagedomain.Page, error) {
+	page := pageFromRecord(f.nextID, record)
+	f.nextID++
+	f.pages = append(f.pages, page)
+	return page, nil
+}
+
+func (f *fakePageRepo) UpdatePage(ctx context.Context, slug string, record pagedomain.PageRecord) (pagedomain.Page, error) {
+	for i, p := range f.pages {
+		if p.Slug == slug {
+			f.pages[i] = pageFromRecord(p.ID, record)
+			return f.pages[i], nil
+		}
+	}
+	return pagedomain.Page{}, pagedomain.ErrPageNotFound
+}
+
+func (f *fakePageRepo) DeletePage(context.Context, string) error {
+	return nil
+}
+
+func (f *fakePageRepo) PageSlugExists(ctx context.Context, slug string) (bool, error) {
+	_, err := f.GetPageBySlug(ctx, slug)
+	return err == nil, nil
+}
+
+func pageFromRecord(id int64, r pagedomain.PageRecord) pagedomain.Page {
+	return pagedomain.Page{
+		ID:        id,
+		Title:     r.Title,
+		Slug:      r.Slug,
+		Summary:   r.Summary,
+		ContentMD: r.ContentMD,
+		Template:  r.Template,
+		Status:    r.Status,
+		ParentID:  r.ParentID,
+		Position:  r.Position,
+	}
+}
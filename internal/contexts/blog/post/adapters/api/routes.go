@@ -1,8 +1,11 @@
 ﻿package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,6 +13,7 @@ import (
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
 	"proto-gin-web/internal/platform/http/responder"
+	"proto-gin-web/internal/platform/validation"
 )
 
 // RegisterRoutes attaches JSON endpoints for posts.
@@ -23,12 +27,14 @@ func RegisterRoutes(r *gin.Engine, postSvc postusecase.PostService) {
 
 // listPostsHandler godoc
 // @Summary      List published posts
-// @Description  Retrieves a paginated list of published posts.
+// @Description  Retrieves a paginated list of published posts. Custom fields filter with
+// @Description  field.<key>=<value>, e.g. field.difficulty=beginner; every filter given must match.
 // @Tags         Public
 // @Produce      json
 // @Param        limit   query     int  false  "Number of posts to return" default(10)
 // @Param        offset  query     int  false  "Pagination offset" default(0)
 // @Success      200  {object}  postListResponse
+// @Failure      400  {object}  errorResponse
 // @Failure      500  {object}  errorResponse
 // @Router       /api/posts [get]
 func listPostsHandler(postSvc postusecase.PostService) gin.HandlerFunc {
@@ -46,10 +52,16 @@ func listPostsHandler(postSvc postusecase.PostService) gin.HandlerFunc {
 			}
 		}
 		rows, err := postSvc.ListPublished(c.Request.Context(), postdomain.ListPostsOptions{
+			Fields: fieldFilters(c),
 			Limit:  limit,
 			Offset: offset,
 		})
 		if err != nil {
+			var verr *validation.Error
+			if errors.As(err, &verr) {
+				responder.JSONError(c, http.StatusBadRequest, verr.Error())
+				return
+			}
 			responder.JSONError(c, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -57,14 +69,31 @@ func listPostsHandler(postSvc postusecase.PostService) gin.HandlerFunc {
 	}
 }
 
+// fieldFilters collects field.<key> query parameters; the first value of each wins.
+func fieldFilters(c *gin.Context) map[string]string {
+	var filters map[string]string
+	for name, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(name, "field.")
+		if !ok || key == "" || len(values) == 0 {
+			continue
+		}
+		if filters == nil {
+			filters = make(map[string]string)
+		}
+		filters[key] = values[0]
+	}
+	return filters
+}
+
 // getPostHandler godoc
 // @Summary      Get a post by slug
-// @Description  Retrieves a post together with its categories and tags.
+// @Description  Retrieves a post together with its categories and tags. Expired posts answer 410 unless they are set to show an expiry banner, in which case expired is true.
 // @Tags         Public
 // @Produce      json
 // @Param        slug  path      string  true  "Post slug"
 // @Success      200  {object}  postResponse
 // @Failure      404  {object}  errorResponse
+// @Failure      410  {object}  errorResponse
 // @Router       /api/posts/{slug} [get]
 func getPostHandler(postSvc postusecase.PostService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -73,6 +102,10 @@ func getPostHandler(postSvc postusecase.PostService) gin.HandlerFunc {
 			responder.JSONError(c, http.StatusNotFound, "post not found")
 			return
 		}
+		if row.Post.Expired(time.Now()) && row.Post.ExpiryMode != postdomain.ExpiryBanner {
+			responder.JSONError(c, http.StatusGone, "post has expired")
+			return
+		}
 		responder.JSONSuccess(c, http.StatusOK, presenters.BuildPublicPostWithRelations(row))
 	}
 }
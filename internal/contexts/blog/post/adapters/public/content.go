@@ -1,22 +1,26 @@
 ﻿package public
 
 import (
-	"html/template"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/microcosm-cc/bluemonday"
-	bf "github.com/russross/blackfriday/v2"
 
+	mediapresenter "proto-gin-web/internal/contexts/admin/media/adapters/view"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
 	postview "proto-gin-web/internal/contexts/blog/post/adapters/view"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
 	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/markdown"
 )
 
-func registerContentRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService) {
+// coverSizes is the sizes attribute of cover images, which span the content column.
+const coverSizes = "(max-width: 960px) 100vw, 960px"
+
+func registerContentRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService, taxonomySvc taxonomyusecase.TaxonomyService, images mediausecase.ImageLookup) {
 	r.GET("/", func(c *gin.Context) {
 		postview.PublicLanding(c, cfg)
 	})
@@ -49,6 +53,15 @@ func registerContentRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase
 			c.String(http.StatusInternalServerError, "internal server error")
 			return
 		}
+		// An empty tag listing may use the slug of a tag merged into another one.
+		if len(rows) == 0 && tag != "" {
+			if target, err := taxonomySvc.ResolveTagAlias(ctx, tag); err == nil {
+				query := c.Request.URL.Query()
+				query.Set("tag", target.Slug)
+				c.Redirect(http.StatusMovedPermanently, "/posts?"+query.Encode())
+				return
+			}
+		}
 		postview.PublicPosts(c, cfg, rows, page, size)
 	})
 
@@ -61,15 +74,18 @@ func registerContentRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase
 			c.String(http.StatusNotFound, "post not found")
 			return
 		}
+		expired := result.Post.Expired(time.Now())
+		if expired {
+			if result.Post.ExpiryMode != postdomain.ExpiryBanner {
+				c.String(http.StatusGone, "this content has expired")
+				return
+			}
+			// still reachable for readers with the link, but no longer indexed
+			c.Header("X-Robots-Tag", "noindex")
+		}
 
-		md := result.Post.ContentMD
-		md = strings.ReplaceAll(md, "\r\n", "\n")
-		md = strings.ReplaceAll(md, "\\r\\n", "\n")
-		md = strings.ReplaceAll(md, "\\n", "\n")
-		unsafe := bf.Run([]byte(md))
-		safe := bluemonday.UGCPolicy().SanitizeBytes(unsafe)
-
-		postview.PublicPostDetail(c, cfg, result, template.HTML(string(safe)))
+		coverAttrs := mediapresenter.ImageAttrs(ctx, images, result.Post.CoverURL, coverSizes)
+		postview.PublicPostDetail(c, cfg, result, markdown.Render(result.Post.ContentMD), coverAttrs, expired)
 	})
 }
 
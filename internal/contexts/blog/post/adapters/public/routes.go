@@ -3,15 +3,21 @@
 import (
 	"github.com/gin-gonic/gin"
 
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
 	"proto-gin-web/internal/platform/config"
 )
 
-// RegisterRoutes wires all public-facing routes.
-func RegisterRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService) {
+// RegisterRoutes wires all public-facing routes. Pages are only read for the sitemap;
+// their own routes live in the page context. Taxonomy backs the category and tag
+// landing pages, and images describes their library cover images.
+func RegisterRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService, pageSvc pageusecase.PageService, taxonomySvc taxonomyusecase.TaxonomyService, images mediausecase.ImageLookup) {
 	registerHealthRoutes(r, postSvc)
-	registerSEORoutes(r, cfg, postSvc)
-	registerContentRoutes(r, cfg, postSvc)
+	registerSEORoutes(r, cfg, postSvc, pageSvc, taxonomySvc)
+	registerContentRoutes(r, cfg, postSvc, taxonomySvc, images)
+	registerTaxonomyRoutes(r, cfg, postSvc, taxonomySvc, images)
 }
 
 
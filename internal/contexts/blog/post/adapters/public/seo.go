@@ -1,19 +1,23 @@
 ﻿package public
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
 	"proto-gin-web/internal/platform/config"
 	"proto-gin-web/internal/platform/seo"
 )
 
-func registerSEORoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService) {
+func registerSEORoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService, pageSvc pageusecase.PageService, taxonomySvc taxonomyusecase.TaxonomyService) {
 	r.GET("/robots.txt", func(c *gin.Context) {
 		c.Header("Content-Type", "text/plain; charset=utf-8")
 		c.String(http.StatusOK, "User-agent: *\nAllow: /\nSitemap: %s/sitemap.xml\n", cfg.BaseURL)
@@ -26,10 +30,22 @@ func registerSEORoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.Pos
 			c.String(http.StatusInternalServerError, "internal server error")
 			return
 		}
+		pagePaths, err := pageSvc.PublishedPaths(ctx)
+		if err != nil {
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
 		paths := []string{"/"}
 		for _, p := range rows {
 			paths = append(paths, "/posts/"+p.Slug)
 		}
+		paths = append(paths, pagePaths...)
+		taxonomyPaths, err := taxonomyLandingPaths(ctx, taxonomySvc)
+		if err != nil {
+			c.String(http.StatusInternalServerError, "internal server error")
+			return
+		}
+		paths = append(paths, taxonomyPaths...)
 		xmlBytes, err := seo.BuildFromPaths(cfg.BaseURL, paths)
 		if err != nil {
 			c.String(http.StatusInternalServerError, "internal server error")
@@ -41,51 +57,95 @@ func registerSEORoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.Pos
 
 	r.GET("/rss.xml", func(c *gin.Context) {
 		ctx := c.Request.Context()
-		rows, err := postSvc.ListPublished(ctx, postdomain.ListPostsOptions{Limit: 20})
+		rows, err := postSvc.ListPublished(ctx, postdomain.ListPostsOptions{Limit: feedSize})
 		if err != nil {
 			c.String(http.StatusInternalServerError, "internal server error")
 			return
 		}
-		base := strings.TrimRight(cfg.BaseURL, "/")
-		var (
-			items     []seo.RSSItem
-			latestPub *time.Time
-		)
-		for _, p := range rows {
-			link := base + "/posts/" + p.Slug
-			items = append(items, seo.RSSItem{
-				Title:           p.Title,
-				Link:            link,
-				Description:     p.Summary,
-				PubDate:         p.PublishedAt,
-				GUID:            link,
-				GUIDIsPermaLink: true,
-			})
-			if p.PublishedAt != nil {
-				if latestPub == nil || p.PublishedAt.After(*latestPub) {
-					latestPub = p.PublishedAt
-				}
+		writeFeed(c, seo.RSS{
+			Title:       cfg.SiteName,
+			Link:        cfg.BaseURL,
+			Description: cfg.SiteDescription,
+		}, cfg.BaseURL, rows)
+	})
+}
+
+// feedSize is the number of latest posts in every RSS feed.
+const feedSize = 20
+
+// writeFeed fills feed with an item per post and writes it as RSS. The feed's pubDate
+// is the newest publication date among the posts.
+func writeFeed(c *gin.Context, feed seo.RSS, baseURL string, posts []postdomain.Post) {
+	base := strings.TrimRight(baseURL, "/")
+	for _, p := range posts {
+		link := base + "/posts/" + p.Slug
+		feed.Items = append(feed.Items, seo.RSSItem{
+			Title:           p.Title,
+			Link:            link,
+			Description:     p.Summary,
+			PubDate:         p.PublishedAt,
+			GUID:            link,
+			GUIDIsPermaLink: true,
+		})
+		if p.PublishedAt != nil {
+			if feed.PubDate == nil || p.PublishedAt.After(*feed.PubDate) {
+				feed.PubDate = p.PublishedAt
 			}
 		}
-		now := time.Now()
-		rss := seo.RSS{
-			Title:         cfg.SiteName,
-			Link:          cfg.BaseURL,
-			Description:   cfg.SiteDescription,
-			LastBuildDate: &now,
-			Items:         items,
+	}
+	now := time.Now()
+	feed.LastBuildDate = &now
+	xmlBytes, err := feed.Build()
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Header("Content-Type", "application/rss+xml; charset=utf-8")
+	c.Writer.Write(xmlBytes)
+}
+
+// taxonomyLandingPaths lists the landing pages that have published posts: tags with
+// posts of their own and categories with posts in them or in a subcategory.
+func taxonomyLandingPaths(ctx context.Context, taxonomySvc taxonomyusecase.TaxonomyService) ([]string, error) {
+	categories, err := taxonomySvc.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := taxonomySvc.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]taxdomain.CategoryWithCount, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+	filled := make(map[int64]bool, len(categories))
+	for _, c := range categories {
+		if c.PostCount == 0 {
+			continue
 		}
-		if latestPub != nil {
-			rss.PubDate = latestPub
+		// mark the category and its ancestors; stop at one already marked
+		for id := c.ID; !filled[id]; {
+			filled[id] = true
+			parent, ok := byID[id]
+			if !ok || parent.ParentID == nil {
+				break
+			}
+			id = *parent.ParentID
 		}
-		xmlBytes, err := rss.Build()
-		if err != nil {
-			c.String(http.StatusInternalServerError, "internal server error")
-			return
+	}
+	var paths []string
+	for _, c := range categories {
+		if filled[c.ID] {
+			paths = append(paths, categoryPath(c.Slug))
 		}
-		c.Header("Content-Type", "application/rss+xml; charset=utf-8")
-		c.Writer.Write(xmlBytes)
-	})
+	}
+	for _, t := range tags {
+		if t.PostCount > 0 {
+			paths = append(paths, tagPath(t.Slug))
+		}
+	}
+	return paths, nil
 }
 
 
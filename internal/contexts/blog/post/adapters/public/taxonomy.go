@@ -0,0 +1,183 @@
+﻿package public
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	mediapresenter "proto-gin-web/internal/contexts/admin/media/adapters/view"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	postview "proto-gin-web/internal/contexts/blog/post/adapters/view"
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
+	"proto-gin-web/internal/platform/config"
+	"proto-gin-web/internal/platform/markdown"
+	"proto-gin-web/internal/platform/seo"
+)
+
+// landingPageSize is the number of posts per category or tag landing page.
+const landingPageSize = 10
+
+// registerTaxonomyRoutes wires the category and tag landing pages and their feeds.
+// Category pages include posts filed under subcategories; tag slugs left behind by a
+// merge redirect to the tag they were merged into.
+func registerTaxonomyRoutes(r *gin.Engine, cfg config.Config, postSvc postusecase.PostService, taxonomySvc taxonomyusecase.TaxonomyService, images mediausecase.ImageLookup) {
+	r.GET("/categories/:slug", func(c *gin.Context) {
+		category, ok := findCategory(c, taxonomySvc)
+		if !ok {
+			return
+		}
+		renderLanding(c, cfg, postSvc, images, categoryLanding(category.Category), postdomain.ListPostsOptions{Category: category.Slug})
+	})
+
+	r.GET("/categories/:slug/rss.xml", func(c *gin.Context) {
+		category, ok := findCategory(c, taxonomySvc)
+		if !ok {
+			return
+		}
+		writeLandingFeed(c, cfg, postSvc, categoryLanding(category.Category), postdomain.ListPostsOptions{Category: category.Slug})
+	})
+
+	r.GET("/tags/:slug", func(c *gin.Context) {
+		tag, ok := findTag(c, taxonomySvc, "")
+		if !ok {
+			return
+		}
+		renderLanding(c, cfg, postSvc, images, tagLanding(tag.Tag), postdomain.ListPostsOptions{Tag: tag.Slug})
+	})
+
+	r.GET("/tags/:slug/rss.xml", func(c *gin.Context) {
+		tag, ok := findTag(c, taxonomySvc, "/rss.xml")
+		if !ok {
+			return
+		}
+		writeLandingFeed(c, cfg, postSvc, tagLanding(tag.Tag), postdomain.ListPostsOptions{Tag: tag.Slug})
+	})
+}
+
+func categoryPath(slug string) string {
+	return "/categories/" + url.PathEscape(slug)
+}
+
+func tagPath(slug string) string {
+	return "/tags/" + url.PathEscape(slug)
+}
+
+func categoryLanding(category taxdomain.Category) postview.TaxonomyLanding {
+	return taxonomyLanding(category.Name, "Posts filed under "+category.Name+".", categoryPath(category.Slug), category.Details)
+}
+
+func tagLanding(tag taxdomain.Tag) postview.TaxonomyLanding {
+	return taxonomyLanding("#"+tag.Name, "Posts tagged "+tag.Name+".", tagPath(tag.Slug), tag.Details)
+}
+
+// taxonomyLanding applies the editable details over the generated heading and
+// description. The description markdown goes through the same sanitizing renderer as
+// post bodies.
+func taxonomyLanding(heading, description, path string, details taxdomain.Details) postview.TaxonomyLanding {
+	landing := postview.TaxonomyLanding{
+		Heading:     heading,
+		Title:       heading,
+		Description: description,
+		Path:        path,
+		CoverURL:    details.CoverURL,
+	}
+	if details.MetaTitle != "" {
+		landing.Title = details.MetaTitle
+	}
+	if details.MetaDescription != "" {
+		landing.Description = details.MetaDescription
+	}
+	if details.DescriptionMD != "" {
+		landing.Intro = markdown.Render(details.DescriptionMD)
+	}
+	return landing
+}
+
+// findCategory loads the category named in the path or answers 404.
+func findCategory(c *gin.Context, taxonomySvc taxonomyusecase.TaxonomyService) (taxdomain.CategoryWithCount, bool) {
+	category, err := taxonomySvc.GetCategory(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, taxdomain.ErrCategoryNotFound) {
+			c.String(http.StatusNotFound, "category not found")
+		} else {
+			c.String(http.StatusInternalServerError, "internal server error")
+		}
+		return taxdomain.CategoryWithCount{}, false
+	}
+	return category, true
+}
+
+// findTag loads the tag named in the path. An alias of a merged tag answers 301 to the
+// same page of the tag it points to, suffix being the part of the path after the slug.
+func findTag(c *gin.Context, taxonomySvc taxonomyusecase.TaxonomyService, suffix string) (taxdomain.TagWithCount, bool) {
+	ctx := c.Request.Context()
+	tag, err := taxonomySvc.GetTag(ctx, c.Param("slug"))
+	if err == nil {
+		return tag, true
+	}
+	if !errors.Is(err, taxdomain.ErrTagNotFound) {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return taxdomain.TagWithCount{}, false
+	}
+	target, aliasErr := taxonomySvc.ResolveTagAlias(ctx, c.Param("slug"))
+	if aliasErr != nil {
+		c.String(http.StatusNotFound, "tag not found")
+		return taxdomain.TagWithCount{}, false
+	}
+	location := tagPath(target.Slug) + suffix
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(http.StatusMovedPermanently, location)
+	return taxdomain.TagWithCount{}, false
+}
+
+// renderLanding renders one page of a landing listing. Pages past the last one answer
+// 404 so crawlers drop them.
+func renderLanding(c *gin.Context, cfg config.Config, postSvc postusecase.PostService, images mediausecase.ImageLookup, landing postview.TaxonomyLanding, opts postdomain.ListPostsOptions) {
+	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	if err != nil || page < 1 {
+		page = 1
+	}
+	// one extra row tells whether a next page exists
+	opts.Limit = landingPageSize + 1
+	opts.Offset = int32((page - 1) * landingPageSize)
+	rows, err := postSvc.ListPublished(c.Request.Context(), opts)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if page > 1 && len(rows) == 0 {
+		c.String(http.StatusNotFound, "page not found")
+		return
+	}
+	landing.HasNext = len(rows) > landingPageSize
+	if landing.HasNext {
+		rows = rows[:landingPageSize]
+	}
+	landing.Posts = rows
+	landing.Page = page
+	landing.CoverAttrs = mediapresenter.ImageAttrs(c.Request.Context(), images, landing.CoverURL, coverSizes)
+	postview.PublicTaxonomyPosts(c, cfg, landing)
+}
+
+// writeLandingFeed writes the latest posts of a landing listing as RSS.
+func writeLandingFeed(c *gin.Context, cfg config.Config, postSvc postusecase.PostService, landing postview.TaxonomyLanding, opts postdomain.ListPostsOptions) {
+	opts.Limit = feedSize
+	rows, err := postSvc.ListPublished(c.Request.Context(), opts)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeFeed(c, seo.RSS{
+		Title:       landing.Title + " · " + cfg.SiteName,
+		Link:        cfg.BaseURL + landing.Path,
+		Description: landing.Description,
+	}, cfg.BaseURL, rows)
+}
@@ -9,17 +9,20 @@ import (
 
 // PublicPost represents the public JSON shape of a blog post.
 type PublicPost struct {
-	ID          int64      `json:"id"`
-	Title       string     `json:"title"`
-	Slug        string     `json:"slug"`
-	Summary     string     `json:"summary"`
-	ContentMD   string     `json:"content_md"`
-	CoverURL    string     `json:"cover_url"`
-	Status      string     `json:"status"`
-	AuthorID    int64      `json:"author_id"`
-	PublishedAt *time.Time `json:"published_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID           int64          `json:"id"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Summary      string         `json:"summary"`
+	ContentMD    string         `json:"content_md"`
+	CoverURL     string         `json:"cover_url"`
+	Status       string         `json:"status"`
+	AuthorID     int64          `json:"author_id"`
+	PublishedAt  *time.Time     `json:"published_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	CustomFields map[string]any `json:"custom_fields"`
+	UnpublishAt  *time.Time     `json:"unpublish_at,omitempty"`
+	Expired      bool           `json:"expired"`
 }
 
 // PublicTaxonomy describes the external JSON shape of category/tag.
@@ -29,11 +32,20 @@ type PublicTaxonomy struct {
 	Slug string `json:"slug"`
 }
 
-// PublicPostWithRelations contains a post and its taxonomies.
+// PublicField describes a custom field in scope for a post; value is null when unset.
+type PublicField struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// PublicPostWithRelations contains a post, its taxonomies and its custom fields.
 type PublicPostWithRelations struct {
 	Post       PublicPost       `json:"post"`
 	Categories []PublicTaxonomy `json:"categories"`
 	Tags       []PublicTaxonomy `json:"tags"`
+	Fields     []PublicField    `json:"fields"`
 }
 
 // BuildPublicPosts converts domain posts into public representation.
@@ -48,17 +60,20 @@ func BuildPublicPosts(posts []postdomain.Post) []PublicPost {
 // BuildPublicPost converts a single post.
 func BuildPublicPost(p postdomain.Post) PublicPost {
 	return PublicPost{
-		ID:          p.ID,
-		Title:       p.Title,
-		Slug:        p.Slug,
-		Summary:     p.Summary,
-		ContentMD:   p.ContentMD,
-		CoverURL:    p.CoverURL,
-		Status:      p.Status,
-		AuthorID:    p.AuthorID,
-		PublishedAt: p.PublishedAt,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:           p.ID,
+		Title:        p.Title,
+		Slug:         p.Slug,
+		Summary:      p.Summary,
+		ContentMD:    p.ContentMD,
+		CoverURL:     p.CoverURL,
+		Status:       p.Status,
+		AuthorID:     p.AuthorID,
+		PublishedAt:  p.PublishedAt,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		CustomFields: p.CustomFields,
+		UnpublishAt:  p.UnpublishAt,
+		Expired:      p.Expired(time.Now()),
 	}
 }
 
@@ -70,7 +85,16 @@ func BuildPublicPostWithRelations(row postdomain.PostWithRelations) PublicPostWi
 		Post:       BuildPublicPost(row.Post),
 		Categories: cats,
 		Tags:       tags,
+		Fields:     mapFields(row.Fields),
+	}
+}
+
+func mapFields(fields []postdomain.FieldValue) []PublicField {
+	result := make([]PublicField, len(fields))
+	for i, f := range fields {
+		result[i] = PublicField{Key: f.Definition.Key, Label: f.Definition.Label, Type: string(f.Definition.Type), Value: f.Value}
 	}
+	return result
 }
 
 func mapCategories(categories []taxdomain.Category) []PublicTaxonomy {
@@ -3,6 +3,7 @@
 import (
 	"html/template"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -53,8 +54,67 @@ func PublicPosts(c *gin.Context, cfg config.Config, posts []postdomain.Post, pag
 	}))
 }
 
-// PublicPostDetail renders a single post detail page.
-func PublicPostDetail(c *gin.Context, cfg config.Config, post postdomain.PostWithRelations, content template.HTML) {
+// TaxonomyLanding is one page of the posts of a category or tag. Path is the public
+// path of its first page; its feed lives at Path + "/rss.xml". Title and Description
+// feed the meta tags, Intro is the rendered description shown above the posts and
+// CoverAttrs the srcset and dimensions of a library cover image.
+type TaxonomyLanding struct {
+	Heading     string
+	Title       string
+	Description string
+	Intro       template.HTML
+	CoverURL    string
+	CoverAttrs  template.HTMLAttr
+	Path        string
+	Posts       []postdomain.Post
+	Page        int64
+	HasNext     bool
+}
+
+// PublicTaxonomyPosts renders a category or tag landing page. Later pages carry their
+// page number in the canonical URL, and every page links the listing's feed.
+func PublicTaxonomyPosts(c *gin.Context, cfg config.Config, landing TaxonomyLanding) {
+	canonical := cfg.BaseURL + pagePath(landing.Path, landing.Page)
+	m := seo.Default(cfg.SiteName, cfg.SiteDescription, cfg.BaseURL).WithPage(landing.Title, landing.Description, canonical, landing.CoverURL)
+	m.Canonical = canonical
+	data := gin.H{
+		"Title":           landing.Title,
+		"Heading":         landing.Heading,
+		"Description":     landing.Description,
+		"Intro":           landing.Intro,
+		"CoverURL":        landing.CoverURL,
+		"CoverAttrs":      landing.CoverAttrs,
+		"Env":             cfg.Env,
+		"BaseURL":         cfg.BaseURL,
+		"SiteName":        cfg.SiteName,
+		"SiteDescription": cfg.SiteDescription,
+		"Posts":           landing.Posts,
+		"Page":            landing.Page,
+		"FeedURL":         landing.Path + "/rss.xml",
+		"FeedTitle":       landing.Title + " · " + cfg.SiteName,
+		"MetaTags":        template.HTML(m.Tags()),
+	}
+	if landing.Page > 1 {
+		data["PrevURL"] = pagePath(landing.Path, landing.Page-1)
+	}
+	if landing.HasNext {
+		data["NextURL"] = pagePath(landing.Path, landing.Page+1)
+	}
+	platformview.RenderHTML(c, http.StatusOK, "taxonomy.tmpl", platformview.WithAdminContext(c, data))
+}
+
+// pagePath returns the path of page n of a listing; the first page has no query.
+func pagePath(path string, n int64) string {
+	if n <= 1 {
+		return path
+	}
+	return path + "?page=" + strconv.FormatInt(n, 10)
+}
+
+// PublicPostDetail renders a single post detail page; coverAttrs holds the srcset and
+// dimensions of a library cover image and expired adds the "this content has expired"
+// banner.
+func PublicPostDetail(c *gin.Context, cfg config.Config, post postdomain.PostWithRelations, content template.HTML, coverAttrs template.HTMLAttr, expired bool) {
 	m := seo.Default(cfg.SiteName, cfg.SiteDescription, cfg.BaseURL).
 		WithPage(post.Post.Title, post.Post.Summary, cfg.BaseURL+"/posts/"+post.Post.Slug, post.Post.CoverURL)
 	m.Type = "article"
@@ -62,9 +122,14 @@ func PublicPostDetail(c *gin.Context, cfg config.Config, post postdomain.PostWit
 		"Title":           post.Post.Title,
 		"Summary":         post.Post.Summary,
 		"CoverURL":        post.Post.CoverURL,
+		"CoverAttrs":      coverAttrs,
 		"ContentHTML":     content,
 		"Categories":      post.Categories,
+		"Breadcrumbs":     post.Breadcrumbs,
+		"BreadcrumbList":  postBreadcrumbList(cfg, post),
 		"Tags":            post.Tags,
+		"Fields":          post.Fields,
+		"Expired":         expired,
 		"Env":             cfg.Env,
 		"BaseURL":         cfg.BaseURL,
 		"SiteName":        cfg.SiteName,
@@ -74,3 +139,17 @@ func PublicPostDetail(c *gin.Context, cfg config.Config, post postdomain.PostWit
 }
 
 
+
+// postBreadcrumbList builds the BreadcrumbList structured data for a post filed under
+// a category: home, the category trail, then the post itself.
+func postBreadcrumbList(cfg config.Config, post postdomain.PostWithRelations) map[string]any {
+	if len(post.Breadcrumbs) == 0 {
+		return nil
+	}
+	crumbs := []seo.Crumb{{Name: cfg.SiteName, URL: cfg.BaseURL + "/"}}
+	for _, c := range post.Breadcrumbs {
+		crumbs = append(crumbs, seo.Crumb{Name: c.Name, URL: cfg.BaseURL + "/categories/" + c.Slug})
+	}
+	crumbs = append(crumbs, seo.Crumb{Name: post.Post.Title, URL: cfg.BaseURL + "/posts/" + post.Post.Slug})
+	return seo.BreadcrumbList(crumbs)
+}
@@ -0,0 +1,37 @@
+package postdomain
+
+import (
+	"errors"
+	"time"
+)
+
+// AutosaveInterval is how long the editor waits after the last keystroke before it
+// autosaves the working copy.
+const AutosaveInterval = 3 * time.Second
+
+// ErrAutosaveNotFound indicates the user has no autosave newer than the post.
+var ErrAutosaveNotFound = errors.New("post: no autosave")
+
+// Autosave is a user's unsaved working copy of a post. It lives apart from the post
+// and never changes what readers see.
+type Autosave struct {
+	PostID       int64          `json:"post_id"`
+	UserID       int64          `json:"user_id"`
+	Title        string         `json:"title"`
+	Summary      string         `json:"summary"`
+	ContentMD    string         `json:"content_md"`
+	CoverURL     string         `json:"cover_url"`
+	CustomFields map[string]any `json:"custom_fields"`
+	SavedAt      time.Time      `json:"saved_at"`
+}
+
+// AutosaveInput stores the working copy of the post Slug for UserID.
+type AutosaveInput struct {
+	Slug         string
+	UserID       int64
+	Title        string
+	Summary      string
+	ContentMD    string
+	CoverURL     string
+	CustomFields map[string]any
+}
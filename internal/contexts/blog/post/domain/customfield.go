@@ -0,0 +1,344 @@
+package postdomain
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// FieldType enumerates the value types a custom field can hold.
+type FieldType string
+
+const (
+	FieldText   FieldType = "text"
+	FieldNumber FieldType = "number"
+	FieldDate   FieldType = "date"
+	FieldURL    FieldType = "url"
+	FieldEnum   FieldType = "enum"
+)
+
+// FieldDateLayout is the storage and input format of date fields.
+const FieldDateLayout = "2006-01-02"
+
+// MaxFieldTextLength caps text field values, counted in characters.
+const MaxFieldTextLength = 1000
+
+// Custom field errors. Definition errors are reported against the definition's own
+// attributes; value errors against "custom_fields.<key>".
+var (
+	ErrFieldNotFound         = errors.New("post: custom field not found")
+	ErrFieldKeyInvalid       = errors.New("key must start with a letter and contain only lowercase letters, digits and underscores")
+	ErrFieldKeyTaken         = errors.New("key is already in use")
+	ErrFieldLabelRequired    = errors.New("label is required")
+	ErrFieldTypeInvalid      = errors.New("type must be one of text, number, date, url, enum")
+	ErrFieldOptionsRequired  = errors.New("enum fields need at least one option")
+	ErrFieldCategoryNotFound = errors.New("category does not exist")
+
+	ErrFieldUnknown       = errors.New("is not a defined custom field")
+	ErrFieldValueRequired = errors.New("is required")
+	ErrFieldNotText       = errors.New("must be text")
+	ErrFieldTextTooLong   = errors.New("must be at most " + strconv.Itoa(MaxFieldTextLength) + " characters")
+	ErrFieldNotNumber     = errors.New("must be a number")
+	ErrFieldNotDate       = errors.New("must be a date formatted as YYYY-MM-DD")
+	ErrFieldNotURL        = errors.New("must be an http(s) url")
+	ErrFieldNotOption     = errors.New("must be one of the allowed options")
+)
+
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// FieldDefinition describes a typed custom field. An empty CategorySlug makes the
+// field available to every post; otherwise only to posts in that category.
+type FieldDefinition struct {
+	ID           int64     `json:"id"`
+	Key          string    `json:"key"`
+	Label        string    `json:"label"`
+	Type         FieldType `json:"type"`
+	Required     bool      `json:"required"`
+	Options      []string  `json:"options,omitempty"`
+	CategorySlug string    `json:"category,omitempty"`
+	Position     int32     `json:"position"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AppliesTo reports whether the definition is in scope for a post in the given categories.
+func (d FieldDefinition) AppliesTo(categorySlugs []string) bool {
+	if d.CategorySlug == "" {
+		return true
+	}
+	for _, slug := range categorySlugs {
+		if slug == d.CategorySlug {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldDefinitionInput carries the editable attributes of a definition. The key is
+// fixed after creation.
+type FieldDefinitionInput struct {
+	Key          string
+	Label        string
+	Type         FieldType
+	Required     bool
+	Options      []string
+	CategorySlug string
+	Position     int32
+}
+
+// FieldValue pairs a definition with the value stored on a post (nil when unset).
+type FieldValue struct {
+	Definition FieldDefinition `json:"definition"`
+	Value      any             `json:"value"`
+}
+
+// Display renders the value for templates.
+func (f FieldValue) Display() string {
+	switch v := f.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// ValidateFieldDefinition checks a definition's attributes.
+func ValidateFieldDefinition(input FieldDefinitionInput) *validation.Error {
+	v := &validation.Error{}
+	if !fieldKeyPattern.MatchString(input.Key) {
+		v.Add("key", ErrFieldKeyInvalid)
+	}
+	if input.Label == "" {
+		v.Add("label", ErrFieldLabelRequired)
+	}
+	switch input.Type {
+	case FieldText, FieldNumber, FieldDate, FieldURL:
+	case FieldEnum:
+		if len(input.Options) == 0 {
+			v.Add("options", ErrFieldOptionsRequired)
+		}
+	default:
+		v.Add("type", ErrFieldTypeInvalid)
+	}
+	return v
+}
+
+// ResolveCustomFields validates values against defs and returns the map to store.
+// Keys without a definition are rejected, empty values are dropped, and required
+// definitions in scope for categorySlugs must be present. Problems are recorded on v.
+func ResolveCustomFields(defs []FieldDefinition, values map[string]any, categorySlugs []string, v *validation.Error) map[string]any {
+	byKey := make(map[string]FieldDefinition, len(defs))
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+
+	out := make(map[string]any, len(values))
+	for _, key := range sortedKeys(values) {
+		def, ok := byKey[key]
+		if !ok {
+			addFieldValueError(v, customFieldPath(key), key, ErrFieldUnknown)
+			continue
+		}
+		coerced, err := CoerceFieldValue(def, values[key])
+		if err != nil {
+			addFieldValueError(v, customFieldPath(key), key, err)
+			continue
+		}
+		if coerced != nil {
+			out[key] = coerced
+		}
+	}
+
+	for _, d := range defs {
+		if !d.Required || !d.AppliesTo(categorySlugs) || v.Has(customFieldPath(d.Key)) {
+			continue
+		}
+		if _, ok := out[d.Key]; !ok {
+			addFieldValueError(v, customFieldPath(d.Key), d.Key, ErrFieldValueRequired)
+		}
+	}
+	return out
+}
+
+// MergeCustomFields applies a JSON Merge Patch to stored values: null removes a key,
+// anything else replaces it.
+func MergeCustomFields(current, patch map[string]any) map[string]any {
+	out := make(map[string]any, len(current)+len(patch))
+	for k, val := range current {
+		out[k] = val
+	}
+	for k, val := range patch {
+		if val == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = val
+	}
+	return out
+}
+
+// CoerceFieldFilters converts query-string filters into typed values so that JSON
+// containment matches the stored representation (e.g. "3" filters number fields as 3).
+func CoerceFieldFilters(defs []FieldDefinition, filters map[string]string, v *validation.Error) map[string]any {
+	if len(filters) == 0 {
+		return nil
+	}
+	byKey := make(map[string]FieldDefinition, len(defs))
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+	out := make(map[string]any, len(filters))
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		def, ok := byKey[key]
+		if !ok {
+			addFieldValueError(v, "field."+key, key, ErrFieldUnknown)
+			continue
+		}
+		coerced, err := CoerceFieldValue(def, filters[key])
+		if err != nil {
+			addFieldValueError(v, "field."+key, key, err)
+			continue
+		}
+		if coerced != nil {
+			out[key] = coerced
+		}
+	}
+	return out
+}
+
+// BuildFieldValues lists the definitions in scope for a post together with its values,
+// in definition order.
+func BuildFieldValues(defs []FieldDefinition, values map[string]any, categorySlugs []string) []FieldValue {
+	out := make([]FieldValue, 0, len(defs))
+	for _, d := range defs {
+		val, set := values[d.Key]
+		if !set && !d.AppliesTo(categorySlugs) {
+			continue
+		}
+		out = append(out, FieldValue{Definition: d, Value: val})
+	}
+	return out
+}
+
+// CoerceFieldValue converts raw (a decoded JSON value or a form string) into the stored
+// representation for def. Empty values yield nil.
+func CoerceFieldValue(def FieldDefinition, raw any) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if def.Type == FieldNumber {
+		return coerceNumber(raw)
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return nil, typeMismatch(def.Type)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	switch def.Type {
+	case FieldText:
+		if utf8.RuneCountInString(s) > MaxFieldTextLength {
+			return nil, ErrFieldTextTooLong
+		}
+		return s, nil
+	case FieldDate:
+		t, err := time.Parse(FieldDateLayout, s)
+		if err != nil {
+			return nil, ErrFieldNotDate
+		}
+		return t.Format(FieldDateLayout), nil
+	case FieldURL:
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, ErrFieldNotURL
+		}
+		return s, nil
+	case FieldEnum:
+		for _, opt := range def.Options {
+			if opt == s {
+				return s, nil
+			}
+		}
+		return nil, ErrFieldNotOption
+	}
+	return nil, ErrFieldTypeInvalid
+}
+
+func coerceNumber(raw any) (any, error) {
+	switch n := raw.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case json.Number:
+		f, err := n.Float64()
+		if err != nil {
+			return nil, ErrFieldNotNumber
+		}
+		return f, nil
+	case string:
+		s := strings.TrimSpace(n)
+		if s == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, ErrFieldNotNumber
+		}
+		return f, nil
+	}
+	return nil, ErrFieldNotNumber
+}
+
+func typeMismatch(t FieldType) error {
+	switch t {
+	case FieldDate:
+		return ErrFieldNotDate
+	case FieldURL:
+		return ErrFieldNotURL
+	case FieldEnum:
+		return ErrFieldNotOption
+	}
+	return ErrFieldNotText
+}
+
+// addFieldValueError records err with a message that names the field, e.g. "difficulty is required".
+func addFieldValueError(v *validation.Error, path, key string, err error) {
+	v.Fields = append(v.Fields, validation.FieldError{Field: path, Message: key + " " + err.Error(), Err: err})
+}
+
+func customFieldPath(key string) string {
+	return "custom_fields." + key
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
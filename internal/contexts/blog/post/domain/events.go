@@ -0,0 +1,31 @@
+package postdomain
+
+// Outbox event types recorded with every post write.
+const (
+	EventPostPublished = "PostPublished"
+	EventPostUpdated   = "PostUpdated"
+	EventPostDeleted   = "PostDeleted"
+)
+
+// EventTypes lists every post event type.
+var EventTypes = []string{EventPostPublished, EventPostUpdated, EventPostDeleted}
+
+// Event is the payload of a post event: the post as written, or as it was before a
+// delete, and its status before the write. PreviousStatus is empty for a new post.
+type Event struct {
+	Post           Post   `json:"post"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+// EventType returns the event a write from one status to another records. A post that
+// becomes published records EventPostPublished, an empty to means the post was deleted,
+// and every other write, new drafts included, records EventPostUpdated.
+func EventType(from, to string) string {
+	switch {
+	case to == "":
+		return EventPostDeleted
+	case to == StatusPublished && from != StatusPublished:
+		return EventPostPublished
+	}
+	return EventPostUpdated
+}
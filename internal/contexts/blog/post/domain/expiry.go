@@ -0,0 +1,36 @@
+package postdomain
+
+import (
+	"errors"
+	"time"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Expiry modes decide what an expired post serves: 410 Gone, or the post itself
+// behind an "expired" banner.
+const (
+	ExpiryGone   = "gone"
+	ExpiryBanner = "banner"
+)
+
+// Expiry validation sentinels.
+var (
+	ErrExpiryModeInvalid  = errors.New("expiry mode must be gone or banner")
+	ErrUnpublishAtInvalid = errors.New("unpublish date must be a valid date and time")
+)
+
+// Expired reports whether the post should no longer render normally at now: it was
+// archived, or its unpublish date passed before the background job archived it.
+func (p Post) Expired(now time.Time) bool {
+	if p.Status == StatusArchived {
+		return true
+	}
+	return p.Status == StatusPublished && p.UnpublishAt != nil && !now.Before(*p.UnpublishAt)
+}
+
+func checkExpiryMode(v *validation.Error, mode string) {
+	if mode != ExpiryGone && mode != ExpiryBanner {
+		v.Add("expiry_mode", ErrExpiryModeInvalid)
+	}
+}
@@ -0,0 +1,32 @@
+package postdomain
+
+import "time"
+
+// Link kinds name what an internal link in post content points at.
+const (
+	LinkPost     = "post"
+	LinkCategory = "category"
+	LinkTag      = "tag"
+	LinkUpload   = "upload"
+)
+
+// LinkFinding is an internal link or upload reference in a post that does not resolve:
+// a post, category or tag slug that does not exist or a file missing from the uploads
+// directory.
+type LinkFinding struct {
+	PostID    int64     `json:"post_id"`
+	PostSlug  string    `json:"post_slug"`
+	PostTitle string    `json:"post_title"`
+	Kind      string    `json:"kind"`
+	URL       string    `json:"url"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// LinkSource is the part of a post the link checker reads.
+type LinkSource struct {
+	PostID    int64
+	Slug      string
+	Title     string
+	ContentMD string
+	CoverURL  string
+}
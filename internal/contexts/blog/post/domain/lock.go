@@ -0,0 +1,48 @@
+package postdomain
+
+import (
+	"errors"
+	"time"
+)
+
+// Edit locks are soft: they warn other editors that someone has a post open but do not
+// block writes. The edit page renews its lock every EditLockHeartbeat; a lock that is
+// not renewed within EditLockTTL lapses.
+const (
+	EditLockTTL       = 90 * time.Second
+	EditLockHeartbeat = 30 * time.Second
+)
+
+var (
+	// ErrPostLocked indicates another user holds the edit lock of the post.
+	ErrPostLocked = errors.New("post: being edited by another user")
+	// ErrLockTakenOver tells the previous holder that another user took the lock over.
+	ErrLockTakenOver = errors.New("post: edit lock taken over by another user")
+)
+
+// EditLock records who is editing a post. PreviousHolderID is set when the lock was
+// taken over and cleared once the previous holder has been told.
+type EditLock struct {
+	Slug             string    `json:"slug"`
+	HolderID         int64     `json:"holder_id"`
+	HolderName       string    `json:"holder_name"`
+	AcquiredAt       time.Time `json:"acquired_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	PreviousHolderID int64     `json:"previous_holder_id,omitempty"`
+}
+
+// LockState describes the edit lock of a post as seen by the acting user.
+type LockState struct {
+	Locked    bool      `json:"locked"`
+	HeldByYou bool      `json:"held_by_you"`
+	Lock      *EditLock `json:"lock,omitempty"`
+}
+
+// LockInput acquires or renews the edit lock of a post on behalf of a user. TakeOver
+// replaces a lock held by someone else.
+type LockInput struct {
+	Slug       string
+	HolderID   int64
+	HolderName string
+	TakeOver   bool
+}
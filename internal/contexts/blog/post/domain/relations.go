@@ -0,0 +1,38 @@
+package postdomain
+
+import (
+	"errors"
+
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+)
+
+// Relation sentinels; validation.FieldError wraps them under "categories" or "tags"
+// together with the entries that matched nothing.
+var (
+	ErrCategoryUnknown = errors.New("unknown categories")
+	ErrTagUnknown      = errors.New("unknown tags")
+)
+
+// RelationSet is the complete set of categories and tags a post should carry. A nil
+// slice leaves that relation as it is and an empty one clears it. Categories are slugs;
+// tags may also be tag names or aliases. With CreateMissingTags a tag entry matching no
+// tag becomes a new tag named after it.
+type RelationSet struct {
+	Categories        []string
+	Tags              []string
+	CreateMissingTags bool
+}
+
+// RelationWrite is a RelationSet checked against the stored categories and tags: the
+// tags to create and the slugs to store. A nil Categories or Tags keeps that relation.
+type RelationWrite struct {
+	CreateTags []taxdomain.TagRecord
+	Categories []string
+	Tags       []string
+}
+
+// PostRelations are the categories and tags a post carries.
+type PostRelations struct {
+	Categories []taxdomain.Category `json:"categories"`
+	Tags       []taxdomain.Tag      `json:"tags"`
+}
@@ -9,21 +9,120 @@ import (
 // PostRepository abstracts persistence operations for posts and their relations.
 type PostRepository interface {
 	ListPublishedPosts(ctx context.Context, limit, offset int32) ([]Post, error)
-	ListPublishedPostsSorted(ctx context.Context, sort string, limit, offset int32) ([]Post, error)
-	ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, limit, offset int32) ([]Post, error)
-	ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, limit, offset int32) ([]Post, error)
+	// The sorted variants only return posts whose custom fields contain every entry of fields.
+	ListPublishedPostsSorted(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]Post, error)
+	ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, fields map[string]any, limit, offset int32) ([]Post, error)
+	ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, fields map[string]any, limit, offset int32) ([]Post, error)
 
 	GetPostBySlug(ctx context.Context, slug string) (Post, error)
 	CreatePost(ctx context.Context, input CreatePostInput) (Post, error)
 	UpdatePostBySlug(ctx context.Context, input UpdatePostInput) (Post, error)
+	PatchPostBySlug(ctx context.Context, input PatchPostInput) (Post, error)
 	DeletePostBySlug(ctx context.Context, slug string) error
+	// ArchiveExpiredPosts archives every published post whose unpublish date has passed
+	// and returns their slugs.
+	ArchiveExpiredPosts(ctx context.Context) ([]string, error)
+	PostSlugExists(ctx context.Context, slug string) (bool, error)
+	AuthorExists(ctx context.Context, id int64) (bool, error)
+	// UserRole returns the role name of a user, or "" when the user has no role or
+	// does not exist.
+	UserRole(ctx context.Context, id int64) (string, error)
 
 	AddCategoryToPost(ctx context.Context, slug, categorySlug string) error
 	RemoveCategoryFromPost(ctx context.Context, slug, categorySlug string) error
 	AddTagToPost(ctx context.Context, slug, tagSlug string) error
 	RemoveTagFromPost(ctx context.Context, slug, tagSlug string) error
+	// ReplacePostRelations creates the tags of write and makes its categories and tags
+	// the complete sets of the post, in one transaction. It returns the created tags.
+	ReplacePostRelations(ctx context.Context, slug string, write RelationWrite) ([]taxdomain.Tag, error)
+	// ExistingCategorySlugs returns the given slugs that belong to a category.
+	ExistingCategorySlugs(ctx context.Context, slugs []string) ([]string, error)
+	// MatchTags maps each entry to the slug of the tag it names: a tag whose slug or alias
+	// equals the entry's slug form in keys (same position), or else whose name equals the
+	// entry ignoring case. Entries matching no tag are absent from the map.
+	MatchTags(ctx context.Context, entries, keys []string) (map[string]string, error)
 
 	ListCategoriesByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error)
+	// ListCategoryTrailByPostSlug returns the post's most deeply nested category preceded
+	// by its ancestors, root first, or nothing when the post has no categories.
+	ListCategoryTrailByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error)
 	ListTagsByPostSlug(ctx context.Context, slug string) ([]taxdomain.Tag, error)
 }
 
+// ReviewRepository persists the editorial review state of posts.
+type ReviewRepository interface {
+	GetReview(ctx context.Context, slug string) (Review, error)
+	ListReviewsByStatus(ctx context.Context, status string) ([]Review, error)
+	// SetReviewer assigns the reviewer of a post; nil clears the assignment.
+	SetReviewer(ctx context.Context, slug string, reviewerID *int64) error
+	// ListReviewers returns the users with a reviewing role.
+	ListReviewers(ctx context.Context) ([]Reviewer, error)
+	CreateReviewComment(ctx context.Context, record ReviewCommentRecord) (ReviewComment, error)
+	ListReviewComments(ctx context.Context, postID int64) ([]ReviewComment, error)
+}
+
+// AutosaveRepository persists one working copy per post and user.
+type AutosaveRepository interface {
+	// SaveAutosave replaces the user's working copy; it fails with ErrPostNotFound when
+	// the post does not exist.
+	SaveAutosave(ctx context.Context, input AutosaveInput) (Autosave, error)
+	GetAutosave(ctx context.Context, slug string, userID int64) (Autosave, error)
+	DeleteAutosave(ctx context.Context, slug string, userID int64) error
+}
+
+// LinkCheckRepository persists the findings of the post link checker.
+type LinkCheckRepository interface {
+	// ListLinkSources returns the content of every post, whatever its status.
+	ListLinkSources(ctx context.Context) ([]LinkSource, error)
+	// ReplaceLinkFindings stores the findings of one post in place of its previous ones.
+	ReplaceLinkFindings(ctx context.Context, postID int64, findings []LinkFinding) error
+	ListLinkFindings(ctx context.Context) ([]LinkFinding, error)
+	ListLinkFindingsByPost(ctx context.Context, slug string) ([]LinkFinding, error)
+}
+
+// TermIndexRepository persists the term counts of posts and the model the tag
+// suggester ranks tags with: how many posts each term occurs in and the centroid of
+// each tag's posts.
+type TermIndexRepository interface {
+	// SavePostTerms replaces the term counts of a post, keeping document frequencies in
+	// step, and returns the IDs of the tags whose centroids count the post before or
+	// after the change. A post that no longer exists is skipped.
+	SavePostTerms(ctx context.Context, postID int64, terms map[string]int) ([]int64, error)
+	// DeletePostTerms drops a post from the index and returns the IDs of the tags whose
+	// centroids counted it.
+	DeletePostTerms(ctx context.Context, postID int64) ([]int64, error)
+	// TermFrequencies returns the number of indexed posts and how many of them contain
+	// each of terms.
+	TermFrequencies(ctx context.Context, terms []string) (int, map[string]int, error)
+	// ListTagTermCounts returns the term counts of the indexed posts carrying a tag.
+	ListTagTermCounts(ctx context.Context, tagID int64) ([]map[string]int, error)
+	// ReplaceTagCentroid stores the centroid of a tag in place of its previous one.
+	ReplaceTagCentroid(ctx context.Context, tagID int64, weights map[string]float64) error
+	// ListTagCentroidWeights returns the centroid weights of terms by tag slug.
+	ListTagCentroidWeights(ctx context.Context, terms []string) (map[string]map[string]float64, error)
+	// ListTagsWithoutCentroid returns the IDs of tags with indexed posts but no centroid.
+	ListTagsWithoutCentroid(ctx context.Context) ([]int64, error)
+	// ListUnindexedTermSources returns up to limit posts that have not been indexed yet.
+	ListUnindexedTermSources(ctx context.Context, limit int32) ([]TermSource, error)
+}
+
+// EditLockStore keeps post edit locks in shared, expiring storage.
+type EditLockStore interface {
+	// GetLock returns the lock of a post, nil when nobody holds it.
+	GetLock(ctx context.Context, slug string) (*EditLock, error)
+	// UpdateLock calls fn with the current lock (nil when unlocked) and atomically stores
+	// what it returns until its ExpiresAt; a nil lock releases it. fn may run more than
+	// once when the lock changes concurrently. When fn fails nothing is written and its
+	// error is returned.
+	UpdateLock(ctx context.Context, slug string, fn func(current *EditLock) (*EditLock, error)) error
+}
+
+// FieldDefinitionRepository persists custom field definitions.
+type FieldDefinitionRepository interface {
+	ListFieldDefinitions(ctx context.Context) ([]FieldDefinition, error)
+	GetFieldDefinition(ctx context.Context, key string) (FieldDefinition, error)
+	CreateFieldDefinition(ctx context.Context, input FieldDefinitionInput) (FieldDefinition, error)
+	UpdateFieldDefinition(ctx context.Context, input FieldDefinitionInput) (FieldDefinition, error)
+	// DeleteFieldDefinition removes the definition and strips its values from every post.
+	DeleteFieldDefinition(ctx context.Context, key string) error
+}
@@ -7,40 +7,94 @@ import (
 )
 
 // ListPostsOptions describes pagination and filtering instructions.
+// Fields filters on custom field values by key, using their query-string form.
 type ListPostsOptions struct {
 	Category string
 	Tag      string
 	Sort     string
+	Fields   map[string]string
 	Limit    int32
 	Offset   int32
 }
 
-// PostWithRelations bundles a post along with its taxonomy associations.
+// PostWithRelations bundles a post along with its taxonomy associations and the
+// custom fields in scope for it. Breadcrumbs is the category trail shown above the
+// post, root first.
 type PostWithRelations struct {
-	Post       Post                  `json:"post"`
-	Categories []taxdomain.Category  `json:"categories"`
-	Tags       []taxdomain.Tag       `json:"tags"`
+	Post        Post                 `json:"post"`
+	Categories  []taxdomain.Category `json:"categories"`
+	Breadcrumbs []taxdomain.Category `json:"breadcrumbs"`
+	Tags        []taxdomain.Tag      `json:"tags"`
+	Fields      []FieldValue         `json:"fields"`
 }
 
-// CreatePostInput describes the data required to create a post.
+// CreatePostInput describes the data required to create a post. ActorID is the admin
+// making the request; their role decides which initial statuses are allowed. A post
+// with UnpublishAt is archived once that time passes; ExpiryMode defaults to ExpiryGone.
+// Relations are stored with the post.
 type CreatePostInput struct {
-	Title       string
-	Slug        string
-	Summary     string
-	ContentMD   string
-	CoverURL    *string
-	Status      string
-	AuthorID    int64
-	PublishedAt *time.Time
+	ActorID      int64
+	Title        string
+	Slug         string
+	Summary      string
+	ContentMD    string
+	CoverURL     *string
+	Status       string
+	AuthorID     int64
+	PublishedAt  *time.Time
+	CustomFields map[string]any
+	UnpublishAt  *time.Time
+	ExpiryMode   string
+	Relations    RelationSet
 }
 
 // UpdatePostInput captures editable fields for an existing post identified by slug.
+// A nil CustomFields keeps the stored values; a non-nil map replaces them. ActorID is
+// the admin making the request; their role guards status changes. A nil UnpublishAt
+// clears the unpublish date and an empty ExpiryMode keeps the stored one. Relations
+// replace the post's categories and tags in the same transaction.
 type UpdatePostInput struct {
-	Slug      string
-	Title     string
-	Summary   string
-	ContentMD string
-	CoverURL  *string
-	Status    string
+	ActorID      int64
+	Slug         string
+	Title        string
+	Summary      string
+	ContentMD    string
+	CoverURL     *string
+	Status       string
+	CustomFields map[string]any
+	UnpublishAt  *time.Time
+	ExpiryMode   string
+	Relations    RelationSet
+}
+
+// PatchField records whether a patch supplied a member and the value it carried.
+type PatchField[T any] struct {
+	Set   bool
+	Value T
 }
 
+// PatchPostInput captures a JSON Merge Patch (RFC 7396) against a post identified by slug.
+// Only fields marked Set are written; for nullable columns a nil Value clears the column.
+// ActorID is the admin making the request; their role guards status changes.
+type PatchPostInput struct {
+	ActorID     int64
+	Slug        string
+	Title       PatchField[string]
+	Summary     PatchField[string]
+	ContentMD   PatchField[string]
+	CoverURL    PatchField[*string]
+	Status      PatchField[string]
+	AuthorID    PatchField[int64]
+	PublishedAt PatchField[*time.Time]
+	// CustomFields is merged into the stored values; a nil member removes that key.
+	CustomFields PatchField[map[string]any]
+	UnpublishAt  PatchField[*time.Time]
+	ExpiryMode   PatchField[string]
+}
+
+// HasChanges reports whether the patch touches at least one field.
+func (p PatchPostInput) HasChanges() bool {
+	return p.Title.Set || p.Summary.Set || p.ContentMD.Set || p.CoverURL.Set ||
+		p.Status.Set || p.AuthorID.Set || p.PublishedAt.Set || p.CustomFields.Set ||
+		p.UnpublishAt.Set || p.ExpiryMode.Set
+}
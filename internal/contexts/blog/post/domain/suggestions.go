@@ -0,0 +1,32 @@
+package postdomain
+
+// TermSource is the part of a post the tag suggester indexes.
+type TermSource struct {
+	PostID    int64
+	Title     string
+	Summary   string
+	ContentMD string
+}
+
+// SuggestTagsInput is the draft a suggestion is made for. Tags lists the slugs the post
+// already carries; they are not suggested again.
+type SuggestTagsInput struct {
+	Title     string   `json:"title"`
+	ContentMD string   `json:"content_md"`
+	Tags      []string `json:"tags"`
+}
+
+// TagSuggestion is an existing tag ranked by how closely the draft resembles the posts
+// carrying it.
+type TagSuggestion struct {
+	Slug  string  `json:"slug"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// TagSuggestions are the ranked existing tags for a draft and the distinctive terms
+// of the draft that no tag covers yet.
+type TagSuggestions struct {
+	Tags    []TagSuggestion `json:"tags"`
+	NewTags []string        `json:"new_tags"`
+}
@@ -0,0 +1,198 @@
+package postdomain
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"proto-gin-web/internal/platform/validation"
+)
+
+// Post lifecycle statuses. in_review, changes_requested and approved belong to the
+// editorial review workflow described in workflow.go.
+const (
+	StatusDraft            = "draft"
+	StatusInReview         = "in_review"
+	StatusChangesRequested = "changes_requested"
+	StatusApproved         = "approved"
+	StatusPublished        = "published"
+	StatusArchived         = "archived"
+)
+
+// Field length limits, counted in characters.
+const (
+	MaxTitleLength    = 200
+	MaxSlugLength     = 160
+	MaxSummaryLength  = 500
+	MaxContentLength  = 200000
+	MaxCoverURLLength = 2048
+)
+
+// Validation sentinels; validation.FieldError wraps them so callers can match with errors.Is.
+var (
+	ErrTitleRequired    = errors.New("title is required")
+	ErrTitleTooLong     = errors.New("title must be at most " + strconv.Itoa(MaxTitleLength) + " characters")
+	ErrSlugRequired     = errors.New("slug is required")
+	ErrSlugInvalid      = errors.New("slug may only contain lowercase letters, digits and single hyphens")
+	ErrSlugTooLong      = errors.New("slug must be at most " + strconv.Itoa(MaxSlugLength) + " characters")
+	ErrSlugTaken        = errors.New("slug is already in use")
+	ErrSummaryTooLong   = errors.New("summary must be at most " + strconv.Itoa(MaxSummaryLength) + " characters")
+	ErrContentTooLong   = errors.New("content must be at most " + strconv.Itoa(MaxContentLength) + " characters")
+	ErrCoverURLInvalid  = errors.New("cover url must be an http(s) url or a site-relative path")
+	ErrCoverURLTooLong  = errors.New("cover url must be at most " + strconv.Itoa(MaxCoverURLLength) + " characters")
+	ErrStatusRequired   = errors.New("status is required")
+	ErrStatusInvalid    = errors.New("status must be one of draft, in_review, changes_requested, approved, published, archived")
+	ErrStatusTransition = errors.New("status transition is not allowed")
+	ErrStatusForbidden  = errors.New("your role may not make this status change")
+	ErrAuthorRequired   = errors.New("author id is required")
+	ErrAuthorNotFound   = errors.New("author does not exist")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ValidateCreate checks a new post against the domain rules. Author existence needs
+// the repository and is left to the caller.
+func ValidateCreate(input CreatePostInput) *validation.Error {
+	v := &validation.Error{}
+	checkTitle(v, input.Title)
+	checkSlug(v, input.Slug)
+	checkSummary(v, input.Summary)
+	checkContent(v, input.ContentMD)
+	checkCoverURL(v, input.CoverURL)
+	checkStatus(v, "", input.Status)
+	checkExpiryMode(v, input.ExpiryMode)
+	if input.AuthorID <= 0 {
+		v.Add("author_id", ErrAuthorRequired)
+	}
+	return v
+}
+
+// ValidateUpdate checks a full update against the domain rules, using current for
+// the status transition.
+func ValidateUpdate(input UpdatePostInput, current Post) *validation.Error {
+	v := &validation.Error{}
+	checkTitle(v, input.Title)
+	checkSummary(v, input.Summary)
+	checkContent(v, input.ContentMD)
+	checkCoverURL(v, input.CoverURL)
+	checkStatus(v, current.Status, input.Status)
+	if input.ExpiryMode != "" {
+		checkExpiryMode(v, input.ExpiryMode)
+	}
+	return v
+}
+
+// ValidatePatch checks the fields carried by a patch, using current for the status
+// transition.
+func ValidatePatch(input PatchPostInput, current Post) *validation.Error {
+	v := &validation.Error{}
+	if input.Title.Set {
+		checkTitle(v, input.Title.Value)
+	}
+	if input.Summary.Set {
+		checkSummary(v, input.Summary.Value)
+	}
+	if input.ContentMD.Set {
+		checkContent(v, input.ContentMD.Value)
+	}
+	if input.CoverURL.Set {
+		checkCoverURL(v, input.CoverURL.Value)
+	}
+	if input.Status.Set {
+		checkStatus(v, current.Status, input.Status.Value)
+	}
+	if input.AuthorID.Set && input.AuthorID.Value <= 0 {
+		v.Add("author_id", ErrAuthorRequired)
+	}
+	if input.ExpiryMode.Set {
+		checkExpiryMode(v, input.ExpiryMode.Value)
+	}
+	return v
+}
+
+// ValidateAutosave only enforces the length limits: a working copy may be incomplete,
+// but it must still fit the post once saved.
+func ValidateAutosave(input AutosaveInput) *validation.Error {
+	v := &validation.Error{}
+	if utf8.RuneCountInString(input.Title) > MaxTitleLength {
+		v.Add("title", ErrTitleTooLong)
+	}
+	checkSummary(v, input.Summary)
+	checkContent(v, input.ContentMD)
+	if len(input.CoverURL) > MaxCoverURLLength {
+		v.Add("cover_url", ErrCoverURLTooLong)
+	}
+	return v
+}
+
+func checkTitle(v *validation.Error, title string) {
+	switch {
+	case title == "":
+		v.Add("title", ErrTitleRequired)
+	case utf8.RuneCountInString(title) > MaxTitleLength:
+		v.Add("title", ErrTitleTooLong)
+	}
+}
+
+func checkSlug(v *validation.Error, slug string) {
+	switch {
+	case slug == "":
+		v.Add("slug", ErrSlugRequired)
+	case len(slug) > MaxSlugLength:
+		v.Add("slug", ErrSlugTooLong)
+	case !slugPattern.MatchString(slug):
+		v.Add("slug", ErrSlugInvalid)
+	}
+}
+
+func checkSummary(v *validation.Error, summary string) {
+	if utf8.RuneCountInString(summary) > MaxSummaryLength {
+		v.Add("summary", ErrSummaryTooLong)
+	}
+}
+
+func checkContent(v *validation.Error, content string) {
+	if utf8.RuneCountInString(content) > MaxContentLength {
+		v.Add("content_md", ErrContentTooLong)
+	}
+}
+
+func checkCoverURL(v *validation.Error, cover *string) {
+	if cover == nil || *cover == "" {
+		return
+	}
+	if len(*cover) > MaxCoverURLLength {
+		v.Add("cover_url", ErrCoverURLTooLong)
+		return
+	}
+	if !isAllowedCoverURL(*cover) {
+		v.Add("cover_url", ErrCoverURLInvalid)
+	}
+}
+
+func checkStatus(v *validation.Error, from, to string) {
+	switch {
+	case to == "":
+		v.Add("status", ErrStatusRequired)
+	case !IsValidStatus(to):
+		v.Add("status", ErrStatusInvalid)
+	case from != "" && !CanTransition(from, to):
+		v.Add("status", ErrStatusTransition)
+	}
+}
+
+// isAllowedCoverURL accepts absolute http(s) URLs and site-relative paths such as
+// /static/uploads/x.png; protocol-relative and other schemes are rejected.
+func isAllowedCoverURL(raw string) bool {
+	if strings.HasPrefix(raw, "/") {
+		return !strings.HasPrefix(raw, "//") && !strings.Contains(raw, `\`)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,218 @@
+package postdomain
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Editorial roles, matching role.name. Writers draft and submit posts, editors review
+// and publish them, admins can do everything editors can and may publish without review.
+const (
+	RoleWriter = "writer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// MaxReviewCommentLength limits review comments, counted in characters.
+const MaxReviewCommentLength = 5000
+
+var (
+	// ErrWorkflowForbidden indicates the acting user has no editorial role, or may not
+	// edit a post in its current status.
+	ErrWorkflowForbidden = errors.New("post: action not permitted for your role")
+
+	ErrReviewerInvalid    = errors.New("reviewer must be an editor or admin")
+	ErrCommentRequired    = errors.New("comment is required")
+	ErrCommentTooLong     = errors.New("comment must be at most " + strconv.Itoa(MaxReviewCommentLength) + " characters")
+	ErrStatusUnchanged    = errors.New("post already has this status")
+	ErrChangesNeedComment = errors.New("requesting changes requires a comment")
+)
+
+var (
+	anyRole   = []string{RoleWriter, RoleEditor, RoleAdmin}
+	reviewers = []string{RoleEditor, RoleAdmin}
+	adminOnly = []string{RoleAdmin}
+)
+
+// statusTransitions lists the statuses reachable from each status and the roles allowed
+// to make each move. Posts reach published through in_review and approved; only admins
+// may skip review. Archived posts must go back through draft before they can be
+// published again.
+var statusTransitions = map[string]map[string][]string{
+	StatusDraft: {
+		StatusInReview:  anyRole,
+		StatusPublished: adminOnly,
+		StatusArchived:  reviewers,
+	},
+	StatusInReview: {
+		StatusDraft:            anyRole,
+		StatusChangesRequested: reviewers,
+		StatusApproved:         reviewers,
+	},
+	StatusChangesRequested: {
+		StatusDraft:    anyRole,
+		StatusInReview: anyRole,
+	},
+	StatusApproved: {
+		StatusDraft:            reviewers,
+		StatusChangesRequested: reviewers,
+		StatusPublished:        reviewers,
+	},
+	StatusPublished: {
+		StatusDraft:    reviewers,
+		StatusArchived: reviewers,
+	},
+	StatusArchived: {
+		StatusDraft: reviewers,
+	},
+}
+
+// initialStatuses lists the statuses a new post may start in, per role.
+var initialStatuses = map[string][]string{
+	StatusDraft:     anyRole,
+	StatusInReview:  anyRole,
+	StatusPublished: adminOnly,
+}
+
+// statusOrder fixes the order statuses are offered in.
+var statusOrder = []string{StatusDraft, StatusInReview, StatusChangesRequested, StatusApproved, StatusPublished, StatusArchived}
+
+// IsValidStatus reports whether status is a known post status.
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// IsValidRole reports whether role takes part in the editorial workflow.
+func IsValidRole(role string) bool {
+	return hasRole(anyRole, role)
+}
+
+// IsReviewer reports whether role may review, approve and publish posts.
+func IsReviewer(role string) bool {
+	return hasRole(reviewers, role)
+}
+
+// CanTransition reports whether the workflow allows a post to move from one status to
+// another, regardless of role. Keeping the same status is always allowed.
+func CanTransition(from, to string) bool {
+	if from == to {
+		return true
+	}
+	_, ok := statusTransitions[from][to]
+	return ok
+}
+
+// CanTransitionAs reports whether role may move a post from one status to another. An
+// empty from means the post is being created. Keeping the same status is always allowed.
+func CanTransitionAs(role, from, to string) bool {
+	if from == to {
+		return true
+	}
+	if from == "" {
+		return hasRole(initialStatuses[to], role)
+	}
+	return hasRole(statusTransitions[from][to], role)
+}
+
+// CanEditAs reports whether role may change a post in the given status. Approved and
+// published posts have been signed off by a reviewer, so only reviewers may edit them.
+func CanEditAs(role, status string) bool {
+	if status == StatusApproved || status == StatusPublished {
+		return hasRole(reviewers, role)
+	}
+	return true
+}
+
+// NextStatuses lists the statuses role may move a post to from its current status, in
+// workflow order. An empty from lists the statuses a new post may start in.
+func NextStatuses(role, from string) []string {
+	var out []string
+	for _, to := range statusOrder {
+		if to != from && CanTransitionAs(role, from, to) {
+			out = append(out, to)
+		}
+	}
+	return out
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Reviewer is a user who may be assigned to review posts.
+type Reviewer struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+}
+
+// Review is the workflow view of a post: its status together with the people involved.
+type Review struct {
+	PostID       int64     `json:"post_id"`
+	Slug         string    `json:"slug"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	AuthorID     int64     `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	ReviewerID   *int64    `json:"reviewer_id,omitempty"`
+	ReviewerName string    `json:"reviewer_name,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ReviewComment is a note left on a post during review. Comments recorded with a status
+// change carry the statuses it moved between.
+type ReviewComment struct {
+	ID         int64     `json:"id"`
+	PostID     int64     `json:"post_id"`
+	AuthorID   *int64    `json:"author_id,omitempty"`
+	AuthorName string    `json:"author_name"`
+	Body       string    `json:"body"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReviewCommentRecord is the shape the repository writes.
+type ReviewCommentRecord struct {
+	PostID     int64
+	AuthorID   int64
+	Body       string
+	FromStatus string
+	ToStatus   string
+}
+
+// ReviewState bundles what the admin needs to act on a post in review: the review
+// itself, the statuses the acting user may move it to, the comment history and the
+// users it can be assigned to.
+type ReviewState struct {
+	Review      Review          `json:"review"`
+	Role        string          `json:"role"`
+	Transitions []string        `json:"transitions"`
+	Comments    []ReviewComment `json:"comments"`
+	Reviewers   []Reviewer      `json:"reviewers"`
+}
+
+// ReviewQueue lists the posts waiting on the acting user. Assigned holds posts in review
+// assigned to them and Unassigned posts in review nobody has picked up (reviewers
+// only); Returned holds their own posts sent back with requested changes.
+type ReviewQueue struct {
+	Assigned   []Review `json:"assigned"`
+	Unassigned []Review `json:"unassigned"`
+	Returned   []Review `json:"returned"`
+}
+
+// TransitionInput moves a post to Status on behalf of ActorID, optionally with a comment.
+type TransitionInput struct {
+	Slug    string
+	ActorID int64
+	Status  string
+	Comment string
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// AutosaveService keeps per-user working copies of posts so long edits survive an
+// expired session or a closed tab.
+type AutosaveService interface {
+	Save(ctx context.Context, input postdomain.AutosaveInput) (postdomain.Autosave, error)
+	Pending(ctx context.Context, slug string, userID int64) (postdomain.Autosave, error)
+	Discard(ctx context.Context, slug string, userID int64) error
+	// DiscardSaved drops the user's working copy once the post itself was saved.
+	DiscardSaved(ctx context.Context, slug string, userID int64)
+}
+
+// DraftAutosaveService implements AutosaveService on top of the post and autosave
+// repositories.
+type DraftAutosaveService struct {
+	posts     postdomain.PostRepository
+	autosaves postdomain.AutosaveRepository
+}
+
+var _ AutosaveService = (*DraftAutosaveService)(nil)
+
+// NewAutosaveService wires the post and autosave repositories into a use case implementation.
+func NewAutosaveService(posts postdomain.PostRepository, autosaves postdomain.AutosaveRepository) *DraftAutosaveService {
+	return &DraftAutosaveService{posts: posts, autosaves: autosaves}
+}
+
+// Save replaces the user's working copy of a post.
+func (s *DraftAutosaveService) Save(ctx context.Context, input postdomain.AutosaveInput) (postdomain.Autosave, error) {
+	input.Slug = strings.TrimSpace(input.Slug)
+	if input.Slug == "" {
+		return postdomain.Autosave{}, errSlugRequired
+	}
+	if err := postdomain.ValidateAutosave(input).Err(); err != nil {
+		return postdomain.Autosave{}, err
+	}
+	return s.autosaves.SaveAutosave(ctx, input)
+}
+
+// Pending returns the user's working copy of a post when it is newer than the post
+// itself; older copies were overtaken by a save and are reported as
+// postdomain.ErrAutosaveNotFound.
+func (s *DraftAutosaveService) Pending(ctx context.Context, slug string, userID int64) (postdomain.Autosave, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return postdomain.Autosave{}, errSlugRequired
+	}
+	post, err := s.posts.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return postdomain.Autosave{}, err
+	}
+	autosave, err := s.autosaves.GetAutosave(ctx, slug, userID)
+	if err != nil {
+		return postdomain.Autosave{}, err
+	}
+	if !autosave.SavedAt.After(post.UpdatedAt) {
+		return postdomain.Autosave{}, postdomain.ErrAutosaveNotFound
+	}
+	return autosave, nil
+}
+
+// Discard drops the user's working copy of a post; discarding none is a no-op.
+func (s *DraftAutosaveService) Discard(ctx context.Context, slug string, userID int64) error {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return errSlugRequired
+	}
+	err := s.autosaves.DeleteAutosave(ctx, slug, userID)
+	if errors.Is(err, postdomain.ErrAutosaveNotFound) {
+		return nil
+	}
+	return err
+}
+
+// DiscardSaved drops a working copy overtaken by a save of the post. Failures are
+// ignored: a leftover copy is older than the post, so Pending no longer offers it.
+func (s *DraftAutosaveService) DiscardSaved(ctx context.Context, slug string, userID int64) {
+	_ = s.Discard(ctx, slug, userID)
+}
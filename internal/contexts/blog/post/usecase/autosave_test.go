@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/validation"
+)
+
+func TestAutosaveSaveValidatesInput(t *testing.T) {
+	autosaves := &fakeAutosaveRepo{rows: map[autosaveKey]postdomain.Autosave{}}
+	svc := NewAutosaveService(&fakePostRepo{}, autosaves)
+
+	_, err := svc.Save(context.Background(), postdomain.AutosaveInput{Slug: "hello", UserID: 1, Summary: strings.Repeat("a", postdomain.MaxSummaryLength+1)})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(autosaves.rows) != 0 {
+		t.Fatal("invalid autosave should not be stored")
+	}
+
+	if _, err := svc.Save(context.Background(), postdomain.AutosaveInput{Slug: " hello ", UserID: 1, Title: "Draft"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if autosaves.rows[autosaveKey{"hello", 1}].Title != "Draft" {
+		t.Fatalf("expected trimmed slug to be stored, got %+v", autosaves.rows)
+	}
+}
+
+func TestAutosavePendingOnlyWhenNewerThanPost(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, UpdatedAt: updated}, nil
+	}
+	autosaves := &fakeAutosaveRepo{rows: map[autosaveKey]postdomain.Autosave{}}
+	svc := NewAutosaveService(repo, autosaves)
+	ctx := context.Background()
+
+	autosaves.rows[autosaveKey{"hello", 1}] = postdomain.Autosave{Title: "old", SavedAt: updated.Add(-time.Minute)}
+	if _, err := svc.Pending(ctx, "hello", 1); !errors.Is(err, postdomain.ErrAutosaveNotFound) {
+		t.Fatalf("expected stale autosave to be hidden, got %v", err)
+	}
+
+	autosaves.rows[autosaveKey{"hello", 1}] = postdomain.Autosave{Title: "new", SavedAt: updated.Add(time.Minute)}
+	autosave, err := svc.Pending(ctx, "hello", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if autosave.Title != "new" {
+		t.Fatalf("unexpected autosave: %+v", autosave)
+	}
+
+	if _, err := svc.Pending(ctx, "hello", 2); !errors.Is(err, postdomain.ErrAutosaveNotFound) {
+		t.Fatalf("autosaves should be per user, got %v", err)
+	}
+}
+
+func TestAutosaveDiscardMissingIsNoop(t *testing.T) {
+	autosaves := &fakeAutosaveRepo{rows: map[autosaveKey]postdomain.Autosave{{"hello", 1}: {Title: "draft"}}}
+	svc := NewAutosaveService(&fakePostRepo{}, autosaves)
+
+	if err := svc.Discard(context.Background(), "hello", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(autosaves.rows) != 0 {
+		t.Fatal("expected autosave to be discarded")
+	}
+	if err := svc.Discard(context.Background(), "hello", 1); err != nil {
+		t.Fatalf("discarding twice should be a no-op, got %v", err)
+	}
+}
+
+func TestAutosaveDiscardSavedDropsCopy(t *testing.T) {
+	autosaves := &fakeAutosaveRepo{rows: map[autosaveKey]postdomain.Autosave{{"hello", 1}: {Title: "draft"}}}
+	svc := NewAutosaveService(&fakePostRepo{}, autosaves)
+
+	svc.DiscardSaved(context.Background(), "hello", 1)
+	if len(autosaves.rows) != 0 {
+		t.Fatal("expected autosave to be discarded")
+	}
+	// nothing to discard, or no slug, is silently ignored
+	svc.DiscardSaved(context.Background(), "hello", 1)
+	svc.DiscardSaved(context.Background(), " ", 1)
+}
+
+type autosaveKey struct {
+	slug   string
+	userID int64
+}
+
+type fakeAutosaveRepo struct {
+	rows map[autosaveKey]postdomain.Autosave
+}
+
+func (f *fakeAutosaveRepo) SaveAutosave(ctx context.Context, input postdomain.AutosaveInput) (postdomain.Autosave, error) {
+	row := postdomain.Autosave{
+		UserID:       input.UserID,
+		Title:        input.Title,
+		Summary:      input.Summary,
+		ContentMD:    input.ContentMD,
+		CoverURL:     input.CoverURL,
+		CustomFields: input.CustomFields,
+		SavedAt:      time.Now(),
+	}
+	f.rows[autosaveKey{input.Slug, input.UserID}] = row
+	return row, nil
+}
+
+func (f *fakeAutosaveRepo) GetAutosave(ctx context.Context, slug string, userID int64) (postdomain.Autosave, error) {
+	row, ok := f.rows[autosaveKey{slug, userID}]
+	if !ok {
+		return postdomain.Autosave{}, postdomain.ErrAutosaveNotFound
+	}
+	return row, nil
+}
+
+func (f *fakeAutosaveRepo) DeleteAutosave(ctx context.Context, slug string, userID int64) error {
+	key := autosaveKey{slug, userID}
+	if _, ok := f.rows[key]; !ok {
+		return postdomain.ErrAutosaveNotFound
+	}
+	delete(f.rows, key)
+	return nil
+}
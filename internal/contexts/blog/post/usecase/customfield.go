@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// CustomFieldService manages the custom field definitions admins attach to posts.
+type CustomFieldService interface {
+	List(ctx context.Context) ([]postdomain.FieldDefinition, error)
+	Create(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error)
+	Update(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FieldService implements CustomFieldService using a repository abstraction.
+type FieldService struct {
+	repo postdomain.FieldDefinitionRepository
+}
+
+var _ CustomFieldService = (*FieldService)(nil)
+
+// NewFieldService wires a field definition repository into a use case implementation.
+func NewFieldService(repo postdomain.FieldDefinitionRepository) *FieldService {
+	return &FieldService{repo: repo}
+}
+
+func (s *FieldService) List(ctx context.Context) ([]postdomain.FieldDefinition, error) {
+	return s.repo.ListFieldDefinitions(ctx)
+}
+
+func (s *FieldService) Create(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	input = normalizeFieldInput(input)
+	if err := postdomain.ValidateFieldDefinition(input).Err(); err != nil {
+		return postdomain.FieldDefinition{}, err
+	}
+	def, err := s.repo.CreateFieldDefinition(ctx, input)
+	return def, fieldRepoError(err)
+}
+
+// Update replaces the attributes of the definition identified by input.Key. Values
+// already stored on posts are re-validated the next time each post is saved.
+func (s *FieldService) Update(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	input = normalizeFieldInput(input)
+	if err := postdomain.ValidateFieldDefinition(input).Err(); err != nil {
+		return postdomain.FieldDefinition{}, err
+	}
+	def, err := s.repo.UpdateFieldDefinition(ctx, input)
+	return def, fieldRepoError(err)
+}
+
+// Delete removes a definition together with the values stored under its key.
+func (s *FieldService) Delete(ctx context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return postdomain.ErrFieldNotFound
+	}
+	return s.repo.DeleteFieldDefinition(ctx, key)
+}
+
+// fieldRepoError turns repository conflicts into field errors so handlers report them as 422.
+func fieldRepoError(err error) error {
+	v := &validation.Error{}
+	switch {
+	case errors.Is(err, postdomain.ErrFieldKeyTaken):
+		v.Add("key", postdomain.ErrFieldKeyTaken)
+	case errors.Is(err, postdomain.ErrFieldCategoryNotFound):
+		v.Add("category", postdomain.ErrFieldCategoryNotFound)
+	default:
+		return err
+	}
+	return v
+}
+
+func normalizeFieldInput(input postdomain.FieldDefinitionInput) postdomain.FieldDefinitionInput {
+	input.Key = strings.TrimSpace(input.Key)
+	input.Label = strings.TrimSpace(input.Label)
+	input.Type = postdomain.FieldType(strings.ToLower(strings.TrimSpace(string(input.Type))))
+	input.CategorySlug = strings.TrimSpace(input.CategorySlug)
+	if input.Type != postdomain.FieldEnum {
+		input.Options = nil
+		return input
+	}
+	seen := make(map[string]struct{}, len(input.Options))
+	options := make([]string, 0, len(input.Options))
+	for _, opt := range input.Options {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		if _, dup := seen[opt]; dup {
+			continue
+		}
+		seen[opt] = struct{}{}
+		options = append(options, opt)
+	}
+	input.Options = options
+	return input
+}
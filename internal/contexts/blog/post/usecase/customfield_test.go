@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/validation"
+)
+
+var testFieldDefs = []postdomain.FieldDefinition{
+	{Key: "difficulty", Label: "Difficulty", Type: postdomain.FieldEnum, Options: []string{"beginner", "advanced"}, Required: true, CategorySlug: "tutorials"},
+	{Key: "repo_url", Label: "Repository", Type: postdomain.FieldURL},
+	{Key: "minutes", Label: "Reading time", Type: postdomain.FieldNumber},
+}
+
+func TestServiceCreateResolvesCustomFields(t *testing.T) {
+	repo := &fakePostRepo{}
+	var got postdomain.CreatePostInput
+	repo.createPostFn = func(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error) {
+		got = input
+		return postdomain.Post{Slug: input.Slug}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	_, err := svc.Create(context.Background(), postdomain.CreatePostInput{
+		Title:        "Hello",
+		Slug:         "hello",
+		AuthorID:     1,
+		CustomFields: map[string]any{"minutes": "12", "repo_url": " "},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.CustomFields) != 1 || got.CustomFields["minutes"] != float64(12) {
+		t.Fatalf("expected coerced minutes only, got %+v", got.CustomFields)
+	}
+}
+
+func TestServiceCreateRejectsInvalidCustomFields(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.createPostFn = func(context.Context, postdomain.CreatePostInput) (postdomain.Post, error) {
+		t.Fatal("CreatePost should not be called")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	_, err := svc.Create(context.Background(), postdomain.CreatePostInput{
+		Title:        "Hello",
+		Slug:         "hello",
+		AuthorID:     1,
+		CustomFields: map[string]any{"repo_url": "ftp://example.com", "colour": "red"},
+	})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if !verr.Has("custom_fields.repo_url") || !verr.Has("custom_fields.colour") {
+		t.Fatalf("unexpected fields: %+v", verr.Fields)
+	}
+	if !errors.Is(err, postdomain.ErrFieldNotURL) || !errors.Is(err, postdomain.ErrFieldUnknown) {
+		t.Fatalf("expected url and unknown field sentinels, got %v", err)
+	}
+}
+
+func TestServiceUpdateRequiresCategoryScopedField(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusDraft}, nil
+	}
+	repo.listCategoriesByPostSlugFn = func(ctx context.Context, slug string) ([]taxdomain.Category, error) {
+		return []taxdomain.Category{{Slug: "tutorials"}}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	_, err := svc.Update(context.Background(), postdomain.UpdatePostInput{
+		Slug:         "hello",
+		Title:        "Hello",
+		CustomFields: map[string]any{},
+	})
+	if !errors.Is(err, postdomain.ErrFieldValueRequired) {
+		t.Fatalf("expected required field error, got %v", err)
+	}
+}
+
+func TestServicePatchMergesCustomFields(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusDraft, CustomFields: map[string]any{"minutes": float64(5), "repo_url": "https://example.com"}}, nil
+	}
+	var got postdomain.PatchPostInput
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		got = input
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	_, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		Slug:         "hello",
+		CustomFields: postdomain.PatchField[map[string]any]{Set: true, Value: map[string]any{"minutes": float64(8), "repo_url": nil}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.CustomFields.Set || len(got.CustomFields.Value) != 1 || got.CustomFields.Value["minutes"] != float64(8) {
+		t.Fatalf("unexpected merged fields: %+v", got.CustomFields)
+	}
+}
+
+func TestServiceListPublishedCoercesFieldFilters(t *testing.T) {
+	repo := &fakePostRepo{}
+	var got map[string]any
+	repo.listPublishedPostsSortedFn = func(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
+		got = fields
+		return nil, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	opts := postdomain.ListPostsOptions{Fields: map[string]string{"difficulty": "beginner", "minutes": "10"}}
+	if _, err := svc.ListPublished(context.Background(), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["difficulty"] != "beginner" || got["minutes"] != float64(10) {
+		t.Fatalf("unexpected filter: %+v", got)
+	}
+
+	opts.Fields = map[string]string{"difficulty": "expert"}
+	if _, err := svc.ListPublished(context.Background(), opts); !errors.Is(err, postdomain.ErrFieldNotOption) {
+		t.Fatalf("expected option error, got %v", err)
+	}
+}
+
+func TestServiceGetBySlugIncludesScopedFields(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, CustomFields: map[string]any{"minutes": float64(3)}}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{defs: testFieldDefs}, nil)
+	result, err := svc.GetBySlug(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// difficulty is scoped to a category the post is not in
+	if len(result.Fields) != 2 || result.Fields[1].Display() != "3" {
+		t.Fatalf("unexpected fields: %+v", result.Fields)
+	}
+}
+
+func TestFieldServiceCreateNormalizesAndValidates(t *testing.T) {
+	repo := &fakeFieldRepo{}
+	svc := NewFieldService(repo)
+
+	_, err := svc.Create(context.Background(), postdomain.FieldDefinitionInput{Key: "Level", Type: "enum"})
+	var verr *validation.Error
+	if !errors.As(err, &verr) || !verr.Has("key") || !verr.Has("label") || !verr.Has("options") {
+		t.Fatalf("expected key, label and options errors, got %v", err)
+	}
+
+	_, err = svc.Create(context.Background(), postdomain.FieldDefinitionInput{
+		Key:     " level ",
+		Label:   " Level ",
+		Type:    " ENUM ",
+		Options: []string{"easy", " ", "easy", "hard"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := repo.created
+	if got.Key != "level" || got.Label != "Level" || got.Type != postdomain.FieldEnum || len(got.Options) != 2 {
+		t.Fatalf("unexpected normalized input: %+v", got)
+	}
+}
+
+func TestFieldServiceCreateReportsTakenKey(t *testing.T) {
+	repo := &fakeFieldRepo{createErr: postdomain.ErrFieldKeyTaken}
+	svc := NewFieldService(repo)
+
+	_, err := svc.Create(context.Background(), postdomain.FieldDefinitionInput{Key: "level", Label: "Level", Type: postdomain.FieldText})
+	var verr *validation.Error
+	if !errors.As(err, &verr) || !verr.Has("key") {
+		t.Fatalf("expected key field error, got %v", err)
+	}
+}
+
+type fakeFieldRepo struct {
+	defs      []postdomain.FieldDefinition
+	created   postdomain.FieldDefinitionInput
+	createErr error
+}
+
+func (f *fakeFieldRepo) ListFieldDefinitions(context.Context) ([]postdomain.FieldDefinition, error) {
+	return f.defs, nil
+}
+
+func (f *fakeFieldRepo) GetFieldDefinition(ctx context.Context, key string) (postdomain.FieldDefinition, error) {
+	for _, d := range f.defs {
+		if d.Key == key {
+			return d, nil
+		}
+	}
+	return postdomain.FieldDefinition{}, postdomain.ErrFieldNotFound
+}
+
+func (f *fakeFieldRepo) CreateFieldDefinition(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	f.created = input
+	if f.createErr != nil {
+		return postdomain.FieldDefinition{}, f.createErr
+	}
+	return postdomain.FieldDefinition{Key: input.Key, Label: input.Label, Type: input.Type, Options: input.Options}, nil
+}
+
+func (f *fakeFieldRepo) UpdateFieldDefinition(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	return postdomain.FieldDefinition{Key: input.Key, Label: input.Label, Type: input.Type, Options: input.Options}, nil
+}
+
+func (f *fakeFieldRepo) DeleteFieldDefinition(context.Context, string) error {
+	return nil
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/markdown"
+	"proto-gin-web/internal/platform/storage"
+)
+
+// uploadsPathPrefix is the public URL path of uploaded files.
+const uploadsPathPrefix = "/static/uploads/"
+
+// LinkCheckService finds internal links and upload references in post content that
+// no longer resolve and keeps the findings for the link report.
+type LinkCheckService interface {
+	// CheckPost re-checks one post and returns its findings.
+	CheckPost(ctx context.Context, slug string) ([]postdomain.LinkFinding, error)
+	// CheckAll re-checks every post and returns the number of findings.
+	CheckAll(ctx context.Context) (int, error)
+	Report(ctx context.Context) ([]postdomain.LinkFinding, error)
+	PostFindings(ctx context.Context, slug string) ([]postdomain.LinkFinding, error)
+}
+
+// ContentLinkChecker implements LinkCheckService. Links to /posts/{slug},
+// /posts?category=, /posts?tag=, the /categories/{slug} and /tags/{slug} landing pages
+// and their feeds are resolved against the stored slugs and tag aliases, and links under
+// /static/uploads/ against the upload storage; relative links and links to other
+// hosts are not checked.
+type ContentLinkChecker struct {
+	posts    postdomain.PostRepository
+	taxonomy taxdomain.TaxonomyRepository
+	links    postdomain.LinkCheckRepository
+	siteHost string
+	uploads  storage.Storage
+	now      func() time.Time
+}
+
+var _ LinkCheckService = (*ContentLinkChecker)(nil)
+
+// NewLinkCheckService wires the repositories into a link checker. Absolute links count
+// as internal when their host is the host of baseURL; uploads holds the files served
+// under /static/uploads/.
+func NewLinkCheckService(posts postdomain.PostRepository, taxonomy taxdomain.TaxonomyRepository, links postdomain.LinkCheckRepository, baseURL string, uploads storage.Storage) *ContentLinkChecker {
+	var host string
+	if u, err := url.Parse(baseURL); err == nil {
+		host = u.Host
+	}
+	return &ContentLinkChecker{posts: posts, taxonomy: taxonomy, links: links, siteHost: host, uploads: uploads, now: time.Now}
+}
+
+// CheckPost re-checks the links of one post and stores its findings.
+func (s *ContentLinkChecker) CheckPost(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return nil, errSlugRequired
+	}
+	post, err := s.posts.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	src := postdomain.LinkSource{PostID: post.ID, Slug: post.Slug, Title: post.Title, ContentMD: post.ContentMD, CoverURL: post.CoverURL}
+	return s.check(ctx, newLinkResolver(s), src)
+}
+
+// CheckAll re-checks every post. A post that fails to check keeps its previous
+// findings; the others are still checked and the failures are returned together.
+func (s *ContentLinkChecker) CheckAll(ctx context.Context) (int, error) {
+	sources, err := s.links.ListLinkSources(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resolver := newLinkResolver(s)
+	var (
+		total int
+		errs  []error
+	)
+	for _, src := range sources {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		findings, err := s.check(ctx, resolver, src)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total += len(findings)
+	}
+	return total, errors.Join(errs...)
+}
+
+// Report lists the stored findings of every post.
+func (s *ContentLinkChecker) Report(ctx context.Context) ([]postdomain.LinkFinding, error) {
+	return s.links.ListLinkFindings(ctx)
+}
+
+// PostFindings lists the stored findings of one post.
+func (s *ContentLinkChecker) PostFindings(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return nil, errSlugRequired
+	}
+	return s.links.ListLinkFindingsByPost(ctx, slug)
+}
+
+func (s *ContentLinkChecker) check(ctx context.Context, resolver *linkResolver, src postdomain.LinkSource) ([]postdomain.LinkFinding, error) {
+	refs := markdown.Links(src.ContentMD)
+	if src.CoverURL != "" {
+		refs = append(refs, src.CoverURL)
+	}
+	now := s.now().UTC()
+	seen := make(map[string]bool, len(refs))
+	findings := []postdomain.LinkFinding{}
+	for _, ref := range refs {
+		target, ok := s.classify(ref)
+		if !ok || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		found, err := resolver.resolve(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			findings = append(findings, postdomain.LinkFinding{
+				PostID:    src.PostID,
+				PostSlug:  src.Slug,
+				PostTitle: src.Title,
+				Kind:      target.kind,
+				URL:       ref,
+				CheckedAt: now,
+			})
+		}
+	}
+	if err := s.links.ReplaceLinkFindings(ctx, src.PostID, findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// linkTarget is an internal link reduced to the post, category, tag or upload it
+// points at.
+type linkTarget struct {
+	kind string
+	ref  string
+}
+
+// classify reports what ref points at, or false when it is not a link the checker
+// can resolve.
+func (s *ContentLinkChecker) classify(ref string) (linkTarget, bool) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return linkTarget{}, false
+	}
+	if u.Scheme != "" || u.Host != "" {
+		internal := (u.Scheme == "" || u.Scheme == "http" || u.Scheme == "https") &&
+			s.siteHost != "" && strings.EqualFold(u.Host, s.siteHost)
+		if !internal {
+			return linkTarget{}, false
+		}
+	}
+	if !strings.HasPrefix(u.Path, "/") {
+		return linkTarget{}, false
+	}
+	p := path.Clean(u.Path)
+	switch {
+	case p == "/posts":
+		q := u.Query()
+		if category := q.Get("category"); category != "" {
+			return linkTarget{kind: postdomain.LinkCategory, ref: category}, true
+		}
+		if tag := q.Get("tag"); tag != "" {
+			return linkTarget{kind: postdomain.LinkTag, ref: tag}, true
+		}
+	case strings.HasPrefix(p, "/posts/"):
+		if slug := strings.TrimPrefix(p, "/posts/"); !strings.Contains(slug, "/") {
+			return linkTarget{kind: postdomain.LinkPost, ref: slug}, true
+		}
+	case strings.HasPrefix(p, "/categories/"):
+		if slug, ok := landingSlug(strings.TrimPrefix(p, "/categories/")); ok {
+			return linkTarget{kind: postdomain.LinkCategory, ref: slug}, true
+		}
+	case strings.HasPrefix(p, "/tags/"):
+		if slug, ok := landingSlug(strings.TrimPrefix(p, "/tags/")); ok {
+			return linkTarget{kind: postdomain.LinkTag, ref: slug}, true
+		}
+	case strings.HasPrefix(p, uploadsPathPrefix):
+		return linkTarget{kind: postdomain.LinkUpload, ref: strings.TrimPrefix(p, uploadsPathPrefix)}, true
+	}
+	return linkTarget{}, false
+}
+
+// landingSlug extracts the slug from the rest of a landing page path, which is either
+// the slug or the slug followed by /rss.xml.
+func landingSlug(rest string) (string, bool) {
+	rest = strings.TrimSuffix(rest, "/rss.xml")
+	if rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	return rest, true
+}
+
+// linkResolver resolves link targets once per check run.
+type linkResolver struct {
+	checker  *ContentLinkChecker
+	resolved map[linkTarget]bool
+}
+
+func newLinkResolver(checker *ContentLinkChecker) *linkResolver {
+	return &linkResolver{checker: checker, resolved: map[linkTarget]bool{}}
+}
+
+func (r *linkResolver) resolve(ctx context.Context, target linkTarget) (bool, error) {
+	if found, ok := r.resolved[target]; ok {
+		return found, nil
+	}
+	var (
+		found bool
+		err   error
+	)
+	switch target.kind {
+	case postdomain.LinkPost:
+		found, err = r.checker.posts.PostSlugExists(ctx, target.ref)
+	case postdomain.LinkCategory:
+		found, err = r.checker.taxonomy.CategorySlugExists(ctx, target.ref)
+	case postdomain.LinkTag:
+		found, err = r.checker.tagExists(ctx, target.ref)
+	case postdomain.LinkUpload:
+		found, err = r.checker.uploadExists(ctx, target.ref)
+	}
+	if err != nil {
+		return false, err
+	}
+	r.resolved[target] = found
+	return found, nil
+}
+
+// tagExists reports whether slug names a tag or an alias left by a tag merge, which
+// redirects to the tag it was merged into.
+func (s *ContentLinkChecker) tagExists(ctx context.Context, slug string) (bool, error) {
+	found, err := s.taxonomy.TagSlugExists(ctx, slug)
+	if err != nil || found {
+		return found, err
+	}
+	_, err = s.taxonomy.ResolveTagAlias(ctx, slug)
+	if errors.Is(err, taxdomain.ErrAliasNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ContentLinkChecker) uploadExists(ctx context.Context, name string) (bool, error) {
+	_, err := s.uploads.Stat(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
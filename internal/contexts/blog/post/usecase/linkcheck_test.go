@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/storage"
+)
+
+func TestLinkCheckPostFindsBrokenLinks(t *testing.T) {
+	uploads := t.TempDir()
+	if err := os.WriteFile(filepath.Join(uploads, "present.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "alive", nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{
+			ID:    7,
+			Slug:  slug,
+			Title: "Links",
+			ContentMD: "[ok](/posts/alive) [gone](/posts/old-slug) [again](/posts/old-slug)\n\n" +
+				"[cat](/posts?category=go) [missing cat](/posts?category=rust) [tag](https://blog.example.com/posts?tag=gone)\n\n" +
+				"[cat page](/categories/go) [missing cat feed](/categories/rust/rss.xml) [tag page](/tags/web) [missing tag page](/tags/gone)\n\n" +
+				"![img](/static/uploads/present.png) ![lost](/static/uploads/lost.png)\n\n" +
+				"[external](https://other.example.com/posts/nope) [page](/p/about) [mail](mailto:me@example.com)",
+			CoverURL: "/static/uploads/cover.png",
+		}, nil
+	}
+	taxonomy := &fakeTaxonomyRepo{categories: map[string]bool{"go": true}, tags: map[string]bool{"web": true}}
+	links := &fakeLinkCheckRepo{findings: map[int64][]postdomain.LinkFinding{}}
+	svc := NewLinkCheckService(repo, taxonomy, links, "https://blog.example.com", storage.NewLocal(uploads, uploadsPathPrefix))
+
+	findings, err := svc.CheckPost(context.Background(), " links ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct{ kind, url string }{
+		{postdomain.LinkPost, "/posts/old-slug"},
+		{postdomain.LinkCategory, "/posts?category=rust"},
+		{postdomain.LinkTag, "https://blog.example.com/posts?tag=gone"},
+		{postdomain.LinkCategory, "/categories/rust/rss.xml"},
+		{postdomain.LinkTag, "/tags/gone"},
+		{postdomain.LinkUpload, "/static/uploads/lost.png"},
+		{postdomain.LinkUpload, "/static/uploads/cover.png"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %+v", len(want), findings)
+	}
+	for i, w := range want {
+		if findings[i].Kind != w.kind || findings[i].URL != w.url || findings[i].PostSlug != "links" {
+			t.Errorf("finding %d = %+v, want %s %s", i, findings[i], w.kind, w.url)
+		}
+	}
+	if len(links.findings[7]) != len(want) {
+		t.Fatalf("expected findings to be stored, got %+v", links.findings[7])
+	}
+}
+
+func TestLinkCheckPostAcceptsMergedTagAliases(t *testing.T) {
+	links := &fakeLinkCheckRepo{findings: map[int64][]postdomain.LinkFinding{}}
+	taxonomy := &fakeTaxonomyRepo{tags: map[string]bool{"golang": true}, aliases: map[string]string{"go": "golang"}}
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 7, Slug: slug, ContentMD: "[page](/tags/go) [feed](/tags/go/rss.xml) [filter](/posts?tag=go) [gone](/tags/rust)"}, nil
+	}
+	svc := NewLinkCheckService(repo, taxonomy, links, "https://blog.example.com", storage.NewLocal(t.TempDir(), uploadsPathPrefix))
+
+	findings, err := svc.CheckPost(context.Background(), "links")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].URL != "/tags/rust" {
+		t.Fatalf("expected only the unknown tag reported, got %+v", findings)
+	}
+}
+
+func TestLinkCheckPostClearsFixedLinks(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "alive", nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 7, Slug: slug, ContentMD: "[ok](/posts/alive)"}, nil
+	}
+	links := &fakeLinkCheckRepo{findings: map[int64][]postdomain.LinkFinding{
+		7: {{PostID: 7, Kind: postdomain.LinkPost, URL: "/posts/old-slug"}},
+	}}
+	svc := NewLinkCheckService(repo, &fakeTaxonomyRepo{}, links, "https://blog.example.com", storage.NewLocal(t.TempDir(), uploadsPathPrefix))
+
+	findings, err := svc.CheckPost(context.Background(), "links")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 || len(links.findings[7]) != 0 {
+		t.Fatalf("expected no findings, got %+v / %+v", findings, links.findings[7])
+	}
+}
+
+func TestLinkCheckAllResolvesEachTargetOnce(t *testing.T) {
+	lookups := 0
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		lookups++
+		return false, nil
+	}
+	links := &fakeLinkCheckRepo{findings: map[int64][]postdomain.LinkFinding{}, sources: []postdomain.LinkSource{
+		{PostID: 1, Slug: "a", ContentMD: "[x](/posts/deleted)"},
+		{PostID: 2, Slug: "b", ContentMD: "[y](/posts/deleted/)"},
+		{PostID: 3, Slug: "c", ContentMD: "nothing to see"},
+	}}
+	svc := NewLinkCheckService(repo, &fakeTaxonomyRepo{}, links, "https://blog.example.com", storage.NewLocal(t.TempDir(), uploadsPathPrefix))
+
+	total, err := svc.CheckAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 2 || lookups != 1 {
+		t.Fatalf("expected 2 findings from 1 lookup, got %d from %d", total, lookups)
+	}
+	if _, ok := links.findings[3]; !ok {
+		t.Fatal("posts without findings should have their findings replaced too")
+	}
+}
+
+func TestLinkCheckAllKeepsGoingAfterFailure(t *testing.T) {
+	boom := errors.New("boom")
+	links := &fakeLinkCheckRepo{
+		findings:   map[int64][]postdomain.LinkFinding{},
+		replaceErr: map[int64]error{1: boom},
+		sources: []postdomain.LinkSource{
+			{PostID: 1, Slug: "a", ContentMD: "[x](/posts/deleted)"},
+			{PostID: 2, Slug: "b", ContentMD: "[y](/posts/deleted)"},
+		},
+	}
+	svc := NewLinkCheckService(&fakePostRepo{}, &fakeTaxonomyRepo{}, links, "https://blog.example.com", storage.NewLocal(t.TempDir(), uploadsPathPrefix))
+
+	total, err := svc.CheckAll(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the failure to be reported, got %v", err)
+	}
+	if total != 1 || len(links.findings[2]) != 1 {
+		t.Fatalf("expected the second post to be checked, got %d / %+v", total, links.findings)
+	}
+}
+
+func TestLinkCheckPostValidates(t *testing.T) {
+	svc := NewLinkCheckService(&fakePostRepo{}, &fakeTaxonomyRepo{}, &fakeLinkCheckRepo{}, "https://blog.example.com", storage.NewLocal(t.TempDir(), uploadsPathPrefix))
+	if _, err := svc.CheckPost(context.Background(), " "); !errors.Is(err, errSlugRequired) {
+		t.Fatalf("expected errSlugRequired, got %v", err)
+	}
+}
+
+type fakeTaxonomyRepo struct {
+	categories map[string]bool
+	tags       map[string]bool
+	// aliases maps merged tag slugs to the tag they now point to.
+	aliases map[string]string
+	// tagList backs ListTags and GetTag.
+	tagList []taxdomain.TagWithCount
+}
+
+func (f *fakeTaxonomyRepo) CreateCategory(context.Context, taxdomain.CategoryRecord) (taxdomain.Category, error) {
+	return taxdomain.Category{}, nil
+}
+
+func (f *fakeTaxonomyRepo) DeleteCategory(context.Context, string) error { return nil }
+
+func (f *fakeTaxonomyRepo) ListCategories(context.Context) ([]taxdomain.CategoryWithCount, error) {
+	return nil, nil
+}
+
+func (f *fakeTaxonomyRepo) GetCategory(context.Context, string) (taxdomain.CategoryWithCount, error) {
+	return taxdomain.CategoryWithCount{}, taxdomain.ErrCategoryNotFound
+}
+
+func (f *fakeTaxonomyRepo) UpdateCategory(context.Context, string, taxdomain.CategoryRecord) (taxdomain.Category, error) {
+	return taxdomain.Category{}, nil
+}
+
+func (f *fakeTaxonomyRepo) ListCategoryAncestors(context.Context, int64) ([]taxdomain.Category, error) {
+	return nil, nil
+}
+
+func (f *fakeTaxonomyRepo) ListTags(context.Context) ([]taxdomain.TagWithCount, error) {
+	return f.tagList, nil
+}
+
+func (f *fakeTaxonomyRepo) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	for _, tag := range f.tagList {
+		if tag.Slug == slug {
+			return tag, nil
+		}
+	}
+	return taxdomain.TagWithCount{}, taxdomain.ErrTagNotFound
+}
+
+func (f *fakeTaxonomyRepo) UpdateTag(context.Context, string, taxdomain.TagRecord) (taxdomain.Tag, error) {
+	return taxdomain.Tag{}, nil
+}
+
+func (f *fakeTaxonomyRepo) CategorySlugExists(ctx context.Context, slug string) (bool, error) {
+	return f.categories[slug], nil
+}
+
+func (f *fakeTaxonomyRepo) CreateTag(context.Context, taxdomain.TagRecord) (taxdomain.Tag, error) {
+	return taxdomain.Tag{}, nil
+}
+
+func (f *fakeTaxonomyRepo) DeleteTag(context.Context, string) error { return nil }
+
+func (f *fakeTaxonomyRepo) MergeTags(context.Context, string, []string) (taxdomain.Tag, error) {
+	return taxdomain.Tag{}, nil
+}
+
+func (f *fakeTaxonomyRepo) ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error) {
+	if target, ok := f.aliases[slug]; ok {
+		return taxdomain.Tag{Slug: target}, nil
+	}
+	return taxdomain.Tag{}, taxdomain.ErrAliasNotFound
+}
+
+func (f *fakeTaxonomyRepo) ListTagAliases(context.Context) ([]taxdomain.TagAlias, error) {
+	return nil, nil
+}
+
+func (f *fakeTaxonomyRepo) DeleteTagAlias(context.Context, string) error { return nil }
+
+func (f *fakeTaxonomyRepo) TagSlugExists(ctx context.Context, slug string) (bool, error) {
+	return f.tags[slug], nil
+}
+
+type fakeLinkCheckRepo struct {
+	sources    []postdomain.LinkSource
+	findings   map[int64][]postdomain.LinkFinding
+	replaceErr map[int64]error
+}
+
+func (f *fakeLinkCheckRepo) ListLinkSources(context.Context) ([]postdomain.LinkSource, error) {
+	return f.sources, nil
+}
+
+func (f *fakeLinkCheckRepo) ReplaceLinkFindings(ctx context.Context, postID int64, findings []postdomain.LinkFinding) error {
+	if err := f.replaceErr[postID]; err != nil {
+		return err
+	}
+	f.findings[postID] = findings
+	return nil
+}
+
+func (f *fakeLinkCheckRepo) ListLinkFindings(context.Context) ([]postdomain.LinkFinding, error) {
+	var out []postdomain.LinkFinding
+	for _, findings := range f.findings {
+		out = append(out, findings...)
+	}
+	return out, nil
+}
+
+func (f *fakeLinkCheckRepo) ListLinkFindingsByPost(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	var out []postdomain.LinkFinding
+	for _, findings := range f.findings {
+		for _, finding := range findings {
+			if finding.PostSlug == slug {
+				out = append(out, finding)
+			}
+		}
+	}
+	return out, nil
+}
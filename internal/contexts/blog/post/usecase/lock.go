@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// LockService manages the soft edit locks that warn editors about each other.
+type LockService interface {
+	State(ctx context.Context, slug string, actorID int64) (postdomain.LockState, error)
+	Acquire(ctx context.Context, input postdomain.LockInput) (postdomain.LockState, error)
+	Release(ctx context.Context, slug string, actorID int64) error
+}
+
+// EditLockService implements LockService on top of an EditLockStore.
+type EditLockService struct {
+	posts postdomain.PostRepository
+	store postdomain.EditLockStore
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+var _ LockService = (*EditLockService)(nil)
+
+// errLockNotHeld aborts a release attempted by someone who does not hold the lock.
+var errLockNotHeld = errors.New("post: edit lock not held")
+
+// NewLockService wires the post repository and lock store into a use case
+// implementation. Locks expire postdomain.EditLockTTL after their last renewal.
+func NewLockService(posts postdomain.PostRepository, store postdomain.EditLockStore) *EditLockService {
+	return &EditLockService{posts: posts, store: store, ttl: postdomain.EditLockTTL, now: time.Now}
+}
+
+// State returns the current edit lock of a post.
+func (s *EditLockService) State(ctx context.Context, slug string, actorID int64) (postdomain.LockState, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return postdomain.LockState{}, errSlugRequired
+	}
+	lock, err := s.store.GetLock(ctx, slug)
+	if err != nil {
+		return postdomain.LockState{}, err
+	}
+	return lockState(lock, actorID), nil
+}
+
+// Acquire takes, renews or, with TakeOver, takes over the edit lock of a post. When
+// someone else holds it the returned state describes their lock and the error is
+// postdomain.ErrPostLocked. A previous holder whose lock was taken over gets
+// postdomain.ErrLockTakenOver once, on their next renewal.
+func (s *EditLockService) Acquire(ctx context.Context, input postdomain.LockInput) (postdomain.LockState, error) {
+	slug := strings.TrimSpace(input.Slug)
+	if slug == "" {
+		return postdomain.LockState{}, errSlugRequired
+	}
+	exists, err := s.posts.PostSlugExists(ctx, slug)
+	if err != nil {
+		return postdomain.LockState{}, err
+	}
+	if !exists {
+		return postdomain.LockState{}, postdomain.ErrPostNotFound
+	}
+
+	var (
+		result  *postdomain.EditLock
+		outcome error
+	)
+	err = s.store.UpdateLock(ctx, slug, func(current *postdomain.EditLock) (*postdomain.EditLock, error) {
+		now := s.now().UTC()
+		result, outcome = nil, nil
+		switch {
+		case current == nil || current.HolderID == input.HolderID:
+			next := &postdomain.EditLock{
+				Slug:       slug,
+				HolderID:   input.HolderID,
+				HolderName: strings.TrimSpace(input.HolderName),
+				AcquiredAt: now,
+				ExpiresAt:  now.Add(s.ttl),
+			}
+			if current != nil {
+				next.AcquiredAt = current.AcquiredAt
+				next.PreviousHolderID = current.PreviousHolderID
+			}
+			result = next
+		case input.TakeOver:
+			result = &postdomain.EditLock{
+				Slug:             slug,
+				HolderID:         input.HolderID,
+				HolderName:       strings.TrimSpace(input.HolderName),
+				AcquiredAt:       now,
+				ExpiresAt:        now.Add(s.ttl),
+				PreviousHolderID: current.HolderID,
+			}
+		case current.PreviousHolderID == input.HolderID:
+			// tell the previous holder once, then forget them
+			next := *current
+			next.PreviousHolderID = 0
+			result, outcome = &next, postdomain.ErrLockTakenOver
+		default:
+			result = current
+			return nil, postdomain.ErrPostLocked
+		}
+		return result, nil
+	})
+	if err != nil && !errors.Is(err, postdomain.ErrPostLocked) {
+		return postdomain.LockState{}, err
+	}
+	if err == nil {
+		err = outcome
+	}
+	return lockState(result, input.HolderID), err
+}
+
+// Release drops the edit lock of a post when the acting user holds it; releasing a
+// lock held by someone else, or no lock, is a no-op.
+func (s *EditLockService) Release(ctx context.Context, slug string, actorID int64) error {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return errSlugRequired
+	}
+	err := s.store.UpdateLock(ctx, slug, func(current *postdomain.EditLock) (*postdomain.EditLock, error) {
+		if current == nil || current.HolderID != actorID {
+			return nil, errLockNotHeld
+		}
+		return nil, nil
+	})
+	if errors.Is(err, errLockNotHeld) {
+		return nil
+	}
+	return err
+}
+
+func lockState(lock *postdomain.EditLock, actorID int64) postdomain.LockState {
+	if lock == nil {
+		return postdomain.LockState{}
+	}
+	return postdomain.LockState{Locked: true, HeldByYou: lock.HolderID == actorID, Lock: lock}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+func TestLockAcquireAndRenew(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello", nil
+	}
+	store := &fakeLockStore{locks: map[string]postdomain.EditLock{}}
+	svc := NewLockService(repo, store)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	state, err := svc.Acquire(context.Background(), postdomain.LockInput{Slug: " hello ", HolderID: 1, HolderName: "Alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.Locked || !state.HeldByYou || state.Lock.HolderName != "Alice" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	acquired := state.Lock.AcquiredAt
+
+	now = now.Add(time.Minute)
+	state, err = svc.Acquire(context.Background(), postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.Lock.AcquiredAt.Equal(acquired) {
+		t.Fatalf("renewal should keep the acquisition time, got %v", state.Lock.AcquiredAt)
+	}
+	if want := now.Add(postdomain.EditLockTTL); !store.locks["hello"].ExpiresAt.Equal(want) {
+		t.Fatalf("expected expiry %v, got %v", want, store.locks["hello"].ExpiresAt)
+	}
+}
+
+func TestLockAcquireReportsOtherHolder(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello", nil
+	}
+	svc := NewLockService(repo, &fakeLockStore{locks: map[string]postdomain.EditLock{}})
+	if _, err := svc.Acquire(context.Background(), postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state, err := svc.Acquire(context.Background(), postdomain.LockInput{Slug: "hello", HolderID: 2, HolderName: "Bob"})
+	if !errors.Is(err, postdomain.ErrPostLocked) {
+		t.Fatalf("expected ErrPostLocked, got %v", err)
+	}
+	if state.HeldByYou || state.Lock == nil || state.Lock.HolderName != "Alice" {
+		t.Fatalf("expected Alice's lock, got %+v", state)
+	}
+}
+
+func TestLockTakeOverNotifiesPreviousHolderOnce(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello", nil
+	}
+	store := &fakeLockStore{locks: map[string]postdomain.EditLock{}}
+	svc := NewLockService(repo, store)
+	ctx := context.Background()
+	if _, err := svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state, err := svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 2, HolderName: "Bob", TakeOver: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.HeldByYou || state.Lock.PreviousHolderID != 1 {
+		t.Fatalf("expected Bob to hold the lock taken from Alice, got %+v", state.Lock)
+	}
+
+	// Bob's heartbeat keeps the notice for Alice
+	if _, err := svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 2, HolderName: "Bob"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state, err = svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"})
+	if !errors.Is(err, postdomain.ErrLockTakenOver) {
+		t.Fatalf("expected ErrLockTakenOver, got %v", err)
+	}
+	if state.Lock.HolderName != "Bob" {
+		t.Fatalf("expected Bob as the new holder, got %+v", state.Lock)
+	}
+	if store.locks["hello"].PreviousHolderID != 0 {
+		t.Fatalf("notice should be consumed")
+	}
+
+	if _, err := svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"}); !errors.Is(err, postdomain.ErrPostLocked) {
+		t.Fatalf("expected ErrPostLocked after the notice, got %v", err)
+	}
+}
+
+func TestLockAcquireUnknownPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello", nil
+	}
+	svc := NewLockService(repo, &fakeLockStore{locks: map[string]postdomain.EditLock{}})
+	if _, err := svc.Acquire(context.Background(), postdomain.LockInput{Slug: "missing", HolderID: 1}); !errors.Is(err, postdomain.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+}
+
+func TestLockReleaseOnlyByHolder(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello", nil
+	}
+	store := &fakeLockStore{locks: map[string]postdomain.EditLock{}}
+	svc := NewLockService(repo, store)
+	ctx := context.Background()
+	if _, err := svc.Acquire(ctx, postdomain.LockInput{Slug: "hello", HolderID: 1, HolderName: "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.Release(ctx, "hello", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := store.locks["hello"]; !ok {
+		t.Fatal("release by another user should keep the lock")
+	}
+	if err := svc.Release(ctx, "hello", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, err := svc.State(ctx, "hello", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Locked {
+		t.Fatalf("expected lock to be released, got %+v", state)
+	}
+}
+
+type fakeLockStore struct {
+	locks map[string]postdomain.EditLock
+}
+
+func (f *fakeLockStore) GetLock(ctx context.Context, slug string) (*postdomain.EditLock, error) {
+	lock, ok := f.locks[slug]
+	if !ok {
+		return nil, nil
+	}
+	return &lock, nil
+}
+
+func (f *fakeLockStore) UpdateLock(ctx context.Context, slug string, fn func(current *postdomain.EditLock) (*postdomain.EditLock, error)) error {
+	current, _ := f.GetLock(ctx, slug)
+	next, err := fn(current)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		delete(f.locks, slug)
+		return nil
+	}
+	f.locks[slug] = *next
+	return nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/slug"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// ReplaceRelations makes set the complete categories and tags of a post in one
+// transaction, recorded as an update of the post, and returns the relations the post
+// ends up with. Unknown categories and tags fail validation and change nothing.
+func (s *Service) ReplaceRelations(ctx context.Context, postSlug string, actorID int64, set postdomain.RelationSet) (postdomain.PostRelations, error) {
+	postSlug = strings.TrimSpace(postSlug)
+	if postSlug == "" {
+		return postdomain.PostRelations{}, errSlugRequired
+	}
+	if err := s.checkRelationEdit(ctx, postSlug, actorID); err != nil {
+		return postdomain.PostRelations{}, err
+	}
+	v := &validation.Error{}
+	plan, err := s.planRelations(ctx, set, v)
+	if err != nil {
+		return postdomain.PostRelations{}, err
+	}
+	if err := v.Err(); err != nil {
+		return postdomain.PostRelations{}, err
+	}
+
+	var out postdomain.PostRelations
+	err = s.recordRelationChange(ctx, postSlug, func(ctx context.Context) ([]outbox.Message, error) {
+		messages, err := s.writeRelations(ctx, postSlug, plan)
+		if err != nil {
+			return nil, err
+		}
+		if out.Categories, err = s.repo.ListCategoriesByPostSlug(ctx, postSlug); err != nil {
+			return nil, err
+		}
+		if out.Tags, err = s.repo.ListTagsByPostSlug(ctx, postSlug); err != nil {
+			return nil, err
+		}
+		return messages, nil
+	})
+	if err != nil {
+		return postdomain.PostRelations{}, err
+	}
+	return out, nil
+}
+
+// planRelations resolves set against the stored categories and tags, recording entries
+// that match nothing on v. Blank entries are ignored and duplicates collapse.
+func (s *Service) planRelations(ctx context.Context, set postdomain.RelationSet, v *validation.Error) (postdomain.RelationWrite, error) {
+	var plan postdomain.RelationWrite
+	if set.Categories != nil {
+		wanted := make([]string, 0, len(set.Categories))
+		seen := make(map[string]bool, len(set.Categories))
+		for _, entry := range set.Categories {
+			key := slug.Make(entry)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			wanted = append(wanted, key)
+		}
+		existing, err := s.repo.ExistingCategorySlugs(ctx, wanted)
+		if err != nil {
+			return postdomain.RelationWrite{}, err
+		}
+		found := make(map[string]bool, len(existing))
+		for _, c := range existing {
+			found[c] = true
+		}
+		var missing []string
+		for _, c := range wanted {
+			if !found[c] {
+				missing = append(missing, c)
+			}
+		}
+		if len(missing) > 0 {
+			v.Add("categories", fmt.Errorf("%w: %s", postdomain.ErrCategoryUnknown, strings.Join(missing, ", ")))
+		}
+		plan.Categories = wanted
+	}
+
+	if set.Tags != nil {
+		entries := make([]string, 0, len(set.Tags))
+		keys := make([]string, 0, len(set.Tags))
+		for _, entry := range set.Tags {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			entries = append(entries, entry)
+			keys = append(keys, slug.Make(entry))
+		}
+		matched, err := s.repo.MatchTags(ctx, entries, keys)
+		if err != nil {
+			return postdomain.RelationWrite{}, err
+		}
+		plan.Tags = make([]string, 0, len(entries))
+		seen := make(map[string]bool, len(entries))
+		var missing []string
+		for i, entry := range entries {
+			tagSlug, ok := matched[entry]
+			if !ok {
+				if !set.CreateMissingTags || keys[i] == "" {
+					missing = append(missing, entry)
+					continue
+				}
+				tagSlug = keys[i]
+			}
+			if seen[tagSlug] {
+				continue
+			}
+			seen[tagSlug] = true
+			if !ok {
+				plan.CreateTags = append(plan.CreateTags, taxdomain.TagRecord{Name: entry, Slug: tagSlug})
+			}
+			plan.Tags = append(plan.Tags, tagSlug)
+		}
+		if len(missing) > 0 {
+			v.Add("tags", fmt.Errorf("%w: %s", postdomain.ErrTagUnknown, strings.Join(missing, ", ")))
+		}
+	}
+	return plan, nil
+}
+
+// writeRelations creates the planned tags and stores the planned sets on the post, in
+// one transaction of the repository. It returns the events of the created tags.
+func (s *Service) writeRelations(ctx context.Context, postSlug string, plan postdomain.RelationWrite) ([]outbox.Message, error) {
+	created, err := s.repo.ReplacePostRelations(ctx, postSlug, plan)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]outbox.Message, 0, len(created))
+	for _, tag := range created {
+		event := taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeCreated, Slug: tag.Slug, Name: tag.Name}
+		msg, err := outbox.NewMessage(taxdomain.EventTaxonomyChanged, event.Kind+":"+event.Slug, event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
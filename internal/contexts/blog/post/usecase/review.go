@@ -0,0 +1,248 @@
+﻿package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/validation"
+)
+
+// ReviewService drives the editorial workflow: status transitions guarded by role,
+// reviewer assignment, review comments and per-user review queues.
+type ReviewService interface {
+	Role(ctx context.Context, actorID int64) (string, error)
+	State(ctx context.Context, slug string, actorID int64) (postdomain.ReviewState, error)
+	Transition(ctx context.Context, input postdomain.TransitionInput) (postdomain.Post, error)
+	AssignReviewer(ctx context.Context, slug string, actorID, reviewerID int64) (postdomain.Review, error)
+	Comment(ctx context.Context, slug string, actorID int64, body string) (postdomain.ReviewComment, error)
+	Queue(ctx context.Context, actorID int64) (postdomain.ReviewQueue, error)
+}
+
+// WorkflowService implements ReviewService on top of the post and review repositories.
+// A transition stores the status change, its review comment and the post event in one
+// transaction, like writes made through Service.
+type WorkflowService struct {
+	posts   postdomain.PostRepository
+	reviews postdomain.ReviewRepository
+	outbox  outbox.Writer
+	now     func() time.Time
+}
+
+var _ ReviewService = (*WorkflowService)(nil)
+
+// NewReviewService wires the post and review repositories and the event outbox into a
+// use case implementation.
+func NewReviewService(posts postdomain.PostRepository, reviews postdomain.ReviewRepository, events outbox.Writer) *WorkflowService {
+	return &WorkflowService{posts: posts, reviews: reviews, outbox: events, now: time.Now}
+}
+
+// Role returns the editorial role of the acting user, "" when they have none.
+func (s *WorkflowService) Role(ctx context.Context, actorID int64) (string, error) {
+	return s.posts.UserRole(ctx, actorID)
+}
+
+// State returns the review of a post along with the statuses the acting user may move
+// it to, its comment history and the users it can be assigned to.
+func (s *WorkflowService) State(ctx context.Context, slug string, actorID int64) (postdomain.ReviewState, error) {
+	role, err := s.posts.UserRole(ctx, actorID)
+	if err != nil {
+		return postdomain.ReviewState{}, err
+	}
+	review, err := s.reviews.GetReview(ctx, strings.TrimSpace(slug))
+	if err != nil {
+		return postdomain.ReviewState{}, err
+	}
+	comments, err := s.reviews.ListReviewComments(ctx, review.PostID)
+	if err != nil {
+		return postdomain.ReviewState{}, err
+	}
+	reviewers, err := s.reviews.ListReviewers(ctx)
+	if err != nil {
+		return postdomain.ReviewState{}, err
+	}
+	return postdomain.ReviewState{
+		Review:      review,
+		Role:        role,
+		Transitions: postdomain.NextStatuses(role, review.Status),
+		Comments:    comments,
+		Reviewers:   reviewers,
+	}, nil
+}
+
+// Transition moves a post to a new status and records the move, with the optional
+// comment, in the review history. Requesting changes requires a comment. Publishing a
+// post for the first time stamps published_at.
+func (s *WorkflowService) Transition(ctx context.Context, input postdomain.TransitionInput) (postdomain.Post, error) {
+	role, err := s.actorRole(ctx, input.ActorID)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	slug := strings.TrimSpace(input.Slug)
+	if slug == "" {
+		return postdomain.Post{}, errSlugRequired
+	}
+	current, err := s.posts.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	to := strings.ToLower(strings.TrimSpace(input.Status))
+	comment := strings.TrimSpace(input.Comment)
+
+	v := &validation.Error{}
+	switch {
+	case to == "":
+		v.Add("status", postdomain.ErrStatusRequired)
+	case !postdomain.IsValidStatus(to):
+		v.Add("status", postdomain.ErrStatusInvalid)
+	case to == current.Status:
+		v.Add("status", postdomain.ErrStatusUnchanged)
+	case !postdomain.CanTransition(current.Status, to):
+		v.Add("status", postdomain.ErrStatusTransition)
+	case !postdomain.CanTransitionAs(role, current.Status, to):
+		v.Add("status", postdomain.ErrStatusForbidden)
+	}
+	switch {
+	case utf8.RuneCountInString(comment) > postdomain.MaxReviewCommentLength:
+		v.Add("comment", postdomain.ErrCommentTooLong)
+	case comment == "" && to == postdomain.StatusChangesRequested:
+		v.Add("comment", postdomain.ErrChangesNeedComment)
+	}
+	if err := v.Err(); err != nil {
+		return postdomain.Post{}, err
+	}
+
+	patch := postdomain.PatchPostInput{
+		ActorID: input.ActorID,
+		Slug:    slug,
+		Status:  postdomain.PatchField[string]{Set: true, Value: to},
+	}
+	if to == postdomain.StatusPublished && current.PublishedAt == nil {
+		now := s.now().UTC()
+		patch.PublishedAt = postdomain.PatchField[*time.Time]{Set: true, Value: &now}
+	}
+	return recordPostWrite(ctx, s.outbox, current.Status, func(ctx context.Context) (postdomain.Post, error) {
+		post, err := s.posts.PatchPostBySlug(ctx, patch)
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		if _, err := s.reviews.CreateReviewComment(ctx, postdomain.ReviewCommentRecord{
+			PostID:     current.ID,
+			AuthorID:   input.ActorID,
+			Body:       comment,
+			FromStatus: current.Status,
+			ToStatus:   to,
+		}); err != nil {
+			return postdomain.Post{}, err
+		}
+		return post, nil
+	})
+}
+
+// AssignReviewer assigns an editor or admin to review a post; a zero reviewerID clears
+// the assignment. Any editorial role may assign, so writers can pick their reviewer
+// when submitting.
+func (s *WorkflowService) AssignReviewer(ctx context.Context, slug string, actorID, reviewerID int64) (postdomain.Review, error) {
+	if _, err := s.actorRole(ctx, actorID); err != nil {
+		return postdomain.Review{}, err
+	}
+	slug = strings.TrimSpace(slug)
+	if _, err := s.reviews.GetReview(ctx, slug); err != nil {
+		return postdomain.Review{}, err
+	}
+	var reviewer *int64
+	if reviewerID != 0 {
+		role, err := s.posts.UserRole(ctx, reviewerID)
+		if err != nil {
+			return postdomain.Review{}, err
+		}
+		if !postdomain.IsReviewer(role) {
+			v := &validation.Error{}
+			v.Add("reviewer_id", postdomain.ErrReviewerInvalid)
+			return postdomain.Review{}, v
+		}
+		reviewer = &reviewerID
+	}
+	if err := s.reviews.SetReviewer(ctx, slug, reviewer); err != nil {
+		return postdomain.Review{}, err
+	}
+	return s.reviews.GetReview(ctx, slug)
+}
+
+// Comment adds a review comment to a post without changing its status.
+func (s *WorkflowService) Comment(ctx context.Context, slug string, actorID int64, body string) (postdomain.ReviewComment, error) {
+	if _, err := s.actorRole(ctx, actorID); err != nil {
+		return postdomain.ReviewComment{}, err
+	}
+	review, err := s.reviews.GetReview(ctx, strings.TrimSpace(slug))
+	if err != nil {
+		return postdomain.ReviewComment{}, err
+	}
+	body = strings.TrimSpace(body)
+	v := &validation.Error{}
+	switch {
+	case body == "":
+		v.Add("body", postdomain.ErrCommentRequired)
+	case utf8.RuneCountInString(body) > postdomain.MaxReviewCommentLength:
+		v.Add("body", postdomain.ErrCommentTooLong)
+	}
+	if err := v.Err(); err != nil {
+		return postdomain.ReviewComment{}, err
+	}
+	return s.reviews.CreateReviewComment(ctx, postdomain.ReviewCommentRecord{
+		PostID:   review.PostID,
+		AuthorID: actorID,
+		Body:     body,
+	})
+}
+
+// Queue lists the posts waiting on the acting user.
+func (s *WorkflowService) Queue(ctx context.Context, actorID int64) (postdomain.ReviewQueue, error) {
+	role, err := s.actorRole(ctx, actorID)
+	if err != nil {
+		return postdomain.ReviewQueue{}, err
+	}
+	inReview, err := s.reviews.ListReviewsByStatus(ctx, postdomain.StatusInReview)
+	if err != nil {
+		return postdomain.ReviewQueue{}, err
+	}
+	returned, err := s.reviews.ListReviewsByStatus(ctx, postdomain.StatusChangesRequested)
+	if err != nil {
+		return postdomain.ReviewQueue{}, err
+	}
+	queue := postdomain.ReviewQueue{
+		Assigned:   []postdomain.Review{},
+		Unassigned: []postdomain.Review{},
+		Returned:   []postdomain.Review{},
+	}
+	for _, r := range inReview {
+		switch {
+		case r.ReviewerID != nil && *r.ReviewerID == actorID:
+			queue.Assigned = append(queue.Assigned, r)
+		case r.ReviewerID == nil && postdomain.IsReviewer(role):
+			queue.Unassigned = append(queue.Unassigned, r)
+		}
+	}
+	for _, r := range returned {
+		if r.AuthorID == actorID {
+			queue.Returned = append(queue.Returned, r)
+		}
+	}
+	return queue, nil
+}
+
+// actorRole returns the role of the acting user, failing with ErrWorkflowForbidden when
+// they take no part in the workflow.
+func (s *WorkflowService) actorRole(ctx context.Context, actorID int64) (string, error) {
+	role, err := s.posts.UserRole(ctx, actorID)
+	if err != nil {
+		return "", err
+	}
+	if !postdomain.IsValidRole(role) {
+		return "", postdomain.ErrWorkflowForbidden
+	}
+	return role, nil
+}
@@ -0,0 +1,321 @@
+﻿package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	"proto-gin-web/internal/platform/validation"
+)
+
+var testRoles = map[int64]string{
+	1: postdomain.RoleAdmin,
+	2: postdomain.RoleEditor,
+	3: postdomain.RoleWriter,
+	4: "",
+}
+
+func TestWorkflowTransitionRecordsComment(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: slug, Status: postdomain.StatusDraft, AuthorID: 3}, nil
+	}
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: input.Slug, Status: input.Status.Value}, nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusDraft, AuthorID: 3}}
+	svc := NewReviewService(repo, reviews, nil)
+
+	post, err := svc.Transition(context.Background(), postdomain.TransitionInput{
+		Slug:    "hello",
+		ActorID: 3,
+		Status:  " In_Review ",
+		Comment: " ready for a look ",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Status != postdomain.StatusInReview {
+		t.Fatalf("expected in_review, got %q", post.Status)
+	}
+	if len(reviews.comments) != 1 {
+		t.Fatalf("expected one recorded comment, got %d", len(reviews.comments))
+	}
+	got := reviews.comments[0]
+	if got.Body != "ready for a look" || got.FromStatus != postdomain.StatusDraft || got.ToStatus != postdomain.StatusInReview || got.AuthorID != 3 {
+		t.Fatalf("unexpected comment record: %+v", got)
+	}
+}
+
+func TestWorkflowTransitionRecordsEvent(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: slug, Status: postdomain.StatusApproved, AuthorID: 3}, nil
+	}
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: input.Slug, Status: input.Status.Value}, nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusApproved, AuthorID: 3}}
+	events := &fakeOutbox{}
+	svc := NewReviewService(repo, reviews, events)
+
+	if _, err := svc.Transition(context.Background(), postdomain.TransitionInput{
+		Slug:    "hello",
+		ActorID: 2,
+		Status:  postdomain.StatusPublished,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := events.decode(t)
+	if len(got) != 1 || got[0].typ != postdomain.EventPostPublished || got[0].PreviousStatus != postdomain.StatusApproved {
+		t.Fatalf("expected a publish event, got %+v", got)
+	}
+}
+
+func TestWorkflowTransitionChecksRole(t *testing.T) {
+	cases := []struct {
+		name    string
+		from    string
+		to      string
+		actor   int64
+		wantErr error
+	}{
+		{"writer cannot approve", postdomain.StatusInReview, postdomain.StatusApproved, 3, postdomain.ErrStatusForbidden},
+		{"writer cannot publish draft", postdomain.StatusDraft, postdomain.StatusPublished, 3, postdomain.ErrStatusForbidden},
+		{"editor cannot skip review", postdomain.StatusDraft, postdomain.StatusPublished, 2, postdomain.ErrStatusForbidden},
+		{"admin may skip review", postdomain.StatusDraft, postdomain.StatusPublished, 1, nil},
+		{"editor approves", postdomain.StatusInReview, postdomain.StatusApproved, 2, nil},
+		{"editor publishes approved", postdomain.StatusApproved, postdomain.StatusPublished, 2, nil},
+		{"archived cannot be published", postdomain.StatusArchived, postdomain.StatusPublished, 1, postdomain.ErrStatusTransition},
+		{"same status", postdomain.StatusDraft, postdomain.StatusDraft, 1, postdomain.ErrStatusUnchanged},
+		{"no role", postdomain.StatusDraft, postdomain.StatusInReview, 4, postdomain.ErrWorkflowForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakePostRepo{}
+			repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+				return testRoles[id], nil
+			}
+			repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+				return postdomain.Post{ID: 10, Slug: slug, Status: tc.from, AuthorID: 3}, nil
+			}
+			repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+				return postdomain.Post{ID: 10, Slug: input.Slug, Status: input.Status.Value}, nil
+			}
+			reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: tc.from, AuthorID: 3}}
+			svc := NewReviewService(repo, reviews, nil)
+			_, err := svc.Transition(context.Background(), postdomain.TransitionInput{Slug: "hello", ActorID: tc.actor, Status: tc.to})
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if len(reviews.comments) != 0 {
+				t.Fatalf("rejected transition should not be recorded")
+			}
+		})
+	}
+}
+
+func TestWorkflowChangesRequestedNeedsComment(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: slug, Status: postdomain.StatusInReview, AuthorID: 3}, nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusInReview, AuthorID: 3}}
+	repo.patchPostBySlugFn = func(context.Context, postdomain.PatchPostInput) (postdomain.Post, error) {
+		t.Fatal("PatchPostBySlug should not be called")
+		return postdomain.Post{}, nil
+	}
+	svc := NewReviewService(repo, reviews, nil)
+
+	_, err := svc.Transition(context.Background(), postdomain.TransitionInput{Slug: "hello", ActorID: 2, Status: postdomain.StatusChangesRequested})
+	var verr *validation.Error
+	if !errors.As(err, &verr) || !verr.Has("comment") {
+		t.Fatalf("expected comment validation error, got %v", err)
+	}
+	if !errors.Is(err, postdomain.ErrChangesNeedComment) {
+		t.Fatalf("expected ErrChangesNeedComment, got %v", err)
+	}
+}
+
+func TestWorkflowPublishStampsPublishedAt(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{ID: 10, Slug: slug, Status: postdomain.StatusApproved, AuthorID: 3}, nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusApproved, AuthorID: 3}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		if !input.PublishedAt.Set || input.PublishedAt.Value == nil || !input.PublishedAt.Value.Equal(now) {
+			t.Fatalf("expected published_at to be stamped, got %+v", input.PublishedAt)
+		}
+		if input.ActorID != 2 {
+			t.Fatalf("expected actor to be passed through, got %d", input.ActorID)
+		}
+		return postdomain.Post{Slug: input.Slug, Status: input.Status.Value, PublishedAt: input.PublishedAt.Value}, nil
+	}
+	svc := NewReviewService(repo, reviews, nil)
+	svc.now = func() time.Time { return now }
+
+	if _, err := svc.Transition(context.Background(), postdomain.TransitionInput{Slug: "hello", ActorID: 2, Status: postdomain.StatusPublished}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWorkflowAssignReviewerValidatesRole(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusInReview, AuthorID: 3}}
+	svc := NewReviewService(repo, reviews, nil)
+
+	_, err := svc.AssignReviewer(context.Background(), "hello", 3, 3)
+	var verr *validation.Error
+	if !errors.As(err, &verr) || !verr.Has("reviewer_id") {
+		t.Fatalf("expected reviewer_id validation error, got %v", err)
+	}
+	if reviews.setCalls != 0 {
+		t.Fatalf("invalid reviewer should not be stored")
+	}
+
+	review, err := svc.AssignReviewer(context.Background(), "hello", 3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.ReviewerID == nil || *review.ReviewerID != 2 {
+		t.Fatalf("expected reviewer 2, got %+v", review.ReviewerID)
+	}
+
+	review, err = svc.AssignReviewer(context.Background(), "hello", 2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.ReviewerID != nil {
+		t.Fatalf("expected reviewer to be cleared, got %d", *review.ReviewerID)
+	}
+}
+
+func TestWorkflowCommentRequiresBody(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	reviews := &fakeReviewRepo{review: postdomain.Review{PostID: 10, Slug: "hello", Status: postdomain.StatusInReview, AuthorID: 3}}
+	svc := NewReviewService(repo, reviews, nil)
+
+	_, err := svc.Comment(context.Background(), "hello", 2, "   ")
+	if !errors.Is(err, postdomain.ErrCommentRequired) {
+		t.Fatalf("expected ErrCommentRequired, got %v", err)
+	}
+	if _, err := svc.Comment(context.Background(), "hello", 4, "hi"); !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("expected ErrWorkflowForbidden, got %v", err)
+	}
+}
+
+func TestWorkflowQueueSplitsByActor(t *testing.T) {
+	editor := int64(2)
+	other := int64(1)
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return testRoles[id], nil
+	}
+	reviews := &fakeReviewRepo{}
+	reviews.byStatus = map[string][]postdomain.Review{
+		postdomain.StatusInReview: {
+			{Slug: "mine", ReviewerID: &editor},
+			{Slug: "theirs", ReviewerID: &other},
+			{Slug: "open"},
+		},
+		postdomain.StatusChangesRequested: {
+			{Slug: "returned", AuthorID: 3},
+			{Slug: "someone-else", AuthorID: 1},
+		},
+	}
+	svc := NewReviewService(repo, reviews, nil)
+
+	queue, err := svc.Queue(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue.Assigned) != 1 || queue.Assigned[0].Slug != "mine" {
+		t.Fatalf("unexpected assigned: %+v", queue.Assigned)
+	}
+	if len(queue.Unassigned) != 1 || queue.Unassigned[0].Slug != "open" {
+		t.Fatalf("unexpected unassigned: %+v", queue.Unassigned)
+	}
+
+	queue, err = svc.Queue(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue.Unassigned) != 0 {
+		t.Fatalf("writers should not see unassigned reviews, got %+v", queue.Unassigned)
+	}
+	if len(queue.Returned) != 1 || queue.Returned[0].Slug != "returned" {
+		t.Fatalf("unexpected returned: %+v", queue.Returned)
+	}
+}
+
+type fakeReviewRepo struct {
+	review   postdomain.Review
+	byStatus map[string][]postdomain.Review
+	comments []postdomain.ReviewCommentRecord
+	setCalls int
+}
+
+func (f *fakeReviewRepo) GetReview(ctx context.Context, slug string) (postdomain.Review, error) {
+	if slug != f.review.Slug {
+		return postdomain.Review{}, postdomain.ErrPostNotFound
+	}
+	return f.review, nil
+}
+
+func (f *fakeReviewRepo) ListReviewsByStatus(ctx context.Context, status string) ([]postdomain.Review, error) {
+	return f.byStatus[status], nil
+}
+
+func (f *fakeReviewRepo) SetReviewer(ctx context.Context, slug string, reviewerID *int64) error {
+	f.setCalls++
+	f.review.ReviewerID = reviewerID
+	return nil
+}
+
+func (f *fakeReviewRepo) ListReviewers(context.Context) ([]postdomain.Reviewer, error) {
+	return nil, nil
+}
+
+func (f *fakeReviewRepo) CreateReviewComment(ctx context.Context, record postdomain.ReviewCommentRecord) (postdomain.ReviewComment, error) {
+	f.comments = append(f.comments, record)
+	author := record.AuthorID
+	return postdomain.ReviewComment{
+		PostID:     record.PostID,
+		AuthorID:   &author,
+		Body:       record.Body,
+		FromStatus: record.FromStatus,
+		ToStatus:   record.ToStatus,
+	}, nil
+}
+
+func (f *fakeReviewRepo) ListReviewComments(context.Context, int64) ([]postdomain.ReviewComment, error) {
+	return nil, nil
+}
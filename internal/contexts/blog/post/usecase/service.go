@@ -6,6 +6,10 @@ import (
 	"strings"
 
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/slug"
+	"proto-gin-web/internal/platform/validation"
 )
 
 const (
@@ -14,8 +18,9 @@ const (
 )
 
 var (
-	errTitleRequired = errors.New("title is required")
-	errSlugRequired  = errors.New("slug is required")
+	errTitleRequired  = postdomain.ErrTitleRequired
+	errSlugRequired   = postdomain.ErrSlugRequired
+	errAuthorRequired = postdomain.ErrAuthorRequired
 )
 
 var allowedSorts = map[string]struct{}{
@@ -32,23 +37,32 @@ type PostService interface {
 	GetBySlug(ctx context.Context, slug string) (postdomain.PostWithRelations, error)
 	Create(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error)
 	Update(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error)
+	Patch(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error)
 	Delete(ctx context.Context, slug string) error
-	AddCategory(ctx context.Context, slug, categorySlug string) error
-	RemoveCategory(ctx context.Context, slug, categorySlug string) error
-	AddTag(ctx context.Context, slug, tagSlug string) error
-	RemoveTag(ctx context.Context, slug, tagSlug string) error
+	SuggestSlug(ctx context.Context, title string) (string, error)
+	AddCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error
+	RemoveCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error
+	AddTag(ctx context.Context, slug string, actorID int64, tagSlug string) error
+	RemoveTag(ctx context.Context, slug string, actorID int64, tagSlug string) error
+	ReplaceRelations(ctx context.Context, slug string, actorID int64, set postdomain.RelationSet) (postdomain.PostRelations, error)
 }
 
 // Service implements PostService using a repository abstraction.
+//
+// Every write records a post event in the outbox, in the same transaction as the
+// write. A nil outbox stores writes without events.
 type Service struct {
-	repo postdomain.PostRepository
+	repo   postdomain.PostRepository
+	fields postdomain.FieldDefinitionRepository
+	outbox outbox.Writer
 }
 
 var _ PostService = (*Service)(nil)
 
-// NewService wires a post repository into a use case implementation.
-func NewService(repo postdomain.PostRepository) *Service {
-	return &Service{repo: repo}
+// NewService wires the post and custom field repositories and the event outbox into a
+// use case implementation.
+func NewService(repo postdomain.PostRepository, fields postdomain.FieldDefinitionRepository, events outbox.Writer) *Service {
+	return &Service{repo: repo, fields: fields, outbox: events}
 }
 
 func (s *Service) ListPublished(ctx context.Context, opts postdomain.ListPostsOptions) ([]postdomain.Post, error) {
@@ -59,13 +73,26 @@ func (s *Service) ListPublished(ctx context.Context, opts postdomain.ListPostsOp
 	}
 	sort := normalizeSort(opts.Sort)
 
+	var fields map[string]any
+	if len(opts.Fields) > 0 {
+		defs, err := s.fields.ListFieldDefinitions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		v := &validation.Error{}
+		fields = postdomain.CoerceFieldFilters(defs, opts.Fields, v)
+		if err := v.Err(); err != nil {
+			return nil, err
+		}
+	}
+
 	switch {
 	case opts.Category != "":
-		return s.repo.ListPublishedPostsByCategorySorted(ctx, opts.Category, sort, limit, offset)
+		return s.repo.ListPublishedPostsByCategorySorted(ctx, opts.Category, sort, fields, limit, offset)
 	case opts.Tag != "":
-		return s.repo.ListPublishedPostsByTagSorted(ctx, opts.Tag, sort, limit, offset)
+		return s.repo.ListPublishedPostsByTagSorted(ctx, opts.Tag, sort, fields, limit, offset)
 	default:
-		return s.repo.ListPublishedPostsSorted(ctx, sort, limit, offset)
+		return s.repo.ListPublishedPostsSorted(ctx, sort, fields, limit, offset)
 	}
 }
 
@@ -83,73 +110,449 @@ func (s *Service) GetBySlug(ctx context.Context, slug string) (postdomain.PostWi
 	if err != nil {
 		return postdomain.PostWithRelations{}, err
 	}
+	trail, err := s.repo.ListCategoryTrailByPostSlug(ctx, slug)
+	if err != nil {
+		return postdomain.PostWithRelations{}, err
+	}
 	tags, err := s.repo.ListTagsByPostSlug(ctx, slug)
 	if err != nil {
 		return postdomain.PostWithRelations{}, err
 	}
+	defs, err := s.fields.ListFieldDefinitions(ctx)
+	if err != nil {
+		return postdomain.PostWithRelations{}, err
+	}
+	fields := postdomain.BuildFieldValues(defs, post.CustomFields, categorySlugs(cats))
 
-	return postdomain.PostWithRelations{Post: post, Categories: cats, Tags: tags}, nil
+	return postdomain.PostWithRelations{Post: post, Categories: cats, Breadcrumbs: trail, Tags: tags, Fields: fields}, nil
 }
 
+// Create validates a new post and stores it. An empty status defaults to draft and an
+// empty slug is generated from the title, with a numeric suffix when already taken.
+// Required custom fields are those of the categories in input.Relations, if any, and
+// the global ones.
 func (s *Service) Create(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error) {
-	if err := validateCreateInput(input); err != nil {
+	input = normalizeCreateInput(input)
+	generated := input.Slug == ""
+	if generated {
+		suggested, err := s.SuggestSlug(ctx, input.Title)
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		input.Slug = suggested
+	}
+
+	v := postdomain.ValidateCreate(input)
+	if !generated && !v.Has("slug") {
+		taken, err := s.repo.PostSlugExists(ctx, input.Slug)
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		if taken {
+			v.Add("slug", postdomain.ErrSlugTaken)
+		}
+	}
+	if err := s.checkAuthor(ctx, v, input.AuthorID); err != nil {
+		return postdomain.Post{}, err
+	}
+	if err := s.checkStatusRole(ctx, v, input.ActorID, "", input.Status); err != nil {
+		return postdomain.Post{}, err
+	}
+	plan, err := s.planRelations(ctx, input.Relations, v)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	resolved, err := s.resolveCustomFields(ctx, input.CustomFields, plan.Categories, v)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	input.CustomFields = resolved
+	if err := v.Err(); err != nil {
+		return postdomain.Post{}, err
+	}
+
+	post, err := recordPostWriteWith(ctx, s.outbox, "", func(ctx context.Context) (postdomain.Post, []outbox.Message, error) {
+		created, err := s.repo.CreatePost(ctx, input)
+		if err != nil {
+			return postdomain.Post{}, nil, err
+		}
+		messages, err := s.writeRelations(ctx, created.Slug, plan)
+		return created, messages, err
+	})
+	if errors.Is(err, postdomain.ErrSlugTaken) {
+		v.Add("slug", postdomain.ErrSlugTaken)
+		return postdomain.Post{}, v
+	}
+	if err != nil {
 		return postdomain.Post{}, err
 	}
-	return s.repo.CreatePost(ctx, normalizeCreateInput(input))
+	return post, nil
+}
+
+// SuggestSlug derives an unused post slug from title. It returns an empty string when
+// the title has nothing that can be transliterated.
+func (s *Service) SuggestSlug(ctx context.Context, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		return "", nil
+	}
+	return slug.Unique(ctx, base, s.repo.PostSlugExists)
 }
 
+// Update replaces the editable fields of a post. An empty status keeps the stored one,
+// and nil custom fields keep the stored values. New categories in input.Relations
+// scope the required custom fields. Only reviewers may update approved or published
+// posts.
 func (s *Service) Update(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error) {
-	if err := validateUpdateInput(input); err != nil {
+	input = normalizeUpdateInput(input)
+	if input.Slug == "" {
+		return postdomain.Post{}, errSlugRequired
+	}
+	current, err := s.repo.GetPostBySlug(ctx, input.Slug)
+	if err != nil {
 		return postdomain.Post{}, err
 	}
-	return s.repo.UpdatePostBySlug(ctx, normalizeUpdateInput(input))
+	if err := s.checkEditRole(ctx, input.ActorID, current.Status); err != nil {
+		return postdomain.Post{}, err
+	}
+	if input.Status == "" {
+		input.Status = current.Status
+	}
+	v := postdomain.ValidateUpdate(input, current)
+	if err := s.checkStatusRole(ctx, v, input.ActorID, current.Status, input.Status); err != nil {
+		return postdomain.Post{}, err
+	}
+	plan, err := s.planRelations(ctx, input.Relations, v)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	if input.CustomFields != nil {
+		var resolved map[string]any
+		if plan.Categories != nil {
+			resolved, err = s.resolveCustomFields(ctx, input.CustomFields, plan.Categories, v)
+		} else {
+			resolved, err = s.resolvePostFields(ctx, input.Slug, input.CustomFields, v)
+		}
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		input.CustomFields = resolved
+	}
+	if err := v.Err(); err != nil {
+		return postdomain.Post{}, err
+	}
+	return recordPostWriteWith(ctx, s.outbox, current.Status, func(ctx context.Context) (postdomain.Post, []outbox.Message, error) {
+		updated, err := s.repo.UpdatePostBySlug(ctx, input)
+		if err != nil {
+			return postdomain.Post{}, nil, err
+		}
+		messages, err := s.writeRelations(ctx, updated.Slug, plan)
+		return updated, messages, err
+	})
+}
+
+// Patch applies a partial update; fields absent from the patch keep their stored values.
+// Like Update, it is limited to reviewers for approved and published posts.
+func (s *Service) Patch(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+	input = normalizePatchInput(input)
+	if input.Slug == "" {
+		return postdomain.Post{}, errSlugRequired
+	}
+	current, err := s.repo.GetPostBySlug(ctx, input.Slug)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	if err := s.checkEditRole(ctx, input.ActorID, current.Status); err != nil {
+		return postdomain.Post{}, err
+	}
+	v := postdomain.ValidatePatch(input, current)
+	if input.Status.Set {
+		if err := s.checkStatusRole(ctx, v, input.ActorID, current.Status, input.Status.Value); err != nil {
+			return postdomain.Post{}, err
+		}
+	}
+	if input.AuthorID.Set {
+		if err := s.checkAuthor(ctx, v, input.AuthorID.Value); err != nil {
+			return postdomain.Post{}, err
+		}
+	}
+	if input.CustomFields.Set {
+		merged := postdomain.MergeCustomFields(current.CustomFields, input.CustomFields.Value)
+		if input.CustomFields.Value == nil {
+			merged = map[string]any{}
+		}
+		resolved, err := s.resolvePostFields(ctx, input.Slug, merged, v)
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		input.CustomFields.Value = resolved
+	}
+	if err := v.Err(); err != nil {
+		return postdomain.Post{}, err
+	}
+	if !input.HasChanges() {
+		return current, nil
+	}
+	return recordPostWrite(ctx, s.outbox, current.Status, func(ctx context.Context) (postdomain.Post, error) {
+		return s.repo.PatchPostBySlug(ctx, input)
+	})
+}
+
+// resolvePostFields validates values for an existing post, scoping required fields
+// to its categories.
+func (s *Service) resolvePostFields(ctx context.Context, slug string, values map[string]any, v *validation.Error) (map[string]any, error) {
+	cats, err := s.repo.ListCategoriesByPostSlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveCustomFields(ctx, values, categorySlugs(cats), v)
+}
+
+// resolveCustomFields validates values against the field definitions, recording
+// problems on v, and returns the map to store.
+func (s *Service) resolveCustomFields(ctx context.Context, values map[string]any, categories []string, v *validation.Error) (map[string]any, error) {
+	defs, err := s.fields.ListFieldDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return postdomain.ResolveCustomFields(defs, values, categories, v), nil
 }
 
+// checkAuthor records a field error when a positive author id does not exist.
+// Non-positive ids are already reported by the domain validators.
+func (s *Service) checkAuthor(ctx context.Context, v *validation.Error, authorID int64) error {
+	if authorID <= 0 {
+		return nil
+	}
+	exists, err := s.repo.AuthorExists(ctx, authorID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		v.Add("author_id", postdomain.ErrAuthorNotFound)
+	}
+	return nil
+}
+
+// checkStatusRole records a field error when the acting user's role may not move a post
+// from one status to another. Moves the workflow itself forbids are already reported by
+// the domain validators.
+func (s *Service) checkStatusRole(ctx context.Context, v *validation.Error, actorID int64, from, to string) error {
+	if from == to || v.Has("status") {
+		return nil
+	}
+	role, err := s.repo.UserRole(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !postdomain.CanTransitionAs(role, from, to) {
+		v.Add("status", postdomain.ErrStatusForbidden)
+	}
+	return nil
+}
+
+// checkEditRole fails with ErrWorkflowForbidden when the acting user's role may not
+// edit a post in status, so writers cannot change a post after review has signed it off.
+func (s *Service) checkEditRole(ctx context.Context, actorID int64, status string) error {
+	if postdomain.CanEditAs("", status) {
+		return nil
+	}
+	role, err := s.repo.UserRole(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !postdomain.CanEditAs(role, status) {
+		return postdomain.ErrWorkflowForbidden
+	}
+	return nil
+}
+
+// ArchiveExpired archives the published posts whose unpublish date has passed and
+// returns their slugs. It is run periodically by a background job rather than exposed
+// through PostService.
+func (s *Service) ArchiveExpired(ctx context.Context) ([]string, error) {
+	var slugs []string
+	err := outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		archived, err := s.repo.ArchiveExpiredPosts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slugs = archived
+		messages := make([]outbox.Message, 0, len(archived))
+		for _, slug := range archived {
+			post, err := s.repo.GetPostBySlug(ctx, slug)
+			if err != nil {
+				return nil, err
+			}
+			msg, err := postMessage(postdomain.StatusPublished, post.Status, post)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, msg)
+		}
+		return messages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// Delete removes a post. Its delete event carries the post as it was.
 func (s *Service) Delete(ctx context.Context, slug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errSlugRequired
 	}
-	return s.repo.DeletePostBySlug(ctx, slug)
+	return outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		current, err := s.repo.GetPostBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.DeletePostBySlug(ctx, slug); err != nil {
+			return nil, err
+		}
+		msg, err := postMessage(current.Status, "", current)
+		if err != nil {
+			return nil, err
+		}
+		return []outbox.Message{msg}, nil
+	})
+}
+
+// recordPostWrite runs write and records the event of the post it returns, which moved
+// from status from, in one transaction.
+func recordPostWrite(ctx context.Context, w outbox.Writer, from string, write func(ctx context.Context) (postdomain.Post, error)) (postdomain.Post, error) {
+	return recordPostWriteWith(ctx, w, from, func(ctx context.Context) (postdomain.Post, []outbox.Message, error) {
+		post, err := write(ctx)
+		return post, nil, err
+	})
+}
+
+// recordPostWriteWith is recordPostWrite for writes that record further messages, which
+// precede the post event.
+func recordPostWriteWith(ctx context.Context, w outbox.Writer, from string, write func(ctx context.Context) (postdomain.Post, []outbox.Message, error)) (postdomain.Post, error) {
+	var post postdomain.Post
+	err := outbox.Record(ctx, w, func(ctx context.Context) ([]outbox.Message, error) {
+		written, messages, err := write(ctx)
+		if err != nil {
+			return nil, err
+		}
+		post = written
+		msg, err := postMessage(from, written.Status, written)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, msg), nil
+	})
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	return post, nil
 }
 
-func (s *Service) AddCategory(ctx context.Context, slug, categorySlug string) error {
+// checkRelationEdit loads the post and fails with ErrWorkflowForbidden when the acting
+// user may not edit it, the same check Update makes before changing a post.
+func (s *Service) checkRelationEdit(ctx context.Context, slug string, actorID int64) error {
+	current, err := s.repo.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	return s.checkEditRole(ctx, actorID, current.Status)
+}
+
+// recordRelationChange runs a category or tag change and records it as an update of
+// the post, in one transaction. change may return messages of its own, such as the
+// events of tags it created; they are recorded ahead of the post's.
+func (s *Service) recordRelationChange(ctx context.Context, slug string, change func(ctx context.Context) ([]outbox.Message, error)) error {
+	return outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		messages, err := change(ctx)
+		if err != nil {
+			return nil, err
+		}
+		post, err := s.repo.GetPostBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := postMessage(post.Status, post.Status, post)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, msg), nil
+	})
+}
+
+// postMessage builds the outbox message of a write that moved post from one status to
+// another. An empty to means the post was deleted.
+func postMessage(from, to string, post postdomain.Post) (outbox.Message, error) {
+	return outbox.NewMessage(postdomain.EventType(from, to), post.Slug, postdomain.Event{Post: post, PreviousStatus: from})
+}
+
+func (s *Service) AddCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errSlugRequired
 	}
 	if strings.TrimSpace(categorySlug) == "" {
 		return errors.New("category slug is required")
 	}
-	return s.repo.AddCategoryToPost(ctx, slug, categorySlug)
+	if err := s.checkRelationEdit(ctx, slug, actorID); err != nil {
+		return err
+	}
+	return s.recordRelationChange(ctx, slug, func(ctx context.Context) ([]outbox.Message, error) {
+		return nil, s.repo.AddCategoryToPost(ctx, slug, categorySlug)
+	})
 }
 
-func (s *Service) RemoveCategory(ctx context.Context, slug, categorySlug string) error {
+func (s *Service) RemoveCategory(ctx context.Context, slug string, actorID int64, categorySlug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errSlugRequired
 	}
 	if strings.TrimSpace(categorySlug) == "" {
 		return errors.New("category slug is required")
 	}
-	return s.repo.RemoveCategoryFromPost(ctx, slug, categorySlug)
+	if err := s.checkRelationEdit(ctx, slug, actorID); err != nil {
+		return err
+	}
+	return s.recordRelationChange(ctx, slug, func(ctx context.Context) ([]outbox.Message, error) {
+		return nil, s.repo.RemoveCategoryFromPost(ctx, slug, categorySlug)
+	})
 }
 
-func (s *Service) AddTag(ctx context.Context, slug, tagSlug string) error {
+func (s *Service) AddTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errSlugRequired
 	}
 	if strings.TrimSpace(tagSlug) == "" {
 		return errors.New("tag slug is required")
 	}
-	return s.repo.AddTagToPost(ctx, slug, tagSlug)
+	if err := s.checkRelationEdit(ctx, slug, actorID); err != nil {
+		return err
+	}
+	return s.recordRelationChange(ctx, slug, func(ctx context.Context) ([]outbox.Message, error) {
+		return nil, s.repo.AddTagToPost(ctx, slug, tagSlug)
+	})
 }
 
-func (s *Service) RemoveTag(ctx context.Context, slug, tagSlug string) error {
+func (s *Service) RemoveTag(ctx context.Context, slug string, actorID int64, tagSlug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errSlugRequired
 	}
 	if strings.TrimSpace(tagSlug) == "" {
 		return errors.New("tag slug is required")
 	}
-	return s.repo.RemoveTagFromPost(ctx, slug, tagSlug)
+	if err := s.checkRelationEdit(ctx, slug, actorID); err != nil {
+		return err
+	}
+	return s.recordRelationChange(ctx, slug, func(ctx context.Context) ([]outbox.Message, error) {
+		return nil, s.repo.RemoveTagFromPost(ctx, slug, tagSlug)
+	})
+}
+
+func categorySlugs(cats []taxdomain.Category) []string {
+	out := make([]string, len(cats))
+	for i, c := range cats {
+		out[i] = c.Slug
+	}
+	return out
 }
 
 func clampLimit(limit int32) int32 {
@@ -170,30 +573,17 @@ func normalizeSort(sort string) string {
 	return "created_at_desc"
 }
 
-func validateCreateInput(input postdomain.CreatePostInput) error {
-	if strings.TrimSpace(input.Title) == "" {
-		return errTitleRequired
-	}
-	if strings.TrimSpace(input.Slug) == "" {
-		return errSlugRequired
-	}
-	return nil
-}
-
-func validateUpdateInput(input postdomain.UpdatePostInput) error {
-	if strings.TrimSpace(input.Slug) == "" {
-		return errSlugRequired
-	}
-	if strings.TrimSpace(input.Title) == "" {
-		return errTitleRequired
-	}
-	return nil
-}
-
 func normalizeCreateInput(input postdomain.CreatePostInput) postdomain.CreatePostInput {
 	input.Title = strings.TrimSpace(input.Title)
 	input.Slug = strings.TrimSpace(input.Slug)
 	input.Status = strings.TrimSpace(input.Status)
+	if input.Status == "" {
+		input.Status = postdomain.StatusDraft
+	}
+	input.ExpiryMode = strings.TrimSpace(input.ExpiryMode)
+	if input.ExpiryMode == "" {
+		input.ExpiryMode = postdomain.ExpiryGone
+	}
 	input.Summary = strings.TrimSpace(input.Summary)
 	if input.CoverURL != nil {
 		trimmed := strings.TrimSpace(*input.CoverURL)
@@ -211,6 +601,7 @@ func normalizeUpdateInput(input postdomain.UpdatePostInput) postdomain.UpdatePos
 	input.Title = strings.TrimSpace(input.Title)
 	input.Summary = strings.TrimSpace(input.Summary)
 	input.Status = strings.TrimSpace(input.Status)
+	input.ExpiryMode = strings.TrimSpace(input.ExpiryMode)
 	if input.CoverURL != nil {
 		trimmed := strings.TrimSpace(*input.CoverURL)
 		if trimmed == "" {
@@ -222,3 +613,19 @@ func normalizeUpdateInput(input postdomain.UpdatePostInput) postdomain.UpdatePos
 	return input
 }
 
+func normalizePatchInput(input postdomain.PatchPostInput) postdomain.PatchPostInput {
+	input.Slug = strings.TrimSpace(input.Slug)
+	input.Title.Value = strings.TrimSpace(input.Title.Value)
+	input.Summary.Value = strings.TrimSpace(input.Summary.Value)
+	input.Status.Value = strings.TrimSpace(input.Status.Value)
+	input.ExpiryMode.Value = strings.TrimSpace(input.ExpiryMode.Value)
+	if input.CoverURL.Value != nil {
+		trimmed := strings.TrimSpace(*input.CoverURL.Value)
+		if trimmed == "" {
+			input.CoverURL.Value = nil
+		} else {
+			input.CoverURL.Value = &trimmed
+		}
+	}
+	return input
+}
@@ -2,16 +2,19 @@
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/validation"
 )
 
 func TestServiceListPublished_Defaults(t *testing.T) {
 	repo := &fakePostRepo{}
-	repo.listPublishedPostsSortedFn = func(ctx context.Context, sort string, limit, offset int32) ([]postdomain.Post, error) {
+	repo.listPublishedPostsSortedFn = func(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 		if sort != "" {
 			t.Fatalf("expected empty sort passthrough, got %q", sort)
 		}
@@ -24,7 +27,7 @@ func TestServiceListPublished_Defaults(t *testing.T) {
 		return []postdomain.Post{{Slug: "hello"}}, nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	result, err := svc.ListPublished(context.Background(), postdomain.ListPostsOptions{})
 	if err != nil {
 		t.Fatalf("ListPublished returned error: %v", err)
@@ -36,7 +39,7 @@ func TestServiceListPublished_Defaults(t *testing.T) {
 
 func TestServiceListPublished_Category(t *testing.T) {
 	repo := &fakePostRepo{}
-	repo.listPublishedPostsByCategorySortedFn = func(ctx context.Context, categorySlug, sort string, limit, offset int32) ([]postdomain.Post, error) {
+	repo.listPublishedPostsByCategorySortedFn = func(ctx context.Context, categorySlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 		if categorySlug != "news" {
 			t.Fatalf("expected category 'news', got %q", categorySlug)
 		}
@@ -45,12 +48,12 @@ func TestServiceListPublished_Category(t *testing.T) {
 		}
 		return []postdomain.Post{{Slug: "filtered"}}, nil
 	}
-	repo.listPublishedPostsSortedFn = func(context.Context, string, int32, int32) ([]postdomain.Post, error) {
+	repo.listPublishedPostsSortedFn = func(context.Context, string, map[string]any, int32, int32) ([]postdomain.Post, error) {
 		t.Fatalf("should not call default list when category provided")
 		return nil, nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	posts, err := svc.ListPublished(context.Background(), postdomain.ListPostsOptions{Category: "news", Limit: 100})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -62,14 +65,14 @@ func TestServiceListPublished_Category(t *testing.T) {
 
 func TestServiceListPublished_InvalidSortFallsBack(t *testing.T) {
 	repo := &fakePostRepo{}
-	repo.listPublishedPostsSortedFn = func(ctx context.Context, sort string, limit, offset int32) ([]postdomain.Post, error) {
+	repo.listPublishedPostsSortedFn = func(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 		if sort != "created_at_desc" {
 			t.Fatalf("expected fallback sort 'created_at_desc', got %q", sort)
 		}
 		return nil, nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	if _, err := svc.ListPublished(context.Background(), postdomain.ListPostsOptions{Sort: "unknown"}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -83,22 +86,25 @@ func TestServiceGetBySlugAggregates(t *testing.T) {
 	repo.listCategoriesByPostSlugFn = func(ctx context.Context, slug string) ([]taxdomain.Category, error) {
 		return []taxdomain.Category{{Slug: "golang"}}, nil
 	}
+	repo.listCategoryTrailByPostSlugFn = func(ctx context.Context, slug string) ([]taxdomain.Category, error) {
+		return []taxdomain.Category{{Slug: "backend"}, {Slug: "golang"}}, nil
+	}
 	repo.listTagsByPostSlugFn = func(ctx context.Context, slug string) ([]taxdomain.Tag, error) {
 		return []taxdomain.Tag{{Slug: "arch"}}, nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	result, err := svc.GetBySlug(context.Background(), "welcome")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if result.Post.Slug != "welcome" || len(result.Categories) != 1 || len(result.Tags) != 1 {
+	if result.Post.Slug != "welcome" || len(result.Categories) != 1 || len(result.Breadcrumbs) != 2 || len(result.Tags) != 1 {
 		t.Fatalf("unexpected aggregate: %+v", result)
 	}
 }
 
 func TestServiceGetBySlugValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
 	if _, err := svc.GetBySlug(context.Background(), "   "); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
@@ -113,12 +119,18 @@ func TestServiceCreateNormalizesCover(t *testing.T) {
 		if input.Title != "Title" || input.Slug != "slug" {
 			t.Fatalf("unexpected input: %+v", input)
 		}
+		if input.Status != postdomain.StatusDraft {
+			t.Fatalf("expected empty status to default to draft, got %q", input.Status)
+		}
+		if input.ExpiryMode != postdomain.ExpiryGone {
+			t.Fatalf("expected empty expiry mode to default to gone, got %q", input.ExpiryMode)
+		}
 		return postdomain.Post{ID: 1, Slug: input.Slug, Title: input.Title}, nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	cover := "   "
-	post, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Title", Slug: "slug", CoverURL: &cover})
+	post, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Title", Slug: "slug", CoverURL: &cover, AuthorID: 1})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -128,15 +140,187 @@ func TestServiceCreateNormalizesCover(t *testing.T) {
 }
 
 func TestServiceCreateValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
 	if _, err := svc.Create(context.Background(), postdomain.CreatePostInput{Slug: "slug"}); !errors.Is(err, errTitleRequired) {
 		t.Fatalf("expected errTitleRequired, got %v", err)
 	}
-	if _, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Title"}); !errors.Is(err, errSlugRequired) {
+	if _, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "🚀"}); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
 }
 
+func TestServiceCreateGeneratesUniqueSlug(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return slug == "hello-world", nil
+	}
+	repo.createPostFn = func(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error) {
+		return postdomain.Post{Slug: input.Slug}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	post, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Hello, World!", AuthorID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Slug != "hello-world-2" {
+		t.Fatalf("expected suffixed slug, got %q", post.Slug)
+	}
+}
+
+func TestServiceCreateRejectsTakenSlug(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.postSlugExistsFn = func(ctx context.Context, slug string) (bool, error) {
+		return true, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	_, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Title", Slug: "taken", AuthorID: 1})
+	if !errors.Is(err, postdomain.ErrSlugTaken) {
+		t.Fatalf("expected ErrSlugTaken, got %v", err)
+	}
+}
+
+func TestServiceCreateCollectsFieldErrors(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.authorExistsFn = func(ctx context.Context, id int64) (bool, error) {
+		return false, nil
+	}
+	repo.createPostFn = func(context.Context, postdomain.CreatePostInput) (postdomain.Post, error) {
+		t.Fatal("invalid input should not reach CreatePost")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	cover := "javascript:alert(1)"
+	_, err := svc.Create(context.Background(), postdomain.CreatePostInput{
+		Title:    "Title",
+		Slug:     "Hello World/2",
+		Status:   "live",
+		CoverURL: &cover,
+		AuthorID: 42,
+	})
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, f := range verr.Fields {
+		fields[f.Field] = true
+	}
+	for _, want := range []string{"slug", "status", "cover_url", "author_id"} {
+		if !fields[want] {
+			t.Fatalf("expected %s to be reported, got %+v", want, verr.Fields)
+		}
+	}
+	if !errors.Is(err, postdomain.ErrAuthorNotFound) || !errors.Is(err, postdomain.ErrSlugInvalid) {
+		t.Fatalf("expected wrapped sentinels, got %v", err)
+	}
+}
+
+func TestServiceUpdateRejectsStatusForbiddenForRole(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		if id != 7 {
+			t.Fatalf("expected role lookup for the actor, got %d", id)
+		}
+		return postdomain.RoleWriter, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusDraft}, nil
+	}
+	repo.updatePostBySlugFn = func(context.Context, postdomain.UpdatePostInput) (postdomain.Post, error) {
+		t.Fatal("forbidden status change should not reach UpdatePostBySlug")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	_, err := svc.Update(context.Background(), postdomain.UpdatePostInput{
+		ActorID: 7,
+		Slug:    "slug",
+		Title:   "Title",
+		Status:  postdomain.StatusPublished,
+	})
+	if !errors.Is(err, postdomain.ErrStatusForbidden) {
+		t.Fatalf("expected ErrStatusForbidden, got %v", err)
+	}
+}
+
+func TestServiceUpdateRejectsWriterEditingPublishedPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return postdomain.RoleWriter, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	repo.updatePostBySlugFn = func(context.Context, postdomain.UpdatePostInput) (postdomain.Post, error) {
+		t.Fatal("writer edit of a published post should not reach UpdatePostBySlug")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	_, err := svc.Update(context.Background(), postdomain.UpdatePostInput{
+		ActorID:   7,
+		Slug:      "slug",
+		Title:     "Rewritten",
+		ContentMD: "New body",
+	})
+	if !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("expected ErrWorkflowForbidden, got %v", err)
+	}
+}
+
+func TestServicePatchRejectsWriterEditingPublishedPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return postdomain.RoleWriter, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	repo.patchPostBySlugFn = func(context.Context, postdomain.PatchPostInput) (postdomain.Post, error) {
+		t.Fatal("writer edit of a published post should not reach PatchPostBySlug")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	_, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		ActorID: 7,
+		Slug:    "slug",
+		Title:   postdomain.PatchField[string]{Set: true, Value: "Rewritten"},
+	})
+	if !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("expected ErrWorkflowForbidden, got %v", err)
+	}
+}
+
+func TestServicePatchAllowsEditorEditingPublishedPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return postdomain.RoleEditor, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		return postdomain.Post{Slug: input.Slug, Title: input.Title.Value, Status: postdomain.StatusPublished}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	post, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		ActorID: 7,
+		Slug:    "slug",
+		Title:   postdomain.PatchField[string]{Set: true, Value: "Fixed typo"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Title != "Fixed typo" {
+		t.Fatalf("unexpected post: %+v", post)
+	}
+}
+
 func TestServiceUpdateNormalizes(t *testing.T) {
 	repo := &fakePostRepo{}
 	repo.updatePostBySlugFn = func(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error) {
@@ -146,10 +330,16 @@ func TestServiceUpdateNormalizes(t *testing.T) {
 		if input.Slug != "slug" || input.Title != "Updated" {
 			t.Fatalf("unexpected update input: %+v", input)
 		}
+		if input.Status != postdomain.StatusPublished {
+			t.Fatalf("expected empty status to keep the stored one, got %q", input.Status)
+		}
 		return postdomain.Post{Slug: input.Slug, Title: input.Title}, nil
 	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	cover := ""
 	post, err := svc.Update(context.Background(), postdomain.UpdatePostInput{Slug: " slug ", Title: " Updated ", CoverURL: &cover})
 	if err != nil {
@@ -161,7 +351,7 @@ func TestServiceUpdateNormalizes(t *testing.T) {
 }
 
 func TestServiceUpdateValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
 	if _, err := svc.Update(context.Background(), postdomain.UpdatePostInput{Slug: ""}); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
@@ -170,8 +360,110 @@ func TestServiceUpdateValidates(t *testing.T) {
 	}
 }
 
+func TestServiceUpdateRejectsStatusTransition(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusArchived}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	_, err := svc.Update(context.Background(), postdomain.UpdatePostInput{
+		Slug:   "slug",
+		Title:  "Title",
+		Status: postdomain.StatusPublished,
+	})
+	if !errors.Is(err, postdomain.ErrStatusTransition) {
+		t.Fatalf("expected ErrStatusTransition, got %v", err)
+	}
+}
+
+func TestServicePatchPassesOnlySetFields(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+		if input.Slug != "slug" {
+			t.Fatalf("expected trimmed slug, got %q", input.Slug)
+		}
+		if !input.Status.Set || input.Status.Value != "published" {
+			t.Fatalf("expected status to be set, got %+v", input.Status)
+		}
+		if input.Title.Set || input.ContentMD.Set || input.Summary.Set {
+			t.Fatalf("unexpected fields marked as set: %+v", input)
+		}
+		if !input.CoverURL.Set || input.CoverURL.Value != nil {
+			t.Fatalf("expected blank cover to clear the column, got %+v", input.CoverURL)
+		}
+		return postdomain.Post{Slug: input.Slug, Status: input.Status.Value}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	blank := "  "
+	post, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		Slug:     " slug ",
+		Status:   postdomain.PatchField[string]{Set: true, Value: " published "},
+		CoverURL: postdomain.PatchField[*string]{Set: true, Value: &blank},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Status != "published" {
+		t.Fatalf("unexpected post: %+v", post)
+	}
+}
+
+func TestServicePatchWithoutChangesReturnsCurrent(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Title: "Current"}, nil
+	}
+	repo.patchPostBySlugFn = func(context.Context, postdomain.PatchPostInput) (postdomain.Post, error) {
+		t.Fatal("empty patch should not reach PatchPostBySlug")
+		return postdomain.Post{}, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	post, err := svc.Patch(context.Background(), postdomain.PatchPostInput{Slug: "slug"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Title != "Current" {
+		t.Fatalf("expected current post, got %+v", post)
+	}
+}
+
+func TestServicePatchValidates(t *testing.T) {
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
+	if _, err := svc.Patch(context.Background(), postdomain.PatchPostInput{Slug: " "}); !errors.Is(err, errSlugRequired) {
+		t.Fatalf("expected errSlugRequired, got %v", err)
+	}
+	if _, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		Slug:  "slug",
+		Title: postdomain.PatchField[string]{Set: true, Value: "  "},
+	}); !errors.Is(err, errTitleRequired) {
+		t.Fatalf("expected errTitleRequired, got %v", err)
+	}
+	if _, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		Slug:     "slug",
+		AuthorID: postdomain.PatchField[int64]{Set: true, Value: 0},
+	}); !errors.Is(err, errAuthorRequired) {
+		t.Fatalf("expected errAuthorRequired, got %v", err)
+	}
+}
+
+func TestServiceRejectsUnknownExpiryMode(t *testing.T) {
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
+	if _, err := svc.Create(context.Background(), postdomain.CreatePostInput{Title: "Title", Slug: "slug", AuthorID: 1, ExpiryMode: "hide"}); !errors.Is(err, postdomain.ErrExpiryModeInvalid) {
+		t.Fatalf("expected ErrExpiryModeInvalid, got %v", err)
+	}
+	if _, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+		Slug:       "slug",
+		ExpiryMode: postdomain.PatchField[string]{Set: true, Value: " "},
+	}); !errors.Is(err, postdomain.ErrExpiryModeInvalid) {
+		t.Fatalf("expected ErrExpiryModeInvalid, got %v", err)
+	}
+}
+
 func TestServiceDeleteValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
 	if err := svc.Delete(context.Background(), " "); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
@@ -188,7 +480,7 @@ func TestServiceDeleteCallsRepo(t *testing.T) {
 		return nil
 	}
 
-	svc := NewService(repo)
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
 	if err := svc.Delete(context.Background(), "slug"); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -197,6 +489,143 @@ func TestServiceDeleteCallsRepo(t *testing.T) {
 	}
 }
 
+func TestServiceRecordsPostEvents(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     string
+	}{
+		{postdomain.StatusDraft, postdomain.StatusPublished, postdomain.EventPostPublished},
+		{postdomain.StatusPublished, postdomain.StatusPublished, postdomain.EventPostUpdated},
+		{postdomain.StatusPublished, postdomain.StatusArchived, postdomain.EventPostUpdated},
+		{postdomain.StatusDraft, postdomain.StatusInReview, postdomain.EventPostUpdated},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+"->"+tc.to, func(t *testing.T) {
+			repo := &fakePostRepo{}
+			repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+				return postdomain.Post{Slug: slug, Title: "Old", Status: tc.from}, nil
+			}
+			repo.patchPostBySlugFn = func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+				return postdomain.Post{Slug: input.Slug, Title: "New", Status: tc.to}, nil
+			}
+			events := &fakeOutbox{}
+			svc := NewService(repo, &fakeFieldRepo{}, events)
+
+			_, err := svc.Patch(context.Background(), postdomain.PatchPostInput{
+				Slug:   "slug",
+				Title:  postdomain.PatchField[string]{Set: true, Value: "New"},
+				Status: postdomain.PatchField[string]{Set: true, Value: tc.to},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := events.decode(t)
+			if len(got) != 1 || got[0].typ != tc.want || got[0].Post.Title != "New" || got[0].PreviousStatus != tc.from {
+				t.Fatalf("expected one %s event with the saved post, got %+v", tc.want, got)
+			}
+			if events.messages[0].Key != "slug" {
+				t.Fatalf("expected the slug as key, got %q", events.messages[0].Key)
+			}
+		})
+	}
+}
+
+func TestServiceCreateRecordsEvent(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.createPostFn = func(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error) {
+		return postdomain.Post{Slug: input.Slug, Status: input.Status}, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	for _, status := range []string{postdomain.StatusDraft, postdomain.StatusPublished} {
+		_, err := svc.Create(context.Background(), postdomain.CreatePostInput{
+			Title: "Title", Slug: "slug-" + status, AuthorID: 1, Status: status,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	got := events.decode(t)
+	if len(got) != 2 || got[0].typ != postdomain.EventPostUpdated || got[1].typ != postdomain.EventPostPublished || got[1].PreviousStatus != "" {
+		t.Fatalf("expected an update for the draft and a publish, got %+v", got)
+	}
+}
+
+func TestServiceDeleteRecordsDeletedPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		if slug == "gone" {
+			return postdomain.Post{}, postdomain.ErrPostNotFound
+		}
+		return postdomain.Post{ID: 3, Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	if err := svc.Delete(context.Background(), "live"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.Delete(context.Background(), "gone"); !errors.Is(err, postdomain.ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+	got := events.decode(t)
+	if len(got) != 1 || got[0].typ != postdomain.EventPostDeleted || got[0].Post.ID != 3 || got[0].PreviousStatus != postdomain.StatusPublished {
+		t.Fatalf("expected one delete event carrying the post, got %+v", got)
+	}
+}
+
+func TestServiceWriteFailsWhenEventCannotBeRecorded(t *testing.T) {
+	repo := &fakePostRepo{}
+	events := &fakeOutbox{err: errors.New("outbox down")}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	if err := svc.Delete(context.Background(), "live"); err == nil {
+		t.Fatal("expected the delete to fail with its event")
+	}
+	if events.rollbacks != 1 {
+		t.Fatalf("expected the delete to roll back, got %d rollbacks", events.rollbacks)
+	}
+}
+
+func TestServiceArchiveExpiredRecordsUpdates(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.archiveExpiredPostsFn = func(context.Context) ([]string, error) {
+		return []string{"a", "b"}, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusArchived}, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	slugs, err := svc.ArchiveExpired(context.Background())
+	if err != nil || len(slugs) != 2 {
+		t.Fatalf("unexpected result %v, %v", slugs, err)
+	}
+	got := events.decode(t)
+	if len(got) != 2 || got[1].typ != postdomain.EventPostUpdated || got[1].Post.Slug != "b" || got[1].PreviousStatus != postdomain.StatusPublished {
+		t.Fatalf("expected an update per archived post, got %+v", got)
+	}
+}
+
+func TestServiceRelationChangeRecordsUpdate(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	if err := svc.AddTag(context.Background(), "slug", 1, "go"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := events.decode(t)
+	if len(got) != 1 || got[0].typ != postdomain.EventPostUpdated || got[0].PreviousStatus != postdomain.StatusPublished {
+		t.Fatalf("expected an update of the tagged post, got %+v", got)
+	}
+}
+
 func TestServiceAddRemoveCategory(t *testing.T) {
 	addCalled := false
 	removeCalled := false
@@ -216,11 +645,11 @@ func TestServiceAddRemoveCategory(t *testing.T) {
 		return nil
 	}
 
-	svc := NewService(repo)
-	if err := svc.AddCategory(context.Background(), "slug", "cat"); err != nil {
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	if err := svc.AddCategory(context.Background(), "slug", 1, "cat"); err != nil {
 		t.Fatalf("AddCategory error: %v", err)
 	}
-	if err := svc.RemoveCategory(context.Background(), "slug", "cat"); err != nil {
+	if err := svc.RemoveCategory(context.Background(), "slug", 1, "cat"); err != nil {
 		t.Fatalf("RemoveCategory error: %v", err)
 	}
 	if !addCalled || !removeCalled {
@@ -247,11 +676,11 @@ func TestServiceAddRemoveTag(t *testing.T) {
 		return nil
 	}
 
-	svc := NewService(repo)
-	if err := svc.AddTag(context.Background(), "slug", "tag"); err != nil {
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	if err := svc.AddTag(context.Background(), "slug", 1, "tag"); err != nil {
 		t.Fatalf("AddTag error: %v", err)
 	}
-	if err := svc.RemoveTag(context.Background(), "slug", "tag"); err != nil {
+	if err := svc.RemoveTag(context.Background(), "slug", 1, "tag"); err != nil {
 		t.Fatalf("RemoveTag error: %v", err)
 	}
 	if !addCalled || !removeCalled {
@@ -260,39 +689,209 @@ func TestServiceAddRemoveTag(t *testing.T) {
 }
 
 func TestServiceAddCategoryValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
-	if err := svc.AddCategory(context.Background(), "", "cat"); !errors.Is(err, errSlugRequired) {
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
+	if err := svc.AddCategory(context.Background(), "", 1, "cat"); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
-	if err := svc.AddCategory(context.Background(), "slug", " "); err == nil {
+	if err := svc.AddCategory(context.Background(), "slug", 1, " "); err == nil {
 		t.Fatal("expected error for empty category slug")
 	}
 }
 
 func TestServiceAddTagValidates(t *testing.T) {
-	svc := NewService(&fakePostRepo{})
-	if err := svc.AddTag(context.Background(), "", "tag"); !errors.Is(err, errSlugRequired) {
+	svc := NewService(&fakePostRepo{}, &fakeFieldRepo{}, nil)
+	if err := svc.AddTag(context.Background(), "", 1, "tag"); !errors.Is(err, errSlugRequired) {
 		t.Fatalf("expected errSlugRequired, got %v", err)
 	}
-	if err := svc.AddTag(context.Background(), "slug", " "); err == nil {
+	if err := svc.AddTag(context.Background(), "slug", 1, " "); err == nil {
 		t.Fatal("expected error for empty tag slug")
 	}
 }
 
+func TestServiceRelationChangesRejectWriterOnPublishedPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.userRoleFn = func(ctx context.Context, id int64) (string, error) {
+		return postdomain.RoleWriter, nil
+	}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	repo.addTagToPostFn = func(context.Context, string, string) error {
+		t.Fatal("writer retag of a published post should not reach AddTagToPost")
+		return nil
+	}
+	repo.removeCategoryFromPostFn = func(context.Context, string, string) error {
+		t.Fatal("writer change of a published post should not reach RemoveCategoryFromPost")
+		return nil
+	}
+	repo.replacePostRelationsFn = func(context.Context, string, postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+		t.Fatal("writer retag of a published post should not reach ReplacePostRelations")
+		return nil, nil
+	}
+
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+	if err := svc.AddTag(context.Background(), "slug", 7, "go"); !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("AddTag: expected ErrWorkflowForbidden, got %v", err)
+	}
+	if err := svc.RemoveCategory(context.Background(), "slug", 7, "news"); !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("RemoveCategory: expected ErrWorkflowForbidden, got %v", err)
+	}
+	if _, err := svc.ReplaceRelations(context.Background(), "slug", 7, postdomain.RelationSet{Tags: []string{"go"}}); !errors.Is(err, postdomain.ErrWorkflowForbidden) {
+		t.Fatalf("ReplaceRelations: expected ErrWorkflowForbidden, got %v", err)
+	}
+}
+
+func TestServiceReplaceRelationsRejectsUnknownEntries(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.existingCategorySlugsFn = func(ctx context.Context, slugs []string) ([]string, error) {
+		return []string{"news"}, nil
+	}
+	repo.matchTagsFn = func(ctx context.Context, entries, keys []string) (map[string]string, error) {
+		return map[string]string{"go": "go"}, nil
+	}
+	repo.replacePostRelationsFn = func(context.Context, string, postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+		t.Fatal("expected no write for an invalid set")
+		return nil, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	_, err := svc.ReplaceRelations(context.Background(), "slug", 1, postdomain.RelationSet{
+		Categories: []string{"news", "nope"},
+		Tags:       []string{"go", "Rust"},
+	})
+	var verr *validation.Error
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 {
+		t.Fatalf("expected errors for categories and tags, got %v", err)
+	}
+	if !errors.Is(err, postdomain.ErrCategoryUnknown) || verr.Fields[0].Message != "unknown categories: nope" || verr.Fields[1].Message != "unknown tags: Rust" {
+		t.Fatalf("expected the unmatched entries to be named, got %+v", verr.Fields)
+	}
+	if len(events.messages) != 0 {
+		t.Fatalf("expected no events, got %d", len(events.messages))
+	}
+}
+
+func TestServiceReplaceRelationsCreatesMissingTags(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.getPostBySlugFn = func(ctx context.Context, slug string) (postdomain.Post, error) {
+		return postdomain.Post{Slug: slug, Status: postdomain.StatusPublished}, nil
+	}
+	repo.matchTagsFn = func(ctx context.Context, entries, keys []string) (map[string]string, error) {
+		return map[string]string{"golang": "go", "Go": "go"}, nil
+	}
+	var created, stored []string
+	repo.replacePostRelationsFn = func(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+		var tags []taxdomain.Tag
+		for _, record := range write.CreateTags {
+			created = append(created, record.Name+"="+record.Slug)
+			tags = append(tags, taxdomain.Tag{Name: record.Name, Slug: record.Slug})
+		}
+		stored = write.Tags
+		return tags, nil
+	}
+	repo.listTagsByPostSlugFn = func(ctx context.Context, slug string) ([]taxdomain.Tag, error) {
+		return []taxdomain.Tag{{Slug: "go"}, {Slug: "machine-learning"}}, nil
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	got, err := svc.ReplaceRelations(context.Background(), " slug ", 1, postdomain.RelationSet{
+		Tags:              []string{"golang", "Go", " ", "Machine Learning", "machine learning"},
+		CreateMissingTags: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 1 || created[0] != "Machine Learning=machine-learning" {
+		t.Fatalf("expected one new tag, got %v", created)
+	}
+	if len(stored) != 2 || stored[0] != "go" || stored[1] != "machine-learning" {
+		t.Fatalf("expected the resolved slugs once each, got %v", stored)
+	}
+	if len(got.Tags) != 2 {
+		t.Fatalf("expected the resulting tags, got %+v", got)
+	}
+	if len(events.messages) != 2 || events.messages[0].Type != taxdomain.EventTaxonomyChanged || events.messages[1].Type != postdomain.EventPostUpdated {
+		t.Fatalf("expected a tag event and a post update, got %+v", events.messages)
+	}
+}
+
+func TestServiceReplaceRelationsKeepsUnsetRelation(t *testing.T) {
+	repo := &fakePostRepo{}
+	var stored []string
+	repo.replacePostRelationsFn = func(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+		if write.Tags != nil {
+			t.Fatal("expected the tags to be left alone")
+		}
+		stored = write.Categories
+		return nil, nil
+	}
+	svc := NewService(repo, &fakeFieldRepo{}, nil)
+
+	if _, err := svc.ReplaceRelations(context.Background(), "slug", 1, postdomain.RelationSet{Categories: []string{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored == nil || len(stored) != 0 {
+		t.Fatalf("expected an empty set to clear the categories, got %v", stored)
+	}
+	if _, err := svc.ReplaceRelations(context.Background(), "", 1, postdomain.RelationSet{}); !errors.Is(err, errSlugRequired) {
+		t.Fatalf("expected errSlugRequired, got %v", err)
+	}
+}
+
+func TestServiceCreateStoresRelationsWithPost(t *testing.T) {
+	repo := &fakePostRepo{}
+	repo.createPostFn = func(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error) {
+		return postdomain.Post{Slug: input.Slug, Status: input.Status}, nil
+	}
+	var relationsOf string
+	var written postdomain.RelationWrite
+	repo.replacePostRelationsFn = func(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+		relationsOf, written = slug, write
+		return nil, errors.New("tags failed")
+	}
+	events := &fakeOutbox{}
+	svc := NewService(repo, &fakeFieldRepo{}, events)
+
+	_, err := svc.Create(context.Background(), postdomain.CreatePostInput{
+		Title: "Title", Slug: "new-post", AuthorID: 1,
+		Relations: postdomain.RelationSet{Categories: []string{"news"}, Tags: []string{"go"}},
+	})
+	if err == nil {
+		t.Fatal("expected the failed tagging to fail the create")
+	}
+	if relationsOf != "new-post" || len(written.Categories) != 1 || len(written.Tags) != 1 {
+		t.Fatalf("expected relations stored on the new post, got %q with %+v", relationsOf, written)
+	}
+	if events.rollbacks != 1 || len(events.messages) != 0 {
+		t.Fatalf("expected the create to roll back, got %d rollbacks and %d messages", events.rollbacks, len(events.messages))
+	}
+}
+
 type fakePostRepo struct {
 	listPublishedPostsFn                 func(ctx context.Context, limit, offset int32) ([]postdomain.Post, error)
-	listPublishedPostsSortedFn           func(ctx context.Context, sort string, limit, offset int32) ([]postdomain.Post, error)
-	listPublishedPostsByCategorySortedFn func(ctx context.Context, categorySlug, sort string, limit, offset int32) ([]postdomain.Post, error)
-	listPublishedPostsByTagSortedFn      func(ctx context.Context, tagSlug, sort string, limit, offset int32) ([]postdomain.Post, error)
+	listPublishedPostsSortedFn           func(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error)
+	listPublishedPostsByCategorySortedFn func(ctx context.Context, categorySlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error)
+	listPublishedPostsByTagSortedFn      func(ctx context.Context, tagSlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error)
 	getPostBySlugFn                      func(ctx context.Context, slug string) (postdomain.Post, error)
 	createPostFn                         func(ctx context.Context, input postdomain.CreatePostInput) (postdomain.Post, error)
 	updatePostBySlugFn                   func(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error)
+	patchPostBySlugFn                    func(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error)
 	deletePostBySlugFn                   func(ctx context.Context, slug string) error
+	archiveExpiredPostsFn                func(ctx context.Context) ([]string, error)
+	postSlugExistsFn                     func(ctx context.Context, slug string) (bool, error)
+	authorExistsFn                       func(ctx context.Context, id int64) (bool, error)
+	userRoleFn                           func(ctx context.Context, id int64) (string, error)
 	addCategoryToPostFn                  func(ctx context.Context, slug, categorySlug string) error
 	removeCategoryFromPostFn             func(ctx context.Context, slug, categorySlug string) error
 	addTagToPostFn                       func(ctx context.Context, slug, tagSlug string) error
 	removeTagFromPostFn                  func(ctx context.Context, slug, tagSlug string) error
+	replacePostRelationsFn               func(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error)
+	existingCategorySlugsFn              func(ctx context.Context, slugs []string) ([]string, error)
+	matchTagsFn                          func(ctx context.Context, entries, keys []string) (map[string]string, error)
 	listCategoriesByPostSlugFn           func(ctx context.Context, slug string) ([]taxdomain.Category, error)
+	listCategoryTrailByPostSlugFn        func(ctx context.Context, slug string) ([]taxdomain.Category, error)
 	listTagsByPostSlugFn                 func(ctx context.Context, slug string) ([]taxdomain.Tag, error)
 }
 
@@ -303,23 +902,23 @@ func (f *fakePostRepo) ListPublishedPosts(ctx context.Context, limit, offset int
 	return nil, nil
 }
 
-func (f *fakePostRepo) ListPublishedPostsSorted(ctx context.Context, sort string, limit, offset int32) ([]postdomain.Post, error) {
+func (f *fakePostRepo) ListPublishedPostsSorted(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 	if f.listPublishedPostsSortedFn != nil {
-		return f.listPublishedPostsSortedFn(ctx, sort, limit, offset)
+		return f.listPublishedPostsSortedFn(ctx, sort, fields, limit, offset)
 	}
 	return nil, nil
 }
 
-func (f *fakePostRepo) ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, limit, offset int32) ([]postdomain.Post, error) {
+func (f *fakePostRepo) ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 	if f.listPublishedPostsByCategorySortedFn != nil {
-		return f.listPublishedPostsByCategorySortedFn(ctx, categorySlug, sort, limit, offset)
+		return f.listPublishedPostsByCategorySortedFn(ctx, categorySlug, sort, fields, limit, offset)
 	}
 	return nil, nil
 }
 
-func (f *fakePostRepo) ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, limit, offset int32) ([]postdomain.Post, error) {
+func (f *fakePostRepo) ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
 	if f.listPublishedPostsByTagSortedFn != nil {
-		return f.listPublishedPostsByTagSortedFn(ctx, tagSlug, sort, limit, offset)
+		return f.listPublishedPostsByTagSortedFn(ctx, tagSlug, sort, fields, limit, offset)
 	}
 	return nil, nil
 }
@@ -345,6 +944,13 @@ func (f *fakePostRepo) UpdatePostBySlug(ctx context.Context, input postdomain.Up
 	return postdomain.Post{}, nil
 }
 
+func (f *fakePostRepo) PatchPostBySlug(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+	if f.patchPostBySlugFn != nil {
+		return f.patchPostBySlugFn(ctx, input)
+	}
+	return postdomain.Post{}, nil
+}
+
 func (f *fakePostRepo) DeletePostBySlug(ctx context.Context, slug string) error {
 	if f.deletePostBySlugFn != nil {
 		return f.deletePostBySlugFn(ctx, slug)
@@ -352,6 +958,36 @@ func (f *fakePostRepo) DeletePostBySlug(ctx context.Context, slug string) error
 	return nil
 }
 
+func (f *fakePostRepo) ArchiveExpiredPosts(ctx context.Context) ([]string, error) {
+	if f.archiveExpiredPostsFn != nil {
+		return f.archiveExpiredPostsFn(ctx)
+	}
+	return nil, nil
+}
+
+func (f *fakePostRepo) PostSlugExists(ctx context.Context, slug string) (bool, error) {
+	if f.postSlugExistsFn != nil {
+		return f.postSlugExistsFn(ctx, slug)
+	}
+	return false, nil
+}
+
+// AuthorExists defaults to true so tests only stub it when exercising unknown authors.
+func (f *fakePostRepo) AuthorExists(ctx context.Context, id int64) (bool, error) {
+	if f.authorExistsFn != nil {
+		return f.authorExistsFn(ctx, id)
+	}
+	return true, nil
+}
+
+// UserRole defaults to admin so tests only stub it when exercising the editorial workflow.
+func (f *fakePostRepo) UserRole(ctx context.Context, id int64) (string, error) {
+	if f.userRoleFn != nil {
+		return f.userRoleFn(ctx, id)
+	}
+	return postdomain.RoleAdmin, nil
+}
+
 func (f *fakePostRepo) AddCategoryToPost(ctx context.Context, slug, categorySlug string) error {
 	if f.addCategoryToPostFn != nil {
 		return f.addCategoryToPostFn(ctx, slug, categorySlug)
@@ -380,6 +1016,38 @@ func (f *fakePostRepo) RemoveTagFromPost(ctx context.Context, slug, tagSlug stri
 	return nil
 }
 
+// ReplacePostRelations defaults to creating every planned tag.
+func (f *fakePostRepo) ReplacePostRelations(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+	if f.replacePostRelationsFn != nil {
+		return f.replacePostRelationsFn(ctx, slug, write)
+	}
+	created := make([]taxdomain.Tag, 0, len(write.CreateTags))
+	for _, record := range write.CreateTags {
+		created = append(created, taxdomain.Tag{Name: record.Name, Slug: record.Slug})
+	}
+	return created, nil
+}
+
+// ExistingCategorySlugs defaults to every slug existing.
+func (f *fakePostRepo) ExistingCategorySlugs(ctx context.Context, slugs []string) ([]string, error) {
+	if f.existingCategorySlugsFn != nil {
+		return f.existingCategorySlugsFn(ctx, slugs)
+	}
+	return slugs, nil
+}
+
+// MatchTags defaults to every entry naming the tag with its slug form.
+func (f *fakePostRepo) MatchTags(ctx context.Context, entries, keys []string) (map[string]string, error) {
+	if f.matchTagsFn != nil {
+		return f.matchTagsFn(ctx, entries, keys)
+	}
+	out := make(map[string]string, len(entries))
+	for i, e := range entries {
+		out[e] = keys[i]
+	}
+	return out, nil
+}
+
 func (f *fakePostRepo) ListCategoriesByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error) {
 	if f.listCategoriesByPostSlugFn != nil {
 		return f.listCategoriesByPostSlugFn(ctx, slug)
@@ -387,6 +1055,13 @@ func (f *fakePostRepo) ListCategoriesByPostSlug(ctx context.Context, slug string
 	return nil, nil
 }
 
+func (f *fakePostRepo) ListCategoryTrailByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error) {
+	if f.listCategoryTrailByPostSlugFn != nil {
+		return f.listCategoryTrailByPostSlugFn(ctx, slug)
+	}
+	return nil, nil
+}
+
 func (f *fakePostRepo) ListTagsByPostSlug(ctx context.Context, slug string) ([]taxdomain.Tag, error) {
 	if f.listTagsByPostSlugFn != nil {
 		return f.listTagsByPostSlugFn(ctx, slug)
@@ -394,3 +1069,45 @@ func (f *fakePostRepo) ListTagsByPostSlug(ctx context.Context, slug string) ([]t
 	return nil, nil
 }
 
+// fakeOutbox collects appended messages; WithinTx drops those of a failed fn, like a
+// rollback would.
+type fakeOutbox struct {
+	messages  []outbox.Message
+	rollbacks int
+	err       error
+}
+
+func (f *fakeOutbox) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	n := len(f.messages)
+	if err := fn(ctx); err != nil {
+		f.messages = f.messages[:n]
+		f.rollbacks++
+		return err
+	}
+	return nil
+}
+
+func (f *fakeOutbox) Append(ctx context.Context, messages ...outbox.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, messages...)
+	return nil
+}
+
+type recordedEvent struct {
+	typ string
+	postdomain.Event
+}
+
+func (f *fakeOutbox) decode(t *testing.T) []recordedEvent {
+	t.Helper()
+	out := make([]recordedEvent, len(f.messages))
+	for i, msg := range f.messages {
+		out[i].typ = msg.Type
+		if err := json.Unmarshal(msg.Payload, &out[i].Event); err != nil {
+			t.Fatalf("message %d payload: %v", i, err)
+		}
+	}
+	return out
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/slug"
+)
+
+const (
+	// TagSuggestionHandlerName is the name the outbox relay keeps the term index offset under.
+	TagSuggestionHandlerName = "tag-suggestions"
+
+	// maxTagSuggestions and maxNewTags cap the two suggestion lists.
+	maxTagSuggestions = 8
+	maxNewTags        = 5
+	// minSuggestionScore drops tags that barely resemble the draft.
+	minSuggestionScore = 0.05
+	// nameMatchBonus is added when every word of a tag's name occurs in the draft, so
+	// tags without posts can still be suggested.
+	nameMatchBonus = 0.25
+	// minNewTagCount is how often a term must occur, title occurrences weighted, to be
+	// proposed as a new tag.
+	minNewTagCount = 2
+	// indexBatch is the number of posts IndexMissing reads at a time.
+	indexBatch = 200
+)
+
+// TagSuggestionService ranks the existing tags that fit a draft and proposes new ones.
+type TagSuggestionService interface {
+	Suggest(ctx context.Context, input postdomain.SuggestTagsInput) (postdomain.TagSuggestions, error)
+	// IndexMissing indexes the posts that have no term counts yet and returns how many
+	// it indexed.
+	IndexMissing(ctx context.Context) (int, error)
+	OutboxHandler() outbox.Handler
+}
+
+// TagSuggester implements TagSuggestionService with TF-IDF: every tag is the centroid
+// of the term vectors of its posts, and tags are ranked by their cosine similarity to
+// the draft. The draft's highest weighted terms that no tag covers are proposed as new
+// tags. Term counts, document frequencies and tag centroids are stored and updated from
+// the outbox, so a change to one post only refreshes that post and the centroids of its
+// tags, and a suggestion only reads the rows of the draft's terms. Centroids keep the
+// document frequencies of their last refresh until one of their posts changes again.
+type TagSuggester struct {
+	terms    postdomain.TermIndexRepository
+	taxonomy taxdomain.TaxonomyRepository
+}
+
+var _ TagSuggestionService = (*TagSuggester)(nil)
+
+// NewTagSuggestionService wires the term index and the tags into a suggester.
+func NewTagSuggestionService(terms postdomain.TermIndexRepository, taxonomy taxdomain.TaxonomyRepository) *TagSuggester {
+	return &TagSuggester{terms: terms, taxonomy: taxonomy}
+}
+
+// Suggest ranks the tags that fit the draft, leaving out those it already carries.
+func (s *TagSuggester) Suggest(ctx context.Context, input postdomain.SuggestTagsInput) (postdomain.TagSuggestions, error) {
+	out := postdomain.TagSuggestions{Tags: []postdomain.TagSuggestion{}, NewTags: []string{}}
+	counts := countTerms(input.Title, "", input.ContentMD)
+	if len(counts) == 0 {
+		return out, nil
+	}
+	terms := make([]string, 0, len(counts))
+	for term := range counts {
+		terms = append(terms, term)
+	}
+	docs, df, err := s.terms.TermFrequencies(ctx, terms)
+	if err != nil {
+		return out, err
+	}
+	// centroids are unit vectors, so the weights of the draft's terms are all the
+	// similarity needs
+	centroids, err := s.terms.ListTagCentroidWeights(ctx, terms)
+	if err != nil {
+		return out, err
+	}
+	tags, err := s.taxonomy.ListTags(ctx)
+	if err != nil {
+		return out, err
+	}
+	draft := tfidf(counts, df, docs)
+
+	skip := make(map[string]bool, len(input.Tags))
+	for _, t := range input.Tags {
+		skip[strings.TrimSpace(t)] = true
+	}
+	known := make(map[string]bool)
+	for _, tag := range tags {
+		known[tag.Slug] = true
+		known[slug.Make(tag.Name)] = true
+		for _, word := range tokenize(tag.Name) {
+			known[word] = true
+		}
+		if skip[tag.Slug] {
+			continue
+		}
+		score := draft.dot(centroids[tag.Slug])
+		if nameMatches(tag.Name, counts) {
+			score += nameMatchBonus
+		}
+		if score < minSuggestionScore {
+			continue
+		}
+		out.Tags = append(out.Tags, postdomain.TagSuggestion{Slug: tag.Slug, Name: tag.Name, Score: math.Round(score*1000) / 1000})
+	}
+	sort.SliceStable(out.Tags, func(i, j int) bool { return out.Tags[i].Score > out.Tags[j].Score })
+	if len(out.Tags) > maxTagSuggestions {
+		out.Tags = out.Tags[:maxTagSuggestions]
+	}
+
+	for _, term := range draft.top() {
+		if len(out.NewTags) == maxNewTags {
+			break
+		}
+		if counts[term] < minNewTagCount || len([]rune(term)) < 3 || known[term] || known[slug.Make(term)] {
+			continue
+		}
+		// terms found in most posts are too broad to tag by
+		if docs > 0 && 2*df[term] > docs {
+			continue
+		}
+		out.NewTags = append(out.NewTags, term)
+	}
+	return out, nil
+}
+
+func nameMatches(name string, counts map[string]int) bool {
+	words := tokenize(name)
+	for _, w := range words {
+		if counts[w] == 0 {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// IndexMissing indexes the posts written before the index existed, or whose events were
+// skipped, a batch at a time, then computes the centroids of tags that have none yet.
+func (s *TagSuggester) IndexMissing(ctx context.Context) (int, error) {
+	indexed := 0
+	for {
+		sources, err := s.terms.ListUnindexedTermSources(ctx, indexBatch)
+		if err != nil {
+			return indexed, err
+		}
+		var tagIDs []int64
+		for _, src := range sources {
+			ids, err := s.terms.SavePostTerms(ctx, src.PostID, countTerms(src.Title, src.Summary, src.ContentMD))
+			if err != nil {
+				return indexed, err
+			}
+			tagIDs = append(tagIDs, ids...)
+			indexed++
+		}
+		if err := s.refreshCentroids(ctx, tagIDs); err != nil {
+			return indexed, err
+		}
+		if len(sources) < indexBatch {
+			break
+		}
+	}
+	tagIDs, err := s.terms.ListTagsWithoutCentroid(ctx)
+	if err != nil {
+		return indexed, err
+	}
+	return indexed, s.refreshCentroids(ctx, tagIDs)
+}
+
+// OutboxHandler returns the relay handler that keeps the term index and the tag
+// centroids current.
+func (s *TagSuggester) OutboxHandler() outbox.Handler {
+	types := append([]string{taxdomain.EventTaxonomyChanged}, postdomain.EventTypes...)
+	return outbox.Handler{Name: TagSuggestionHandlerName, Types: types, Handle: s.HandleEvent}
+}
+
+// HandleEvent re-indexes the post of a post event, or drops it from the index when it
+// was deleted, and refreshes the centroids of the tags it carries or carried. A tag
+// merge refreshes the tag the posts moved to; deleted tags lose their centroid with them
+// and renames need nothing, as centroids are read by tag slug when suggesting.
+func (s *TagSuggester) HandleEvent(ctx context.Context, msg outbox.Message) error {
+	if msg.Type == taxdomain.EventTaxonomyChanged {
+		var event taxdomain.ChangeEvent
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return fmt.Errorf("decode %s payload: %w", msg.Type, err)
+		}
+		if event.Kind != taxdomain.KindTag || event.Change != taxdomain.ChangeMerged {
+			return nil
+		}
+		target, err := s.taxonomy.GetTag(ctx, event.MergedInto)
+		if errors.Is(err, taxdomain.ErrTagNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return s.refreshCentroids(ctx, []int64{target.ID})
+	}
+
+	var event postdomain.Event
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("decode %s payload: %w", msg.Type, err)
+	}
+	var tagIDs []int64
+	var err error
+	if msg.Type == postdomain.EventPostDeleted {
+		tagIDs, err = s.terms.DeletePostTerms(ctx, event.Post.ID)
+	} else {
+		tagIDs, err = s.terms.SavePostTerms(ctx, event.Post.ID, countTerms(event.Post.Title, event.Post.Summary, event.Post.ContentMD))
+	}
+	if err != nil {
+		return err
+	}
+	return s.refreshCentroids(ctx, tagIDs)
+}
+
+// refreshCentroids recomputes the centroid of each tag from the posts carrying it now,
+// weighted by the current document frequencies.
+func (s *TagSuggester) refreshCentroids(ctx context.Context, tagIDs []int64) error {
+	done := make(map[int64]bool, len(tagIDs))
+	for _, id := range tagIDs {
+		if done[id] {
+			continue
+		}
+		done[id] = true
+		posts, err := s.terms.ListTagTermCounts(ctx, id)
+		if err != nil {
+			return err
+		}
+		var terms []string
+		seen := make(map[string]bool)
+		for _, counts := range posts {
+			for term := range counts {
+				if !seen[term] {
+					seen[term] = true
+					terms = append(terms, term)
+				}
+			}
+		}
+		docs, df, err := s.terms.TermFrequencies(ctx, terms)
+		if err != nil {
+			return err
+		}
+		centroid := termVector{}
+		for _, counts := range posts {
+			centroid.add(tfidf(counts, df, docs))
+		}
+		centroid.normalize()
+		if err := s.terms.ReplaceTagCentroid(ctx, id, centroid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
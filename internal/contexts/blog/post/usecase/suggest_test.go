@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+)
+
+// suggestionPosts and suggestionTags are a small corpus: posts 1 and 2 are tagged gin
+// (tag 1), posts 3 and 4 postgres (tag 2), and redis (tag 3) has no posts.
+var (
+	suggestionPosts = []postdomain.TermSource{
+		{PostID: 1, Title: "Routing in Gin", ContentMD: "Gin groups routes and middleware. Gin handlers read the context."},
+		{PostID: 2, Title: "Gin middleware", ContentMD: "Write middleware for gin: logging, recovery and auth."},
+		{PostID: 3, Title: "Postgres indexes", ContentMD: "A btree index speeds up postgres queries; explain shows the plan."},
+		{PostID: 4, Title: "Tuning Postgres", ContentMD: "Vacuum and postgres autovacuum settings for busy tables."},
+	}
+	suggestionTags = []taxdomain.TagWithCount{
+		{Tag: taxdomain.Tag{ID: 1, Name: "Gin", Slug: "gin"}, PostCount: 2},
+		{Tag: taxdomain.Tag{ID: 2, Name: "Postgres", Slug: "postgres"}, PostCount: 2},
+		{Tag: taxdomain.Tag{ID: 3, Name: "Redis", Slug: "redis"}},
+	}
+)
+
+// fakeTermIndex keeps the term index in memory. postTags holds the tags each post
+// carries now, counted the tags its terms were last counted under.
+type fakeTermIndex struct {
+	terms     map[int64]map[string]int
+	postTags  map[int64][]int64
+	counted   map[int64][]int64
+	tagSlugs  map[int64]string
+	centroids map[int64]map[string]float64
+	sources   []postdomain.TermSource
+	deleted   []int64
+	requests  int
+}
+
+func (f *fakeTermIndex) SavePostTerms(ctx context.Context, postID int64, terms map[string]int) ([]int64, error) {
+	if f.terms == nil {
+		f.terms = make(map[int64]map[string]int)
+		f.counted = make(map[int64][]int64)
+	}
+	f.terms[postID] = terms
+	tagIDs := append(append([]int64(nil), f.counted[postID]...), f.postTags[postID]...)
+	f.counted[postID] = f.postTags[postID]
+	for i, src := range f.sources {
+		if src.PostID == postID {
+			f.sources = append(f.sources[:i], f.sources[i+1:]...)
+			break
+		}
+	}
+	return tagIDs, nil
+}
+
+func (f *fakeTermIndex) DeletePostTerms(ctx context.Context, postID int64) ([]int64, error) {
+	tagIDs := f.counted[postID]
+	delete(f.terms, postID)
+	delete(f.counted, postID)
+	f.deleted = append(f.deleted, postID)
+	return tagIDs, nil
+}
+
+func (f *fakeTermIndex) TermFrequencies(ctx context.Context, terms []string) (int, map[string]int, error) {
+	df := make(map[string]int)
+	for _, term := range terms {
+		for _, counts := range f.terms {
+			if _, ok := counts[term]; ok {
+				df[term]++
+			}
+		}
+	}
+	return len(f.terms), df, nil
+}
+
+func (f *fakeTermIndex) ListTagTermCounts(ctx context.Context, tagID int64) ([]map[string]int, error) {
+	var out []map[string]int
+	for postID, counts := range f.terms {
+		for _, id := range f.postTags[postID] {
+			if id == tagID {
+				out = append(out, counts)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTermIndex) ReplaceTagCentroid(ctx context.Context, tagID int64, weights map[string]float64) error {
+	if f.centroids == nil {
+		f.centroids = make(map[int64]map[string]float64)
+	}
+	f.centroids[tagID] = weights
+	return nil
+}
+
+func (f *fakeTermIndex) ListTagCentroidWeights(ctx context.Context, terms []string) (map[string]map[string]float64, error) {
+	out := make(map[string]map[string]float64)
+	for tagID, weights := range f.centroids {
+		for _, term := range terms {
+			if w, ok := weights[term]; ok {
+				if out[f.tagSlugs[tagID]] == nil {
+					out[f.tagSlugs[tagID]] = make(map[string]float64)
+				}
+				out[f.tagSlugs[tagID]][term] = w
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTermIndex) ListTagsWithoutCentroid(context.Context) ([]int64, error) {
+	var out []int64
+	for postID := range f.terms {
+		for _, id := range f.postTags[postID] {
+			if _, ok := f.centroids[id]; !ok {
+				out = append(out, id)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+func (f *fakeTermIndex) ListUnindexedTermSources(ctx context.Context, limit int32) ([]postdomain.TermSource, error) {
+	f.requests++
+	if int(limit) < len(f.sources) {
+		return append([]postdomain.TermSource(nil), f.sources[:limit]...), nil
+	}
+	return append([]postdomain.TermSource(nil), f.sources...), nil
+}
+
+func TestSuggestRanksTagsBySimilarity(t *testing.T) {
+	index := &fakeTermIndex{
+		sources:  append([]postdomain.TermSource(nil), suggestionPosts...),
+		postTags: map[int64][]int64{1: {1}, 2: {1}, 3: {2}, 4: {2}},
+		tagSlugs: map[int64]string{1: "gin", 2: "postgres"},
+	}
+	svc := NewTagSuggestionService(index, &fakeTaxonomyRepo{tagList: suggestionTags})
+	if _, err := svc.IndexMissing(context.Background()); err != nil {
+		t.Fatalf("IndexMissing returned error: %v", err)
+	}
+
+	got, err := svc.Suggest(context.Background(), postdomain.SuggestTagsInput{
+		Title:     "Caching Gin responses in Redis",
+		ContentMD: "A gin middleware that stores rendered routes in redis. Redis keys expire; the middleware skips POST.\n\n```go\nvacuum := postgres\n```",
+	})
+	if err != nil {
+		t.Fatalf("Suggest returned error: %v", err)
+	}
+	var slugs []string
+	for _, s := range got.Tags {
+		slugs = append(slugs, s.Slug)
+	}
+	// redis has no posts but its name occurs; code blocks are not indexed, so postgres is left out
+	if !reflect.DeepEqual(slugs, []string{"gin", "redis"}) {
+		t.Fatalf("unexpected tags %+v", got.Tags)
+	}
+	// tag names and terms used once in the body are not proposed
+	if want := []string{"caching", "responses", "middleware"}; !reflect.DeepEqual(got.NewTags, want) {
+		t.Fatalf("new tags = %q, want %q", got.NewTags, want)
+	}
+}
+
+func TestSuggestSkipsCurrentTags(t *testing.T) {
+	index := &fakeTermIndex{
+		sources:  append([]postdomain.TermSource(nil), suggestionPosts...),
+		postTags: map[int64][]int64{1: {1}, 2: {1}, 3: {2}, 4: {2}},
+		tagSlugs: map[int64]string{1: "gin", 2: "postgres"},
+	}
+	svc := NewTagSuggestionService(index, &fakeTaxonomyRepo{tagList: suggestionTags})
+	if _, err := svc.IndexMissing(context.Background()); err != nil {
+		t.Fatalf("IndexMissing returned error: %v", err)
+	}
+
+	got, err := svc.Suggest(context.Background(), postdomain.SuggestTagsInput{
+		Title:     "Postgres index bloat",
+		ContentMD: "Reindex postgres tables after a big vacuum.",
+		Tags:      []string{"postgres"},
+	})
+	if err != nil {
+		t.Fatalf("Suggest returned error: %v", err)
+	}
+	for _, s := range got.Tags {
+		if s.Slug == "postgres" {
+			t.Fatalf("current tag suggested again: %+v", got.Tags)
+		}
+	}
+}
+
+func TestSuggestEmptyDraft(t *testing.T) {
+	svc := NewTagSuggestionService(&fakeTermIndex{}, &fakeTaxonomyRepo{tagList: suggestionTags})
+
+	got, err := svc.Suggest(context.Background(), postdomain.SuggestTagsInput{Title: "  ", ContentMD: "the and of"})
+	if err != nil {
+		t.Fatalf("Suggest returned error: %v", err)
+	}
+	if got.Tags == nil || got.NewTags == nil || len(got.Tags)+len(got.NewTags) != 0 {
+		t.Fatalf("expected empty, non-nil lists, got %+v", got)
+	}
+}
+
+func TestTagSuggesterHandleEvent(t *testing.T) {
+	index := &fakeTermIndex{postTags: map[int64][]int64{9: {3}}, tagSlugs: map[int64]string{3: "redis"}}
+	svc := NewTagSuggestionService(index, &fakeTaxonomyRepo{tagList: suggestionTags})
+
+	payload, _ := json.Marshal(postdomain.Event{Post: postdomain.Post{ID: 9, Title: "Redis streams", ContentMD: "Consumer groups"}})
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: postdomain.EventPostUpdated, Payload: payload}); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if want := map[string]int{"redis": 3, "streams": 3, "consumer": 1, "groups": 1}; !reflect.DeepEqual(index.terms[9], want) {
+		t.Fatalf("unexpected terms %v", index.terms[9])
+	}
+	if index.centroids[3]["streams"] == 0 {
+		t.Fatalf("expected the centroid of the post's tag to be refreshed, got %v", index.centroids[3])
+	}
+
+	// the post loses its tag: the tag it was counted under is refreshed without it
+	index.postTags[9] = nil
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: postdomain.EventPostUpdated, Payload: payload}); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if len(index.centroids[3]) != 0 {
+		t.Fatalf("expected the removed tag's centroid to be emptied, got %v", index.centroids[3])
+	}
+
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: postdomain.EventPostDeleted, Payload: payload}); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if _, ok := index.terms[9]; ok || !reflect.DeepEqual(index.deleted, []int64{9}) {
+		t.Fatalf("expected post 9 to be dropped, got %v", index.deleted)
+	}
+}
+
+func TestTagSuggesterHandleEventRefreshesMergeTarget(t *testing.T) {
+	index := &fakeTermIndex{
+		terms:    map[int64]map[string]int{5: countTerms("Redis pipelines", "", "")},
+		postTags: map[int64][]int64{5: {3}},
+		tagSlugs: map[int64]string{3: "redis"},
+	}
+	svc := NewTagSuggestionService(index, &fakeTaxonomyRepo{tagList: suggestionTags})
+
+	payload, _ := json.Marshal(taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeMerged, Slug: "redis-db", MergedInto: "redis"})
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: taxdomain.EventTaxonomyChanged, Payload: payload}); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if index.centroids[3]["pipelines"] == 0 {
+		t.Fatalf("expected the merge target's centroid to be refreshed, got %v", index.centroids)
+	}
+
+	payload, _ = json.Marshal(taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeMerged, Slug: "old", MergedInto: "gone"})
+	if err := svc.HandleEvent(context.Background(), outbox.Message{Type: taxdomain.EventTaxonomyChanged, Payload: payload}); err != nil {
+		t.Fatalf("expected a merge into a deleted tag to be skipped, got %v", err)
+	}
+}
+
+func TestIndexMissingReadsInBatches(t *testing.T) {
+	index := &fakeTermIndex{postTags: map[int64][]int64{100: {1}}, tagSlugs: map[int64]string{1: "gin"}}
+	svc := NewTagSuggestionService(index, &fakeTaxonomyRepo{tagList: suggestionTags})
+	for i := 0; i < indexBatch+1; i++ {
+		index.sources = append(index.sources, postdomain.TermSource{PostID: int64(100 + i), Title: "Draft"})
+	}
+
+	n, err := svc.IndexMissing(context.Background())
+	if err != nil {
+		t.Fatalf("IndexMissing returned error: %v", err)
+	}
+	if n != indexBatch+1 || index.requests != 2 || len(index.sources) != 0 {
+		t.Fatalf("indexed %d in %d requests, %d left", n, index.requests, len(index.sources))
+	}
+	if index.centroids[1]["draft"] == 0 {
+		t.Fatalf("expected tag centroids to be computed, got %v", index.centroids)
+	}
+}
+
+func TestCountTermsSkipsMarkup(t *testing.T) {
+	got := countTerms("Go Modules", "", "See [the docs](https://go.dev/ref/mod) or https://example.com/x.\n\n<img src=\"/static/a.png\"> modules 2024")
+	want := map[string]int{"go": 3, "modules": 4, "see": 1, "docs": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("countTerms() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// titleWeight is how many times a term in the title counts compared to one in the body.
+const titleWeight = 3
+
+var (
+	codeFence       = regexp.MustCompile("(?s)```.*?```")
+	bareURL         = regexp.MustCompile(`https?://\S+`)
+	linkDestination = regexp.MustCompile(`\]\([^)]*\)`)
+	htmlElement     = regexp.MustCompile(`<[^>]+>`)
+)
+
+// stopWords are dropped from term counts; they say nothing about a post's topic.
+var stopWords = func() map[string]bool {
+	words := strings.Fields(`a about above after again against all also am an and any are as at be because been
+		before being below between both but by can could did do does doing down during each few for from
+		further had has have having he her here hers herself him himself his how however i if in into is it its
+		itself just let like make many me more most much must my myself new no nor not now of off on once
+		one only or other our ours ourselves out over own same she should so some such than that the their
+		theirs them themselves then there these they this those through to too two under until up us use
+		used using very was way we well were what when where which while who whom why will with would you
+		your yours yourself yourselves get got via vs etc`)
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}()
+
+// countTerms counts the terms of a post, title terms weighted up. Fenced code, URLs,
+// link targets and HTML tags are skipped.
+func countTerms(title, summary, contentMD string) map[string]int {
+	counts := make(map[string]int)
+	for _, term := range tokenize(title) {
+		counts[term] += titleWeight
+	}
+	for _, term := range tokenize(summary) {
+		counts[term]++
+	}
+	body := codeFence.ReplaceAllString(contentMD, " ")
+	body = linkDestination.ReplaceAllString(body, "]")
+	body = bareURL.ReplaceAllString(body, " ")
+	body = htmlElement.ReplaceAllString(body, " ")
+	for _, term := range tokenize(body) {
+		counts[term]++
+	}
+	return counts
+}
+
+// tokenize lowercases text and splits it into runs of letters and digits, dropping stop
+// words, single characters and bare numbers.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || stopWords[f] || isNumber(f) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// termVector maps terms to weights.
+type termVector map[string]float64
+
+// tfidf weighs counts by 1+ln(tf) times the smoothed inverse document frequency and
+// scales the result to unit length.
+func tfidf(counts map[string]int, df map[string]int, docs int) termVector {
+	v := make(termVector, len(counts))
+	for term, n := range counts {
+		if n <= 0 {
+			continue
+		}
+		v[term] = (1 + math.Log(float64(n))) * idf(df[term], docs)
+	}
+	v.normalize()
+	return v
+}
+
+func idf(df, docs int) float64 {
+	return math.Log(float64(docs+1)/float64(df+1)) + 1
+}
+
+func (v termVector) add(other termVector) {
+	for term, w := range other {
+		v[term] += w
+	}
+}
+
+func (v termVector) normalize() {
+	var sum float64
+	for _, w := range v {
+		sum += w * w
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for term := range v {
+		v[term] /= norm
+	}
+}
+
+// dot is the cosine similarity of two unit vectors.
+func (v termVector) dot(other termVector) float64 {
+	if len(other) < len(v) {
+		v, other = other, v
+	}
+	var sum float64
+	for term, w := range v {
+		sum += w * other[term]
+	}
+	return sum
+}
+
+// top returns the terms of v by descending weight, ties by term.
+func (v termVector) top() []string {
+	terms := make([]string, 0, len(v))
+	for term := range v {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if v[terms[i]] != v[terms[j]] {
+			return v[terms[i]] > v[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	return terms
+}
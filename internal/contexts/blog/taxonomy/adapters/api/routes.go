@@ -0,0 +1,104 @@
+﻿package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
+	"proto-gin-web/internal/platform/http/responder"
+)
+
+// RegisterRoutes attaches JSON endpoints for categories and tags.
+func RegisterRoutes(r *gin.Engine, taxonomySvc taxonomyusecase.TaxonomyService) {
+	api := r.Group("/api")
+	{
+		api.GET("/categories", listCategoriesHandler(taxonomySvc))
+		api.GET("/categories/:slug", getCategoryHandler(taxonomySvc))
+		api.GET("/tags", listTagsHandler(taxonomySvc))
+	}
+}
+
+// listCategoriesHandler godoc
+// @Summary      List categories
+// @Description  Lists every category in display order (position, then name) with the number of published posts filed directly under it. With tree=1 top-level categories are returned, each nesting its subcategories in children.
+// @Tags         Public
+// @Produce      json
+// @Param        tree  query     bool  false  "Nest categories under their parents"
+// @Success      200   {object}  categoryListResponse
+// @Failure      500   {object}  errorResponse
+// @Router       /api/categories [get]
+func listCategoriesHandler(taxonomySvc taxonomyusecase.TaxonomyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tree, _ := strconv.ParseBool(c.Query("tree")); tree {
+			nodes, err := taxonomySvc.ListCategoryTree(c.Request.Context())
+			if err != nil {
+				responder.JSONError(c, http.StatusInternalServerError, "failed to list categories")
+				return
+			}
+			responder.JSONSuccess(c, http.StatusOK, nonNil(nodes))
+			return
+		}
+		categories, err := taxonomySvc.ListCategories(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list categories")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, nonNil(categories))
+	}
+}
+
+// getCategoryHandler godoc
+// @Summary      Get a category
+// @Description  Fetches a category with the number of published posts filed under it.
+// @Tags         Public
+// @Produce      json
+// @Param        slug  path      string  true  "Category slug"
+// @Success      200   {object}  categoryResponse
+// @Failure      404   {object}  errorResponse
+// @Failure      500   {object}  errorResponse
+// @Router       /api/categories/{slug} [get]
+func getCategoryHandler(taxonomySvc taxonomyusecase.TaxonomyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		category, err := taxonomySvc.GetCategory(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			if errors.Is(err, taxdomain.ErrCategoryNotFound) {
+				responder.JSONError(c, http.StatusNotFound, "category not found")
+				return
+			}
+			responder.JSONError(c, http.StatusInternalServerError, "failed to get category")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, category)
+	}
+}
+
+// listTagsHandler godoc
+// @Summary      List tags
+// @Description  Lists every tag in display order (position, then name) with the number of published posts carrying it.
+// @Tags         Public
+// @Produce      json
+// @Success      200  {object}  tagListResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /api/tags [get]
+func listTagsHandler(taxonomySvc taxonomyusecase.TaxonomyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tags, err := taxonomySvc.ListTags(c.Request.Context())
+		if err != nil {
+			responder.JSONError(c, http.StatusInternalServerError, "failed to list tags")
+			return
+		}
+		responder.JSONSuccess(c, http.StatusOK, nonNil(tags))
+	}
+}
+
+// nonNil makes an empty list encode as [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,27 @@
+﻿package api
+
+import taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+
+// categoryListResponse documents the JSON envelope returned by /api/categories.
+type categoryListResponse struct {
+	Ok   bool                          `json:"ok"`
+	Data []taxdomain.CategoryWithCount `json:"data"`
+}
+
+// categoryResponse documents the JSON envelope returned by /api/categories/{slug}.
+type categoryResponse struct {
+	Ok   bool                        `json:"ok"`
+	Data taxdomain.CategoryWithCount `json:"data"`
+}
+
+// tagListResponse documents the JSON envelope returned by /api/tags.
+type tagListResponse struct {
+	Ok   bool                     `json:"ok"`
+	Data []taxdomain.TagWithCount `json:"data"`
+}
+
+// errorResponse documents the JSON error envelope.
+type errorResponse struct {
+	Ok    bool   `json:"ok"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,30 @@
+package taxdomain
+
+import "errors"
+
+var (
+	// ErrCategoryNotFound indicates no category has the slug.
+	ErrCategoryNotFound = errors.New("taxonomy: category not found")
+	// ErrTagNotFound indicates no tag has the slug.
+	ErrTagNotFound = errors.New("taxonomy: tag not found")
+	// ErrNameRequired indicates a blank category or tag name.
+	ErrNameRequired = errors.New("taxonomy: name is required")
+	// ErrSlugRequired indicates a slug that is blank once normalized.
+	ErrSlugRequired = errors.New("taxonomy: slug is required")
+	// ErrNameTaken indicates another category or tag of the same kind has the name.
+	ErrNameTaken = errors.New("taxonomy: name is already in use")
+	// ErrSlugTaken indicates another category or tag of the same kind has the slug.
+	ErrSlugTaken = errors.New("taxonomy: slug is already in use")
+	// ErrParentNotFound indicates no category has the requested parent slug.
+	ErrParentNotFound = errors.New("taxonomy: parent category not found")
+	// ErrAliasNotFound indicates no tag alias has the slug.
+	ErrAliasNotFound = errors.New("taxonomy: tag alias not found")
+	// ErrMergeSourceRequired indicates a merge without source tags.
+	ErrMergeSourceRequired = errors.New("taxonomy: at least one source tag is required")
+	// ErrMergeIntoSelf indicates a merge listing the target among its sources.
+	ErrMergeIntoSelf = errors.New("taxonomy: a tag cannot be merged into itself")
+	// ErrCoverInvalid indicates a cover that is neither an http(s) URL nor a site path.
+	ErrCoverInvalid = errors.New("taxonomy: cover must be an http(s) URL or a path starting with /")
+	// ErrParentCycle indicates a parent that is the category itself or one of its descendants.
+	ErrParentCycle = errors.New("taxonomy: a category cannot be nested under itself or its descendants")
+)
@@ -0,0 +1,28 @@
+package taxdomain
+
+// EventTaxonomyChanged is the outbox event type recorded when a category or tag is
+// created, renamed, deleted or merged into another tag.
+const EventTaxonomyChanged = "TaxonomyChanged"
+
+// Taxonomy kinds and changes reported by EventTaxonomyChanged.
+const (
+	KindCategory = "category"
+	KindTag      = "tag"
+
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+	ChangeMerged  = "merged"
+)
+
+// ChangeEvent is the payload of EventTaxonomyChanged. Name is empty for deletes;
+// PreviousSlug is set when an update changed the slug and MergedInto names the tag a
+// merged tag's posts moved to.
+type ChangeEvent struct {
+	Kind         string `json:"kind"`
+	Change       string `json:"change"`
+	Slug         string `json:"slug"`
+	Name         string `json:"name,omitempty"`
+	PreviousSlug string `json:"previous_slug,omitempty"`
+	MergedInto   string `json:"merged_into,omitempty"`
+}
@@ -4,8 +4,30 @@ import "context"
 
 // TaxonomyRepository abstracts persistence of categories and tags.
 type TaxonomyRepository interface {
-	CreateCategory(ctx context.Context, input CreateCategoryInput) (Category, error)
+	// ListCategories returns every category in display order with its published post count.
+	ListCategories(ctx context.Context) ([]CategoryWithCount, error)
+	GetCategory(ctx context.Context, slug string) (CategoryWithCount, error)
+	CreateCategory(ctx context.Context, record CategoryRecord) (Category, error)
+	UpdateCategory(ctx context.Context, slug string, record CategoryRecord) (Category, error)
+	// ListCategoryAncestors walks parent links up from the category with the given id
+	// and returns its ancestors, root first.
+	ListCategoryAncestors(ctx context.Context, id int64) ([]Category, error)
 	DeleteCategory(ctx context.Context, slug string) error
-	CreateTag(ctx context.Context, input CreateTagInput) (Tag, error)
+	CategorySlugExists(ctx context.Context, slug string) (bool, error)
+	// ListTags returns every tag in display order with its published post count.
+	ListTags(ctx context.Context) ([]TagWithCount, error)
+	GetTag(ctx context.Context, slug string) (TagWithCount, error)
+	CreateTag(ctx context.Context, record TagRecord) (Tag, error)
+	UpdateTag(ctx context.Context, slug string, record TagRecord) (Tag, error)
 	DeleteTag(ctx context.Context, slug string) error
+	TagSlugExists(ctx context.Context, slug string) (bool, error)
+	// MergeTags moves the posts of the source tags to the target, skipping posts that
+	// already carry it, deletes the sources and keeps their slugs as aliases of the
+	// target, all in one transaction.
+	MergeTags(ctx context.Context, targetSlug string, sourceSlugs []string) (Tag, error)
+	// ResolveTagAlias returns the tag an alias slug points to. It reports ErrAliasNotFound
+	// when the slug is no alias or a tag with that slug exists.
+	ResolveTagAlias(ctx context.Context, slug string) (Tag, error)
+	ListTagAliases(ctx context.Context) ([]TagAlias, error)
+	DeleteTagAlias(ctx context.Context, slug string) error
 }
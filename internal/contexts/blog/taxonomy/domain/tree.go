@@ -0,0 +1,39 @@
+package taxdomain
+
+// CategoryNode is a category with its child categories, for the category tree.
+type CategoryNode struct {
+	CategoryWithCount
+	Children []CategoryNode `json:"children"`
+}
+
+// BuildCategoryTree nests categories under their parents, keeping the input order among
+// siblings. Categories whose parent is not in the list become roots.
+func BuildCategoryTree(categories []CategoryWithCount) []CategoryNode {
+	known := make(map[int64]bool, len(categories))
+	for _, c := range categories {
+		known[c.ID] = true
+	}
+	children := make(map[int64][]CategoryWithCount)
+	var roots []CategoryWithCount
+	for _, c := range categories {
+		if c.ParentID != nil && known[*c.ParentID] && *c.ParentID != c.ID {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+	visited := make(map[int64]bool, len(categories))
+	var build func(level []CategoryWithCount) []CategoryNode
+	build = func(level []CategoryWithCount) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(level))
+		for _, c := range level {
+			if visited[c.ID] {
+				continue
+			}
+			visited[c.ID] = true
+			nodes = append(nodes, CategoryNode{CategoryWithCount: c, Children: build(children[c.ID])})
+		}
+		return nodes
+	}
+	return build(roots)
+}
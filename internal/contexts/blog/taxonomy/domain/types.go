@@ -1,10 +1,19 @@
 package taxdomain
 
-// Category represents a taxonomy group assigned to posts.
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Category represents a taxonomy group assigned to posts. Categories may be nested
+// under a parent category.
 type Category struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	ParentID *int64 `json:"parent_id,omitempty"`
+	Details
 }
 
 // Tag is a flexible label attached to posts.
@@ -12,17 +21,126 @@ type Tag struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
+	Details
+}
+
+// Details are the optional fields of a category or tag shown on its landing page:
+// a markdown intro, a share image and overrides of the page's meta title and
+// description. Listings order siblings by Position, then by name. Relations listed on
+// a post leave them empty.
+type Details struct {
+	DescriptionMD   string `json:"description_md,omitempty"`
+	CoverURL        string `json:"cover_url,omitempty"`
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	Position        int32  `json:"position,omitempty"`
+}
+
+// DetailsPatch changes some details; nil fields keep their current value.
+type DetailsPatch struct {
+	DescriptionMD   *string
+	CoverURL        *string
+	MetaTitle       *string
+	MetaDescription *string
+	Position        *int32
+}
+
+// Apply returns d with the fields set in p replaced, strings trimmed.
+func (p DetailsPatch) Apply(d Details) Details {
+	set := func(dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+		}
+	}
+	set(&d.DescriptionMD, p.DescriptionMD)
+	set(&d.CoverURL, p.CoverURL)
+	set(&d.MetaTitle, p.MetaTitle)
+	set(&d.MetaDescription, p.MetaDescription)
+	if p.Position != nil {
+		d.Position = *p.Position
+	}
+	return d
 }
 
-// CreateCategoryInput holds fields required to create a category.
+// Validate rejects a cover that is neither an absolute http(s) URL nor a site path.
+func (d Details) Validate() error {
+	if d.CoverURL == "" || (strings.HasPrefix(d.CoverURL, "/") && !strings.HasPrefix(d.CoverURL, "//")) {
+		return nil
+	}
+	if u, err := url.Parse(d.CoverURL); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return nil
+	}
+	return ErrCoverInvalid
+}
+
+// CreateCategoryInput holds fields required to create a category. An empty
+// ParentSlug creates a top-level category.
 type CreateCategoryInput struct {
-	Name string
-	Slug string
+	Name       string
+	Slug       string
+	ParentSlug string
+	Details    DetailsPatch
 }
 
 // CreateTagInput holds fields required to create a tag.
 type CreateTagInput struct {
-	Name string
-	Slug string
+	Name    string
+	Slug    string
+	Details DetailsPatch
+}
+
+// CategoryWithCount is a category with the number of published posts filed under it.
+type CategoryWithCount struct {
+	Category
+	PostCount int64 `json:"post_count"`
 }
 
+// TagWithCount is a tag with the number of published posts carrying it.
+type TagWithCount struct {
+	Tag
+	PostCount int64 `json:"post_count"`
+}
+
+// UpdateCategoryInput renames or moves a category. An empty slug keeps the current
+// one; a nil ParentSlug keeps the current parent and an empty one makes it top-level.
+type UpdateCategoryInput struct {
+	Name       string
+	Slug       string
+	ParentSlug *string
+	Details    DetailsPatch
+}
+
+// CategoryRecord is the shape the repository writes; the parent is already resolved to an id.
+type CategoryRecord struct {
+	Name     string
+	Slug     string
+	ParentID *int64
+	Details  Details
+}
+
+// UpdateTagInput renames a tag. An empty slug keeps the current one.
+type UpdateTagInput struct {
+	Name    string
+	Slug    string
+	Details DetailsPatch
+}
+
+// TagRecord is the shape the repository writes for a tag.
+type TagRecord struct {
+	Name    string
+	Slug    string
+	Details Details
+}
+
+// TagAlias is the slug of a tag merged into Tag. Links using the slug redirect to Tag
+// unless a tag with that slug exists again.
+type TagAlias struct {
+	Slug      string    `json:"slug"`
+	Tag       Tag       `json:"tag"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateTagGroup lists tags whose names look alike, most used first.
+type DuplicateTagGroup struct {
+	Tags []TagWithCount `json:"tags"`
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+
+	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/slug"
+)
+
+// minSimilarity is how alike two tag keys must be, as 1 - edit distance / longer
+// length, to count as near-duplicates.
+const minSimilarity = 0.8
+
+// nameSuffixes are dropped before comparing keys, so "go" matches "golang" and "vue"
+// matches "vuejs".
+var nameSuffixes = []string{"lang", "js"}
+
+// groupNearDuplicates groups tags whose normalized names are equal or nearly equal.
+// Groups are ordered by their first tag; inside a group the most used tag comes first
+// as the suggested merge target. Tags without a look-alike are left out.
+func groupNearDuplicates(tags []taxdomain.TagWithCount) []taxdomain.DuplicateTagGroup {
+	keys := make([]string, len(tags))
+	for i, t := range tags {
+		keys[i] = tagKey(t.Name)
+	}
+	parent := make([]int, len(tags))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range tags {
+		for j := i + 1; j < len(tags); j++ {
+			if nearDuplicate(keys[i], keys[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]taxdomain.TagWithCount)
+	var roots []int
+	for i, t := range tags {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], t)
+	}
+	var groups []taxdomain.DuplicateTagGroup
+	for _, root := range roots {
+		group := members[root]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(a, b int) bool { return group[a].PostCount > group[b].PostCount })
+		groups = append(groups, taxdomain.DuplicateTagGroup{Tags: group})
+	}
+	return groups
+}
+
+// tagKey reduces a name to lowercase ASCII letters and digits, so "Go-lang", "golang"
+// and "GoLang" share a key.
+func tagKey(name string) string {
+	return strings.ReplaceAll(slug.Make(name), "-", "")
+}
+
+func nearDuplicate(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if stem(a) == stem(b) {
+		return true
+	}
+	longer := max(len(a), len(b))
+	return 1-float64(editDistance(a, b))/float64(longer) >= minSimilarity
+}
+
+func stem(key string) string {
+	for _, suffix := range nameSuffixes {
+		if trimmed, ok := strings.CutSuffix(key, suffix); ok && len(trimmed) >= 2 {
+			return trimmed
+		}
+	}
+	return key
+}
+
+// editDistance is the Levenshtein distance between two ASCII keys.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
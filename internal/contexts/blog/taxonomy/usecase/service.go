@@ -6,26 +6,65 @@ import (
 	"strings"
 
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
+	"proto-gin-web/internal/platform/slug"
 )
 
 // TaxonomyService coordinates category and tag operations.
 type TaxonomyService interface {
+	ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error)
+	ListCategoryTree(ctx context.Context) ([]taxdomain.CategoryNode, error)
+	GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error)
 	CreateCategory(ctx context.Context, input taxdomain.CreateCategoryInput) (taxdomain.Category, error)
+	UpdateCategory(ctx context.Context, slug string, input taxdomain.UpdateCategoryInput) (taxdomain.Category, error)
 	DeleteCategory(ctx context.Context, slug string) error
+	ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error)
+	GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error)
 	CreateTag(ctx context.Context, input taxdomain.CreateTagInput) (taxdomain.Tag, error)
+	UpdateTag(ctx context.Context, slug string, input taxdomain.UpdateTagInput) (taxdomain.Tag, error)
 	DeleteTag(ctx context.Context, slug string) error
+	MergeTags(ctx context.Context, target string, sources []string) (taxdomain.Tag, error)
+	ListDuplicateTags(ctx context.Context) ([]taxdomain.DuplicateTagGroup, error)
+	ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error)
+	ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error)
+	DeleteTagAlias(ctx context.Context, slug string) error
 }
 
 // Service implements TaxonomyService with validation and repository delegation.
+// Creates, updates and deletes record a TaxonomyChanged event in the same transaction.
 type Service struct {
-	repo taxdomain.TaxonomyRepository
+	repo   taxdomain.TaxonomyRepository
+	outbox outbox.Writer
 }
 
 var _ TaxonomyService = (*Service)(nil)
 
-// NewService creates a taxonomy service.
-func NewService(repo taxdomain.TaxonomyRepository) *Service {
-	return &Service{repo: repo}
+// NewService creates a taxonomy service writing its events to the given outbox.
+func NewService(repo taxdomain.TaxonomyRepository, events outbox.Writer) *Service {
+	return &Service{repo: repo, outbox: events}
+}
+
+// ListCategories lists every category with its published post count.
+func (s *Service) ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error) {
+	return s.repo.ListCategories(ctx)
+}
+
+// ListCategoryTree lists every category nested under its parent, siblings in display order.
+func (s *Service) ListCategoryTree(ctx context.Context) ([]taxdomain.CategoryNode, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return taxdomain.BuildCategoryTree(categories), nil
+}
+
+// GetCategory fetches a category with its published post count.
+func (s *Service) GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return taxdomain.CategoryWithCount{}, taxdomain.ErrCategoryNotFound
+	}
+	return s.repo.GetCategory(ctx, slug)
 }
 
 // CreateCategory validates input and persists a new category.
@@ -34,10 +73,77 @@ func (s *Service) CreateCategory(ctx context.Context, input taxdomain.CreateCate
 	if err != nil {
 		return taxdomain.Category{}, err
 	}
-	return s.repo.CreateCategory(ctx, taxdomain.CreateCategoryInput{
-		Name: normalized.name,
-		Slug: normalized.slug,
+	details := input.Details.Apply(taxdomain.Details{})
+	if err := details.Validate(); err != nil {
+		return taxdomain.Category{}, err
+	}
+	parentID, err := s.resolveParent(ctx, 0, input.ParentSlug)
+	if err != nil {
+		return taxdomain.Category{}, err
+	}
+	if normalized.generated {
+		if normalized.slug, err = slug.Unique(ctx, normalized.slug, s.repo.CategorySlugExists); err != nil {
+			return taxdomain.Category{}, err
+		}
+	}
+	var category taxdomain.Category
+	err = s.record(ctx, taxdomain.ChangeEvent{Kind: taxdomain.KindCategory, Change: taxdomain.ChangeCreated, Slug: normalized.slug, Name: normalized.name}, func(ctx context.Context) error {
+		created, err := s.repo.CreateCategory(ctx, taxdomain.CategoryRecord{
+			Name:     normalized.name,
+			Slug:     normalized.slug,
+			ParentID: parentID,
+			Details:  details,
+		})
+		category = created
+		return err
 	})
+	if err != nil {
+		return taxdomain.Category{}, err
+	}
+	return category, nil
+}
+
+// UpdateCategory renames the category at slug, moves it to input.Slug when one is
+// given, re-parents it when input.ParentSlug is set and changes the details set in
+// input.Details. Posts keep the category, as relations are stored by id.
+func (s *Service) UpdateCategory(ctx context.Context, slug string, input taxdomain.UpdateCategoryInput) (taxdomain.Category, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return taxdomain.Category{}, taxdomain.ErrCategoryNotFound
+	}
+	normalized, err := normalizeRename(input.Name, input.Slug, slug)
+	if err != nil {
+		return taxdomain.Category{}, err
+	}
+	current, err := s.repo.GetCategory(ctx, slug)
+	if err != nil {
+		return taxdomain.Category{}, err
+	}
+	details := input.Details.Apply(current.Details)
+	if err := details.Validate(); err != nil {
+		return taxdomain.Category{}, err
+	}
+	parentID := current.ParentID
+	if input.ParentSlug != nil {
+		if parentID, err = s.resolveParent(ctx, current.ID, *input.ParentSlug); err != nil {
+			return taxdomain.Category{}, err
+		}
+	}
+	var category taxdomain.Category
+	err = s.record(ctx, renameEvent(taxdomain.KindCategory, slug, normalized), func(ctx context.Context) error {
+		updated, err := s.repo.UpdateCategory(ctx, slug, taxdomain.CategoryRecord{
+			Name:     normalized.name,
+			Slug:     normalized.slug,
+			ParentID: parentID,
+			Details:  details,
+		})
+		category = updated
+		return err
+	})
+	if err != nil {
+		return taxdomain.Category{}, err
+	}
+	return category, nil
 }
 
 // DeleteCategory removes a category by slug.
@@ -45,7 +151,24 @@ func (s *Service) DeleteCategory(ctx context.Context, slug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errors.New("taxonomy: category slug is required")
 	}
-	return s.repo.DeleteCategory(ctx, strings.TrimSpace(slug))
+	slug = strings.TrimSpace(slug)
+	return s.record(ctx, taxdomain.ChangeEvent{Kind: taxdomain.KindCategory, Change: taxdomain.ChangeDeleted, Slug: slug}, func(ctx context.Context) error {
+		return s.repo.DeleteCategory(ctx, slug)
+	})
+}
+
+// ListTags lists every tag with its published post count.
+func (s *Service) ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error) {
+	return s.repo.ListTags(ctx)
+}
+
+// GetTag fetches a tag with its published post count.
+func (s *Service) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return taxdomain.TagWithCount{}, taxdomain.ErrTagNotFound
+	}
+	return s.repo.GetTag(ctx, slug)
 }
 
 // CreateTag validates input and persists a new tag.
@@ -54,10 +177,64 @@ func (s *Service) CreateTag(ctx context.Context, input taxdomain.CreateTagInput)
 	if err != nil {
 		return taxdomain.Tag{}, err
 	}
-	return s.repo.CreateTag(ctx, taxdomain.CreateTagInput{
-		Name: normalized.name,
-		Slug: normalized.slug,
+	details := input.Details.Apply(taxdomain.Details{})
+	if err := details.Validate(); err != nil {
+		return taxdomain.Tag{}, err
+	}
+	if normalized.generated {
+		if normalized.slug, err = slug.Unique(ctx, normalized.slug, s.repo.TagSlugExists); err != nil {
+			return taxdomain.Tag{}, err
+		}
+	}
+	var tag taxdomain.Tag
+	err = s.record(ctx, taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeCreated, Slug: normalized.slug, Name: normalized.name}, func(ctx context.Context) error {
+		created, err := s.repo.CreateTag(ctx, taxdomain.TagRecord{
+			Name:    normalized.name,
+			Slug:    normalized.slug,
+			Details: details,
+		})
+		tag = created
+		return err
+	})
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	return tag, nil
+}
+
+// UpdateTag renames the tag at slug, moves it to input.Slug when one is given and
+// changes the details set in input.Details.
+func (s *Service) UpdateTag(ctx context.Context, slug string, input taxdomain.UpdateTagInput) (taxdomain.Tag, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return taxdomain.Tag{}, taxdomain.ErrTagNotFound
+	}
+	normalized, err := normalizeRename(input.Name, input.Slug, slug)
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	current, err := s.repo.GetTag(ctx, slug)
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	details := input.Details.Apply(current.Details)
+	if err := details.Validate(); err != nil {
+		return taxdomain.Tag{}, err
+	}
+	var tag taxdomain.Tag
+	err = s.record(ctx, renameEvent(taxdomain.KindTag, slug, normalized), func(ctx context.Context) error {
+		updated, err := s.repo.UpdateTag(ctx, slug, taxdomain.TagRecord{
+			Name:    normalized.name,
+			Slug:    normalized.slug,
+			Details: details,
+		})
+		tag = updated
+		return err
 	})
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	return tag, nil
 }
 
 // DeleteTag removes a tag by slug.
@@ -65,23 +242,181 @@ func (s *Service) DeleteTag(ctx context.Context, slug string) error {
 	if strings.TrimSpace(slug) == "" {
 		return errors.New("taxonomy: tag slug is required")
 	}
-	return s.repo.DeleteTag(ctx, strings.TrimSpace(slug))
+	slug = strings.TrimSpace(slug)
+	return s.record(ctx, taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeDeleted, Slug: slug}, func(ctx context.Context) error {
+		return s.repo.DeleteTag(ctx, slug)
+	})
+}
+
+// MergeTags moves the posts of the source tags to target and deletes the sources,
+// keeping their slugs as aliases of target so old links redirect.
+func (s *Service) MergeTags(ctx context.Context, target string, sources []string) (taxdomain.Tag, error) {
+	target = strings.ToLower(strings.TrimSpace(target))
+	if target == "" {
+		return taxdomain.Tag{}, taxdomain.ErrTagNotFound
+	}
+	var slugs []string
+	seen := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if source == "" || seen[source] {
+			continue
+		}
+		if source == target {
+			return taxdomain.Tag{}, taxdomain.ErrMergeIntoSelf
+		}
+		seen[source] = true
+		slugs = append(slugs, source)
+	}
+	if len(slugs) == 0 {
+		return taxdomain.Tag{}, taxdomain.ErrMergeSourceRequired
+	}
+	events := make([]taxdomain.ChangeEvent, len(slugs))
+	for i, source := range slugs {
+		events[i] = taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeMerged, Slug: source, MergedInto: target}
+	}
+	var tag taxdomain.Tag
+	err := s.recordAll(ctx, events, func(ctx context.Context) error {
+		merged, err := s.repo.MergeTags(ctx, target, slugs)
+		tag = merged
+		return err
+	})
+	if err != nil {
+		return taxdomain.Tag{}, err
+	}
+	return tag, nil
+}
+
+// ListDuplicateTags groups tags whose names look alike, as candidates for a merge.
+func (s *Service) ListDuplicateTags(ctx context.Context) ([]taxdomain.DuplicateTagGroup, error) {
+	tags, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groupNearDuplicates(tags), nil
+}
+
+// ResolveTagAlias returns the tag a merged tag's slug now points to.
+func (s *Service) ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return taxdomain.Tag{}, taxdomain.ErrAliasNotFound
+	}
+	return s.repo.ResolveTagAlias(ctx, slug)
+}
+
+// ListTagAliases lists every tag alias by slug.
+func (s *Service) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	return s.repo.ListTagAliases(ctx)
+}
+
+// DeleteTagAlias removes an alias; links using it stop redirecting.
+func (s *Service) DeleteTagAlias(ctx context.Context, slug string) error {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return taxdomain.ErrAliasNotFound
+	}
+	return s.repo.DeleteTagAlias(ctx, slug)
+}
+
+// record runs change and records event for it in one transaction.
+func (s *Service) record(ctx context.Context, event taxdomain.ChangeEvent, change func(ctx context.Context) error) error {
+	return s.recordAll(ctx, []taxdomain.ChangeEvent{event}, change)
+}
+
+// recordAll is record for changes touching several categories or tags.
+func (s *Service) recordAll(ctx context.Context, events []taxdomain.ChangeEvent, change func(ctx context.Context) error) error {
+	return outbox.Record(ctx, s.outbox, func(ctx context.Context) ([]outbox.Message, error) {
+		if err := change(ctx); err != nil {
+			return nil, err
+		}
+		messages := make([]outbox.Message, len(events))
+		for i, event := range events {
+			msg, err := outbox.NewMessage(taxdomain.EventTaxonomyChanged, event.Kind+":"+event.Slug, event)
+			if err != nil {
+				return nil, err
+			}
+			messages[i] = msg
+		}
+		return messages, nil
+	})
+}
+
+// renameEvent describes an update of the category or tag at slug.
+func renameEvent(kind, slug string, normalized normalizedPair) taxdomain.ChangeEvent {
+	event := taxdomain.ChangeEvent{Kind: kind, Change: taxdomain.ChangeUpdated, Slug: normalized.slug, Name: normalized.name}
+	if normalized.slug != slug {
+		event.PreviousSlug = slug
+	}
+	return event
+}
+
+// resolveParent looks up parentSlug and rejects parents that would create a cycle with
+// the category identified by selfID (zero for new categories).
+func (s *Service) resolveParent(ctx context.Context, selfID int64, parentSlug string) (*int64, error) {
+	parentSlug = strings.TrimSpace(parentSlug)
+	if parentSlug == "" {
+		return nil, nil
+	}
+	parent, err := s.repo.GetCategory(ctx, parentSlug)
+	if errors.Is(err, taxdomain.ErrCategoryNotFound) {
+		return nil, taxdomain.ErrParentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if selfID != 0 {
+		if parent.ID == selfID {
+			return nil, taxdomain.ErrParentCycle
+		}
+		ancestors, err := s.repo.ListCategoryAncestors(ctx, parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range ancestors {
+			if a.ID == selfID {
+				return nil, taxdomain.ErrParentCycle
+			}
+		}
+	}
+	return &parent.ID, nil
 }
 
 type normalizedPair struct {
-	name string
-	slug string
+	name      string
+	slug      string
+	generated bool
 }
 
-func normalizeNameSlug(name, slug string) (normalizedPair, error) {
+// normalizeNameSlug trims the name and runs the slug (or the name, when the slug is
+// blank) through the shared slug generator. Generated slugs still need a uniqueness check.
+func normalizeNameSlug(name, rawSlug string) (normalizedPair, error) {
 	n := strings.TrimSpace(name)
-	s := strings.TrimSpace(slug)
 	if n == "" {
-		return normalizedPair{}, errors.New("taxonomy: name is required")
+		return normalizedPair{}, taxdomain.ErrNameRequired
+	}
+	source := strings.TrimSpace(rawSlug)
+	generated := source == ""
+	if generated {
+		source = n
 	}
+	s := slug.Make(source)
 	if s == "" {
-		return normalizedPair{}, errors.New("taxonomy: slug is required")
+		return normalizedPair{}, taxdomain.ErrSlugRequired
+	}
+	return normalizedPair{name: n, slug: s, generated: generated}, nil
+}
+
+// normalizeRename is normalizeNameSlug for updates, where a blank slug keeps current
+// as it is rather than being generated from the new name.
+func normalizeRename(name, rawSlug, current string) (normalizedPair, error) {
+	if strings.TrimSpace(rawSlug) != "" {
+		return normalizeNameSlug(name, rawSlug)
+	}
+	n := strings.TrimSpace(name)
+	if n == "" {
+		return normalizedPair{}, taxdomain.ErrNameRequired
 	}
-	return normalizedPair{name: n, slug: strings.ToLower(s)}, nil
+	return normalizedPair{name: n, slug: current}, nil
 }
 
@@ -2,15 +2,17 @@
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
+	"proto-gin-web/internal/platform/outbox"
 )
 
 type mockRepo struct {
-	categoryInput taxdomain.CreateCategoryInput
-	tagInput      taxdomain.CreateTagInput
+	categoryInput taxdomain.CategoryRecord
+	tagInput      taxdomain.TagRecord
 	categorySlug  string
 	tagSlug       string
 
@@ -20,33 +22,121 @@ type mockRepo struct {
 	errCategory error
 	errTag      error
 	errDelete   error
+
+	existingSlugs map[string]bool
+	// categories, when set, backs GetCategory, ListCategories and ListCategoryAncestors.
+	categories []taxdomain.Category
+
+	updateSlug     string
+	categoryUpdate taxdomain.CategoryRecord
+	tagUpdate      taxdomain.TagRecord
+	errUpdate      error
+
+	mergeTarget  string
+	mergeSources []string
+}
+
+func (m *mockRepo) ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error) {
+	out := make([]taxdomain.CategoryWithCount, len(m.categories))
+	for i, c := range m.categories {
+		out[i] = taxdomain.CategoryWithCount{Category: c}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error) {
+	if m.categories == nil {
+		return taxdomain.CategoryWithCount{Category: m.categoryResult}, m.errCategory
+	}
+	for _, c := range m.categories {
+		if c.Slug == slug {
+			return taxdomain.CategoryWithCount{Category: c}, nil
+		}
+	}
+	return taxdomain.CategoryWithCount{}, taxdomain.ErrCategoryNotFound
+}
+
+func (m *mockRepo) ListCategoryAncestors(ctx context.Context, id int64) ([]taxdomain.Category, error) {
+	byID := make(map[int64]taxdomain.Category, len(m.categories))
+	for _, c := range m.categories {
+		byID[c.ID] = c
+	}
+	var chain []taxdomain.Category
+	for parentID := byID[id].ParentID; parentID != nil; parentID = byID[*parentID].ParentID {
+		chain = append([]taxdomain.Category{byID[*parentID]}, chain...)
+	}
+	return chain, nil
+}
+
+func (m *mockRepo) UpdateCategory(ctx context.Context, slug string, input taxdomain.CategoryRecord) (taxdomain.Category, error) {
+	m.updateSlug, m.categoryUpdate = slug, input
+	return m.categoryResult, m.errUpdate
+}
+
+func (m *mockRepo) ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	return taxdomain.TagWithCount{Tag: m.tagResult}, m.errTag
+}
+
+func (m *mockRepo) UpdateTag(ctx context.Context, slug string, input taxdomain.TagRecord) (taxdomain.Tag, error) {
+	m.updateSlug, m.tagUpdate = slug, input
+	return m.tagResult, m.errUpdate
 }
 
-func (m *mockRepo) CreateCategory(ctx context.Context, input taxdomain.CreateCategoryInput) (taxdomain.Category, error) {
+func (m *mockRepo) CreateCategory(ctx context.Context, input taxdomain.CategoryRecord) (taxdomain.Category, error) {
 	m.categoryInput = input
 	return m.categoryResult, m.errCategory
 }
 
+func (m *mockRepo) CategorySlugExists(ctx context.Context, slug string) (bool, error) {
+	return m.existingSlugs[slug], nil
+}
+
 func (m *mockRepo) DeleteCategory(ctx context.Context, slug string) error {
 	m.categorySlug = slug
 	return m.errDelete
 }
 
-func (m *mockRepo) CreateTag(ctx context.Context, input taxdomain.CreateTagInput) (taxdomain.Tag, error) {
+func (m *mockRepo) CreateTag(ctx context.Context, input taxdomain.TagRecord) (taxdomain.Tag, error) {
 	m.tagInput = input
 	return m.tagResult, m.errTag
 }
 
+func (m *mockRepo) TagSlugExists(ctx context.Context, slug string) (bool, error) {
+	return m.existingSlugs[slug], nil
+}
+
 func (m *mockRepo) DeleteTag(ctx context.Context, slug string) error {
 	m.tagSlug = slug
 	return m.errDelete
 }
 
+func (m *mockRepo) MergeTags(ctx context.Context, targetSlug string, sourceSlugs []string) (taxdomain.Tag, error) {
+	m.mergeTarget, m.mergeSources = targetSlug, sourceSlugs
+	return m.tagResult, m.errTag
+}
+
+func (m *mockRepo) ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error) {
+	return m.tagResult, m.errTag
+}
+
+func (m *mockRepo) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) DeleteTagAlias(ctx context.Context, slug string) error {
+	m.tagSlug = slug
+	return m.errDelete
+}
+
 func TestService_CreateCategory_normalizesInput(t *testing.T) {
 	repo := &mockRepo{
 		categoryResult: taxdomain.Category{ID: 1, Name: "Foo", Slug: "foo"},
 	}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
 	result, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{
 		Name: "  Foo  ",
@@ -64,7 +154,7 @@ func TestService_CreateCategory_normalizesInput(t *testing.T) {
 }
 
 func TestService_CreateCategory_validation(t *testing.T) {
-	svc := NewService(&mockRepo{})
+	svc := NewService(&mockRepo{}, nil)
 
 	_, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{
 		Name: "",
@@ -75,7 +165,7 @@ func TestService_CreateCategory_validation(t *testing.T) {
 	}
 
 	_, err = svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{
-		Name: "Name",
+		Name: "!!!",
 		Slug: "",
 	})
 	if err == nil || err.Error() != "taxonomy: slug is required" {
@@ -83,9 +173,21 @@ func TestService_CreateCategory_validation(t *testing.T) {
 	}
 }
 
+func TestService_CreateCategory_generatesUniqueSlug(t *testing.T) {
+	repo := &mockRepo{existingSlugs: map[string]bool{"go-tips": true}}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "Go Tips"}); err != nil {
+		t.Fatalf("CreateCategory returned error: %v", err)
+	}
+	if repo.categoryInput.Slug != "go-tips-2" {
+		t.Fatalf("expected suffixed slug, got %q", repo.categoryInput.Slug)
+	}
+}
+
 func TestService_DeleteCategory(t *testing.T) {
 	repo := &mockRepo{}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
 	if err := svc.DeleteCategory(context.Background(), "  slug  "); err != nil {
 		t.Fatalf("DeleteCategory returned error: %v", err)
@@ -104,7 +206,7 @@ func TestService_CreateTag_normalizesInput(t *testing.T) {
 	repo := &mockRepo{
 		tagResult: taxdomain.Tag{ID: 1, Name: "Bar", Slug: "bar"},
 	}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
 	result, err := svc.CreateTag(context.Background(), taxdomain.CreateTagInput{
 		Name: "  Bar ",
@@ -122,7 +224,7 @@ func TestService_CreateTag_normalizesInput(t *testing.T) {
 }
 
 func TestService_CreateTag_validation(t *testing.T) {
-	svc := NewService(&mockRepo{})
+	svc := NewService(&mockRepo{}, nil)
 
 	_, err := svc.CreateTag(context.Background(), taxdomain.CreateTagInput{
 		Name: "",
@@ -133,7 +235,7 @@ func TestService_CreateTag_validation(t *testing.T) {
 	}
 
 	_, err = svc.CreateTag(context.Background(), taxdomain.CreateTagInput{
-		Name: "Name",
+		Name: "!!!",
 		Slug: "",
 	})
 	if err == nil || err.Error() != "taxonomy: slug is required" {
@@ -141,9 +243,21 @@ func TestService_CreateTag_validation(t *testing.T) {
 	}
 }
 
+func TestService_CreateTag_transliteratesName(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.CreateTag(context.Background(), taxdomain.CreateTagInput{Name: "数据库"}); err != nil {
+		t.Fatalf("CreateTag returned error: %v", err)
+	}
+	if repo.tagInput.Slug != "shu-ju-ku" {
+		t.Fatalf("expected pinyin slug, got %q", repo.tagInput.Slug)
+	}
+}
+
 func TestService_DeleteTag(t *testing.T) {
 	repo := &mockRepo{}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
 	if err := svc.DeleteTag(context.Background(), "  slug "); err != nil {
 		t.Fatalf("DeleteTag returned error: %v", err)
@@ -158,13 +272,172 @@ func TestService_DeleteTag(t *testing.T) {
 	}
 }
 
+func TestService_GetCategory_blankSlugIsNotFound(t *testing.T) {
+	svc := NewService(&mockRepo{}, nil)
+
+	if _, err := svc.GetCategory(context.Background(), "  "); !errors.Is(err, taxdomain.ErrCategoryNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestService_UpdateCategory_keepsSlugWhenBlank(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.UpdateCategory(context.Background(), " news ", taxdomain.UpdateCategoryInput{Name: "  Latest News "}); err != nil {
+		t.Fatalf("UpdateCategory returned error: %v", err)
+	}
+	if repo.updateSlug != "news" || repo.categoryUpdate != (taxdomain.CategoryRecord{Name: "Latest News", Slug: "news"}) {
+		t.Fatalf("unexpected update %q %+v", repo.updateSlug, repo.categoryUpdate)
+	}
+}
+
+func TestService_UpdateCategory_validation(t *testing.T) {
+	svc := NewService(&mockRepo{}, nil)
+
+	if _, err := svc.UpdateCategory(context.Background(), "news", taxdomain.UpdateCategoryInput{Name: " "}); !errors.Is(err, taxdomain.ErrNameRequired) {
+		t.Fatalf("expected name error, got %v", err)
+	}
+	if _, err := svc.UpdateCategory(context.Background(), "news", taxdomain.UpdateCategoryInput{Name: "News", Slug: "!!!"}); !errors.Is(err, taxdomain.ErrSlugRequired) {
+		t.Fatalf("expected slug error, got %v", err)
+	}
+	if _, err := svc.UpdateCategory(context.Background(), "", taxdomain.UpdateCategoryInput{Name: "News"}); !errors.Is(err, taxdomain.ErrCategoryNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestService_UpdateTag_normalizesNewSlug(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.UpdateTag(context.Background(), "golang", taxdomain.UpdateTagInput{Name: "Go", Slug: " Go Lang "}); err != nil {
+		t.Fatalf("UpdateTag returned error: %v", err)
+	}
+	if repo.updateSlug != "golang" || repo.tagUpdate != (taxdomain.TagRecord{Name: "Go", Slug: "go-lang"}) {
+		t.Fatalf("unexpected update %q %+v", repo.updateSlug, repo.tagUpdate)
+	}
+}
+
+func TestService_UpdateTag_patchesDetails(t *testing.T) {
+	repo := &mockRepo{tagResult: taxdomain.Tag{Name: "Go", Slug: "go", Details: taxdomain.Details{
+		DescriptionMD: "All things *Go*.",
+		CoverURL:      "/static/go.png",
+		Position:      2,
+	}}}
+	svc := NewService(repo, nil)
+
+	title := "  Go articles "
+	if _, err := svc.UpdateTag(context.Background(), "go", taxdomain.UpdateTagInput{Name: "Go", Details: taxdomain.DetailsPatch{MetaTitle: &title}}); err != nil {
+		t.Fatalf("UpdateTag returned error: %v", err)
+	}
+	want := taxdomain.Details{DescriptionMD: "All things *Go*.", CoverURL: "/static/go.png", MetaTitle: "Go articles", Position: 2}
+	if repo.tagUpdate.Details != want {
+		t.Fatalf("unexpected details %+v", repo.tagUpdate.Details)
+	}
+}
+
+func TestService_CreateCategory_rejectsInvalidCover(t *testing.T) {
+	svc := NewService(&mockRepo{}, nil)
+
+	for _, cover := range []string{"javascript:alert(1)", "//evil.example/x.png", "images/x.png"} {
+		_, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "News", Details: taxdomain.DetailsPatch{CoverURL: &cover}})
+		if !errors.Is(err, taxdomain.ErrCoverInvalid) {
+			t.Fatalf("cover %q: expected ErrCoverInvalid, got %v", cover, err)
+		}
+	}
+	for _, cover := range []string{"", "/uploads/news.png", "https://cdn.example.com/news.png"} {
+		if _, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "News", Details: taxdomain.DetailsPatch{CoverURL: &cover}}); err != nil {
+			t.Fatalf("cover %q: unexpected error %v", cover, err)
+		}
+	}
+}
+
+func nestedCategories() []taxdomain.Category {
+	backend, golang := int64(1), int64(2)
+	return []taxdomain.Category{
+		{ID: 1, Name: "Backend", Slug: "backend"},
+		{ID: 3, Name: "Gin", Slug: "gin", ParentID: &golang},
+		{ID: 2, Name: "Go", Slug: "go", ParentID: &backend},
+		{ID: 4, Name: "News", Slug: "news"},
+	}
+}
+
+func TestService_CreateCategory_resolvesParent(t *testing.T) {
+	repo := &mockRepo{categories: nestedCategories()}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "Echo", ParentSlug: " go "}); err != nil {
+		t.Fatalf("CreateCategory returned error: %v", err)
+	}
+	if repo.categoryInput.ParentID == nil || *repo.categoryInput.ParentID != 2 {
+		t.Fatalf("expected parent 2, got %+v", repo.categoryInput.ParentID)
+	}
+
+	if _, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "Echo", ParentSlug: "missing"}); !errors.Is(err, taxdomain.ErrParentNotFound) {
+		t.Fatalf("expected parent not found, got %v", err)
+	}
+}
+
+func TestService_UpdateCategory_parent(t *testing.T) {
+	repo := &mockRepo{categories: nestedCategories()}
+	svc := NewService(repo, nil)
+	ptr := func(s string) *string { return &s }
+
+	if _, err := svc.UpdateCategory(context.Background(), "go", taxdomain.UpdateCategoryInput{Name: "Go"}); err != nil {
+		t.Fatalf("UpdateCategory returned error: %v", err)
+	}
+	if repo.categoryUpdate.ParentID == nil || *repo.categoryUpdate.ParentID != 1 {
+		t.Fatalf("expected the parent to be kept, got %+v", repo.categoryUpdate.ParentID)
+	}
+
+	if _, err := svc.UpdateCategory(context.Background(), "go", taxdomain.UpdateCategoryInput{Name: "Go", ParentSlug: ptr("")}); err != nil {
+		t.Fatalf("UpdateCategory returned error: %v", err)
+	}
+	if repo.categoryUpdate.ParentID != nil {
+		t.Fatalf("expected a top-level category, got parent %d", *repo.categoryUpdate.ParentID)
+	}
+
+	for _, parent := range []string{"backend", "go", "gin"} {
+		_, err := svc.UpdateCategory(context.Background(), "backend", taxdomain.UpdateCategoryInput{Name: "Backend", ParentSlug: ptr(parent)})
+		if !errors.Is(err, taxdomain.ErrParentCycle) {
+			t.Fatalf("parent %q: expected cycle error, got %v", parent, err)
+		}
+	}
+
+	if _, err := svc.UpdateCategory(context.Background(), "gin", taxdomain.UpdateCategoryInput{Name: "Gin", ParentSlug: ptr("news")}); err != nil {
+		t.Fatalf("UpdateCategory returned error: %v", err)
+	}
+	if repo.categoryUpdate.ParentID == nil || *repo.categoryUpdate.ParentID != 4 {
+		t.Fatalf("expected parent 4, got %+v", repo.categoryUpdate.ParentID)
+	}
+}
+
+func TestService_ListCategoryTree(t *testing.T) {
+	svc := NewService(&mockRepo{categories: nestedCategories()}, nil)
+
+	tree, err := svc.ListCategoryTree(context.Background())
+	if err != nil {
+		t.Fatalf("ListCategoryTree returned error: %v", err)
+	}
+	if len(tree) != 2 || tree[0].Slug != "backend" || tree[1].Slug != "news" {
+		t.Fatalf("unexpected roots %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Slug != "go" ||
+		len(tree[0].Children[0].Children) != 1 || tree[0].Children[0].Children[0].Slug != "gin" {
+		t.Fatalf("unexpected nesting %+v", tree[0])
+	}
+	if tree[1].Children == nil {
+		t.Fatal("leaf children should encode as an empty list")
+	}
+}
+
 func TestService_RepoErrorsPropagated(t *testing.T) {
 	repo := &mockRepo{
 		errCategory: errors.New("db error"),
 		errTag:      errors.New("db error"),
 		errDelete:   errors.New("delete error"),
 	}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
 	_, err := svc.CreateCategory(context.Background(), taxdomain.CreateCategoryInput{Name: "Foo", Slug: "foo"})
 	if err == nil || err.Error() != "db error" {
@@ -185,5 +458,142 @@ func TestService_RepoErrorsPropagated(t *testing.T) {
 	if err == nil || err.Error() != "delete error" {
 		t.Fatalf("expected delete error, got %v", err)
 	}
+
+	repo.errTag, repo.errUpdate = nil, taxdomain.ErrSlugTaken
+	if _, err = svc.UpdateTag(context.Background(), "slug", taxdomain.UpdateTagInput{Name: "Bar", Slug: "taken"}); !errors.Is(err, taxdomain.ErrSlugTaken) {
+		t.Fatalf("expected slug taken, got %v", err)
+	}
+}
+
+func TestService_RecordsRenameWithPreviousSlug(t *testing.T) {
+	events := &recordingOutbox{}
+	svc := NewService(&mockRepo{}, events)
+
+	if _, err := svc.UpdateCategory(context.Background(), "news", taxdomain.UpdateCategoryInput{Name: "Updates", Slug: "updates"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.UpdateTag(context.Background(), "go", taxdomain.UpdateTagInput{Name: "Golang"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.messages) != 2 {
+		t.Fatalf("expected two events, got %+v", events.messages)
+	}
+	want := []taxdomain.ChangeEvent{
+		{Kind: taxdomain.KindCategory, Change: taxdomain.ChangeUpdated, Slug: "updates", Name: "Updates", PreviousSlug: "news"},
+		{Kind: taxdomain.KindTag, Change: taxdomain.ChangeUpdated, Slug: "go", Name: "Golang"},
+	}
+	for i, message := range events.messages {
+		var payload taxdomain.ChangeEvent
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			t.Fatalf("payload: %v", err)
+		}
+		if payload != want[i] {
+			t.Fatalf("event %d: got %+v, want %+v", i, payload, want[i])
+		}
+	}
+}
+
+func TestService_RecordsTaxonomyChanged(t *testing.T) {
+	events := &recordingOutbox{}
+	repo := &mockRepo{errDelete: errors.New("boom")}
+	svc := NewService(repo, events)
+
+	if _, err := svc.CreateTag(context.Background(), taxdomain.CreateTagInput{Name: "Go"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteCategory(context.Background(), "news"); err == nil {
+		t.Fatal("expected the delete error")
+	}
+	if len(events.messages) != 1 || events.messages[0].Type != taxdomain.EventTaxonomyChanged || events.messages[0].Key != "tag:go" {
+		t.Fatalf("expected one event for the created tag, got %+v", events.messages)
+	}
+	var payload taxdomain.ChangeEvent
+	if err := json.Unmarshal(events.messages[0].Payload, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload != (taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeCreated, Slug: "go", Name: "Go"}) {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestService_MergeTags_normalizesSources(t *testing.T) {
+	events := &recordingOutbox{}
+	repo := &mockRepo{tagResult: taxdomain.Tag{ID: 1, Name: "Go", Slug: "go"}}
+	svc := NewService(repo, events)
+
+	tag, err := svc.MergeTags(context.Background(), " GO ", []string{" Golang", "go-lang", "golang", " "})
+	if err != nil {
+		t.Fatalf("MergeTags returned error: %v", err)
+	}
+	if tag.Slug != "go" {
+		t.Fatalf("unexpected tag %+v", tag)
+	}
+	if repo.mergeTarget != "go" || len(repo.mergeSources) != 2 || repo.mergeSources[0] != "golang" || repo.mergeSources[1] != "go-lang" {
+		t.Fatalf("unexpected merge call %q <- %v", repo.mergeTarget, repo.mergeSources)
+	}
+	if len(events.messages) != 2 {
+		t.Fatalf("expected one event per source, got %+v", events.messages)
+	}
+	var payload taxdomain.ChangeEvent
+	if err := json.Unmarshal(events.messages[1].Payload, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload != (taxdomain.ChangeEvent{Kind: taxdomain.KindTag, Change: taxdomain.ChangeMerged, Slug: "go-lang", MergedInto: "go"}) {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
 }
 
+func TestService_MergeTags_validation(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, nil)
+
+	if _, err := svc.MergeTags(context.Background(), "go", []string{"golang", "Go"}); !errors.Is(err, taxdomain.ErrMergeIntoSelf) {
+		t.Fatalf("expected merge into self, got %v", err)
+	}
+	if _, err := svc.MergeTags(context.Background(), "go", []string{" "}); !errors.Is(err, taxdomain.ErrMergeSourceRequired) {
+		t.Fatalf("expected source required, got %v", err)
+	}
+	if repo.mergeTarget != "" {
+		t.Fatalf("repository should not be called, got target %q", repo.mergeTarget)
+	}
+}
+
+func TestGroupNearDuplicates(t *testing.T) {
+	tags := []taxdomain.TagWithCount{
+		{Tag: taxdomain.Tag{Name: "go", Slug: "go"}, PostCount: 2},
+		{Tag: taxdomain.Tag{Name: "Docker", Slug: "docker"}, PostCount: 4},
+		{Tag: taxdomain.Tag{Name: "golang", Slug: "golang"}, PostCount: 9},
+		{Tag: taxdomain.Tag{Name: "Go-lang", Slug: "go-lang"}, PostCount: 1},
+		{Tag: taxdomain.Tag{Name: "Kubernetes", Slug: "kubernetes"}, PostCount: 3},
+		{Tag: taxdomain.Tag{Name: "Kubernetis", Slug: "kubernetis"}, PostCount: 1},
+		{Tag: taxdomain.Tag{Name: "Rust", Slug: "rust"}, PostCount: 5},
+	}
+
+	groups := groupNearDuplicates(tags)
+	if len(groups) != 2 {
+		t.Fatalf("expected two groups, got %+v", groups)
+	}
+	var slugs []string
+	for _, tag := range groups[0].Tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	if len(slugs) != 3 || slugs[0] != "golang" || slugs[1] != "go" || slugs[2] != "go-lang" {
+		t.Fatalf("unexpected go group %v", slugs)
+	}
+	if len(groups[1].Tags) != 2 || groups[1].Tags[0].Slug != "kubernetes" {
+		t.Fatalf("unexpected kubernetes group %+v", groups[1])
+	}
+}
+
+type recordingOutbox struct {
+	messages []outbox.Message
+}
+
+func (r *recordingOutbox) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *recordingOutbox) Append(ctx context.Context, messages ...outbox.Message) error {
+	r.messages = append(r.messages, messages...)
+	return nil
+}
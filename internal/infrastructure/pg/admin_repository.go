@@ -75,6 +75,17 @@ func (r *AdminAccountRepository) UpdateProfile(ctx context.Context, email string
 	return mapStoredAdmin(user), nil
 }
 
+func (r *AdminAccountRepository) UpdateRole(ctx context.Context, id, roleID int64) (authdomain.StoredAdmin, error) {
+	user, err := r.queries.UpdateUserRole(ctx, id, roleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return authdomain.StoredAdmin{}, authdomain.ErrAdminNotFound
+		}
+		return authdomain.StoredAdmin{}, err
+	}
+	return mapStoredAdmin(user), nil
+}
+
 func (r *AdminAccountRepository) FindRoleByName(ctx context.Context, name string) (authdomain.AdminRole, error) {
 	role, err := r.queries.GetRoleByName(ctx, name)
 	if err != nil {
@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// AutosaveRepository implements postdomain.AutosaveRepository backed by pgx queries.
+type AutosaveRepository struct {
+	queries *Queries
+}
+
+// NewAutosaveRepository constructs an AutosaveRepository from a pool.
+func NewAutosaveRepository(pool *pgxpool.Pool) *AutosaveRepository {
+	return &AutosaveRepository{queries: New(pool)}
+}
+
+var _ postdomain.AutosaveRepository = (*AutosaveRepository)(nil)
+
+func (r *AutosaveRepository) SaveAutosave(ctx context.Context, input postdomain.AutosaveInput) (postdomain.Autosave, error) {
+	fields, err := encodeCustomFields(input.CustomFields)
+	if err != nil {
+		return postdomain.Autosave{}, err
+	}
+	row, err := r.queries.SavePostAutosave(ctx, SavePostAutosaveParams{
+		Slug:         input.Slug,
+		UserID:       input.UserID,
+		Title:        input.Title,
+		Summary:      input.Summary,
+		ContentMd:    input.ContentMD,
+		CoverURL:     input.CoverURL,
+		CustomFields: fields,
+	})
+	if err != nil {
+		// the upsert selects from post, so no row means no post
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.Autosave{}, postdomain.ErrPostNotFound
+		}
+		return postdomain.Autosave{}, err
+	}
+	return mapAutosave(row), nil
+}
+
+func (r *AutosaveRepository) GetAutosave(ctx context.Context, slug string, userID int64) (postdomain.Autosave, error) {
+	row, err := r.queries.GetPostAutosave(ctx, slug, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.Autosave{}, postdomain.ErrAutosaveNotFound
+		}
+		return postdomain.Autosave{}, err
+	}
+	return mapAutosave(row), nil
+}
+
+func (r *AutosaveRepository) DeleteAutosave(ctx context.Context, slug string, userID int64) error {
+	return r.queries.DeletePostAutosave(ctx, slug, userID)
+}
+
+func mapAutosave(a PostAutosave) postdomain.Autosave {
+	fields := map[string]any{}
+	if len(a.CustomFields) > 0 {
+		_ = json.Unmarshal(a.CustomFields, &fields)
+	}
+	return postdomain.Autosave{
+		PostID:       a.PostID,
+		UserID:       a.UserID,
+		Title:        a.Title,
+		Summary:      a.Summary,
+		ContentMD:    a.ContentMd,
+		CoverURL:     a.CoverURL,
+		CustomFields: fields,
+		SavedAt:      a.SavedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// CustomFieldRepository implements postdomain.FieldDefinitionRepository backed by pgx queries.
+type CustomFieldRepository struct {
+	queries *Queries
+}
+
+// NewCustomFieldRepository constructs a CustomFieldRepository from a pool.
+func NewCustomFieldRepository(pool *pgxpool.Pool) *CustomFieldRepository {
+	return &CustomFieldRepository{queries: New(pool)}
+}
+
+var _ postdomain.FieldDefinitionRepository = (*CustomFieldRepository)(nil)
+
+func (r *CustomFieldRepository) ListFieldDefinitions(ctx context.Context) ([]postdomain.FieldDefinition, error) {
+	rows, err := r.queries.ListCustomFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.FieldDefinition, len(rows))
+	for i, f := range rows {
+		out[i] = mapCustomField(f)
+	}
+	return out, nil
+}
+
+func (r *CustomFieldRepository) GetFieldDefinition(ctx context.Context, key string) (postdomain.FieldDefinition, error) {
+	row, err := r.queries.GetCustomFieldByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.FieldDefinition{}, postdomain.ErrFieldNotFound
+		}
+		return postdomain.FieldDefinition{}, err
+	}
+	return mapCustomField(row), nil
+}
+
+func (r *CustomFieldRepository) CreateFieldDefinition(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	categoryID, err := r.categoryID(ctx, input.CategorySlug)
+	if err != nil {
+		return postdomain.FieldDefinition{}, err
+	}
+	row, err := r.queries.CreateCustomField(ctx, CreateCustomFieldParams{
+		Key:        input.Key,
+		Label:      input.Label,
+		Type:       string(input.Type),
+		Required:   input.Required,
+		Options:    fieldOptions(input.Options),
+		CategoryID: categoryID,
+		Position:   input.Position,
+	})
+	if err != nil {
+		if errors.Is(err, ErrCustomFieldKeyAlreadyExists) {
+			return postdomain.FieldDefinition{}, postdomain.ErrFieldKeyTaken
+		}
+		return postdomain.FieldDefinition{}, err
+	}
+	return mapCustomField(row), nil
+}
+
+func (r *CustomFieldRepository) UpdateFieldDefinition(ctx context.Context, input postdomain.FieldDefinitionInput) (postdomain.FieldDefinition, error) {
+	categoryID, err := r.categoryID(ctx, input.CategorySlug)
+	if err != nil {
+		return postdomain.FieldDefinition{}, err
+	}
+	row, err := r.queries.UpdateCustomField(ctx, UpdateCustomFieldParams{
+		Key:        input.Key,
+		Label:      input.Label,
+		Type:       string(input.Type),
+		Required:   input.Required,
+		Options:    fieldOptions(input.Options),
+		CategoryID: categoryID,
+		Position:   input.Position,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.FieldDefinition{}, postdomain.ErrFieldNotFound
+		}
+		return postdomain.FieldDefinition{}, err
+	}
+	return mapCustomField(row), nil
+}
+
+func (r *CustomFieldRepository) DeleteFieldDefinition(ctx context.Context, key string) error {
+	n, err := r.queries.DeleteCustomField(ctx, key)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return postdomain.ErrFieldNotFound
+	}
+	return nil
+}
+
+// categoryID resolves the optional category scope; an empty slug means every post.
+func (r *CustomFieldRepository) categoryID(ctx context.Context, slug string) (*int64, error) {
+	if slug == "" {
+		return nil, nil
+	}
+	id, err := r.queries.GetCategoryIDBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, postdomain.ErrFieldCategoryNotFound
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+// fieldOptions keeps the NOT NULL options column satisfied for non-enum fields.
+func fieldOptions(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return options
+}
+
+func mapCustomField(f CustomField) postdomain.FieldDefinition {
+	return postdomain.FieldDefinition{
+		ID:           f.ID,
+		Key:          f.Key,
+		Label:        f.Label,
+		Type:         postdomain.FieldType(f.Type),
+		Required:     f.Required,
+		Options:      f.Options,
+		CategorySlug: f.CategorySlug,
+		Position:     f.Position,
+		CreatedAt:    f.CreatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// LinkCheckRepository implements postdomain.LinkCheckRepository backed by pgx queries.
+type LinkCheckRepository struct {
+	queries *Queries
+}
+
+// NewLinkCheckRepository constructs a LinkCheckRepository from a pool.
+func NewLinkCheckRepository(pool *pgxpool.Pool) *LinkCheckRepository {
+	return &LinkCheckRepository{queries: New(pool)}
+}
+
+var _ postdomain.LinkCheckRepository = (*LinkCheckRepository)(nil)
+
+func (r *LinkCheckRepository) ListLinkSources(ctx context.Context) ([]postdomain.LinkSource, error) {
+	rows, err := r.queries.ListLinkSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.LinkSource, len(rows))
+	for i, row := range rows {
+		out[i] = postdomain.LinkSource{
+			PostID:    row.PostID,
+			Slug:      row.Slug,
+			Title:     row.Title,
+			ContentMD: row.ContentMd,
+			CoverURL:  row.CoverURL,
+		}
+	}
+	return out, nil
+}
+
+// ReplaceLinkFindings deletes and inserts in one statement, so readers never see a
+// post half checked.
+func (r *LinkCheckRepository) ReplaceLinkFindings(ctx context.Context, postID int64, findings []postdomain.LinkFinding) error {
+	params := ReplaceLinkFindingsParams{
+		PostID:    postID,
+		Kinds:     make([]string, len(findings)),
+		URLs:      make([]string, len(findings)),
+		CheckedAt: time.Now(),
+	}
+	for i, f := range findings {
+		params.Kinds[i] = f.Kind
+		params.URLs[i] = f.URL
+		params.CheckedAt = f.CheckedAt
+	}
+	return r.queries.ReplaceLinkFindings(ctx, params)
+}
+
+func (r *LinkCheckRepository) ListLinkFindings(ctx context.Context) ([]postdomain.LinkFinding, error) {
+	rows, err := r.queries.ListLinkFindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapLinkFindings(rows), nil
+}
+
+func (r *LinkCheckRepository) ListLinkFindingsByPost(ctx context.Context, slug string) ([]postdomain.LinkFinding, error) {
+	rows, err := r.queries.ListLinkFindingsByPost(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return mapLinkFindings(rows), nil
+}
+
+func mapLinkFindings(rows []LinkFinding) []postdomain.LinkFinding {
+	out := make([]postdomain.LinkFinding, len(rows))
+	for i, f := range rows {
+		out[i] = postdomain.LinkFinding{
+			PostID:    f.PostID,
+			PostSlug:  f.PostSlug,
+			PostTitle: f.PostTitle,
+			Kind:      f.Kind,
+			URL:       f.URL,
+			CheckedAt: f.CheckedAt,
+		}
+	}
+	return out
+}
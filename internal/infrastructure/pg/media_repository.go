@@ -0,0 +1,213 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	mediadomain "proto-gin-web/internal/contexts/admin/media/domain"
+)
+
+// MediaRepository implements mediadomain.MediaRepository backed by pgx queries.
+type MediaRepository struct {
+	queries *Queries
+}
+
+// NewMediaRepository constructs a MediaRepository from a pool.
+func NewMediaRepository(pool *pgxpool.Pool) *MediaRepository {
+	return &MediaRepository{queries: New(pool)}
+}
+
+var _ mediadomain.MediaRepository = (*MediaRepository)(nil)
+
+func (r *MediaRepository) ListMedia(ctx context.Context, opts mediadomain.ListOptions) ([]mediadomain.Media, error) {
+	list := r.queries.ListMedia
+	if opts.WithUsage {
+		list = r.queries.ListMediaWithUsage
+	}
+	rows, err := list(ctx, opts.Query, opts.Limit, opts.Offset)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]mediadomain.Media, len(rows))
+	for i, m := range rows {
+		out[i] = mapMedia(m)
+	}
+	if err := r.attachVariants(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *MediaRepository) GetMedia(ctx context.Context, id int64) (mediadomain.Media, error) {
+	return r.mediaResult(ctx)(r.queries.GetMedia(ctx, id))
+}
+
+func (r *MediaRepository) GetMediaByChecksum(ctx context.Context, checksum string) (mediadomain.Media, error) {
+	return r.mediaResult(ctx)(r.queries.GetMediaByChecksum(ctx, checksum))
+}
+
+func (r *MediaRepository) GetMediaByFilename(ctx context.Context, filename string) (mediadomain.Media, error) {
+	return r.mediaResult(ctx)(r.queries.GetMediaByFilename(ctx, filename))
+}
+
+func (r *MediaRepository) CreateMedia(ctx context.Context, record mediadomain.Record) (mediadomain.Media, error) {
+	row, err := r.queries.CreateMedia(ctx, CreateMediaParams{
+		Filename:     record.Filename,
+		OriginalName: record.OriginalName,
+		MimeType:     record.MIMEType,
+		SizeBytes:    record.SizeBytes,
+		Width:        record.Width,
+		Height:       record.Height,
+		AltText:      record.AltText,
+		Caption:      record.Caption,
+		UploadedBy:   record.UploadedBy,
+		Checksum:     record.Checksum,
+	})
+	if err != nil {
+		return mediadomain.Media{}, err
+	}
+	return mapMedia(row), nil
+}
+
+func (r *MediaRepository) UpdateMediaText(ctx context.Context, id int64, input mediadomain.TextInput) (mediadomain.Media, error) {
+	return r.mediaResult(ctx)(r.queries.UpdateMediaText(ctx, id, input.AltText, input.Caption))
+}
+
+func (r *MediaRepository) DeleteMedia(ctx context.Context, id int64) error {
+	n, err := r.queries.DeleteMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return mediadomain.ErrMediaNotFound
+	}
+	return nil
+}
+
+// DeleteUnusedMedia checks for references and deletes in one transaction that holds
+// the content tables in share mode, so saves of posts, pages, categories and tags wait
+// until it is done.
+func (r *MediaRepository) DeleteUnusedMedia(ctx context.Context, id int64) ([]mediadomain.Usage, error) {
+	var usage []mediadomain.Usage
+	err := WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		if err := r.queries.LockMediaContent(ctx); err != nil {
+			return err
+		}
+		found, err := r.ListMediaUsage(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			usage = found
+			return nil
+		}
+		return r.DeleteMedia(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *MediaRepository) ListMediaUsage(ctx context.Context, id int64) ([]mediadomain.Usage, error) {
+	rows, err := r.queries.ListMediaUsage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]mediadomain.Usage, len(rows))
+	for i, u := range rows {
+		out[i] = mediadomain.Usage{Kind: u.Kind, Slug: u.Slug, Title: u.Title}
+	}
+	return out, nil
+}
+
+func (r *MediaRepository) ReplaceMediaVariants(ctx context.Context, mediaID int64, variants []mediadomain.Variant) error {
+	return WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		if err := r.queries.DeleteMediaVariants(ctx, mediaID); err != nil {
+			return err
+		}
+		for _, v := range variants {
+			if err := r.queries.CreateMediaVariant(ctx, MediaVariant{
+				MediaID:   mediaID,
+				Width:     v.Width,
+				Height:    v.Height,
+				Filename:  v.Filename,
+				MimeType:  v.MIMEType,
+				SizeBytes: v.SizeBytes,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// mediaResult maps a single-row lookup, translating a missing row and loading the
+// variants of a found one.
+func (r *MediaRepository) mediaResult(ctx context.Context) func(Media, error) (mediadomain.Media, error) {
+	return func(row Media, err error) (mediadomain.Media, error) {
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return mediadomain.Media{}, mediadomain.ErrMediaNotFound
+			}
+			return mediadomain.Media{}, err
+		}
+		out := []mediadomain.Media{mapMedia(row)}
+		if err := r.attachVariants(ctx, out); err != nil {
+			return mediadomain.Media{}, err
+		}
+		return out[0], nil
+	}
+}
+
+func (r *MediaRepository) attachVariants(ctx context.Context, media []mediadomain.Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(media))
+	index := make(map[int64]int, len(media))
+	for i, m := range media {
+		ids[i] = m.ID
+		index[m.ID] = i
+	}
+	rows, err := r.queries.ListMediaVariants(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, v := range rows {
+		i := index[v.MediaID]
+		media[i].Variants = append(media[i].Variants, mediadomain.Variant{
+			Width:     v.Width,
+			Height:    v.Height,
+			Filename:  v.Filename,
+			URL:       mediadomain.URLFor(v.Filename),
+			MIMEType:  v.MimeType,
+			SizeBytes: v.SizeBytes,
+		})
+	}
+	return nil
+}
+
+func mapMedia(m Media) mediadomain.Media {
+	return mediadomain.Media{
+		ID:           m.ID,
+		Filename:     m.Filename,
+		OriginalName: m.OriginalName,
+		URL:          mediadomain.URLFor(m.Filename),
+		MIMEType:     m.MimeType,
+		SizeBytes:    m.SizeBytes,
+		Width:        m.Width,
+		Height:       m.Height,
+		AltText:      m.AltText,
+		Caption:      m.Caption,
+		UploadedBy:   m.UploadedBy,
+		UploaderName: m.UploaderName,
+		Checksum:     m.Checksum,
+		UsageCount:   m.UsageCount,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+)
+
+// MenuRepository implements menudomain.MenuRepository backed by pgx queries.
+type MenuRepository struct {
+	queries *Queries
+}
+
+// NewMenuRepository constructs a MenuRepository from a pool.
+func NewMenuRepository(pool *pgxpool.Pool) *MenuRepository {
+	return &MenuRepository{queries: New(pool)}
+}
+
+var _ menudomain.MenuRepository = (*MenuRepository)(nil)
+
+func (r *MenuRepository) ListMenus(ctx context.Context) ([]menudomain.Menu, error) {
+	rows, err := r.queries.ListMenus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]menudomain.Menu, len(rows))
+	for i, m := range rows {
+		out[i] = mapMenu(m)
+	}
+	return out, nil
+}
+
+func (r *MenuRepository) GetMenu(ctx context.Context, name string) (menudomain.Menu, error) {
+	row, err := r.queries.GetMenuByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return menudomain.Menu{}, menudomain.ErrMenuNotFound
+		}
+		return menudomain.Menu{}, err
+	}
+	return mapMenu(row), nil
+}
+
+func (r *MenuRepository) CreateMenu(ctx context.Context, input menudomain.MenuInput) (menudomain.Menu, error) {
+	row, err := r.queries.CreateMenu(ctx, input.Name, input.Label)
+	if err != nil {
+		if errors.Is(err, ErrMenuNameAlreadyExists) {
+			return menudomain.Menu{}, menudomain.ErrNameTaken
+		}
+		return menudomain.Menu{}, err
+	}
+	return mapMenu(row), nil
+}
+
+func (r *MenuRepository) DeleteMenu(ctx context.Context, name string) error {
+	n, err := r.queries.DeleteMenu(ctx, name)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return menudomain.ErrMenuNotFound
+	}
+	return nil
+}
+
+func (r *MenuRepository) ListItems(ctx context.Context, menuID int64) ([]menudomain.Item, error) {
+	rows, err := r.queries.ListMenuItems(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]menudomain.Item, len(rows))
+	for i, item := range rows {
+		out[i] = mapMenuItem(item)
+	}
+	return out, nil
+}
+
+func (r *MenuRepository) ResolveTarget(ctx context.Context, targetType, slug string) (int64, error) {
+	var (
+		id  int64
+		err error
+	)
+	switch targetType {
+	case menudomain.TargetPost:
+		id, err = r.queries.GetPostIDBySlug(ctx, slug)
+	case menudomain.TargetPage:
+		id, err = r.queries.GetPageIDBySlug(ctx, slug)
+	case menudomain.TargetCategory:
+		id, err = r.queries.GetCategoryIDBySlug(ctx, slug)
+	case menudomain.TargetTag:
+		id, err = r.queries.GetTagIDBySlug(ctx, slug)
+	default:
+		return 0, menudomain.ErrTargetNotFound
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, menudomain.ErrTargetNotFound
+	}
+	return id, err
+}
+
+func (r *MenuRepository) CreateItem(ctx context.Context, record menudomain.ItemRecord) (menudomain.Item, error) {
+	id, err := r.queries.CreateMenuItem(ctx, menuItemParams(record))
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	return r.getItem(ctx, id)
+}
+
+func (r *MenuRepository) UpdateItem(ctx context.Context, id int64, record menudomain.ItemRecord) (menudomain.Item, error) {
+	n, err := r.queries.UpdateMenuItem(ctx, id, menuItemParams(record))
+	if err != nil {
+		return menudomain.Item{}, err
+	}
+	if n == 0 {
+		return menudomain.Item{}, menudomain.ErrItemNotFound
+	}
+	return r.getItem(ctx, id)
+}
+
+func (r *MenuRepository) DeleteItem(ctx context.Context, menuID, id int64) error {
+	n, err := r.queries.DeleteMenuItem(ctx, menuID, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return menudomain.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *MenuRepository) ReorderItems(ctx context.Context, menuID int64, order []menudomain.ItemPosition) error {
+	ids := make([]int64, len(order))
+	parentIDs := make([]*int64, len(order))
+	positions := make([]int32, len(order))
+	for i, pos := range order {
+		ids[i] = pos.ID
+		parentIDs[i] = pos.ParentID
+		positions[i] = pos.Position
+	}
+	return r.queries.ReorderMenuItems(ctx, menuID, ids, parentIDs, positions)
+}
+
+func (r *MenuRepository) getItem(ctx context.Context, id int64) (menudomain.Item, error) {
+	row, err := r.queries.GetMenuItem(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return menudomain.Item{}, menudomain.ErrItemNotFound
+		}
+		return menudomain.Item{}, err
+	}
+	return mapMenuItem(row), nil
+}
+
+func menuItemParams(record menudomain.ItemRecord) MenuItemParams {
+	return MenuItemParams{
+		MenuID:     record.MenuID,
+		ParentID:   record.ParentID,
+		Label:      record.Label,
+		TargetType: record.TargetType,
+		TargetID:   record.TargetID,
+		URL:        record.URL,
+		Position:   record.Position,
+	}
+}
+
+func mapMenu(m Menu) menudomain.Menu {
+	return menudomain.Menu{ID: m.ID, Name: m.Name, Label: m.Label, CreatedAt: m.CreatedAt}
+}
+
+func mapMenuItem(i MenuItem) menudomain.Item {
+	return menudomain.Item{
+		ID:         i.ID,
+		MenuID:     i.MenuID,
+		ParentID:   i.ParentID,
+		Label:      i.Label,
+		TargetType: i.TargetType,
+		TargetID:   i.TargetID,
+		URL:        i.URL,
+		Position:   i.Position,
+		TargetSlug: i.TargetSlug,
+		TargetPath: i.TargetPath,
+		TargetLive: i.TargetLive,
+	}
+}
@@ -0,0 +1,132 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	newsletterdomain "proto-gin-web/internal/contexts/blog/newsletter/domain"
+)
+
+// NewsletterRepository implements newsletterdomain.NewsletterRepository backed by pgx queries.
+type NewsletterRepository struct {
+	pool    *pgxpool.Pool
+	queries *Queries
+}
+
+// NewNewsletterRepository constructs a NewsletterRepository from a pool.
+func NewNewsletterRepository(pool *pgxpool.Pool) *NewsletterRepository {
+	return &NewsletterRepository{pool: pool, queries: New(pool)}
+}
+
+var _ newsletterdomain.NewsletterRepository = (*NewsletterRepository)(nil)
+
+func (r *NewsletterRepository) GetSubscriberByEmail(ctx context.Context, email string) (newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscriberResult(r.queries.GetNewsletterSubscriberByEmail(ctx, email))
+}
+
+func (r *NewsletterRepository) CreateSubscriber(ctx context.Context, email, tokenHash string) (newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscriberResult(r.queries.CreateNewsletterSubscriber(ctx, email, tokenHash))
+}
+
+func (r *NewsletterRepository) ResetSubscriber(ctx context.Context, id int64, tokenHash string) (newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscriberResult(r.queries.ResetNewsletterSubscriber(ctx, id, tokenHash))
+}
+
+func (r *NewsletterRepository) ConfirmSubscriber(ctx context.Context, tokenHash string, now time.Time) (newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscriberResult(r.queries.ConfirmNewsletterSubscriber(ctx, tokenHash, now))
+}
+
+func (r *NewsletterRepository) Unsubscribe(ctx context.Context, id int64, now time.Time) (newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscriberResult(r.queries.UnsubscribeNewsletterSubscriber(ctx, id, now))
+}
+
+func (r *NewsletterRepository) ListSubscribers(ctx context.Context, status string) ([]newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscribers(r.queries.ListNewsletterSubscribers(ctx, status))
+}
+
+func (r *NewsletterRepository) QueuePost(ctx context.Context, postID int64) error {
+	return r.queries.QueueNewsletterPost(ctx, postID)
+}
+
+func (r *NewsletterRepository) OpenIssue(ctx context.Context, now time.Time, lease time.Duration) (newsletterdomain.Issue, bool, error) {
+	var (
+		issue NewsletterIssue
+		found bool
+	)
+	err := WithinTx(ctx, r.pool, func(ctx context.Context) error {
+		var err error
+		issue, err = r.queries.ClaimNewsletterIssue(ctx, now, now.Add(lease))
+		if errors.Is(err, pgx.ErrNoRows) {
+			issue, err = r.queries.CreateNewsletterIssue(ctx, now.Add(lease))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		found = err == nil
+		return err
+	})
+	if err != nil || !found {
+		return newsletterdomain.Issue{}, false, err
+	}
+	return newsletterdomain.Issue{ID: issue.ID, PostIDs: issue.PostIDs, Cursor: issue.LastSubscriberID}, true, nil
+}
+
+func (r *NewsletterRepository) ListIssuePosts(ctx context.Context, postIDs []int64) ([]newsletterdomain.IssuePost, error) {
+	rows, err := r.queries.ListNewsletterIssuePosts(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]newsletterdomain.IssuePost, len(rows))
+	for i, p := range rows {
+		out[i] = newsletterdomain.IssuePost{ID: p.ID, Title: p.Title, Slug: p.Slug, Summary: p.Summary}
+	}
+	return out, nil
+}
+
+func (r *NewsletterRepository) ListRecipients(ctx context.Context, afterID int64, limit int32) ([]newsletterdomain.Subscriber, error) {
+	return mapNewsletterSubscribers(r.queries.ListNewsletterRecipients(ctx, afterID, limit))
+}
+
+func (r *NewsletterRepository) AdvanceIssue(ctx context.Context, issueID, cursor int64, leaseUntil time.Time) error {
+	return r.queries.AdvanceNewsletterIssue(ctx, issueID, cursor, leaseUntil)
+}
+
+func (r *NewsletterRepository) CompleteIssue(ctx context.Context, issueID int64, now time.Time) error {
+	return r.queries.CompleteNewsletterIssue(ctx, issueID, now)
+}
+
+func mapNewsletterSubscriberResult(s NewsletterSubscriber, err error) (newsletterdomain.Subscriber, error) {
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return newsletterdomain.Subscriber{}, newsletterdomain.ErrSubscriberNotFound
+		}
+		return newsletterdomain.Subscriber{}, err
+	}
+	return mapNewsletterSubscriber(s), nil
+}
+
+func mapNewsletterSubscribers(rows []NewsletterSubscriber, err error) ([]newsletterdomain.Subscriber, error) {
+	if err != nil {
+		return nil, err
+	}
+	out := make([]newsletterdomain.Subscriber, len(rows))
+	for i, s := range rows {
+		out[i] = mapNewsletterSubscriber(s)
+	}
+	return out, nil
+}
+
+func mapNewsletterSubscriber(s NewsletterSubscriber) newsletterdomain.Subscriber {
+	return newsletterdomain.Subscriber{
+		ID:             s.ID,
+		Email:          s.Email,
+		Status:         s.Status,
+		CreatedAt:      s.CreatedAt,
+		ConfirmedAt:    s.ConfirmedAt,
+		UnsubscribedAt: s.UnsubscribedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proto-gin-web/internal/platform/outbox"
+)
+
+// OutboxRepository stores domain events in the outbox table and relay offsets next to
+// them. It also opens the transactions repositories join through their context.
+type OutboxRepository struct {
+	pool    *pgxpool.Pool
+	queries *Queries
+}
+
+// NewOutboxRepository constructs an OutboxRepository from a pool.
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool, queries: New(pool)}
+}
+
+var (
+	_ outbox.Writer = (*OutboxRepository)(nil)
+	_ outbox.Store  = (*OutboxRepository)(nil)
+)
+
+func (r *OutboxRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithinTx(ctx, r.pool, fn)
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, messages ...outbox.Message) error {
+	for _, m := range messages {
+		if err := r.queries.AppendOutbox(ctx, m.Type, m.Key, m.Payload, m.OccurredAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *OutboxRepository) ClaimOffset(ctx context.Context, handler string) (outbox.Position, error) {
+	if err := r.queries.EnsureOutboxOffset(ctx, handler); err != nil {
+		return outbox.Position{}, err
+	}
+	txID, id, err := r.queries.LockOutboxOffset(ctx, handler)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return outbox.Position{}, outbox.ErrOffsetLocked
+		}
+		return outbox.Position{}, err
+	}
+	return outbox.Position{TxID: txID, ID: id}, nil
+}
+
+func (r *OutboxRepository) Fetch(ctx context.Context, after outbox.Position, limit int32) ([]outbox.Message, error) {
+	rows, err := r.queries.FetchOutbox(ctx, after.TxID, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]outbox.Message, len(rows))
+	for i, m := range rows {
+		out[i] = outbox.Message{
+			ID:         m.ID,
+			TxID:       m.TxID,
+			Type:       m.Type,
+			Key:        m.Key,
+			Payload:    m.Payload,
+			OccurredAt: m.OccurredAt,
+		}
+	}
+	return out, nil
+}
+
+func (r *OutboxRepository) SaveOffset(ctx context.Context, handler string, pos outbox.Position) error {
+	return r.queries.SaveOutboxOffset(ctx, handler, pos.TxID, pos.ID)
+}
+
+func (r *OutboxRepository) Cleanup(ctx context.Context, before time.Time, handlers []string) (int64, error) {
+	return r.queries.CleanupOutbox(ctx, before, handlers)
+}
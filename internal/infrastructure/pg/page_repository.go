@@ -0,0 +1,132 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	pagedomain "proto-gin-web/internal/contexts/blog/page/domain"
+)
+
+// PageRepository implements pagedomain.PageRepository backed by pgx queries.
+type PageRepository struct {
+	queries *Queries
+}
+
+// NewPageRepository constructs a PageRepository from a pool.
+func NewPageRepository(pool *pgxpool.Pool) *PageRepository {
+	return &PageRepository{queries: New(pool)}
+}
+
+var _ pagedomain.PageRepository = (*PageRepository)(nil)
+
+func (r *PageRepository) ListPages(ctx context.Context) ([]pagedomain.Page, error) {
+	rows, err := r.queries.ListPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapPages(rows), nil
+}
+
+func (r *PageRepository) ListPublishedChildren(ctx context.Context, parentID int64) ([]pagedomain.Page, error) {
+	rows, err := r.queries.ListPublishedChildPages(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return mapPages(rows), nil
+}
+
+func (r *PageRepository) ListAncestors(ctx context.Context, id int64) ([]pagedomain.Page, error) {
+	rows, err := r.queries.ListPageAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapPages(rows), nil
+}
+
+func (r *PageRepository) GetPageBySlug(ctx context.Context, slug string) (pagedomain.Page, error) {
+	row, err := r.queries.GetPageBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pagedomain.Page{}, pagedomain.ErrPageNotFound
+		}
+		return pagedomain.Page{}, err
+	}
+	return mapPage(row), nil
+}
+
+func (r *PageRepository) CreatePage(ctx context.Context, record pagedomain.PageRecord) (pagedomain.Page, error) {
+	row, err := r.queries.CreatePage(ctx, pageParams(record))
+	if err != nil {
+		if errors.Is(err, ErrPageSlugAlreadyExists) {
+			return pagedomain.Page{}, pagedomain.ErrSlugTaken
+		}
+		return pagedomain.Page{}, err
+	}
+	return mapPage(row), nil
+}
+
+func (r *PageRepository) UpdatePage(ctx context.Context, slug string, record pagedomain.PageRecord) (pagedomain.Page, error) {
+	row, err := r.queries.UpdatePage(ctx, slug, pageParams(record))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pagedomain.Page{}, pagedomain.ErrPageNotFound
+		}
+		return pagedomain.Page{}, err
+	}
+	return mapPage(row), nil
+}
+
+func (r *PageRepository) DeletePage(ctx context.Context, slug string) error {
+	n, err := r.queries.DeletePage(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pagedomain.ErrPageNotFound
+	}
+	return nil
+}
+
+func (r *PageRepository) PageSlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.queries.PageSlugExists(ctx, slug)
+}
+
+func pageParams(record pagedomain.PageRecord) PageParams {
+	return PageParams{
+		Title:     record.Title,
+		Slug:      record.Slug,
+		Summary:   record.Summary,
+		ContentMD: record.ContentMD,
+		Template:  record.Template,
+		Status:    record.Status,
+		ParentID:  record.ParentID,
+		Position:  record.Position,
+	}
+}
+
+func mapPages(rows []Page) []pagedomain.Page {
+	out := make([]pagedomain.Page, len(rows))
+	for i, p := range rows {
+		out[i] = mapPage(p)
+	}
+	return out
+}
+
+func mapPage(p Page) pagedomain.Page {
+	return pagedomain.Page{
+		ID:        p.ID,
+		Title:     p.Title,
+		Slug:      p.Slug,
+		Summary:   p.Summary,
+		ContentMD: p.ContentMD,
+		Template:  p.Template,
+		Status:    p.Status,
+		ParentID:  p.ParentID,
+		Position:  p.Position,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -2,7 +2,10 @@
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
@@ -29,24 +32,36 @@ func (r *PostRepository) ListPublishedPosts(ctx context.Context, limit, offset i
 	return mapPosts(posts), nil
 }
 
-func (r *PostRepository) ListPublishedPostsSorted(ctx context.Context, sort string, limit, offset int32) ([]postdomain.Post, error) {
-	posts, err := r.queries.ListPublishedPostsSorted(ctx, sort, limit, offset)
+func (r *PostRepository) ListPublishedPostsSorted(ctx context.Context, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
+	filter, err := encodeFieldFilter(fields)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := r.queries.ListPublishedPostsSorted(ctx, sort, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 	return mapPosts(posts), nil
 }
 
-func (r *PostRepository) ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, limit, offset int32) ([]postdomain.Post, error) {
-	posts, err := r.queries.ListPublishedPostsByCategorySorted(ctx, categorySlug, sort, limit, offset)
+func (r *PostRepository) ListPublishedPostsByCategorySorted(ctx context.Context, categorySlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
+	filter, err := encodeFieldFilter(fields)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := r.queries.ListPublishedPostsByCategorySorted(ctx, categorySlug, sort, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 	return mapPosts(posts), nil
 }
 
-func (r *PostRepository) ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, limit, offset int32) ([]postdomain.Post, error) {
-	posts, err := r.queries.ListPublishedPostsByTagSorted(ctx, tagSlug, sort, limit, offset)
+func (r *PostRepository) ListPublishedPostsByTagSorted(ctx context.Context, tagSlug, sort string, fields map[string]any, limit, offset int32) ([]postdomain.Post, error) {
+	filter, err := encodeFieldFilter(fields)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := r.queries.ListPublishedPostsByTagSorted(ctx, tagSlug, sort, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +71,9 @@ func (r *PostRepository) ListPublishedPostsByTagSorted(ctx context.Context, tagS
 func (r *PostRepository) GetPostBySlug(ctx context.Context, slug string) (postdomain.Post, error) {
 	post, err := r.queries.GetPostBySlug(ctx, slug)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.Post{}, postdomain.ErrPostNotFound
+		}
 		return postdomain.Post{}, err
 	}
 	return mapPost(post), nil
@@ -70,12 +88,22 @@ func (r *PostRepository) CreatePost(ctx context.Context, input postdomain.Create
 		Status:      input.Status,
 		AuthorID:    input.AuthorID,
 		PublishedAt: input.PublishedAt,
+		UnpublishAt: input.UnpublishAt,
+		ExpiryMode:  input.ExpiryMode,
 	}
 	if input.CoverURL != nil {
 		params.CoverUrl = input.CoverURL
 	}
+	fields, err := encodeCustomFields(input.CustomFields)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	params.CustomFields = fields
 	post, err := r.queries.CreatePost(ctx, params)
 	if err != nil {
+		if errors.Is(err, ErrPostSlugAlreadyExists) {
+			return postdomain.Post{}, postdomain.ErrSlugTaken
+		}
 		return postdomain.Post{}, err
 	}
 	return mapPost(post), nil
@@ -83,15 +111,22 @@ func (r *PostRepository) CreatePost(ctx context.Context, input postdomain.Create
 
 func (r *PostRepository) UpdatePostBySlug(ctx context.Context, input postdomain.UpdatePostInput) (postdomain.Post, error) {
 	params := UpdatePostBySlugParams{
-		Slug:      input.Slug,
-		Title:     input.Title,
-		Summary:   input.Summary,
-		ContentMd: input.ContentMD,
-		Status:    input.Status,
+		Slug:        input.Slug,
+		Title:       input.Title,
+		Summary:     input.Summary,
+		ContentMd:   input.ContentMD,
+		Status:      input.Status,
+		UnpublishAt: input.UnpublishAt,
+		ExpiryMode:  input.ExpiryMode,
 	}
 	if input.CoverURL != nil {
 		params.CoverUrl = input.CoverURL
 	}
+	fields, err := encodeCustomFields(input.CustomFields)
+	if err != nil {
+		return postdomain.Post{}, err
+	}
+	params.CustomFields = fields
 	post, err := r.queries.UpdatePostBySlug(ctx, params)
 	if err != nil {
 		return postdomain.Post{}, err
@@ -99,10 +134,71 @@ func (r *PostRepository) UpdatePostBySlug(ctx context.Context, input postdomain.
 	return mapPost(post), nil
 }
 
+func (r *PostRepository) PatchPostBySlug(ctx context.Context, input postdomain.PatchPostInput) (postdomain.Post, error) {
+	var fields []byte
+	if input.CustomFields.Set {
+		encoded, err := encodeCustomFields(input.CustomFields.Value)
+		if err != nil {
+			return postdomain.Post{}, err
+		}
+		fields = encoded
+	}
+	post, err := r.queries.PatchPostBySlug(ctx, PatchPostBySlugParams{
+		Slug:            input.Slug,
+		SetTitle:        input.Title.Set,
+		Title:           input.Title.Value,
+		SetSummary:      input.Summary.Set,
+		Summary:         input.Summary.Value,
+		SetContentMd:    input.ContentMD.Set,
+		ContentMd:       input.ContentMD.Value,
+		SetCoverUrl:     input.CoverURL.Set,
+		CoverUrl:        input.CoverURL.Value,
+		SetStatus:       input.Status.Set,
+		Status:          input.Status.Value,
+		SetAuthorID:     input.AuthorID.Set,
+		AuthorID:        input.AuthorID.Value,
+		SetPublishedAt:  input.PublishedAt.Set,
+		PublishedAt:     input.PublishedAt.Value,
+		SetCustomFields: input.CustomFields.Set,
+		CustomFields:    fields,
+		SetUnpublishAt:  input.UnpublishAt.Set,
+		UnpublishAt:     input.UnpublishAt.Value,
+		SetExpiryMode:   input.ExpiryMode.Set,
+		ExpiryMode:      input.ExpiryMode.Value,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.Post{}, postdomain.ErrPostNotFound
+		}
+		return postdomain.Post{}, err
+	}
+	return mapPost(post), nil
+}
+
 func (r *PostRepository) DeletePostBySlug(ctx context.Context, slug string) error {
 	return r.queries.DeletePostBySlug(ctx, slug)
 }
 
+func (r *PostRepository) ArchiveExpiredPosts(ctx context.Context) ([]string, error) {
+	return r.queries.ArchiveExpiredPosts(ctx)
+}
+
+func (r *PostRepository) PostSlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.queries.PostSlugExists(ctx, slug)
+}
+
+func (r *PostRepository) AuthorExists(ctx context.Context, id int64) (bool, error) {
+	return r.queries.AuthorExists(ctx, id)
+}
+
+func (r *PostRepository) UserRole(ctx context.Context, id int64) (string, error) {
+	role, err := r.queries.GetUserRole(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
 func (r *PostRepository) AddCategoryToPost(ctx context.Context, slug, categorySlug string) error {
 	return r.queries.AddCategoryToPost(ctx, slug, categorySlug)
 }
@@ -119,6 +215,59 @@ func (r *PostRepository) RemoveTagFromPost(ctx context.Context, slug, tagSlug st
 	return r.queries.RemoveTagFromPost(ctx, slug, tagSlug)
 }
 
+func (r *PostRepository) ReplacePostRelations(ctx context.Context, slug string, write postdomain.RelationWrite) ([]taxdomain.Tag, error) {
+	created := make([]taxdomain.Tag, 0, len(write.CreateTags))
+	err := WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		for _, record := range write.CreateTags {
+			row, err := r.queries.CreateTag(ctx, CreateTagParams{Name: record.Name, Slug: record.Slug, TaxonomyDetails: TaxonomyDetails(record.Details)})
+			if err != nil {
+				return mapTaxonomyError(err, taxdomain.ErrTagNotFound)
+			}
+			created = append(created, mapTag(row))
+		}
+		if write.Categories != nil {
+			if err := r.queries.ReplacePostCategories(ctx, slug, write.Categories); err != nil {
+				return err
+			}
+		}
+		if write.Tags != nil {
+			if err := r.queries.ReplacePostTags(ctx, slug, write.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *PostRepository) ExistingCategorySlugs(ctx context.Context, slugs []string) ([]string, error) {
+	return r.queries.ListExistingCategorySlugs(ctx, nonNilSlugs(slugs))
+}
+
+func (r *PostRepository) MatchTags(ctx context.Context, entries, keys []string) (map[string]string, error) {
+	rows, err := r.queries.MatchTags(ctx, nonNilSlugs(entries), nonNilSlugs(keys))
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(rows))
+	for _, row := range rows {
+		out[row.Entry] = row.Slug
+	}
+	return out, nil
+}
+
+// nonNilSlugs turns a nil slice into an empty one, which pgx sends as an empty array
+// rather than NULL.
+func nonNilSlugs(slugs []string) []string {
+	if slugs == nil {
+		return []string{}
+	}
+	return slugs
+}
+
 func (r *PostRepository) ListCategoriesByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error) {
 	cats, err := r.queries.ListCategoriesByPostSlug(ctx, slug)
 	if err != nil {
@@ -127,6 +276,14 @@ func (r *PostRepository) ListCategoriesByPostSlug(ctx context.Context, slug stri
 	return mapCategories(cats), nil
 }
 
+func (r *PostRepository) ListCategoryTrailByPostSlug(ctx context.Context, slug string) ([]taxdomain.Category, error) {
+	cats, err := r.queries.ListCategoryTrailByPostSlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return mapCategories(cats), nil
+}
+
 func (r *PostRepository) ListTagsByPostSlug(ctx context.Context, slug string) ([]taxdomain.Tag, error) {
 	tags, err := r.queries.ListTagsByPostSlug(ctx, slug)
 	if err != nil {
@@ -144,25 +301,50 @@ func mapPosts(posts []Post) []postdomain.Post {
 }
 
 func mapPost(p Post) postdomain.Post {
+	fields := map[string]any{}
+	if len(p.CustomFields) > 0 {
+		// the column only ever holds objects written by encodeCustomFields
+		_ = json.Unmarshal(p.CustomFields, &fields)
+	}
 	return postdomain.Post{
-		ID:          p.ID,
-		Title:       p.Title,
-		Slug:        p.Slug,
-		Summary:     p.Summary,
-		ContentMD:   p.ContentMd,
-		CoverURL:    p.CoverUrl,
-		Status:      p.Status,
-		AuthorID:    p.AuthorID,
-		PublishedAt: p.PublishedAt,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:           p.ID,
+		Title:        p.Title,
+		Slug:         p.Slug,
+		Summary:      p.Summary,
+		ContentMD:    p.ContentMd,
+		CoverURL:     p.CoverUrl,
+		Status:       p.Status,
+		AuthorID:     p.AuthorID,
+		PublishedAt:  p.PublishedAt,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		CustomFields: fields,
+		UnpublishAt:  p.UnpublishAt,
+		ExpiryMode:   p.ExpiryMode,
+	}
+}
+
+// encodeCustomFields marshals stored values; nil stays nil so the queries keep the
+// current column value.
+func encodeCustomFields(fields map[string]any) ([]byte, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// encodeFieldFilter marshals a containment filter; an empty filter matches every post.
+func encodeFieldFilter(fields map[string]any) ([]byte, error) {
+	if len(fields) == 0 {
+		return []byte("{}"), nil
 	}
+	return json.Marshal(fields)
 }
 
 func mapCategories(categories []Category) []taxdomain.Category {
 	out := make([]taxdomain.Category, len(categories))
 	for i, c := range categories {
-		out[i] = taxdomain.Category{ID: c.ID, Name: c.Name, Slug: c.Slug}
+		out[i] = mapCategory(c)
 	}
 	return out
 }
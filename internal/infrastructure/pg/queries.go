@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -19,35 +20,52 @@ type Queries struct {
 
 var ErrEmailAlreadyExists = errors.New("email already exists")
 
+var ErrPostSlugAlreadyExists = errors.New("post slug already exists")
+
 // New wraps a pgx pool to provide strongly typed query helpers.
 func New(pool *pgxpool.Pool) *Queries {
 	return &Queries{pool: pool}
 }
 
 type Post struct {
-	ID          int64
-	Title       string
-	Slug        string
-	Summary     string
-	ContentMd   string
-	CoverUrl    string
-	Status      string
-	AuthorID    int64
-	PublishedAt *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           int64
+	Title        string
+	Slug         string
+	Summary      string
+	ContentMd    string
+	CoverUrl     string
+	Status       string
+	AuthorID     int64
+	PublishedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CustomFields []byte
+	UnpublishAt  *time.Time
+	ExpiryMode   string
 }
 
 type Category struct {
-	ID   int64
-	Name string
-	Slug string
+	ID       int64
+	Name     string
+	Slug     string
+	ParentID *int64
+	TaxonomyDetails
 }
 
 type Tag struct {
 	ID   int64
 	Name string
 	Slug string
+	TaxonomyDetails
+}
+
+// TaxonomyDetails are the landing page columns shared by category and tag.
+type TaxonomyDetails struct {
+	DescriptionMD   string
+	CoverURL        string
+	MetaTitle       string
+	MetaDescription string
+	Position        int32
 }
 
 type Role struct {
@@ -65,33 +83,66 @@ type User struct {
 }
 
 type CreatePostParams struct {
-	Title       string
-	Slug        string
-	Summary     string
-	ContentMd   string
-	CoverUrl    *string
-	Status      string
-	AuthorID    int64
-	PublishedAt *time.Time
+	Title        string
+	Slug         string
+	Summary      string
+	ContentMd    string
+	CoverUrl     *string
+	Status       string
+	AuthorID     int64
+	PublishedAt  *time.Time
+	CustomFields []byte
+	UnpublishAt  *time.Time
+	ExpiryMode   string
 }
 
 type UpdatePostBySlugParams struct {
-	Slug      string
-	Title     string
-	Summary   string
-	ContentMd string
-	CoverUrl  *string
-	Status    string
+	Slug         string
+	Title        string
+	Summary      string
+	ContentMd    string
+	CoverUrl     *string
+	Status       string
+	CustomFields []byte
+	UnpublishAt  *time.Time
+	ExpiryMode   string
+}
+
+type PatchPostBySlugParams struct {
+	Slug            string
+	SetTitle        bool
+	Title           string
+	SetSummary      bool
+	Summary         string
+	SetContentMd    bool
+	ContentMd       string
+	SetCoverUrl     bool
+	CoverUrl        *string
+	SetStatus       bool
+	Status          string
+	SetAuthorID     bool
+	AuthorID        int64
+	SetPublishedAt  bool
+	PublishedAt     *time.Time
+	SetCustomFields bool
+	CustomFields    []byte
+	SetUnpublishAt  bool
+	UnpublishAt     *time.Time
+	SetExpiryMode   bool
+	ExpiryMode      string
 }
 
 type CreateCategoryParams struct {
-	Name string
-	Slug string
+	Name     string
+	Slug     string
+	ParentID *int64
+	TaxonomyDetails
 }
 
 type CreateTagParams struct {
 	Name string
 	Slug string
+	TaxonomyDetails
 }
 
 type CreateUserParams struct {
@@ -108,33 +159,35 @@ type UpdateUserProfileParams struct {
 }
 
 func (q *Queries) ListPublishedPosts(ctx context.Context, limit, offset int32) ([]Post, error) {
-	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at FROM post WHERE status = 'published' ORDER BY COALESCE(published_at, created_at) DESC LIMIT $1 OFFSET $2`
+	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode FROM post WHERE status = 'published' AND (unpublish_at IS NULL OR unpublish_at > NOW()) ORDER BY COALESCE(published_at, created_at) DESC LIMIT $1 OFFSET $2`
 	return q.listPosts(ctx, stmt, limit, offset)
 }
 
-func (q *Queries) ListPublishedPostsSorted(ctx context.Context, sort string, limit, offset int32) ([]Post, error) {
-	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at FROM post WHERE status = 'published' ORDER BY CASE WHEN $1 = 'published_at_asc' THEN published_at END ASC, CASE WHEN $1 = 'published_at_desc' THEN published_at END DESC, CASE WHEN $1 = 'created_at_asc' THEN created_at END ASC, CASE WHEN $1 = 'created_at_desc' OR $1 = '' THEN created_at END DESC NULLS LAST LIMIT $2 OFFSET $3`
-	return q.listPosts(ctx, stmt, sort, limit, offset)
+func (q *Queries) ListPublishedPostsSorted(ctx context.Context, sort string, fields []byte, limit, offset int32) ([]Post, error) {
+	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode FROM post WHERE status = 'published' AND (unpublish_at IS NULL OR unpublish_at > NOW()) AND custom_fields @> $2::jsonb ORDER BY CASE WHEN $1 = 'published_at_asc' THEN published_at END ASC, CASE WHEN $1 = 'published_at_desc' THEN published_at END DESC, CASE WHEN $1 = 'created_at_asc' THEN created_at END ASC, CASE WHEN $1 = 'created_at_desc' OR $1 = '' THEN created_at END DESC NULLS LAST LIMIT $3 OFFSET $4`
+	return q.listPosts(ctx, stmt, sort, fields, limit, offset)
 }
 
-func (q *Queries) ListPublishedPostsByCategorySorted(ctx context.Context, slug string, sort string, limit, offset int32) ([]Post, error) {
-	const stmt = `SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at FROM post p JOIN post_category pc ON pc.post_id = p.id JOIN category c ON c.id = pc.category_id WHERE p.status = 'published' AND c.slug = $1 ORDER BY CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC, CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC, CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC, CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC NULLS LAST LIMIT $3 OFFSET $4`
-	return q.listPosts(ctx, stmt, slug, sort, limit, offset)
+// ListPublishedPostsByCategorySorted lists posts filed under the category or any of its
+// descendants. The depth guard stops runaway recursion on bad data.
+func (q *Queries) ListPublishedPostsByCategorySorted(ctx context.Context, slug string, sort string, fields []byte, limit, offset int32) ([]Post, error) {
+	const stmt = categorySubtree + `SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode FROM post p WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW()) AND EXISTS (SELECT 1 FROM post_category pc WHERE pc.post_id = p.id AND pc.category_id IN (SELECT id FROM subtree)) AND p.custom_fields @> $3::jsonb ORDER BY CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC, CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC, CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC, CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC NULLS LAST LIMIT $4 OFFSET $5`
+	return q.listPosts(ctx, stmt, slug, sort, fields, limit, offset)
 }
 
-func (q *Queries) ListPublishedPostsByTagSorted(ctx context.Context, slug string, sort string, limit, offset int32) ([]Post, error) {
-	const stmt = `SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at FROM post p JOIN post_tag pt ON pt.post_id = p.id JOIN tag t ON t.id = pt.tag_id WHERE p.status = 'published' AND t.slug = $1 ORDER BY CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC, CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC, CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC, CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC NULLS LAST LIMIT $3 OFFSET $4`
-	return q.listPosts(ctx, stmt, slug, sort, limit, offset)
+func (q *Queries) ListPublishedPostsByTagSorted(ctx context.Context, slug string, sort string, fields []byte, limit, offset int32) ([]Post, error) {
+	const stmt = `SELECT p.id, p.title, p.slug, p.summary, p.content_md, p.cover_url, p.status, p.author_id, p.published_at, p.created_at, p.updated_at, p.custom_fields, p.unpublish_at, p.expiry_mode FROM post p JOIN post_tag pt ON pt.post_id = p.id JOIN tag t ON t.id = pt.tag_id WHERE p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW()) AND t.slug = $1 AND p.custom_fields @> $3::jsonb ORDER BY CASE WHEN $2 = 'published_at_asc' THEN p.published_at END ASC, CASE WHEN $2 = 'published_at_desc' THEN p.published_at END DESC, CASE WHEN $2 = 'created_at_asc' THEN p.created_at END ASC, CASE WHEN $2 = 'created_at_desc' OR $2 = '' THEN p.created_at END DESC NULLS LAST LIMIT $4 OFFSET $5`
+	return q.listPosts(ctx, stmt, slug, sort, fields, limit, offset)
 }
 
 func (q *Queries) GetPostBySlug(ctx context.Context, slug string) (Post, error) {
-	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at FROM post WHERE slug = $1`
-	row := q.pool.QueryRow(ctx, stmt, slug)
+	const stmt = `SELECT id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode FROM post WHERE slug = $1`
+	row := q.conn(ctx).QueryRow(ctx, stmt, slug)
 	return scanPost(row)
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
-	const stmt = `INSERT INTO post (title, slug, summary, content_md, cover_url, status, author_id, published_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at`
+	const stmt = `INSERT INTO post (title, slug, summary, content_md, cover_url, status, author_id, published_at, custom_fields, unpublish_at, expiry_mode) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::jsonb, '{}'::jsonb), $10, $11) RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode`
 	var cover any
 	if arg.CoverUrl != nil {
 		cover = *arg.CoverUrl
@@ -143,53 +196,243 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 	if arg.PublishedAt != nil {
 		published = *arg.PublishedAt
 	}
-	row := q.pool.QueryRow(ctx, stmt, arg.Title, arg.Slug, arg.Summary, arg.ContentMd, cover, arg.Status, arg.AuthorID, published)
-	return scanPost(row)
+	var unpublish any
+	if arg.UnpublishAt != nil {
+		unpublish = *arg.UnpublishAt
+	}
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Title, arg.Slug, arg.Summary, arg.ContentMd, cover, arg.Status, arg.AuthorID, published, arg.CustomFields, unpublish, arg.ExpiryMode)
+	post, err := scanPost(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && (pgErr.ConstraintName == "post_slug_key" || pgErr.ConstraintName == "idx_post_slug") {
+			return Post{}, ErrPostSlugAlreadyExists
+		}
+		return Post{}, err
+	}
+	return post, nil
 }
 
 func (q *Queries) UpdatePostBySlug(ctx context.Context, arg UpdatePostBySlugParams) (Post, error) {
-	const stmt = `UPDATE post SET title = $2, summary = $3, content_md = $4, cover_url = $5, status = $6, updated_at = NOW() WHERE slug = $1 RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at`
+	const stmt = `UPDATE post SET title = $2, summary = $3, content_md = $4, cover_url = $5, status = $6, custom_fields = COALESCE($7::jsonb, custom_fields), unpublish_at = $8, expiry_mode = COALESCE(NULLIF($9, ''), expiry_mode), updated_at = NOW() WHERE slug = $1 RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode`
+	var cover any
+	if arg.CoverUrl != nil {
+		cover = *arg.CoverUrl
+	}
+	var unpublish any
+	if arg.UnpublishAt != nil {
+		unpublish = *arg.UnpublishAt
+	}
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Slug, arg.Title, arg.Summary, arg.ContentMd, cover, arg.Status, arg.CustomFields, unpublish, arg.ExpiryMode)
+	return scanPost(row)
+}
+
+func (q *Queries) PatchPostBySlug(ctx context.Context, arg PatchPostBySlugParams) (Post, error) {
+	const stmt = `UPDATE post SET title = CASE WHEN $2::bool THEN $3::text ELSE title END, summary = CASE WHEN $4::bool THEN $5::text ELSE summary END, content_md = CASE WHEN $6::bool THEN $7::text ELSE content_md END, cover_url = CASE WHEN $8::bool THEN $9::text ELSE cover_url END, status = CASE WHEN $10::bool THEN $11::text ELSE status END, author_id = CASE WHEN $12::bool THEN $13::bigint ELSE author_id END, published_at = CASE WHEN $14::bool THEN $15::timestamptz ELSE published_at END, custom_fields = CASE WHEN $16::bool THEN $17::jsonb ELSE custom_fields END, unpublish_at = CASE WHEN $18::bool THEN $19::timestamptz ELSE unpublish_at END, expiry_mode = CASE WHEN $20::bool THEN $21::text ELSE expiry_mode END, updated_at = NOW() WHERE slug = $1 RETURNING id, title, slug, summary, content_md, cover_url, status, author_id, published_at, created_at, updated_at, custom_fields, unpublish_at, expiry_mode`
 	var cover any
 	if arg.CoverUrl != nil {
 		cover = *arg.CoverUrl
 	}
-	row := q.pool.QueryRow(ctx, stmt, arg.Slug, arg.Title, arg.Summary, arg.ContentMd, cover, arg.Status)
+	var published any
+	if arg.PublishedAt != nil {
+		published = *arg.PublishedAt
+	}
+	var unpublish any
+	if arg.UnpublishAt != nil {
+		unpublish = *arg.UnpublishAt
+	}
+	row := q.conn(ctx).QueryRow(ctx, stmt,
+		arg.Slug,
+		arg.SetTitle, arg.Title,
+		arg.SetSummary, arg.Summary,
+		arg.SetContentMd, arg.ContentMd,
+		arg.SetCoverUrl, cover,
+		arg.SetStatus, arg.Status,
+		arg.SetAuthorID, arg.AuthorID,
+		arg.SetPublishedAt, published,
+		arg.SetCustomFields, arg.CustomFields,
+		arg.SetUnpublishAt, unpublish,
+		arg.SetExpiryMode, arg.ExpiryMode,
+	)
 	return scanPost(row)
 }
 
+// ArchiveExpiredPosts archives the published posts whose unpublish date has passed and
+// returns their slugs.
+func (q *Queries) ArchiveExpiredPosts(ctx context.Context) ([]string, error) {
+	const stmt = `UPDATE post SET status = 'archived', updated_at = NOW() WHERE status = 'published' AND unpublish_at <= NOW() RETURNING slug`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		out = append(out, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (q *Queries) DeletePostBySlug(ctx context.Context, slug string) error {
 	const stmt = `DELETE FROM post WHERE slug = $1`
-	_, err := q.pool.Exec(ctx, stmt, slug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug)
 	return err
 }
 
+func (q *Queries) PostSlugExists(ctx context.Context, slug string) (bool, error) {
+	const stmt = `SELECT EXISTS (SELECT 1 FROM post WHERE slug = $1)`
+	var exists bool
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&exists)
+	return exists, err
+}
+
+func (q *Queries) AuthorExists(ctx context.Context, id int64) (bool, error) {
+	const stmt = `SELECT EXISTS (SELECT 1 FROM app_user WHERE id = $1)`
+	var exists bool
+	err := q.conn(ctx).QueryRow(ctx, stmt, id).Scan(&exists)
+	return exists, err
+}
+
+func (q *Queries) GetUserRole(ctx context.Context, id int64) (string, error) {
+	const stmt = `SELECT COALESCE(r.name, '') FROM app_user u LEFT JOIN role r ON r.id = u.role_id WHERE u.id = $1`
+	var role string
+	err := q.conn(ctx).QueryRow(ctx, stmt, id).Scan(&role)
+	return role, err
+}
+
 func (q *Queries) AddCategoryToPost(ctx context.Context, slug, categorySlug string) error {
 	const stmt = `INSERT INTO post_category (post_id, category_id) SELECT p.id, c.id FROM post p, category c WHERE p.slug = $1 AND c.slug = $2 ON CONFLICT DO NOTHING`
-	_, err := q.pool.Exec(ctx, stmt, slug, categorySlug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, categorySlug)
 	return err
 }
 
 func (q *Queries) RemoveCategoryFromPost(ctx context.Context, slug, categorySlug string) error {
 	const stmt = `DELETE FROM post_category USING post p, category c WHERE post_category.post_id = p.id AND post_category.category_id = c.id AND p.slug = $1 AND c.slug = $2`
-	_, err := q.pool.Exec(ctx, stmt, slug, categorySlug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, categorySlug)
 	return err
 }
 
 func (q *Queries) AddTagToPost(ctx context.Context, slug, tagSlug string) error {
 	const stmt = `INSERT INTO post_tag (post_id, tag_id) SELECT p.id, t.id FROM post p, tag t WHERE p.slug = $1 AND t.slug = $2 ON CONFLICT DO NOTHING`
-	_, err := q.pool.Exec(ctx, stmt, slug, tagSlug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, tagSlug)
 	return err
 }
 
 func (q *Queries) RemoveTagFromPost(ctx context.Context, slug, tagSlug string) error {
 	const stmt = `DELETE FROM post_tag USING post p, tag t WHERE post_tag.post_id = p.id AND post_tag.tag_id = t.id AND p.slug = $1 AND t.slug = $2`
-	_, err := q.pool.Exec(ctx, stmt, slug, tagSlug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, tagSlug)
+	return err
+}
+
+// ReplacePostCategories makes the categories with the given slugs the complete category
+// set of a post in one statement; unknown slugs are ignored.
+func (q *Queries) ReplacePostCategories(ctx context.Context, slug string, categorySlugs []string) error {
+	const stmt = `WITH target AS (SELECT id FROM post WHERE slug = $1), wanted AS (SELECT id FROM category WHERE slug = ANY($2)), ` +
+		`removed AS (DELETE FROM post_category pc USING target WHERE pc.post_id = target.id AND pc.category_id NOT IN (SELECT id FROM wanted)) ` +
+		`INSERT INTO post_category (post_id, category_id) SELECT target.id, wanted.id FROM target, wanted ON CONFLICT DO NOTHING`
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, categorySlugs)
+	return err
+}
+
+// ReplacePostTags makes the tags with the given slugs the complete tag set of a post in
+// one statement; unknown slugs are ignored.
+func (q *Queries) ReplacePostTags(ctx context.Context, slug string, tagSlugs []string) error {
+	const stmt = `WITH target AS (SELECT id FROM post WHERE slug = $1), wanted AS (SELECT id FROM tag WHERE slug = ANY($2)), ` +
+		`removed AS (DELETE FROM post_tag pt USING target WHERE pt.post_id = target.id AND pt.tag_id NOT IN (SELECT id FROM wanted)) ` +
+		`INSERT INTO post_tag (post_id, tag_id) SELECT target.id, wanted.id FROM target, wanted ON CONFLICT DO NOTHING`
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, tagSlugs)
 	return err
 }
 
+// ListExistingCategorySlugs returns the given slugs that belong to a category.
+func (q *Queries) ListExistingCategorySlugs(ctx context.Context, slugs []string) ([]string, error) {
+	const stmt = `SELECT slug FROM category WHERE slug = ANY($1)`
+	rows, err := q.conn(ctx).Query(ctx, stmt, slugs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagMatch pairs a requested tag entry with the slug of the tag it matched.
+type TagMatch struct {
+	Entry string
+	Slug  string
+}
+
+// MatchTags matches each entry, by position with its slug form in keys, to a tag: a tag
+// with that slug first, then a tag aliased by it, then a tag with the entry as its name
+// ignoring case. Entries matching nothing are left out.
+func (q *Queries) MatchTags(ctx context.Context, entries, keys []string) ([]TagMatch, error) {
+	const stmt = `SELECT k.entry, m.slug FROM unnest($1::text[], $2::text[]) AS k(entry, key) JOIN LATERAL (` +
+		`SELECT t.slug, 0 AS rank FROM tag t WHERE t.slug = k.key ` +
+		`UNION ALL SELECT t.slug, 1 FROM tag_alias a JOIN tag t ON t.id = a.tag_id WHERE a.slug = k.key ` +
+		`UNION ALL SELECT t.slug, 2 FROM tag t WHERE lower(t.name) = lower(k.entry) ` +
+		`ORDER BY rank LIMIT 1) m ON true`
+	rows, err := q.conn(ctx).Query(ctx, stmt, entries, keys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TagMatch
+	for rows.Next() {
+		var m TagMatch
+		if err := rows.Scan(&m.Entry, &m.Slug); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (q *Queries) ListCategoriesByPostSlug(ctx context.Context, slug string) ([]Category, error) {
-	const stmt = `SELECT c.id, c.name, c.slug FROM category c JOIN post_category pc ON pc.category_id = c.id JOIN post p ON p.id = pc.post_id WHERE p.slug = $1 ORDER BY c.name ASC`
-	rows, err := q.pool.Query(ctx, stmt, slug)
+	const stmt = `SELECT c.id, c.name, c.slug, c.parent_id FROM category c JOIN post_category pc ON pc.category_id = c.id JOIN post p ON p.id = pc.post_id WHERE p.slug = $1 ORDER BY c.position ASC, c.name ASC`
+	return q.listCategories(ctx, stmt, slug)
+}
+
+// ListCategoryTrailByPostSlug returns the breadcrumb trail of a post: the most deeply
+// nested of its categories (ties broken by name) preceded by its ancestors, root first.
+// The depth guard stops runaway recursion on bad data.
+func (q *Queries) ListCategoryTrailByPostSlug(ctx context.Context, slug string) ([]Category, error) {
+	const stmt = `WITH RECURSIVE chain AS (SELECT c.id AS leaf_id, c.name AS leaf_name, c.id, c.name, c.slug, c.parent_id, 0 AS depth FROM category c JOIN post_category pc ON pc.category_id = c.id JOIN post p ON p.id = pc.post_id WHERE p.slug = $1 UNION ALL SELECT chain.leaf_id, chain.leaf_name, c.id, c.name, c.slug, c.parent_id, chain.depth + 1 FROM category c JOIN chain ON c.id = chain.parent_id WHERE chain.depth < 32), ` +
+		`leaf AS (SELECT leaf_id FROM chain GROUP BY leaf_id, leaf_name ORDER BY MAX(depth) DESC, leaf_name ASC LIMIT 1) ` +
+		`SELECT chain.id, chain.name, chain.slug, chain.parent_id FROM chain JOIN leaf ON leaf.leaf_id = chain.leaf_id ORDER BY chain.depth DESC`
+	return q.listCategories(ctx, stmt, slug)
+}
+
+// ListCategoryAncestors walks parent links upwards from the category with the given id
+// and returns the ancestors root first. The depth guard stops runaway recursion on bad data.
+func (q *Queries) ListCategoryAncestors(ctx context.Context, id int64) ([]Category, error) {
+	const stmt = `WITH RECURSIVE chain AS (SELECT c.id, c.name, c.slug, c.parent_id, 1 AS depth FROM category c WHERE c.id = (SELECT parent_id FROM category WHERE id = $1) UNION ALL SELECT c.id, c.name, c.slug, c.parent_id, chain.depth + 1 FROM category c JOIN chain ON c.id = chain.parent_id WHERE chain.depth < 32) SELECT id, name, slug, parent_id FROM chain ORDER BY depth DESC`
+	return q.listCategories(ctx, stmt, id)
+}
+
+func (q *Queries) listCategories(ctx context.Context, stmt string, args ...any) ([]Category, error) {
+	rows, err := q.conn(ctx).Query(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +441,7 @@ func (q *Queries) ListCategoriesByPostSlug(ctx context.Context, slug string) ([]
 	var out []Category
 	for rows.Next() {
 		var c Category
-		if err := rows.Scan(&c.ID, &c.Name, &c.Slug); err != nil {
+		if err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.ParentID); err != nil {
 			return nil, err
 		}
 		out = append(out, c)
@@ -210,8 +453,8 @@ func (q *Queries) ListCategoriesByPostSlug(ctx context.Context, slug string) ([]
 }
 
 func (q *Queries) ListTagsByPostSlug(ctx context.Context, slug string) ([]Tag, error) {
-	const stmt = `SELECT t.id, t.name, t.slug FROM tag t JOIN post_tag pt ON pt.tag_id = t.id JOIN post p ON p.id = pt.post_id WHERE p.slug = $1 ORDER BY t.name ASC`
-	rows, err := q.pool.Query(ctx, stmt, slug)
+	const stmt = `SELECT t.id, t.name, t.slug FROM tag t JOIN post_tag pt ON pt.tag_id = t.id JOIN post p ON p.id = pt.post_id WHERE p.slug = $1 ORDER BY t.position ASC, t.name ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, slug)
 	if err != nil {
 		return nil, err
 	}
@@ -231,35 +474,267 @@ func (q *Queries) ListTagsByPostSlug(ctx context.Context, slug string) ([]Tag, e
 	return out, nil
 }
 
+var (
+	ErrTaxonomyNameAlreadyExists = errors.New("taxonomy name already exists")
+	ErrTaxonomySlugAlreadyExists = errors.New("taxonomy slug already exists")
+)
+
+// taxonomyConflict maps unique violations on category and tag names and slugs.
+func taxonomyConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case "category_name_key", "tag_name_key":
+		return ErrTaxonomyNameAlreadyExists
+	case "category_slug_key", "idx_category_slug", "tag_slug_key", "idx_tag_slug":
+		return ErrTaxonomySlugAlreadyExists
+	}
+	return err
+}
+
+type CategoryWithCount struct {
+	Category
+	PostCount int64
+}
+
+type TagWithCount struct {
+	Tag
+	PostCount int64
+}
+
+// categorySubtree selects the ids of the category with slug $1 and all its descendants.
+const categorySubtree = `WITH RECURSIVE subtree AS (SELECT id, 1 AS depth FROM category WHERE slug = $1 UNION ALL SELECT c.id, subtree.depth + 1 FROM category c JOIN subtree ON c.parent_id = subtree.id WHERE subtree.depth < 32) `
+
+// publishedPostJoin counts only posts readers can see, like the public listings.
+const publishedPostJoin = `p.status = 'published' AND (p.unpublish_at IS NULL OR p.unpublish_at > NOW())`
+
+// categoryColumns and tagColumns select a category or tag with its details, in the
+// order of categoryFields and tagFields.
+const (
+	categoryColumns = `c.id, c.name, c.slug, c.parent_id, c.description_md, c.cover_url, c.meta_title, c.meta_description, c.position`
+	tagColumns      = `t.id, t.name, t.slug, t.description_md, t.cover_url, t.meta_title, t.meta_description, t.position`
+)
+
+func categoryFields(c *Category) []any {
+	return []any{&c.ID, &c.Name, &c.Slug, &c.ParentID, &c.DescriptionMD, &c.CoverURL, &c.MetaTitle, &c.MetaDescription, &c.Position}
+}
+
+func tagFields(t *Tag) []any {
+	return []any{&t.ID, &t.Name, &t.Slug, &t.DescriptionMD, &t.CoverURL, &t.MetaTitle, &t.MetaDescription, &t.Position}
+}
+
+func (q *Queries) ListCategories(ctx context.Context) ([]CategoryWithCount, error) {
+	const stmt = `SELECT ` + categoryColumns + `, COUNT(p.id) FROM category c LEFT JOIN post_category pc ON pc.category_id = c.id LEFT JOIN post p ON p.id = pc.post_id AND ` + publishedPostJoin + ` GROUP BY c.id ORDER BY c.position ASC, c.name ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []CategoryWithCount
+	for rows.Next() {
+		var c CategoryWithCount
+		if err := rows.Scan(append(categoryFields(&c.Category), &c.PostCount)...); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (CategoryWithCount, error) {
+	const stmt = `SELECT ` + categoryColumns + `, COUNT(p.id) FROM category c LEFT JOIN post_category pc ON pc.category_id = c.id LEFT JOIN post p ON p.id = pc.post_id AND ` + publishedPostJoin + ` WHERE c.slug = $1 GROUP BY c.id`
+	var c CategoryWithCount
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(append(categoryFields(&c.Category), &c.PostCount)...)
+	return c, err
+}
+
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	const stmt = `INSERT INTO category (name, slug) VALUES ($1, $2) RETURNING id, name, slug`
+	const stmt = `INSERT INTO category AS c (name, slug, parent_id, description_md, cover_url, meta_title, meta_description, position) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING ` + categoryColumns
 	var c Category
-	err := q.pool.QueryRow(ctx, stmt, arg.Name, arg.Slug).Scan(&c.ID, &c.Name, &c.Slug)
-	return c, err
+	err := q.conn(ctx).QueryRow(ctx, stmt, arg.Name, arg.Slug, arg.ParentID, arg.DescriptionMD, arg.CoverURL, arg.MetaTitle, arg.MetaDescription, arg.Position).Scan(categoryFields(&c)...)
+	return c, taxonomyConflict(err)
+}
+
+func (q *Queries) UpdateCategoryBySlug(ctx context.Context, slug string, arg CreateCategoryParams) (Category, error) {
+	const stmt = `UPDATE category AS c SET name = $2, slug = $3, parent_id = $4, description_md = $5, cover_url = $6, meta_title = $7, meta_description = $8, position = $9 WHERE slug = $1 RETURNING ` + categoryColumns
+	var c Category
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug, arg.Name, arg.Slug, arg.ParentID, arg.DescriptionMD, arg.CoverURL, arg.MetaTitle, arg.MetaDescription, arg.Position).Scan(categoryFields(&c)...)
+	return c, taxonomyConflict(err)
+}
+
+func (q *Queries) CategorySlugExists(ctx context.Context, slug string) (bool, error) {
+	const stmt = `SELECT EXISTS (SELECT 1 FROM category WHERE slug = $1)`
+	var exists bool
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&exists)
+	return exists, err
 }
 
 func (q *Queries) DeleteCategoryBySlug(ctx context.Context, slug string) error {
 	const stmt = `DELETE FROM category WHERE slug = $1`
-	_, err := q.pool.Exec(ctx, stmt, slug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug)
 	return err
 }
 
+func (q *Queries) ListTags(ctx context.Context) ([]TagWithCount, error) {
+	const stmt = `SELECT ` + tagColumns + `, COUNT(p.id) FROM tag t LEFT JOIN post_tag pt ON pt.tag_id = t.id LEFT JOIN post p ON p.id = pt.post_id AND ` + publishedPostJoin + ` GROUP BY t.id ORDER BY t.position ASC, t.name ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TagWithCount
+	for rows.Next() {
+		var t TagWithCount
+		if err := rows.Scan(append(tagFields(&t.Tag), &t.PostCount)...); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetTagBySlug(ctx context.Context, slug string) (TagWithCount, error) {
+	const stmt = `SELECT ` + tagColumns + `, COUNT(p.id) FROM tag t LEFT JOIN post_tag pt ON pt.tag_id = t.id LEFT JOIN post p ON p.id = pt.post_id AND ` + publishedPostJoin + ` WHERE t.slug = $1 GROUP BY t.id`
+	var t TagWithCount
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(append(tagFields(&t.Tag), &t.PostCount)...)
+	return t, err
+}
+
 func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error) {
-	const stmt = `INSERT INTO tag (name, slug) VALUES ($1, $2) RETURNING id, name, slug`
+	const stmt = `INSERT INTO tag AS t (name, slug, description_md, cover_url, meta_title, meta_description, position) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + tagColumns
 	var t Tag
-	err := q.pool.QueryRow(ctx, stmt, arg.Name, arg.Slug).Scan(&t.ID, &t.Name, &t.Slug)
-	return t, err
+	err := q.conn(ctx).QueryRow(ctx, stmt, arg.Name, arg.Slug, arg.DescriptionMD, arg.CoverURL, arg.MetaTitle, arg.MetaDescription, arg.Position).Scan(tagFields(&t)...)
+	return t, taxonomyConflict(err)
+}
+
+func (q *Queries) UpdateTagBySlug(ctx context.Context, slug string, arg CreateTagParams) (Tag, error) {
+	const stmt = `UPDATE tag AS t SET name = $2, slug = $3, description_md = $4, cover_url = $5, meta_title = $6, meta_description = $7, position = $8 WHERE slug = $1 RETURNING ` + tagColumns
+	var t Tag
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug, arg.Name, arg.Slug, arg.DescriptionMD, arg.CoverURL, arg.MetaTitle, arg.MetaDescription, arg.Position).Scan(tagFields(&t)...)
+	return t, taxonomyConflict(err)
+}
+
+func (q *Queries) TagSlugExists(ctx context.Context, slug string) (bool, error) {
+	const stmt = `SELECT EXISTS (SELECT 1 FROM tag WHERE slug = $1)`
+	var exists bool
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&exists)
+	return exists, err
 }
 
 func (q *Queries) DeleteTagBySlug(ctx context.Context, slug string) error {
 	const stmt = `DELETE FROM tag WHERE slug = $1`
-	_, err := q.pool.Exec(ctx, stmt, slug)
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug)
 	return err
 }
 
+// LockTagBySlug reads a tag and locks its row until the transaction ends.
+func (q *Queries) LockTagBySlug(ctx context.Context, slug string) (Tag, error) {
+	const stmt = `SELECT ` + tagColumns + ` FROM tag t WHERE t.slug = $1 FOR UPDATE`
+	var t Tag
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(tagFields(&t)...)
+	return t, err
+}
+
+// LockTagIDsBySlugs returns the ids of the tags with the given slugs, locking their rows
+// until the transaction ends. Unknown slugs are skipped.
+func (q *Queries) LockTagIDsBySlugs(ctx context.Context, slugs []string) ([]int64, error) {
+	const stmt = `SELECT id FROM tag WHERE slug = ANY($1) ORDER BY id FOR UPDATE`
+	rows, err := q.conn(ctx).Query(ctx, stmt, slugs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MergeTags moves the posts of the source tags to the target, skipping posts that
+// already carry it, re-points the sources' aliases, turns the source slugs into aliases
+// and deletes the sources. Run it inside a transaction.
+func (q *Queries) MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	stmts := []string{
+		`INSERT INTO post_tag (post_id, tag_id) SELECT DISTINCT post_id, $1 FROM post_tag WHERE tag_id = ANY($2) ON CONFLICT DO NOTHING`,
+		`UPDATE tag_alias SET tag_id = $1 WHERE tag_id = ANY($2)`,
+		`INSERT INTO tag_alias (slug, tag_id) SELECT slug, $1 FROM tag WHERE id = ANY($2) ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id`,
+		`DELETE FROM tag WHERE id = ANY($2) AND id <> $1`,
+	}
+	for _, stmt := range stmts {
+		if _, err := q.conn(ctx).Exec(ctx, stmt, targetID, sourceIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type TagAlias struct {
+	Slug      string
+	Tag       Tag
+	CreatedAt time.Time
+}
+
+// ResolveTagAlias returns the tag an alias points to, unless a tag has the alias slug.
+func (q *Queries) ResolveTagAlias(ctx context.Context, slug string) (Tag, error) {
+	const stmt = `SELECT t.id, t.name, t.slug FROM tag_alias a JOIN tag t ON t.id = a.tag_id WHERE a.slug = $1 AND NOT EXISTS (SELECT 1 FROM tag WHERE slug = $1)`
+	var t Tag
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&t.ID, &t.Name, &t.Slug)
+	return t, err
+}
+
+func (q *Queries) ListTagAliases(ctx context.Context) ([]TagAlias, error) {
+	const stmt = `SELECT a.slug, a.created_at, t.id, t.name, t.slug FROM tag_alias a JOIN tag t ON t.id = a.tag_id ORDER BY a.slug ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TagAlias
+	for rows.Next() {
+		var a TagAlias
+		if err := rows.Scan(&a.Slug, &a.CreatedAt, &a.Tag.ID, &a.Tag.Name, &a.Tag.Slug); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) DeleteTagAlias(ctx context.Context, slug string) (int64, error) {
+	const stmt = `DELETE FROM tag_alias WHERE slug = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, slug)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
 	const stmt = `SELECT id, email, display_name, password_hash, role_id, created_at FROM app_user WHERE email = $1`
-	row := q.pool.QueryRow(ctx, stmt, email)
+	row := q.conn(ctx).QueryRow(ctx, stmt, email)
 	var u User
 	if err := row.Scan(&u.ID, &u.Email, &u.DisplayName, &u.PasswordHash, &u.RoleID, &u.CreatedAt); err != nil {
 		return User{}, err
@@ -269,7 +744,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 	const stmt = `SELECT id, email, display_name, password_hash, role_id, created_at FROM app_user WHERE id = $1`
-	row := q.pool.QueryRow(ctx, stmt, id)
+	row := q.conn(ctx).QueryRow(ctx, stmt, id)
 	var u User
 	if err := row.Scan(&u.ID, &u.Email, &u.DisplayName, &u.PasswordHash, &u.RoleID, &u.CreatedAt); err != nil {
 		return User{}, err
@@ -283,7 +758,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	if arg.RoleID != nil {
 		role = *arg.RoleID
 	}
-	row := q.pool.QueryRow(ctx, stmt, arg.Email, arg.DisplayName, arg.PasswordHash, role)
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Email, arg.DisplayName, arg.PasswordHash, role)
 	var u User
 	if err := row.Scan(&u.ID, &u.Email, &u.DisplayName, &u.PasswordHash, &u.RoleID, &u.CreatedAt); err != nil {
 		var pgErr *pgconn.PgError
@@ -301,7 +776,17 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 	if arg.PasswordHash != nil {
 		password = *arg.PasswordHash
 	}
-	row := q.pool.QueryRow(ctx, stmt, arg.Email, arg.DisplayName, password)
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Email, arg.DisplayName, password)
+	var u User
+	if err := row.Scan(&u.ID, &u.Email, &u.DisplayName, &u.PasswordHash, &u.RoleID, &u.CreatedAt); err != nil {
+		return User{}, err
+	}
+	return u, nil
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, id, roleID int64) (User, error) {
+	const stmt = `UPDATE app_user SET role_id = $2 WHERE id = $1 RETURNING id, email, display_name, password_hash, role_id, created_at`
+	row := q.conn(ctx).QueryRow(ctx, stmt, id, roleID)
 	var u User
 	if err := row.Scan(&u.ID, &u.Email, &u.DisplayName, &u.PasswordHash, &u.RoleID, &u.CreatedAt); err != nil {
 		return User{}, err
@@ -311,7 +796,7 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 
 func (q *Queries) GetRoleByName(ctx context.Context, name string) (Role, error) {
 	const stmt = `SELECT id, name FROM role WHERE name = $1`
-	row := q.pool.QueryRow(ctx, stmt, name)
+	row := q.conn(ctx).QueryRow(ctx, stmt, name)
 	var r Role
 	if err := row.Scan(&r.ID, &r.Name); err != nil {
 		return Role{}, err
@@ -320,7 +805,7 @@ func (q *Queries) GetRoleByName(ctx context.Context, name string) (Role, error)
 }
 
 func (q *Queries) listPosts(ctx context.Context, stmt string, args ...any) ([]Post, error) {
-	rows, err := q.pool.Query(ctx, stmt, args...)
+	rows, err := q.conn(ctx).Query(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -343,8 +828,8 @@ func (q *Queries) listPosts(ctx context.Context, stmt string, args ...any) ([]Po
 func scanPost(row pgx.Row) (Post, error) {
 	var p Post
 	var cover sql.NullString
-	var published pgtype.Timestamptz
-	if err := row.Scan(&p.ID, &p.Title, &p.Slug, &p.Summary, &p.ContentMd, &cover, &p.Status, &p.AuthorID, &published, &p.CreatedAt, &p.UpdatedAt); err != nil {
+	var published, unpublish pgtype.Timestamptz
+	if err := row.Scan(&p.ID, &p.Title, &p.Slug, &p.Summary, &p.ContentMd, &cover, &p.Status, &p.AuthorID, &published, &p.CreatedAt, &p.UpdatedAt, &p.CustomFields, &unpublish, &p.ExpiryMode); err != nil {
 		return Post{}, err
 	}
 	if cover.Valid {
@@ -354,5 +839,1543 @@ func scanPost(row pgx.Row) (Post, error) {
 		t := published.Time
 		p.PublishedAt = &t
 	}
+	if unpublish.Valid {
+		t := unpublish.Time
+		p.UnpublishAt = &t
+	}
 	return p, nil
 }
+
+type CustomField struct {
+	ID           int64
+	Key          string
+	Label        string
+	Type         string
+	Required     bool
+	Options      []string
+	CategorySlug string
+	Position     int32
+	CreatedAt    time.Time
+}
+
+type CreateCustomFieldParams struct {
+	Key        string
+	Label      string
+	Type       string
+	Required   bool
+	Options    []string
+	CategoryID *int64
+	Position   int32
+}
+
+type UpdateCustomFieldParams struct {
+	Key        string
+	Label      string
+	Type       string
+	Required   bool
+	Options    []string
+	CategoryID *int64
+	Position   int32
+}
+
+var ErrCustomFieldKeyAlreadyExists = errors.New("custom field key already exists")
+
+func (q *Queries) ListCustomFields(ctx context.Context) ([]CustomField, error) {
+	const stmt = `SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at FROM custom_field f LEFT JOIN category c ON c.id = f.category_id ORDER BY f.position ASC, f.id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []CustomField
+	for rows.Next() {
+		f, err := scanCustomField(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetCustomFieldByKey(ctx context.Context, key string) (CustomField, error) {
+	const stmt = `SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at FROM custom_field f LEFT JOIN category c ON c.id = f.category_id WHERE f.key = $1`
+	return scanCustomField(q.conn(ctx).QueryRow(ctx, stmt, key))
+}
+
+func (q *Queries) GetCategoryIDBySlug(ctx context.Context, slug string) (int64, error) {
+	const stmt = `SELECT id FROM category WHERE slug = $1`
+	var id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&id)
+	return id, err
+}
+
+func (q *Queries) CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error) {
+	const stmt = `WITH f AS (INSERT INTO custom_field (key, label, type, required, options, category_id, position) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *) SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at FROM f LEFT JOIN category c ON c.id = f.category_id`
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Key, arg.Label, arg.Type, arg.Required, arg.Options, arg.CategoryID, arg.Position)
+	field, err := scanCustomField(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "custom_field_key_key" {
+			return CustomField{}, ErrCustomFieldKeyAlreadyExists
+		}
+		return CustomField{}, err
+	}
+	return field, nil
+}
+
+func (q *Queries) UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (CustomField, error) {
+	const stmt = `WITH f AS (UPDATE custom_field SET label = $2, type = $3, required = $4, options = $5, category_id = $6, position = $7 WHERE key = $1 RETURNING *) SELECT f.id, f.key, f.label, f.type, f.required, f.options, COALESCE(c.slug, ''), f.position, f.created_at FROM f LEFT JOIN category c ON c.id = f.category_id`
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Key, arg.Label, arg.Type, arg.Required, arg.Options, arg.CategoryID, arg.Position)
+	return scanCustomField(row)
+}
+
+// DeleteCustomField removes the definition and its stored values in one statement,
+// returning the number of definitions deleted.
+func (q *Queries) DeleteCustomField(ctx context.Context, key string) (int64, error) {
+	const stmt = `WITH deleted AS (DELETE FROM custom_field WHERE key = $1 RETURNING key), stripped AS (UPDATE post SET custom_fields = custom_fields - $1::text WHERE custom_fields ? $1::text AND EXISTS (SELECT 1 FROM deleted)) SELECT COUNT(*) FROM deleted`
+	var n int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, key).Scan(&n)
+	return n, err
+}
+
+func scanCustomField(row pgx.Row) (CustomField, error) {
+	var f CustomField
+	if err := row.Scan(&f.ID, &f.Key, &f.Label, &f.Type, &f.Required, &f.Options, &f.CategorySlug, &f.Position, &f.CreatedAt); err != nil {
+		return CustomField{}, err
+	}
+	return f, nil
+}
+
+type Page struct {
+	ID        int64
+	Title     string
+	Slug      string
+	Summary   string
+	ContentMD string
+	Template  string
+	Status    string
+	ParentID  *int64
+	Position  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PageParams struct {
+	Title     string
+	Slug      string
+	Summary   string
+	ContentMD string
+	Template  string
+	Status    string
+	ParentID  *int64
+	Position  int32
+}
+
+var ErrPageSlugAlreadyExists = errors.New("page slug already exists")
+
+const pageColumns = `id, title, slug, summary, content_md, template, status, parent_id, position, created_at, updated_at`
+
+func (q *Queries) ListPages(ctx context.Context) ([]Page, error) {
+	const stmt = `SELECT ` + pageColumns + ` FROM page ORDER BY position ASC, title ASC`
+	return q.queryPages(ctx, stmt)
+}
+
+func (q *Queries) ListPublishedChildPages(ctx context.Context, parentID int64) ([]Page, error) {
+	const stmt = `SELECT ` + pageColumns + ` FROM page WHERE parent_id = $1 AND status = 'published' ORDER BY position ASC, title ASC`
+	return q.queryPages(ctx, stmt, parentID)
+}
+
+// ListPageAncestors walks parent links upwards from the page with the given id and
+// returns the ancestors root first. The depth guard stops runaway recursion on bad data.
+func (q *Queries) ListPageAncestors(ctx context.Context, id int64) ([]Page, error) {
+	const stmt = `WITH RECURSIVE chain AS (SELECT p.*, 1 AS depth FROM page p WHERE p.id = (SELECT parent_id FROM page WHERE id = $1) UNION ALL SELECT p.*, chain.depth + 1 FROM page p JOIN chain ON p.id = chain.parent_id WHERE chain.depth < 32) SELECT ` + pageColumns + ` FROM chain ORDER BY depth DESC`
+	return q.queryPages(ctx, stmt, id)
+}
+
+func (q *Queries) GetPageBySlug(ctx context.Context, slug string) (Page, error) {
+	const stmt = `SELECT ` + pageColumns + ` FROM page WHERE slug = $1`
+	return scanPage(q.conn(ctx).QueryRow(ctx, stmt, slug))
+}
+
+func (q *Queries) PageSlugExists(ctx context.Context, slug string) (bool, error) {
+	const stmt = `SELECT EXISTS (SELECT 1 FROM page WHERE slug = $1)`
+	var exists bool
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&exists)
+	return exists, err
+}
+
+func (q *Queries) CreatePage(ctx context.Context, arg PageParams) (Page, error) {
+	const stmt = `INSERT INTO page (title, slug, summary, content_md, template, status, parent_id, position) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING ` + pageColumns
+	row := q.conn(ctx).QueryRow(ctx, stmt, arg.Title, arg.Slug, arg.Summary, arg.ContentMD, arg.Template, arg.Status, arg.ParentID, arg.Position)
+	page, err := scanPage(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "page_slug_key" {
+			return Page{}, ErrPageSlugAlreadyExists
+		}
+		return Page{}, err
+	}
+	return page, nil
+}
+
+func (q *Queries) UpdatePage(ctx context.Context, slug string, arg PageParams) (Page, error) {
+	const stmt = `UPDATE page SET title = $2, summary = $3, content_md = $4, template = $5, status = $6, parent_id = $7, position = $8, updated_at = NOW() WHERE slug = $1 RETURNING ` + pageColumns
+	row := q.conn(ctx).QueryRow(ctx, stmt, slug, arg.Title, arg.Summary, arg.ContentMD, arg.Template, arg.Status, arg.ParentID, arg.Position)
+	return scanPage(row)
+}
+
+// DeletePage removes the page and returns the number of rows deleted; children are
+// detached by the parent_id foreign key.
+func (q *Queries) DeletePage(ctx context.Context, slug string) (int64, error) {
+	const stmt = `DELETE FROM page WHERE slug = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, slug)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (q *Queries) queryPages(ctx context.Context, stmt string, args ...any) ([]Page, error) {
+	rows, err := q.conn(ctx).Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Page
+	for rows.Next() {
+		p, err := scanPage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scanPage(row pgx.Row) (Page, error) {
+	var p Page
+	if err := row.Scan(&p.ID, &p.Title, &p.Slug, &p.Summary, &p.ContentMD, &p.Template, &p.Status, &p.ParentID, &p.Position, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return Page{}, err
+	}
+	return p, nil
+}
+
+type Menu struct {
+	ID        int64
+	Name      string
+	Label     string
+	CreatedAt time.Time
+}
+
+type MenuItem struct {
+	ID         int64
+	MenuID     int64
+	ParentID   *int64
+	Label      string
+	TargetType string
+	TargetID   *int64
+	URL        string
+	Position   int32
+	TargetSlug string
+	TargetPath string
+	TargetLive bool
+}
+
+type MenuItemParams struct {
+	MenuID     int64
+	ParentID   *int64
+	Label      string
+	TargetType string
+	TargetID   *int64
+	URL        string
+	Position   int32
+}
+
+var ErrMenuNameAlreadyExists = errors.New("menu name already exists")
+
+// menuItemSelect resolves each internal target to its current slug; page targets also
+// get their full path, built top-down from the root pages.
+const menuItemSelect = `WITH RECURSIVE page_path AS (SELECT id, '/p/' || slug AS path FROM page WHERE parent_id IS NULL UNION ALL SELECT c.id, pp.path || '/' || c.slug FROM page c JOIN page_path pp ON c.parent_id = pp.id) ` +
+	`SELECT i.id, i.menu_id, i.parent_id, i.label, i.target_type, i.target_id, i.url, i.position, COALESCE(p.slug, pg.slug, c.slug, t.slug, ''), COALESCE(pp.path, ''), ` +
+	`CASE i.target_type WHEN 'post' THEN COALESCE(p.status = 'published', FALSE) WHEN 'page' THEN COALESCE(pg.status = 'published', FALSE) WHEN 'category' THEN c.id IS NOT NULL WHEN 'tag' THEN t.id IS NOT NULL ELSE TRUE END ` +
+	`FROM menu_item i LEFT JOIN post p ON i.target_type = 'post' AND p.id = i.target_id LEFT JOIN page pg ON i.target_type = 'page' AND pg.id = i.target_id LEFT JOIN page_path pp ON i.target_type = 'page' AND pp.id = i.target_id ` +
+	`LEFT JOIN category c ON i.target_type = 'category' AND c.id = i.target_id LEFT JOIN tag t ON i.target_type = 'tag' AND t.id = i.target_id`
+
+func (q *Queries) ListMenus(ctx context.Context) ([]Menu, error) {
+	const stmt = `SELECT id, name, label, created_at FROM menu ORDER BY name ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Menu
+	for rows.Next() {
+		var m Menu
+		if err := rows.Scan(&m.ID, &m.Name, &m.Label, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetMenuByName(ctx context.Context, name string) (Menu, error) {
+	const stmt = `SELECT id, name, label, created_at FROM menu WHERE name = $1`
+	var m Menu
+	err := q.conn(ctx).QueryRow(ctx, stmt, name).Scan(&m.ID, &m.Name, &m.Label, &m.CreatedAt)
+	return m, err
+}
+
+func (q *Queries) CreateMenu(ctx context.Context, name, label string) (Menu, error) {
+	const stmt = `INSERT INTO menu (name, label) VALUES ($1, $2) RETURNING id, name, label, created_at`
+	var m Menu
+	err := q.conn(ctx).QueryRow(ctx, stmt, name, label).Scan(&m.ID, &m.Name, &m.Label, &m.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "menu_name_key" {
+			return Menu{}, ErrMenuNameAlreadyExists
+		}
+		return Menu{}, err
+	}
+	return m, nil
+}
+
+func (q *Queries) DeleteMenu(ctx context.Context, name string) (int64, error) {
+	const stmt = `DELETE FROM menu WHERE name = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, name)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (q *Queries) ListMenuItems(ctx context.Context, menuID int64) ([]MenuItem, error) {
+	const stmt = menuItemSelect + ` WHERE i.menu_id = $1 ORDER BY i.position ASC, i.id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, menuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []MenuItem
+	for rows.Next() {
+		item, err := scanMenuItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetMenuItem(ctx context.Context, id int64) (MenuItem, error) {
+	const stmt = menuItemSelect + ` WHERE i.id = $1`
+	return scanMenuItem(q.conn(ctx).QueryRow(ctx, stmt, id))
+}
+
+func (q *Queries) GetPostIDBySlug(ctx context.Context, slug string) (int64, error) {
+	const stmt = `SELECT id FROM post WHERE slug = $1`
+	var id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&id)
+	return id, err
+}
+
+func (q *Queries) GetPageIDBySlug(ctx context.Context, slug string) (int64, error) {
+	const stmt = `SELECT id FROM page WHERE slug = $1`
+	var id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&id)
+	return id, err
+}
+
+func (q *Queries) GetTagIDBySlug(ctx context.Context, slug string) (int64, error) {
+	const stmt = `SELECT id FROM tag WHERE slug = $1`
+	var id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, slug).Scan(&id)
+	return id, err
+}
+
+func (q *Queries) CreateMenuItem(ctx context.Context, arg MenuItemParams) (int64, error) {
+	const stmt = `INSERT INTO menu_item (menu_id, parent_id, label, target_type, target_id, url, position) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	var id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, arg.MenuID, arg.ParentID, arg.Label, arg.TargetType, arg.TargetID, arg.URL, arg.Position).Scan(&id)
+	return id, err
+}
+
+func (q *Queries) UpdateMenuItem(ctx context.Context, id int64, arg MenuItemParams) (int64, error) {
+	const stmt = `UPDATE menu_item SET parent_id = $3, label = $4, target_type = $5, target_id = $6, url = $7, position = $8 WHERE id = $1 AND menu_id = $2`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, id, arg.MenuID, arg.ParentID, arg.Label, arg.TargetType, arg.TargetID, arg.URL, arg.Position)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (q *Queries) DeleteMenuItem(ctx context.Context, menuID, id int64) (int64, error) {
+	const stmt = `DELETE FROM menu_item WHERE id = $1 AND menu_id = $2`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, id, menuID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ReorderMenuItems applies every new parent and position in a single statement so
+// readers never see a half-moved tree.
+func (q *Queries) ReorderMenuItems(ctx context.Context, menuID int64, ids []int64, parentIDs []*int64, positions []int32) error {
+	const stmt = `UPDATE menu_item m SET parent_id = u.parent_id, position = u.position FROM unnest($2::bigint[], $3::bigint[], $4::int[]) AS u(id, parent_id, position) WHERE m.id = u.id AND m.menu_id = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, menuID, ids, parentIDs, positions)
+	return err
+}
+
+func scanMenuItem(row pgx.Row) (MenuItem, error) {
+	var i MenuItem
+	if err := row.Scan(&i.ID, &i.MenuID, &i.ParentID, &i.Label, &i.TargetType, &i.TargetID, &i.URL, &i.Position, &i.TargetSlug, &i.TargetPath, &i.TargetLive); err != nil {
+		return MenuItem{}, err
+	}
+	return i, nil
+}
+
+type PostReview struct {
+	PostID       int64
+	Slug         string
+	Title        string
+	Status       string
+	AuthorID     int64
+	AuthorName   string
+	ReviewerID   *int64
+	ReviewerName string
+	UpdatedAt    time.Time
+}
+
+type Reviewer struct {
+	ID          int64
+	DisplayName string
+	Email       string
+	Role        string
+}
+
+type ReviewComment struct {
+	ID         int64
+	PostID     int64
+	AuthorID   *int64
+	AuthorName string
+	Body       string
+	FromStatus string
+	ToStatus   string
+	CreatedAt  time.Time
+}
+
+type ReviewCommentParams struct {
+	PostID     int64
+	AuthorID   int64
+	Body       string
+	FromStatus string
+	ToStatus   string
+}
+
+const postReviewSelect = `SELECT p.id, p.slug, p.title, p.status, p.author_id, a.display_name, p.reviewer_id, COALESCE(rv.display_name, ''), p.updated_at FROM post p JOIN app_user a ON a.id = p.author_id LEFT JOIN app_user rv ON rv.id = p.reviewer_id`
+
+func (q *Queries) GetPostReview(ctx context.Context, slug string) (PostReview, error) {
+	const stmt = postReviewSelect + ` WHERE p.slug = $1`
+	return scanPostReview(q.conn(ctx).QueryRow(ctx, stmt, slug))
+}
+
+func (q *Queries) ListPostReviewsByStatus(ctx context.Context, status string) ([]PostReview, error) {
+	const stmt = postReviewSelect + ` WHERE p.status = $1 ORDER BY p.updated_at ASC, p.id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []PostReview
+	for rows.Next() {
+		r, err := scanPostReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) SetPostReviewer(ctx context.Context, slug string, reviewerID *int64) (int64, error) {
+	const stmt = `UPDATE post SET reviewer_id = $2 WHERE slug = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, slug, reviewerID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (q *Queries) ListReviewers(ctx context.Context) ([]Reviewer, error) {
+	const stmt = `SELECT u.id, u.display_name, u.email, r.name FROM app_user u JOIN role r ON r.id = u.role_id WHERE r.name IN ('editor', 'admin') ORDER BY u.display_name ASC, u.id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Reviewer
+	for rows.Next() {
+		var r Reviewer
+		if err := rows.Scan(&r.ID, &r.DisplayName, &r.Email, &r.Role); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) CreateReviewComment(ctx context.Context, arg ReviewCommentParams) (ReviewComment, error) {
+	const stmt = `WITH c AS (INSERT INTO post_review_comment (post_id, author_id, body, from_status, to_status) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')) RETURNING id, post_id, author_id, body, from_status, to_status, created_at) SELECT c.id, c.post_id, c.author_id, COALESCE(u.display_name, ''), c.body, COALESCE(c.from_status, ''), COALESCE(c.to_status, ''), c.created_at FROM c LEFT JOIN app_user u ON u.id = c.author_id`
+	return scanReviewComment(q.conn(ctx).QueryRow(ctx, stmt, arg.PostID, arg.AuthorID, arg.Body, arg.FromStatus, arg.ToStatus))
+}
+
+func (q *Queries) ListReviewComments(ctx context.Context, postID int64) ([]ReviewComment, error) {
+	const stmt = `SELECT c.id, c.post_id, c.author_id, COALESCE(u.display_name, ''), c.body, COALESCE(c.from_status, ''), COALESCE(c.to_status, ''), c.created_at FROM post_review_comment c LEFT JOIN app_user u ON u.id = c.author_id WHERE c.post_id = $1 ORDER BY c.created_at ASC, c.id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ReviewComment
+	for rows.Next() {
+		c, err := scanReviewComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scanPostReview(row pgx.Row) (PostReview, error) {
+	var r PostReview
+	if err := row.Scan(&r.PostID, &r.Slug, &r.Title, &r.Status, &r.AuthorID, &r.AuthorName, &r.ReviewerID, &r.ReviewerName, &r.UpdatedAt); err != nil {
+		return PostReview{}, err
+	}
+	return r, nil
+}
+
+func scanReviewComment(row pgx.Row) (ReviewComment, error) {
+	var c ReviewComment
+	if err := row.Scan(&c.ID, &c.PostID, &c.AuthorID, &c.AuthorName, &c.Body, &c.FromStatus, &c.ToStatus, &c.CreatedAt); err != nil {
+		return ReviewComment{}, err
+	}
+	return c, nil
+}
+
+type PostAutosave struct {
+	PostID       int64
+	UserID       int64
+	Title        string
+	Summary      string
+	ContentMd    string
+	CoverURL     string
+	CustomFields []byte
+	SavedAt      time.Time
+}
+
+type SavePostAutosaveParams struct {
+	Slug         string
+	UserID       int64
+	Title        string
+	Summary      string
+	ContentMd    string
+	CoverURL     string
+	CustomFields []byte
+}
+
+func (q *Queries) SavePostAutosave(ctx context.Context, arg SavePostAutosaveParams) (PostAutosave, error) {
+	const stmt = `INSERT INTO post_autosave (post_id, user_id, title, summary, content_md, cover_url, custom_fields, saved_at) SELECT p.id, $2, $3, $4, $5, $6, COALESCE($7::jsonb, '{}'::jsonb), NOW() FROM post p WHERE p.slug = $1 ON CONFLICT (post_id, user_id) DO UPDATE SET title = EXCLUDED.title, summary = EXCLUDED.summary, content_md = EXCLUDED.content_md, cover_url = EXCLUDED.cover_url, custom_fields = EXCLUDED.custom_fields, saved_at = EXCLUDED.saved_at RETURNING post_id, user_id, title, summary, content_md, cover_url, custom_fields, saved_at`
+	return scanPostAutosave(q.conn(ctx).QueryRow(ctx, stmt, arg.Slug, arg.UserID, arg.Title, arg.Summary, arg.ContentMd, arg.CoverURL, arg.CustomFields))
+}
+
+func (q *Queries) GetPostAutosave(ctx context.Context, slug string, userID int64) (PostAutosave, error) {
+	const stmt = `SELECT a.post_id, a.user_id, a.title, a.summary, a.content_md, a.cover_url, a.custom_fields, a.saved_at FROM post_autosave a JOIN post p ON p.id = a.post_id WHERE p.slug = $1 AND a.user_id = $2`
+	return scanPostAutosave(q.conn(ctx).QueryRow(ctx, stmt, slug, userID))
+}
+
+func (q *Queries) DeletePostAutosave(ctx context.Context, slug string, userID int64) error {
+	const stmt = `DELETE FROM post_autosave a USING post p WHERE p.id = a.post_id AND p.slug = $1 AND a.user_id = $2`
+	_, err := q.conn(ctx).Exec(ctx, stmt, slug, userID)
+	return err
+}
+
+func scanPostAutosave(row pgx.Row) (PostAutosave, error) {
+	var a PostAutosave
+	if err := row.Scan(&a.PostID, &a.UserID, &a.Title, &a.Summary, &a.ContentMd, &a.CoverURL, &a.CustomFields, &a.SavedAt); err != nil {
+		return PostAutosave{}, err
+	}
+	return a, nil
+}
+
+type LinkSource struct {
+	PostID    int64
+	Slug      string
+	Title     string
+	ContentMd string
+	CoverURL  string
+}
+
+type LinkFinding struct {
+	PostID    int64
+	PostSlug  string
+	PostTitle string
+	Kind      string
+	URL       string
+	CheckedAt time.Time
+}
+
+type ReplaceLinkFindingsParams struct {
+	PostID    int64
+	Kinds     []string
+	URLs      []string
+	CheckedAt time.Time
+}
+
+func (q *Queries) ListLinkSources(ctx context.Context) ([]LinkSource, error) {
+	const stmt = `SELECT id, slug, title, content_md, COALESCE(cover_url, '') FROM post ORDER BY id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []LinkSource
+	for rows.Next() {
+		var s LinkSource
+		if err := rows.Scan(&s.PostID, &s.Slug, &s.Title, &s.ContentMd, &s.CoverURL); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) ReplaceLinkFindings(ctx context.Context, arg ReplaceLinkFindingsParams) error {
+	const stmt = `WITH cleared AS (DELETE FROM post_link_finding WHERE post_id = $1) INSERT INTO post_link_finding (post_id, kind, url, checked_at) SELECT $1, f.kind, f.url, $4 FROM unnest($2::text[], $3::text[]) AS f(kind, url)`
+	_, err := q.conn(ctx).Exec(ctx, stmt, arg.PostID, arg.Kinds, arg.URLs, arg.CheckedAt)
+	return err
+}
+
+const linkFindingSelect = `SELECT f.post_id, p.slug, p.title, f.kind, f.url, f.checked_at FROM post_link_finding f JOIN post p ON p.id = f.post_id`
+
+func (q *Queries) ListLinkFindings(ctx context.Context) ([]LinkFinding, error) {
+	const stmt = linkFindingSelect + ` ORDER BY p.slug ASC, f.id ASC`
+	return q.listLinkFindings(ctx, stmt)
+}
+
+func (q *Queries) ListLinkFindingsByPost(ctx context.Context, slug string) ([]LinkFinding, error) {
+	const stmt = linkFindingSelect + ` WHERE p.slug = $1 ORDER BY f.id ASC`
+	return q.listLinkFindings(ctx, stmt, slug)
+}
+
+func (q *Queries) listLinkFindings(ctx context.Context, stmt string, args ...any) ([]LinkFinding, error) {
+	rows, err := q.conn(ctx).Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []LinkFinding
+	for rows.Next() {
+		var f LinkFinding
+		if err := rows.Scan(&f.PostID, &f.PostSlug, &f.PostTitle, &f.Kind, &f.URL, &f.CheckedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type TermSource struct {
+	PostID    int64
+	Title     string
+	Summary   string
+	ContentMd string
+}
+
+type TagCentroidWeight struct {
+	TagSlug string
+	Term    string
+	Weight  float64
+}
+
+// LockPostTerms returns the stored terms and tag IDs of a post, locking its row for the
+// rest of the transaction.
+func (q *Queries) LockPostTerms(ctx context.Context, postID int64) ([]byte, []int64, error) {
+	const stmt = `SELECT terms, tag_ids FROM post_term_vector WHERE post_id = $1 FOR UPDATE`
+	var terms []byte
+	var tagIDs []int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, postID).Scan(&terms, &tagIDs)
+	return terms, tagIDs, err
+}
+
+// SavePostTerms stores the terms of a post together with the tags it carries now and
+// returns those tags. It answers pgx.ErrNoRows when the post no longer exists.
+func (q *Queries) SavePostTerms(ctx context.Context, postID int64, terms []byte) ([]int64, error) {
+	const stmt = `INSERT INTO post_term_vector (post_id, terms, tag_ids, indexed_at) SELECT p.id, $2::jsonb, ARRAY(SELECT pt.tag_id FROM post_tag pt WHERE pt.post_id = p.id ORDER BY pt.tag_id), NOW() FROM post p WHERE p.id = $1 ON CONFLICT (post_id) DO UPDATE SET terms = EXCLUDED.terms, tag_ids = EXCLUDED.tag_ids, indexed_at = EXCLUDED.indexed_at RETURNING tag_ids`
+	var tagIDs []int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, postID, terms).Scan(&tagIDs)
+	return tagIDs, err
+}
+
+func (q *Queries) DeletePostTerms(ctx context.Context, postID int64) ([]byte, []int64, error) {
+	const stmt = `DELETE FROM post_term_vector WHERE post_id = $1 RETURNING terms, tag_ids`
+	var terms []byte
+	var tagIDs []int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, postID).Scan(&terms, &tagIDs)
+	return terms, tagIDs, err
+}
+
+func (q *Queries) AddTermDocumentFrequencies(ctx context.Context, terms []string) error {
+	const stmt = `INSERT INTO term_document_frequency (term, posts) SELECT UNNEST($1::text[]), 1 ON CONFLICT (term) DO UPDATE SET posts = term_document_frequency.posts + 1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, terms)
+	return err
+}
+
+// RemoveTermDocumentFrequencies decrements the frequency of each term and drops the
+// terms no post contains any more.
+func (q *Queries) RemoveTermDocumentFrequencies(ctx context.Context, terms []string) error {
+	const stmt = `UPDATE term_document_frequency SET posts = posts - 1 WHERE term = ANY($1::text[])`
+	if _, err := q.conn(ctx).Exec(ctx, stmt, terms); err != nil {
+		return err
+	}
+	const cleanup = `DELETE FROM term_document_frequency WHERE term = ANY($1::text[]) AND posts <= 0`
+	_, err := q.conn(ctx).Exec(ctx, cleanup, terms)
+	return err
+}
+
+func (q *Queries) CountIndexedPosts(ctx context.Context) (int64, error) {
+	const stmt = `SELECT COUNT(*) FROM post_term_vector`
+	var n int64
+	err := q.conn(ctx).QueryRow(ctx, stmt).Scan(&n)
+	return n, err
+}
+
+func (q *Queries) ListTermDocumentFrequencies(ctx context.Context, terms []string) (map[string]int, error) {
+	const stmt = `SELECT term, posts FROM term_document_frequency WHERE term = ANY($1::text[])`
+	rows, err := q.conn(ctx).Query(ctx, stmt, terms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]int)
+	for rows.Next() {
+		var term string
+		var posts int32
+		if err := rows.Scan(&term, &posts); err != nil {
+			return nil, err
+		}
+		out[term] = int(posts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) ListTagTermCounts(ctx context.Context, tagID int64) ([][]byte, error) {
+	const stmt = `SELECT v.terms FROM post_term_vector v JOIN post_tag pt ON pt.post_id = v.post_id WHERE pt.tag_id = $1 ORDER BY v.post_id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out [][]byte
+	for rows.Next() {
+		var terms []byte
+		if err := rows.Scan(&terms); err != nil {
+			return nil, err
+		}
+		out = append(out, terms)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplaceTagCentroid swaps the stored centroid of a tag, skipping tags that no longer
+// exist, and records the tag on the term rows of its posts so a post later losing the
+// tag refreshes it again. Run it inside a transaction.
+func (q *Queries) ReplaceTagCentroid(ctx context.Context, tagID int64, terms []string, weights []float64) error {
+	const clear = `DELETE FROM tag_term_centroid WHERE tag_id = $1`
+	if _, err := q.conn(ctx).Exec(ctx, clear, tagID); err != nil {
+		return err
+	}
+	const insert = `INSERT INTO tag_term_centroid (tag_id, term, weight) SELECT t.id, w.term, w.weight FROM tag t, UNNEST($2::text[], $3::float8[]) AS w(term, weight) WHERE t.id = $1`
+	if _, err := q.conn(ctx).Exec(ctx, insert, tagID, terms, weights); err != nil {
+		return err
+	}
+	const mark = `UPDATE post_term_vector v SET tag_ids = array_append(v.tag_ids, $1) FROM post_tag pt WHERE pt.post_id = v.post_id AND pt.tag_id = $1 AND NOT ($1 = ANY(v.tag_ids))`
+	_, err := q.conn(ctx).Exec(ctx, mark, tagID)
+	return err
+}
+
+func (q *Queries) ListTagCentroidWeights(ctx context.Context, terms []string) ([]TagCentroidWeight, error) {
+	const stmt = `SELECT t.slug, c.term, c.weight FROM tag_term_centroid c JOIN tag t ON t.id = c.tag_id WHERE c.term = ANY($1::text[])`
+	rows, err := q.conn(ctx).Query(ctx, stmt, terms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TagCentroidWeight
+	for rows.Next() {
+		var w TagCentroidWeight
+		if err := rows.Scan(&w.TagSlug, &w.Term, &w.Weight); err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) ListTagsWithoutCentroid(ctx context.Context) ([]int64, error) {
+	const stmt = `SELECT DISTINCT pt.tag_id FROM post_tag pt JOIN post_term_vector v ON v.post_id = pt.post_id WHERE NOT EXISTS (SELECT 1 FROM tag_term_centroid c WHERE c.tag_id = pt.tag_id) ORDER BY pt.tag_id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) ListUnindexedTermSources(ctx context.Context, limit int32) ([]TermSource, error) {
+	const stmt = `SELECT p.id, p.title, p.summary, p.content_md FROM post p LEFT JOIN post_term_vector v ON v.post_id = p.id WHERE v.post_id IS NULL ORDER BY p.id ASC LIMIT $1`
+	rows, err := q.conn(ctx).Query(ctx, stmt, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TermSource
+	for rows.Next() {
+		var s TermSource
+		if err := rows.Scan(&s.PostID, &s.Title, &s.Summary, &s.ContentMd); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type WebhookSubscription struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WebhookSubscriptionParams struct {
+	URL    string
+	Secret string
+	Events []string
+	Active bool
+}
+
+type WebhookDelivery struct {
+	ID              int64
+	SubscriptionID  int64
+	SubscriptionURL string
+	Event           string
+	Payload         []byte
+	Status          string
+	Attempts        int32
+	NextAttemptAt   *time.Time
+	LastStatusCode  int32
+	LastError       string
+	CreatedAt       time.Time
+	DeliveredAt     *time.Time
+}
+
+type QueuedWebhookDelivery struct {
+	WebhookDelivery
+	Secret string
+}
+
+type WebhookAttempt struct {
+	ID          int64
+	DeliveryID  int64
+	AttemptedAt time.Time
+	StatusCode  int32
+	Error       string
+	DurationMs  int64
+}
+
+type RecordWebhookAttemptParams struct {
+	DeliveryID    int64
+	AttemptedAt   time.Time
+	StatusCode    int32
+	Error         string
+	DurationMs    int64
+	Status        string
+	NextAttemptAt *time.Time
+}
+
+const webhookSubscriptionColumns = `id, url, secret, events, active, created_at, updated_at`
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, s.url, d.event, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	const stmt = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription ORDER BY id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WebhookSubscription
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error) {
+	const stmt = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription WHERE id = $1`
+	return scanWebhookSubscription(q.conn(ctx).QueryRow(ctx, stmt, id))
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg WebhookSubscriptionParams) (WebhookSubscription, error) {
+	const stmt = `INSERT INTO webhook_subscription (url, secret, events, active) VALUES ($1, $2, $3, $4) RETURNING ` + webhookSubscriptionColumns
+	return scanWebhookSubscription(q.conn(ctx).QueryRow(ctx, stmt, arg.URL, arg.Secret, arg.Events, arg.Active))
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, id int64, arg WebhookSubscriptionParams) (WebhookSubscription, error) {
+	const stmt = `UPDATE webhook_subscription SET url = $2, secret = $3, events = $4, active = $5, updated_at = NOW() WHERE id = $1 RETURNING ` + webhookSubscriptionColumns
+	return scanWebhookSubscription(q.conn(ctx).QueryRow(ctx, stmt, id, arg.URL, arg.Secret, arg.Events, arg.Active))
+}
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error) {
+	const stmt = `DELETE FROM webhook_subscription WHERE id = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, id)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanWebhookSubscription(row pgx.Row) (WebhookSubscription, error) {
+	var s WebhookSubscription
+	if err := row.Scan(&s.ID, &s.URL, &s.Secret, &s.Events, &s.Active, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return WebhookSubscription{}, err
+	}
+	return s, nil
+}
+
+// EnqueueWebhookDeliveries queues payload for every active subscription to event and
+// returns the number of deliveries queued.
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, event string, payload []byte) (int64, error) {
+	const stmt = `INSERT INTO webhook_delivery (subscription_id, event, payload, next_attempt_at) SELECT id, $1, $2, NOW() FROM webhook_subscription WHERE active AND $1 = ANY(events)`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, event, payload)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ClaimDueWebhookDeliveries locks due deliveries, skipping rows another worker holds,
+// and moves their next attempt to leaseUntil.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, limit int32, leaseUntil time.Time) ([]QueuedWebhookDelivery, error) {
+	const stmt = `WITH due AS (SELECT d.id FROM webhook_delivery d JOIN webhook_subscription s ON s.id = d.subscription_id WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND s.active ORDER BY d.next_attempt_at ASC, d.id ASC LIMIT $2 FOR UPDATE OF d SKIP LOCKED) ` +
+		`UPDATE webhook_delivery d SET next_attempt_at = $3 FROM due, webhook_subscription s WHERE d.id = due.id AND s.id = d.subscription_id RETURNING ` + webhookDeliveryColumns + `, s.secret`
+	rows, err := q.conn(ctx).Query(ctx, stmt, now, limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []QueuedWebhookDelivery
+	for rows.Next() {
+		var d QueuedWebhookDelivery
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.SubscriptionURL, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt, &d.Secret); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecordWebhookAttempt logs an attempt and updates its delivery in one statement.
+func (q *Queries) RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAttemptParams) error {
+	const stmt = `WITH attempt AS (INSERT INTO webhook_delivery_attempt (delivery_id, attempted_at, status_code, error, duration_ms) VALUES ($1, $2, $3, $4, $5)) ` +
+		`UPDATE webhook_delivery SET status = $6, attempts = attempts + 1, next_attempt_at = $7, last_status_code = $3, last_error = $4, delivered_at = CASE WHEN $6 = 'delivered' THEN $2 ELSE delivered_at END WHERE id = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, arg.DeliveryID, arg.AttemptedAt, arg.StatusCode, arg.Error, arg.DurationMs, arg.Status, arg.NextAttemptAt)
+	return err
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, limit int32) ([]WebhookDelivery, error) {
+	const stmt = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery d JOIN webhook_subscription s ON s.id = d.subscription_id WHERE d.subscription_id = $1 ORDER BY d.id DESC LIMIT $2`
+	rows, err := q.conn(ctx).Query(ctx, stmt, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	const stmt = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery d JOIN webhook_subscription s ON s.id = d.subscription_id WHERE d.id = $1`
+	return scanWebhookDelivery(q.conn(ctx).QueryRow(ctx, stmt, id))
+}
+
+// RequeueWebhookDelivery makes a delivery pending and due at now with its attempt
+// count reset.
+func (q *Queries) RequeueWebhookDelivery(ctx context.Context, id int64, now time.Time) (WebhookDelivery, error) {
+	const stmt = `WITH d AS (UPDATE webhook_delivery SET status = 'pending', attempts = 0, next_attempt_at = $2 WHERE id = $1 RETURNING *) ` +
+		`SELECT ` + webhookDeliveryColumns + ` FROM d JOIN webhook_subscription s ON s.id = d.subscription_id`
+	return scanWebhookDelivery(q.conn(ctx).QueryRow(ctx, stmt, id, now))
+}
+
+func scanWebhookDelivery(row pgx.Row) (WebhookDelivery, error) {
+	var d WebhookDelivery
+	if err := row.Scan(&d.ID, &d.SubscriptionID, &d.SubscriptionURL, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt); err != nil {
+		return WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+func (q *Queries) ListWebhookAttempts(ctx context.Context, deliveryID int64) ([]WebhookAttempt, error) {
+	const stmt = `SELECT id, delivery_id, attempted_at, status_code, error, duration_ms FROM webhook_delivery_attempt WHERE delivery_id = $1 ORDER BY id DESC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WebhookAttempt
+	for rows.Next() {
+		var a WebhookAttempt
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.AttemptedAt, &a.StatusCode, &a.Error, &a.DurationMs); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type OutboxMessage struct {
+	ID         int64
+	TxID       int64
+	Type       string
+	Key        string
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+func (q *Queries) AppendOutbox(ctx context.Context, eventType, key string, payload []byte, occurredAt time.Time) error {
+	const stmt = `INSERT INTO outbox (type, key, payload, occurred_at) VALUES ($1, $2, $3, $4)`
+	_, err := q.conn(ctx).Exec(ctx, stmt, eventType, key, payload, occurredAt)
+	return err
+}
+
+// FetchOutbox returns messages after the given position in (tx_id, id) order, limited
+// to transactions older than every transaction still running.
+func (q *Queries) FetchOutbox(ctx context.Context, afterTxID, afterID int64, limit int32) ([]OutboxMessage, error) {
+	const stmt = `SELECT id, tx_id, type, key, payload, occurred_at FROM outbox WHERE (tx_id, id) > ($1, $2) AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint ORDER BY tx_id ASC, id ASC LIMIT $3`
+	rows, err := q.conn(ctx).Query(ctx, stmt, afterTxID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []OutboxMessage
+	for rows.Next() {
+		var m OutboxMessage
+		if err := rows.Scan(&m.ID, &m.TxID, &m.Type, &m.Key, &m.Payload, &m.OccurredAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnsureOutboxOffset starts a handler seen for the first time at the transactions
+// still running, so it skips messages written before it existed.
+func (q *Queries) EnsureOutboxOffset(ctx context.Context, handler string) error {
+	const stmt = `INSERT INTO outbox_offset (handler, last_tx_id, last_id) VALUES ($1, pg_snapshot_xmin(pg_current_snapshot())::text::bigint, 0) ON CONFLICT (handler) DO NOTHING`
+	_, err := q.conn(ctx).Exec(ctx, stmt, handler)
+	return err
+}
+
+// LockOutboxOffset locks a handler's offset for the rest of the transaction. It
+// returns pgx.ErrNoRows when another transaction holds the lock.
+func (q *Queries) LockOutboxOffset(ctx context.Context, handler string) (int64, int64, error) {
+	const stmt = `SELECT last_tx_id, last_id FROM outbox_offset WHERE handler = $1 FOR UPDATE SKIP LOCKED`
+	var txID, id int64
+	err := q.conn(ctx).QueryRow(ctx, stmt, handler).Scan(&txID, &id)
+	return txID, id, err
+}
+
+func (q *Queries) SaveOutboxOffset(ctx context.Context, handler string, txID, id int64) error {
+	const stmt = `UPDATE outbox_offset SET last_tx_id = $2, last_id = $3, updated_at = NOW() WHERE handler = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, handler, txID, id)
+	return err
+}
+
+// CleanupOutbox deletes messages that occurred before the cutoff and that every given
+// handler has read past.
+func (q *Queries) CleanupOutbox(ctx context.Context, before time.Time, handlers []string) (int64, error) {
+	const stmt = `DELETE FROM outbox o WHERE o.occurred_at < $1 AND NOT EXISTS (SELECT 1 FROM outbox_offset f WHERE f.handler = ANY($2) AND (o.tx_id, o.id) > (f.last_tx_id, f.last_id))`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, before, handlers)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+type NewsletterSubscriber struct {
+	ID             int64
+	Email          string
+	Status         string
+	CreatedAt      time.Time
+	ConfirmedAt    *time.Time
+	UnsubscribedAt *time.Time
+}
+
+type NewsletterIssue struct {
+	ID               int64
+	PostIDs          []int64
+	LastSubscriberID int64
+}
+
+type NewsletterIssuePost struct {
+	ID      int64
+	Title   string
+	Slug    string
+	Summary string
+}
+
+const newsletterSubscriberColumns = `id, email, status, created_at, confirmed_at, unsubscribed_at`
+
+func (q *Queries) GetNewsletterSubscriberByEmail(ctx context.Context, email string) (NewsletterSubscriber, error) {
+	const stmt = `SELECT ` + newsletterSubscriberColumns + ` FROM newsletter_subscriber WHERE email = $1`
+	return scanNewsletterSubscriber(q.conn(ctx).QueryRow(ctx, stmt, email))
+}
+
+func (q *Queries) CreateNewsletterSubscriber(ctx context.Context, email, tokenHash string) (NewsletterSubscriber, error) {
+	const stmt = `INSERT INTO newsletter_subscriber (email, confirm_token_hash) VALUES ($1, $2) RETURNING ` + newsletterSubscriberColumns
+	return scanNewsletterSubscriber(q.conn(ctx).QueryRow(ctx, stmt, email, tokenHash))
+}
+
+func (q *Queries) ResetNewsletterSubscriber(ctx context.Context, id int64, tokenHash string) (NewsletterSubscriber, error) {
+	const stmt = `UPDATE newsletter_subscriber SET status = 'pending', confirm_token_hash = $2, confirmed_at = NULL, unsubscribed_at = NULL WHERE id = $1 RETURNING ` + newsletterSubscriberColumns
+	return scanNewsletterSubscriber(q.conn(ctx).QueryRow(ctx, stmt, id, tokenHash))
+}
+
+func (q *Queries) ConfirmNewsletterSubscriber(ctx context.Context, tokenHash string, now time.Time) (NewsletterSubscriber, error) {
+	const stmt = `UPDATE newsletter_subscriber SET status = 'confirmed', confirmed_at = $2, confirm_token_hash = NULL WHERE confirm_token_hash = $1 AND status = 'pending' RETURNING ` + newsletterSubscriberColumns
+	return scanNewsletterSubscriber(q.conn(ctx).QueryRow(ctx, stmt, tokenHash, now))
+}
+
+// UnsubscribeNewsletterSubscriber keeps the original time when the subscriber had
+// already left, so repeated unsubscribe links change nothing.
+func (q *Queries) UnsubscribeNewsletterSubscriber(ctx context.Context, id int64, now time.Time) (NewsletterSubscriber, error) {
+	const stmt = `UPDATE newsletter_subscriber SET status = 'unsubscribed', unsubscribed_at = CASE WHEN status = 'unsubscribed' THEN unsubscribed_at ELSE $2 END, confirm_token_hash = NULL WHERE id = $1 RETURNING ` + newsletterSubscriberColumns
+	return scanNewsletterSubscriber(q.conn(ctx).QueryRow(ctx, stmt, id, now))
+}
+
+func (q *Queries) ListNewsletterSubscribers(ctx context.Context, status string) ([]NewsletterSubscriber, error) {
+	const stmt = `SELECT ` + newsletterSubscriberColumns + ` FROM newsletter_subscriber WHERE $1 = '' OR status = $1 ORDER BY id DESC`
+	return q.listNewsletterSubscribers(ctx, stmt, status)
+}
+
+func (q *Queries) ListNewsletterRecipients(ctx context.Context, afterID int64, limit int32) ([]NewsletterSubscriber, error) {
+	const stmt = `SELECT ` + newsletterSubscriberColumns + ` FROM newsletter_subscriber WHERE status = 'confirmed' AND id > $1 ORDER BY id ASC LIMIT $2`
+	return q.listNewsletterSubscribers(ctx, stmt, afterID, limit)
+}
+
+func (q *Queries) listNewsletterSubscribers(ctx context.Context, stmt string, args ...any) ([]NewsletterSubscriber, error) {
+	rows, err := q.conn(ctx).Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []NewsletterSubscriber
+	for rows.Next() {
+		s, err := scanNewsletterSubscriber(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scanNewsletterSubscriber(row pgx.Row) (NewsletterSubscriber, error) {
+	var s NewsletterSubscriber
+	if err := row.Scan(&s.ID, &s.Email, &s.Status, &s.CreatedAt, &s.ConfirmedAt, &s.UnsubscribedAt); err != nil {
+		return NewsletterSubscriber{}, err
+	}
+	return s, nil
+}
+
+func (q *Queries) QueueNewsletterPost(ctx context.Context, postID int64) error {
+	const stmt = `INSERT INTO newsletter_post (post_id) VALUES ($1) ON CONFLICT (post_id) DO NOTHING`
+	_, err := q.conn(ctx).Exec(ctx, stmt, postID)
+	return err
+}
+
+// ClaimNewsletterIssue holds the oldest unfinished issue until leaseUntil, skipping
+// issues another sender holds. It returns pgx.ErrNoRows when there is none.
+func (q *Queries) ClaimNewsletterIssue(ctx context.Context, now, leaseUntil time.Time) (NewsletterIssue, error) {
+	const stmt = `UPDATE newsletter_issue SET locked_until = $2 WHERE id = (SELECT id FROM newsletter_issue WHERE completed_at IS NULL AND (locked_until IS NULL OR locked_until <= $1) ORDER BY id ASC LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING id, post_ids, last_subscriber_id`
+	return scanNewsletterIssue(q.conn(ctx).QueryRow(ctx, stmt, now, leaseUntil))
+}
+
+// CreateNewsletterIssue moves every queued post into a new issue held until
+// leaseUntil. It returns pgx.ErrNoRows when no post is queued.
+func (q *Queries) CreateNewsletterIssue(ctx context.Context, leaseUntil time.Time) (NewsletterIssue, error) {
+	const stmt = `WITH queued AS (SELECT post_id FROM newsletter_post WHERE issue_id IS NULL FOR UPDATE SKIP LOCKED), ` +
+		`issue AS (INSERT INTO newsletter_issue (post_ids, locked_until) SELECT array_agg(post_id ORDER BY post_id), $1 FROM queued HAVING COUNT(*) > 0 RETURNING id, post_ids, last_subscriber_id), ` +
+		`linked AS (UPDATE newsletter_post p SET issue_id = issue.id FROM issue WHERE p.post_id = ANY(issue.post_ids)) ` +
+		`SELECT id, post_ids, last_subscriber_id FROM issue`
+	return scanNewsletterIssue(q.conn(ctx).QueryRow(ctx, stmt, leaseUntil))
+}
+
+func scanNewsletterIssue(row pgx.Row) (NewsletterIssue, error) {
+	var i NewsletterIssue
+	if err := row.Scan(&i.ID, &i.PostIDs, &i.LastSubscriberID); err != nil {
+		return NewsletterIssue{}, err
+	}
+	return i, nil
+}
+
+func (q *Queries) ListNewsletterIssuePosts(ctx context.Context, postIDs []int64) ([]NewsletterIssuePost, error) {
+	const stmt = `SELECT id, title, slug, summary FROM post WHERE id = ANY($1::bigint[]) AND status = 'published' ORDER BY COALESCE(published_at, created_at) ASC, id ASC`
+	rows, err := q.conn(ctx).Query(ctx, stmt, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []NewsletterIssuePost
+	for rows.Next() {
+		var p NewsletterIssuePost
+		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.Summary); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) AdvanceNewsletterIssue(ctx context.Context, id, lastSubscriberID int64, leaseUntil time.Time) error {
+	const stmt = `UPDATE newsletter_issue SET last_subscriber_id = $2, locked_until = $3 WHERE id = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, id, lastSubscriberID, leaseUntil)
+	return err
+}
+
+func (q *Queries) CompleteNewsletterIssue(ctx context.Context, id int64, now time.Time) error {
+	const stmt = `UPDATE newsletter_issue SET completed_at = $2, locked_until = NULL WHERE id = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, id, now)
+	return err
+}
+
+type Media struct {
+	ID           int64
+	Filename     string
+	OriginalName string
+	MimeType     string
+	SizeBytes    int64
+	Width        int32
+	Height       int32
+	AltText      string
+	Caption      string
+	UploadedBy   *int64
+	UploaderName string
+	Checksum     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	UsageCount   int64
+}
+
+type CreateMediaParams struct {
+	Filename     string
+	OriginalName string
+	MimeType     string
+	SizeBytes    int64
+	Width        int32
+	Height       int32
+	AltText      string
+	Caption      string
+	UploadedBy   *int64
+	Checksum     string
+}
+
+type MediaUsage struct {
+	Kind  string
+	Slug  string
+	Title string
+}
+
+const mediaColumns = `m.id, m.filename, m.original_name, m.mime_type, m.size_bytes, m.width, m.height, m.alt_text, m.caption, m.uploaded_by, COALESCE(u.display_name, ''), m.checksum, m.created_at, m.updated_at`
+
+// mediaFiles names the stored files of media row m: the original and its resized
+// variants, which content may embed instead of the original.
+const mediaFiles = `(SELECT m.filename UNION ALL SELECT v.filename FROM media_variant v WHERE v.media_id = m.id) f(filename)`
+
+// mediaMentions is a condition true when one of the files listed by mediaFiles occurs
+// in any of cols.
+func mediaMentions(cols ...string) string {
+	conds := make([]string, len(cols))
+	for i, col := range cols {
+		conds[i] = `strpos(` + col + `, '/' || f.filename) > 0`
+	}
+	return `EXISTS (SELECT 1 FROM ` + mediaFiles + ` WHERE ` + strings.Join(conds, ` OR `) + `)`
+}
+
+// mediaUsage selects the kind, slug and title of the posts, pages, categories and tags
+// whose cover or content mentions a file of media row m.
+var mediaUsage = `SELECT 'post' AS kind, p.slug, p.title FROM post p WHERE ` + mediaMentions(`COALESCE(p.cover_url, '')`, `p.content_md`) +
+	` UNION ALL SELECT 'page', pg.slug, pg.title FROM page pg WHERE ` + mediaMentions(`pg.content_md`) +
+	` UNION ALL SELECT 'category', c.slug, c.name FROM category c WHERE ` + mediaMentions(`c.cover_url`, `c.description_md`) +
+	` UNION ALL SELECT 'tag', t.slug, t.name FROM tag t WHERE ` + mediaMentions(`t.cover_url`, `t.description_md`)
+
+func (q *Queries) ListMedia(ctx context.Context, search string, limit, offset int32) ([]Media, error) {
+	const stmt = `SELECT ` + mediaColumns + `, 0 FROM media m LEFT JOIN app_user u ON u.id = m.uploaded_by ` + mediaListWhere
+	return q.listMedia(ctx, stmt, search, limit, offset)
+}
+
+// ListMediaWithUsage is ListMedia with usage counts, which scan all content for every
+// row returned.
+func (q *Queries) ListMediaWithUsage(ctx context.Context, search string, limit, offset int32) ([]Media, error) {
+	stmt := `SELECT ` + mediaColumns + `, (SELECT COUNT(*) FROM (` + mediaUsage + `) mu) FROM media m LEFT JOIN app_user u ON u.id = m.uploaded_by ` + mediaListWhere
+	return q.listMedia(ctx, stmt, search, limit, offset)
+}
+
+const mediaListWhere = `WHERE $1 = '' OR m.original_name ILIKE '%' || $1 || '%' OR m.alt_text ILIKE '%' || $1 || '%' OR m.caption ILIKE '%' || $1 || '%' ` +
+	`ORDER BY m.created_at DESC, m.id DESC LIMIT $2 OFFSET $3`
+
+func (q *Queries) listMedia(ctx context.Context, stmt, search string, limit, offset int32) ([]Media, error) {
+	rows, err := q.conn(ctx).Query(ctx, stmt, search, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Media
+	for rows.Next() {
+		var m Media
+		if err := rows.Scan(&m.ID, &m.Filename, &m.OriginalName, &m.MimeType, &m.SizeBytes, &m.Width, &m.Height, &m.AltText, &m.Caption, &m.UploadedBy, &m.UploaderName, &m.Checksum, &m.CreatedAt, &m.UpdatedAt, &m.UsageCount); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) GetMedia(ctx context.Context, id int64) (Media, error) {
+	const stmt = `SELECT ` + mediaColumns + ` FROM media m LEFT JOIN app_user u ON u.id = m.uploaded_by WHERE m.id = $1`
+	return scanMedia(q.conn(ctx).QueryRow(ctx, stmt, id))
+}
+
+func (q *Queries) GetMediaByChecksum(ctx context.Context, checksum string) (Media, error) {
+	const stmt = `SELECT ` + mediaColumns + ` FROM media m LEFT JOIN app_user u ON u.id = m.uploaded_by WHERE m.checksum = $1 ORDER BY m.id ASC LIMIT 1`
+	return scanMedia(q.conn(ctx).QueryRow(ctx, stmt, checksum))
+}
+
+func (q *Queries) CreateMedia(ctx context.Context, arg CreateMediaParams) (Media, error) {
+	const stmt = `WITH m AS (INSERT INTO media (filename, original_name, mime_type, size_bytes, width, height, alt_text, caption, uploaded_by, checksum) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *) ` +
+		`SELECT ` + mediaColumns + ` FROM m LEFT JOIN app_user u ON u.id = m.uploaded_by`
+	return scanMedia(q.conn(ctx).QueryRow(ctx, stmt, arg.Filename, arg.OriginalName, arg.MimeType, arg.SizeBytes, arg.Width, arg.Height, arg.AltText, arg.Caption, arg.UploadedBy, arg.Checksum))
+}
+
+func (q *Queries) UpdateMediaText(ctx context.Context, id int64, altText, caption string) (Media, error) {
+	const stmt = `WITH m AS (UPDATE media SET alt_text = $2, caption = $3, updated_at = NOW() WHERE id = $1 RETURNING *) ` +
+		`SELECT ` + mediaColumns + ` FROM m LEFT JOIN app_user u ON u.id = m.uploaded_by`
+	return scanMedia(q.conn(ctx).QueryRow(ctx, stmt, id, altText, caption))
+}
+
+func (q *Queries) DeleteMedia(ctx context.Context, id int64) (int64, error) {
+	const stmt = `DELETE FROM media WHERE id = $1`
+	tag, err := q.conn(ctx).Exec(ctx, stmt, id)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// LockMediaContent blocks writes to the tables media usage is searched in until the
+// transaction ends, while still letting them be read.
+func (q *Queries) LockMediaContent(ctx context.Context) error {
+	const stmt = `LOCK TABLE post, page, category, tag IN SHARE MODE`
+	_, err := q.conn(ctx).Exec(ctx, stmt)
+	return err
+}
+
+func (q *Queries) ListMediaUsage(ctx context.Context, mediaID int64) ([]MediaUsage, error) {
+	stmt := `SELECT kind, slug, title FROM media m, LATERAL (` + mediaUsage + `) mu WHERE m.id = $1 ORDER BY 1, 2`
+	rows, err := q.conn(ctx).Query(ctx, stmt, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []MediaUsage
+	for rows.Next() {
+		var u MediaUsage
+		if err := rows.Scan(&u.Kind, &u.Slug, &u.Title); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scanMedia(row pgx.Row) (Media, error) {
+	var m Media
+	if err := row.Scan(&m.ID, &m.Filename, &m.OriginalName, &m.MimeType, &m.SizeBytes, &m.Width, &m.Height, &m.AltText, &m.Caption, &m.UploadedBy, &m.UploaderName, &m.Checksum, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return Media{}, err
+	}
+	return m, nil
+}
+
+type MediaVariant struct {
+	MediaID   int64
+	Width     int32
+	Height    int32
+	Filename  string
+	MimeType  string
+	SizeBytes int64
+}
+
+func (q *Queries) GetMediaByFilename(ctx context.Context, filename string) (Media, error) {
+	const stmt = `SELECT ` + mediaColumns + ` FROM media m LEFT JOIN app_user u ON u.id = m.uploaded_by WHERE m.filename = $1`
+	return scanMedia(q.conn(ctx).QueryRow(ctx, stmt, filename))
+}
+
+func (q *Queries) ListMediaVariants(ctx context.Context, mediaIDs []int64) ([]MediaVariant, error) {
+	const stmt = `SELECT media_id, width, height, filename, mime_type, size_bytes FROM media_variant WHERE media_id = ANY($1::bigint[]) ORDER BY media_id, width`
+	rows, err := q.conn(ctx).Query(ctx, stmt, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []MediaVariant
+	for rows.Next() {
+		var v MediaVariant
+		if err := rows.Scan(&v.MediaID, &v.Width, &v.Height, &v.Filename, &v.MimeType, &v.SizeBytes); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *Queries) DeleteMediaVariants(ctx context.Context, mediaID int64) error {
+	const stmt = `DELETE FROM media_variant WHERE media_id = $1`
+	_, err := q.conn(ctx).Exec(ctx, stmt, mediaID)
+	return err
+}
+
+func (q *Queries) CreateMediaVariant(ctx context.Context, arg MediaVariant) error {
+	const stmt = `INSERT INTO media_variant (media_id, width, height, filename, mime_type, size_bytes) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := q.conn(ctx).Exec(ctx, stmt, arg.MediaID, arg.Width, arg.Height, arg.Filename, arg.MimeType, arg.SizeBytes)
+	return err
+}
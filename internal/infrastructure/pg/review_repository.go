@@ -0,0 +1,122 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// ReviewRepository implements postdomain.ReviewRepository backed by pgx queries.
+type ReviewRepository struct {
+	queries *Queries
+}
+
+// NewReviewRepository constructs a ReviewRepository from a pool.
+func NewReviewRepository(pool *pgxpool.Pool) *ReviewRepository {
+	return &ReviewRepository{queries: New(pool)}
+}
+
+var _ postdomain.ReviewRepository = (*ReviewRepository)(nil)
+
+func (r *ReviewRepository) GetReview(ctx context.Context, slug string) (postdomain.Review, error) {
+	row, err := r.queries.GetPostReview(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return postdomain.Review{}, postdomain.ErrPostNotFound
+		}
+		return postdomain.Review{}, err
+	}
+	return mapReview(row), nil
+}
+
+func (r *ReviewRepository) ListReviewsByStatus(ctx context.Context, status string) ([]postdomain.Review, error) {
+	rows, err := r.queries.ListPostReviewsByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.Review, len(rows))
+	for i, row := range rows {
+		out[i] = mapReview(row)
+	}
+	return out, nil
+}
+
+func (r *ReviewRepository) SetReviewer(ctx context.Context, slug string, reviewerID *int64) error {
+	n, err := r.queries.SetPostReviewer(ctx, slug, reviewerID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return postdomain.ErrPostNotFound
+	}
+	return nil
+}
+
+func (r *ReviewRepository) ListReviewers(ctx context.Context) ([]postdomain.Reviewer, error) {
+	rows, err := r.queries.ListReviewers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.Reviewer, len(rows))
+	for i, row := range rows {
+		out[i] = postdomain.Reviewer{ID: row.ID, DisplayName: row.DisplayName, Email: row.Email, Role: row.Role}
+	}
+	return out, nil
+}
+
+func (r *ReviewRepository) CreateReviewComment(ctx context.Context, record postdomain.ReviewCommentRecord) (postdomain.ReviewComment, error) {
+	row, err := r.queries.CreateReviewComment(ctx, ReviewCommentParams{
+		PostID:     record.PostID,
+		AuthorID:   record.AuthorID,
+		Body:       record.Body,
+		FromStatus: record.FromStatus,
+		ToStatus:   record.ToStatus,
+	})
+	if err != nil {
+		return postdomain.ReviewComment{}, err
+	}
+	return mapReviewComment(row), nil
+}
+
+func (r *ReviewRepository) ListReviewComments(ctx context.Context, postID int64) ([]postdomain.ReviewComment, error) {
+	rows, err := r.queries.ListReviewComments(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.ReviewComment, len(rows))
+	for i, row := range rows {
+		out[i] = mapReviewComment(row)
+	}
+	return out, nil
+}
+
+func mapReview(r PostReview) postdomain.Review {
+	return postdomain.Review{
+		PostID:       r.PostID,
+		Slug:         r.Slug,
+		Title:        r.Title,
+		Status:       r.Status,
+		AuthorID:     r.AuthorID,
+		AuthorName:   r.AuthorName,
+		ReviewerID:   r.ReviewerID,
+		ReviewerName: r.ReviewerName,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+func mapReviewComment(c ReviewComment) postdomain.ReviewComment {
+	return postdomain.ReviewComment{
+		ID:         c.ID,
+		PostID:     c.PostID,
+		AuthorID:   c.AuthorID,
+		AuthorName: c.AuthorName,
+		Body:       c.Body,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		CreatedAt:  c.CreatedAt,
+	}
+}
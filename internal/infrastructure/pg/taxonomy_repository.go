@@ -2,6 +2,9 @@
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
 
 	taxdomain "proto-gin-web/internal/contexts/blog/taxonomy/domain"
 )
@@ -18,35 +21,178 @@ func NewTaxonomyRepository(queries *Queries) *TaxonomyRepository {
 
 var _ taxdomain.TaxonomyRepository = (*TaxonomyRepository)(nil)
 
-func (r *TaxonomyRepository) CreateCategory(ctx context.Context, input taxdomain.CreateCategoryInput) (taxdomain.Category, error) {
-	row, err := r.queries.CreateCategory(ctx, CreateCategoryParams{Name: input.Name, Slug: input.Slug})
+func (r *TaxonomyRepository) ListCategories(ctx context.Context) ([]taxdomain.CategoryWithCount, error) {
+	rows, err := r.queries.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]taxdomain.CategoryWithCount, len(rows))
+	for i, row := range rows {
+		out[i] = taxdomain.CategoryWithCount{Category: mapCategory(row.Category), PostCount: row.PostCount}
+	}
+	return out, nil
+}
+
+func (r *TaxonomyRepository) GetCategory(ctx context.Context, slug string) (taxdomain.CategoryWithCount, error) {
+	row, err := r.queries.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return taxdomain.CategoryWithCount{}, taxdomain.ErrCategoryNotFound
+		}
+		return taxdomain.CategoryWithCount{}, err
+	}
+	return taxdomain.CategoryWithCount{Category: mapCategory(row.Category), PostCount: row.PostCount}, nil
+}
+
+func (r *TaxonomyRepository) CreateCategory(ctx context.Context, record taxdomain.CategoryRecord) (taxdomain.Category, error) {
+	row, err := r.queries.CreateCategory(ctx, CreateCategoryParams{Name: record.Name, Slug: record.Slug, ParentID: record.ParentID, TaxonomyDetails: TaxonomyDetails(record.Details)})
+	if err != nil {
+		return taxdomain.Category{}, mapTaxonomyError(err, taxdomain.ErrCategoryNotFound)
+	}
+	return mapCategory(row), nil
+}
+
+func (r *TaxonomyRepository) UpdateCategory(ctx context.Context, slug string, record taxdomain.CategoryRecord) (taxdomain.Category, error) {
+	row, err := r.queries.UpdateCategoryBySlug(ctx, slug, CreateCategoryParams{Name: record.Name, Slug: record.Slug, ParentID: record.ParentID, TaxonomyDetails: TaxonomyDetails(record.Details)})
+	if err != nil {
+		return taxdomain.Category{}, mapTaxonomyError(err, taxdomain.ErrCategoryNotFound)
+	}
+	return mapCategory(row), nil
+}
+
+func (r *TaxonomyRepository) ListCategoryAncestors(ctx context.Context, id int64) ([]taxdomain.Category, error) {
+	rows, err := r.queries.ListCategoryAncestors(ctx, id)
 	if err != nil {
-		return taxdomain.Category{}, err
+		return nil, err
 	}
-	return taxdomain.Category{
-		ID:   row.ID,
-		Name: row.Name,
-		Slug: row.Slug,
-	}, nil
+	return mapCategories(rows), nil
+}
+
+func (r *TaxonomyRepository) CategorySlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.queries.CategorySlugExists(ctx, slug)
 }
 
 func (r *TaxonomyRepository) DeleteCategory(ctx context.Context, slug string) error {
 	return r.queries.DeleteCategoryBySlug(ctx, slug)
 }
 
-func (r *TaxonomyRepository) CreateTag(ctx context.Context, input taxdomain.CreateTagInput) (taxdomain.Tag, error) {
-	row, err := r.queries.CreateTag(ctx, CreateTagParams{Name: input.Name, Slug: input.Slug})
+func (r *TaxonomyRepository) ListTags(ctx context.Context) ([]taxdomain.TagWithCount, error) {
+	rows, err := r.queries.ListTags(ctx)
 	if err != nil {
-		return taxdomain.Tag{}, err
+		return nil, err
+	}
+	out := make([]taxdomain.TagWithCount, len(rows))
+	for i, row := range rows {
+		out[i] = taxdomain.TagWithCount{Tag: mapTag(row.Tag), PostCount: row.PostCount}
 	}
-	return taxdomain.Tag{
-		ID:   row.ID,
-		Name: row.Name,
-		Slug: row.Slug,
-	}, nil
+	return out, nil
+}
+
+func (r *TaxonomyRepository) GetTag(ctx context.Context, slug string) (taxdomain.TagWithCount, error) {
+	row, err := r.queries.GetTagBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return taxdomain.TagWithCount{}, taxdomain.ErrTagNotFound
+		}
+		return taxdomain.TagWithCount{}, err
+	}
+	return taxdomain.TagWithCount{Tag: mapTag(row.Tag), PostCount: row.PostCount}, nil
+}
+
+func (r *TaxonomyRepository) CreateTag(ctx context.Context, record taxdomain.TagRecord) (taxdomain.Tag, error) {
+	row, err := r.queries.CreateTag(ctx, CreateTagParams{Name: record.Name, Slug: record.Slug, TaxonomyDetails: TaxonomyDetails(record.Details)})
+	if err != nil {
+		return taxdomain.Tag{}, mapTaxonomyError(err, taxdomain.ErrTagNotFound)
+	}
+	return mapTag(row), nil
+}
+
+func (r *TaxonomyRepository) UpdateTag(ctx context.Context, slug string, record taxdomain.TagRecord) (taxdomain.Tag, error) {
+	row, err := r.queries.UpdateTagBySlug(ctx, slug, CreateTagParams{Name: record.Name, Slug: record.Slug, TaxonomyDetails: TaxonomyDetails(record.Details)})
+	if err != nil {
+		return taxdomain.Tag{}, mapTaxonomyError(err, taxdomain.ErrTagNotFound)
+	}
+	return mapTag(row), nil
+}
+
+func (r *TaxonomyRepository) TagSlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.queries.TagSlugExists(ctx, slug)
 }
 
 func (r *TaxonomyRepository) DeleteTag(ctx context.Context, slug string) error {
 	return r.queries.DeleteTagBySlug(ctx, slug)
 }
 
+func (r *TaxonomyRepository) MergeTags(ctx context.Context, targetSlug string, sourceSlugs []string) (taxdomain.Tag, error) {
+	var target Tag
+	err := WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		var err error
+		if target, err = r.queries.LockTagBySlug(ctx, targetSlug); err != nil {
+			return err
+		}
+		sourceIDs, err := r.queries.LockTagIDsBySlugs(ctx, sourceSlugs)
+		if err != nil {
+			return err
+		}
+		if len(sourceIDs) != len(sourceSlugs) {
+			return pgx.ErrNoRows
+		}
+		return r.queries.MergeTags(ctx, target.ID, sourceIDs)
+	})
+	if err != nil {
+		return taxdomain.Tag{}, mapTaxonomyError(err, taxdomain.ErrTagNotFound)
+	}
+	return mapTag(target), nil
+}
+
+func (r *TaxonomyRepository) ResolveTagAlias(ctx context.Context, slug string) (taxdomain.Tag, error) {
+	row, err := r.queries.ResolveTagAlias(ctx, slug)
+	if err != nil {
+		return taxdomain.Tag{}, mapTaxonomyError(err, taxdomain.ErrAliasNotFound)
+	}
+	return mapTag(row), nil
+}
+
+func (r *TaxonomyRepository) ListTagAliases(ctx context.Context) ([]taxdomain.TagAlias, error) {
+	rows, err := r.queries.ListTagAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]taxdomain.TagAlias, len(rows))
+	for i, row := range rows {
+		out[i] = taxdomain.TagAlias{Slug: row.Slug, Tag: mapTag(row.Tag), CreatedAt: row.CreatedAt}
+	}
+	return out, nil
+}
+
+func (r *TaxonomyRepository) DeleteTagAlias(ctx context.Context, slug string) error {
+	n, err := r.queries.DeleteTagAlias(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return taxdomain.ErrAliasNotFound
+	}
+	return nil
+}
+
+func mapCategory(row Category) taxdomain.Category {
+	return taxdomain.Category{ID: row.ID, Name: row.Name, Slug: row.Slug, ParentID: row.ParentID, Details: taxdomain.Details(row.TaxonomyDetails)}
+}
+
+func mapTag(row Tag) taxdomain.Tag {
+	return taxdomain.Tag{ID: row.ID, Name: row.Name, Slug: row.Slug, Details: taxdomain.Details(row.TaxonomyDetails)}
+}
+
+// mapTaxonomyError translates missing rows and name/slug conflicts to domain errors.
+func mapTaxonomyError(err, notFound error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return notFound
+	case errors.Is(err, ErrTaxonomyNameAlreadyExists):
+		return taxdomain.ErrNameTaken
+	case errors.Is(err, ErrTaxonomySlugAlreadyExists):
+		return taxdomain.ErrSlugTaken
+	}
+	return err
+}
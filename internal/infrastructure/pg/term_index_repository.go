@@ -0,0 +1,193 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// TermIndexRepository implements postdomain.TermIndexRepository backed by pgx queries.
+type TermIndexRepository struct {
+	queries *Queries
+}
+
+// NewTermIndexRepository constructs a TermIndexRepository from a pool.
+func NewTermIndexRepository(pool *pgxpool.Pool) *TermIndexRepository {
+	return &TermIndexRepository{queries: New(pool)}
+}
+
+var _ postdomain.TermIndexRepository = (*TermIndexRepository)(nil)
+
+// SavePostTerms locks the post's previous counts so document frequencies only move by
+// the terms that were added or removed.
+func (r *TermIndexRepository) SavePostTerms(ctx context.Context, postID int64, terms map[string]int) ([]int64, error) {
+	if terms == nil {
+		terms = map[string]int{}
+	}
+	raw, err := json.Marshal(terms)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []int64
+	err = WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		old := map[string]int{}
+		oldRaw, oldTagIDs, err := r.queries.LockPostTerms(ctx, postID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return err
+		default:
+			if old, err = decodeTerms(postID, oldRaw); err != nil {
+				return err
+			}
+		}
+		newTagIDs, err := r.queries.SavePostTerms(ctx, postID, raw)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.queries.RemoveTermDocumentFrequencies(ctx, missingTerms(old, terms)); err != nil {
+			return err
+		}
+		if err := r.queries.AddTermDocumentFrequencies(ctx, missingTerms(terms, old)); err != nil {
+			return err
+		}
+		tagIDs = mergeIDs(oldTagIDs, newTagIDs)
+		return nil
+	})
+	return tagIDs, err
+}
+
+func (r *TermIndexRepository) DeletePostTerms(ctx context.Context, postID int64) ([]int64, error) {
+	var tagIDs []int64
+	err := WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		raw, ids, err := r.queries.DeletePostTerms(ctx, postID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		terms, err := decodeTerms(postID, raw)
+		if err != nil {
+			return err
+		}
+		tagIDs = ids
+		return r.queries.RemoveTermDocumentFrequencies(ctx, missingTerms(terms, nil))
+	})
+	return tagIDs, err
+}
+
+func (r *TermIndexRepository) TermFrequencies(ctx context.Context, terms []string) (int, map[string]int, error) {
+	docs, err := r.queries.CountIndexedPosts(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	df, err := r.queries.ListTermDocumentFrequencies(ctx, terms)
+	if err != nil {
+		return 0, nil, err
+	}
+	return int(docs), df, nil
+}
+
+func (r *TermIndexRepository) ListTagTermCounts(ctx context.Context, tagID int64) ([]map[string]int, error) {
+	rows, err := r.queries.ListTagTermCounts(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]int, len(rows))
+	for i, raw := range rows {
+		if err := json.Unmarshal(raw, &out[i]); err != nil {
+			return nil, fmt.Errorf("decode terms of a post tagged %d: %w", tagID, err)
+		}
+	}
+	return out, nil
+}
+
+func (r *TermIndexRepository) ReplaceTagCentroid(ctx context.Context, tagID int64, weights map[string]float64) error {
+	terms := make([]string, 0, len(weights))
+	for term := range weights {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	values := make([]float64, len(terms))
+	for i, term := range terms {
+		values[i] = weights[term]
+	}
+	return WithinTx(ctx, r.queries.pool, func(ctx context.Context) error {
+		return r.queries.ReplaceTagCentroid(ctx, tagID, terms, values)
+	})
+}
+
+func (r *TermIndexRepository) ListTagCentroidWeights(ctx context.Context, terms []string) (map[string]map[string]float64, error) {
+	rows, err := r.queries.ListTagCentroidWeights(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]map[string]float64)
+	for _, row := range rows {
+		if out[row.TagSlug] == nil {
+			out[row.TagSlug] = make(map[string]float64)
+		}
+		out[row.TagSlug][row.Term] = row.Weight
+	}
+	return out, nil
+}
+
+func (r *TermIndexRepository) ListTagsWithoutCentroid(ctx context.Context) ([]int64, error) {
+	return r.queries.ListTagsWithoutCentroid(ctx)
+}
+
+func (r *TermIndexRepository) ListUnindexedTermSources(ctx context.Context, limit int32) ([]postdomain.TermSource, error) {
+	rows, err := r.queries.ListUnindexedTermSources(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]postdomain.TermSource, len(rows))
+	for i, row := range rows {
+		out[i] = postdomain.TermSource{PostID: row.PostID, Title: row.Title, Summary: row.Summary, ContentMD: row.ContentMd}
+	}
+	return out, nil
+}
+
+func decodeTerms(postID int64, raw []byte) (map[string]int, error) {
+	var terms map[string]int
+	if err := json.Unmarshal(raw, &terms); err != nil {
+		return nil, fmt.Errorf("decode terms of post %d: %w", postID, err)
+	}
+	return terms, nil
+}
+
+// missingTerms returns the terms of a that b lacks, sorted so concurrent writers update
+// frequency rows in the same order.
+func missingTerms(a, b map[string]int) []string {
+	out := []string{}
+	for term := range a {
+		if _, ok := b[term]; !ok {
+			out = append(out, term)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func mergeIDs(a, b []int64) []int64 {
+	seen := make(map[int64]bool, len(a)+len(b))
+	var out []int64
+	for _, id := range append(append([]int64(nil), a...), b...) {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
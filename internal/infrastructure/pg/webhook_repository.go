@@ -0,0 +1,196 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	webhookdomain "proto-gin-web/internal/contexts/admin/webhook/domain"
+)
+
+// WebhookRepository implements webhookdomain.WebhookRepository backed by pgx queries.
+type WebhookRepository struct {
+	queries *Queries
+}
+
+// NewWebhookRepository constructs a WebhookRepository from a pool.
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{queries: New(pool)}
+}
+
+var _ webhookdomain.WebhookRepository = (*WebhookRepository)(nil)
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]webhookdomain.Subscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]webhookdomain.Subscription, len(rows))
+	for i, s := range rows {
+		out[i] = mapWebhookSubscription(s)
+	}
+	return out, nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int64) (webhookdomain.Subscription, error) {
+	row, err := r.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhookdomain.Subscription{}, webhookdomain.ErrSubscriptionNotFound
+		}
+		return webhookdomain.Subscription{}, err
+	}
+	return mapWebhookSubscription(row), nil
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	row, err := r.queries.CreateWebhookSubscription(ctx, webhookSubscriptionParams(input))
+	if err != nil {
+		return webhookdomain.Subscription{}, err
+	}
+	return mapWebhookSubscription(row), nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, id int64, input webhookdomain.SubscriptionInput) (webhookdomain.Subscription, error) {
+	row, err := r.queries.UpdateWebhookSubscription(ctx, id, webhookSubscriptionParams(input))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhookdomain.Subscription{}, webhookdomain.ErrSubscriptionNotFound
+		}
+		return webhookdomain.Subscription{}, err
+	}
+	return mapWebhookSubscription(row), nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	n, err := r.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return webhookdomain.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error) {
+	return r.queries.EnqueueWebhookDeliveries(ctx, event, payload)
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int32, lease time.Duration) ([]webhookdomain.QueuedDelivery, error) {
+	rows, err := r.queries.ClaimDueWebhookDeliveries(ctx, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]webhookdomain.QueuedDelivery, len(rows))
+	for i, d := range rows {
+		out[i] = webhookdomain.QueuedDelivery{Delivery: mapWebhookDelivery(d.WebhookDelivery), Secret: d.Secret}
+	}
+	return out, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, record webhookdomain.AttemptRecord) error {
+	return r.queries.RecordWebhookAttempt(ctx, RecordWebhookAttemptParams{
+		DeliveryID:    record.DeliveryID,
+		AttemptedAt:   record.AttemptedAt,
+		StatusCode:    record.StatusCode,
+		Error:         record.Error,
+		DurationMs:    record.Duration.Milliseconds(),
+		Status:        record.Status,
+		NextAttemptAt: record.NextAttemptAt,
+	})
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int32) ([]webhookdomain.Delivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]webhookdomain.Delivery, len(rows))
+	for i, d := range rows {
+		out[i] = mapWebhookDelivery(d)
+	}
+	return out, nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (webhookdomain.Delivery, error) {
+	row, err := r.queries.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhookdomain.Delivery{}, webhookdomain.ErrDeliveryNotFound
+		}
+		return webhookdomain.Delivery{}, err
+	}
+	return mapWebhookDelivery(row), nil
+}
+
+func (r *WebhookRepository) ListAttempts(ctx context.Context, deliveryID int64) ([]webhookdomain.Attempt, error) {
+	rows, err := r.queries.ListWebhookAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]webhookdomain.Attempt, len(rows))
+	for i, a := range rows {
+		out[i] = webhookdomain.Attempt{
+			ID:          a.ID,
+			DeliveryID:  a.DeliveryID,
+			AttemptedAt: a.AttemptedAt,
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+			DurationMS:  a.DurationMs,
+		}
+	}
+	return out, nil
+}
+
+func (r *WebhookRepository) RequeueDelivery(ctx context.Context, id int64, now time.Time) (webhookdomain.Delivery, error) {
+	row, err := r.queries.RequeueWebhookDelivery(ctx, id, now)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhookdomain.Delivery{}, webhookdomain.ErrDeliveryNotFound
+		}
+		return webhookdomain.Delivery{}, err
+	}
+	return mapWebhookDelivery(row), nil
+}
+
+func webhookSubscriptionParams(input webhookdomain.SubscriptionInput) WebhookSubscriptionParams {
+	return WebhookSubscriptionParams{
+		URL:    input.URL,
+		Secret: input.Secret,
+		Events: input.Events,
+		Active: input.Active,
+	}
+}
+
+func mapWebhookSubscription(s WebhookSubscription) webhookdomain.Subscription {
+	return webhookdomain.Subscription{
+		ID:        s.ID,
+		URL:       s.URL,
+		Secret:    s.Secret,
+		Events:    s.Events,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func mapWebhookDelivery(d WebhookDelivery) webhookdomain.Delivery {
+	return webhookdomain.Delivery{
+		ID:              d.ID,
+		SubscriptionID:  d.SubscriptionID,
+		SubscriptionURL: d.SubscriptionURL,
+		Event:           d.Event,
+		Payload:         d.Payload,
+		Status:          d.Status,
+		Attempts:        d.Attempts,
+		NextAttemptAt:   d.NextAttemptAt,
+		LastStatusCode:  d.LastStatusCode,
+		LastError:       d.LastError,
+		CreatedAt:       d.CreatedAt,
+		DeliveredAt:     d.DeliveredAt,
+	}
+}
@@ -0,0 +1,96 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	postdomain "proto-gin-web/internal/contexts/blog/post/domain"
+)
+
+// maxLockUpdateAttempts bounds the optimistic retries of UpdateLock under contention.
+const maxLockUpdateAttempts = 5
+
+// EditLockStore implements postdomain.EditLockStore using Redis, next to the admin
+// sessions, so every instance sees the same locks.
+type EditLockStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewEditLockStore wires a redis client into the store.
+func NewEditLockStore(client *redis.Client) *EditLockStore {
+	return &EditLockStore{
+		client:    client,
+		keyPrefix: "admin",
+	}
+}
+
+var _ postdomain.EditLockStore = (*EditLockStore)(nil)
+
+func (s *EditLockStore) GetLock(ctx context.Context, slug string) (*postdomain.EditLock, error) {
+	return readLock(ctx, s.client, s.key(slug))
+}
+
+// UpdateLock runs fn inside a WATCH/MULTI transaction on the lock key and retries when
+// another writer changed the key in between.
+func (s *EditLockStore) UpdateLock(ctx context.Context, slug string, fn func(current *postdomain.EditLock) (*postdomain.EditLock, error)) error {
+	key := s.key(slug)
+	update := func(tx *redis.Tx) error {
+		current, err := readLock(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		next, err := fn(current)
+		if err != nil {
+			return err
+		}
+		var data []byte
+		ttl := time.Duration(0)
+		if next != nil {
+			ttl = time.Until(next.ExpiresAt)
+			if data, err = json.Marshal(next); err != nil {
+				return err
+			}
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if ttl <= 0 {
+				pipe.Del(ctx, key)
+				return nil
+			}
+			pipe.Set(ctx, key, data, ttl)
+			return nil
+		})
+		return err
+	}
+	for attempt := 0; attempt < maxLockUpdateAttempts; attempt++ {
+		err := s.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+func readLock(ctx context.Context, client redis.Cmdable, key string) (*postdomain.EditLock, error) {
+	data, err := client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var lock postdomain.EditLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+func (s *EditLockStore) key(slug string) string {
+	return fmt.Sprintf("%s:post-locks:%s", s.keyPrefix, slug)
+}
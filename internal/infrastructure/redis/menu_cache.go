@@ -0,0 +1,63 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	menudomain "proto-gin-web/internal/contexts/blog/menu/domain"
+)
+
+// MenuCache implements menudomain.MenuCache using Redis so every instance sees the
+// same invalidations.
+type MenuCache struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewMenuCache wires a redis client into the cache. Entries expire after ttl even
+// without an explicit invalidation.
+func NewMenuCache(client *redis.Client, ttl time.Duration) *MenuCache {
+	return &MenuCache{
+		client:    client,
+		keyPrefix: "menu",
+		ttl:       ttl,
+	}
+}
+
+var _ menudomain.MenuCache = (*MenuCache)(nil)
+
+func (c *MenuCache) Get(ctx context.Context, name string) ([]menudomain.Link, bool, error) {
+	data, err := c.client.Get(ctx, c.key(name)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var links []menudomain.Link
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, false, err
+	}
+	return links, true, nil
+}
+
+func (c *MenuCache) Set(ctx context.Context, name string, links []menudomain.Link) error {
+	data, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(name), data, c.ttl).Err()
+}
+
+func (c *MenuCache) Invalidate(ctx context.Context, name string) error {
+	return c.client.Del(ctx, c.key(name)).Err()
+}
+
+func (c *MenuCache) key(name string) string {
+	return fmt.Sprintf("%s:resolved:%s", c.keyPrefix, name)
+}
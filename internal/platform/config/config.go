@@ -1,10 +1,24 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// EnvProduction is the APP_ENV value of production deployments.
+const EnvProduction = "production"
+
+// devNewsletterSecret signs newsletter links outside production, so they work without
+// setup. It is public, so production must set NEWSLETTER_SECRET instead.
+const devNewsletterSecret = "dev-newsletter-secret"
+
+// ErrNewsletterSecretRequired is returned by Validate when production runs without
+// NEWSLETTER_SECRET.
+var ErrNewsletterSecretRequired = errors.New("config: NEWSLETTER_SECRET must be set in production")
+
 type Config struct {
 	Env     string
 	Port    string
@@ -25,12 +39,45 @@ type Config struct {
 	RedisDB            int
 	SessionCookieName  string
 	RememberCookieName string
+
+	MailDriver   string
+	MailFrom     string
+	MailDir      string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	NewsletterSecret     string
+	NewsletterMode       string
+	NewsletterDigestHour int
+
+	ImageVariantWidths []int
+	ImageJPEGQuality   int
+
+	StorageDriver    string
+	StorageLocalDir  string
+	StorageRedirect  bool
+	S3Endpoint       string
+	S3PublicEndpoint string
+	S3Region         string
+	S3Bucket         string
+	S3Prefix         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3UseSSL         bool
+	S3URLExpiry      time.Duration
 }
 
 func Load() Config {
 	redisDB := getEnvInt("REDIS_DB", 0)
+	env := getEnv("APP_ENV", "development")
+	newsletterSecret := devNewsletterSecret
+	if env == EnvProduction {
+		newsletterSecret = ""
+	}
 	return Config{
-		Env:                getEnv("APP_ENV", "development"),
+		Env:                env,
 		Port:               getEnv("PORT", "8080"),
 		LogFile:            getEnv("LOG_FILE", "logs/app.log"),
 		DBUser:             getEnv("POSTGRES_USER", "proto_user"),
@@ -46,7 +93,44 @@ func Load() Config {
 		RedisDB:            redisDB,
 		SessionCookieName:  getEnv("ADMIN_SESSION_COOKIE", "admin_session"),
 		RememberCookieName: getEnv("ADMIN_REMEMBER_COOKIE", "admin_remember"),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "Prototype <noreply@localhost>"),
+		MailDir:      getEnv("MAIL_DIR", "logs/mail"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "1025"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		NewsletterSecret:     getEnv("NEWSLETTER_SECRET", newsletterSecret),
+		NewsletterMode:       getEnv("NEWSLETTER_MODE", "immediate"),
+		NewsletterDigestHour: getEnvInt("NEWSLETTER_DIGEST_HOUR", 8),
+
+		ImageVariantWidths: getEnvInts("IMAGE_VARIANT_WIDTHS", []int{320, 640, 1280}),
+		ImageJPEGQuality:   getEnvInt("IMAGE_JPEG_QUALITY", 82),
+
+		StorageDriver:    getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:  getEnv("STORAGE_LOCAL_DIR", "web/static/uploads"),
+		StorageRedirect:  getEnvBool("STORAGE_REDIRECT", false),
+		S3Endpoint:       getEnv("S3_ENDPOINT", ""),
+		S3PublicEndpoint: getEnv("S3_PUBLIC_ENDPOINT", ""),
+		S3Region:         getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:         getEnv("S3_BUCKET", ""),
+		S3Prefix:         getEnv("S3_PREFIX", ""),
+		S3AccessKey:      getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:      getEnv("S3_SECRET_KEY", ""),
+		S3UseSSL:         getEnvBool("S3_USE_SSL", true),
+		S3URLExpiry:      getEnvDuration("S3_URL_EXPIRY", 15*time.Minute),
+	}
+}
+
+// Validate reports settings that have no safe default in production. Callers should
+// refuse to start when it fails.
+func (c Config) Validate() error {
+	if c.Env == EnvProduction && c.NewsletterSecret == "" {
+		return ErrNewsletterSecretRequired
 	}
+	return nil
 }
 
 func getEnv(key, fallback string) string {
@@ -64,3 +148,41 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration reads a time.ParseDuration value such as "15m"; non-positive values
+// fall back.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvInts reads a comma separated list of positive integers, falling back when the
+// variable is unset or any entry is not one.
+func getEnvInts(key string, fallback []int) []int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	var out []int
+	for _, part := range strings.Split(v, ",") {
+		parsed, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || parsed <= 0 {
+			return fallback
+		}
+		out = append(out, parsed)
+	}
+	return out
+}
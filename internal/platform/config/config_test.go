@@ -1,6 +1,10 @@
 package config
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+)
 
 func TestLoadDefaults(t *testing.T) {
 	// ensure empty values trigger fallbacks
@@ -46,3 +50,68 @@ func TestLoadOverrides(t *testing.T) {
 		t.Fatalf("expected BaseURL override 'https://example.com', got %q", cfg.BaseURL)
 	}
 }
+
+func TestLoadImageVariantWidths(t *testing.T) {
+	t.Setenv("IMAGE_VARIANT_WIDTHS", "480, 960")
+	if got := Load().ImageVariantWidths; len(got) != 2 || got[0] != 480 || got[1] != 960 {
+		t.Fatalf("expected widths [480 960], got %v", got)
+	}
+
+	t.Setenv("IMAGE_VARIANT_WIDTHS", "480,wide")
+	if got := Load().ImageVariantWidths; len(got) != 3 || got[0] != 320 {
+		t.Fatalf("expected the default widths for an invalid list, got %v", got)
+	}
+}
+
+func TestLoadStorage(t *testing.T) {
+	cfg := Load()
+	if cfg.StorageDriver != "local" || cfg.StorageLocalDir != "web/static/uploads" || !cfg.S3UseSSL || cfg.S3URLExpiry != 15*time.Minute {
+		t.Fatalf("unexpected storage defaults %+v", cfg)
+	}
+
+	t.Setenv("STORAGE_DRIVER", "s3")
+	t.Setenv("STORAGE_REDIRECT", "true")
+	t.Setenv("S3_USE_SSL", "false")
+	t.Setenv("S3_URL_EXPIRY", "1h")
+	cfg = Load()
+	if cfg.StorageDriver != "s3" || !cfg.StorageRedirect || cfg.S3UseSSL || cfg.S3URLExpiry != time.Hour {
+		t.Fatalf("unexpected storage overrides %+v", cfg)
+	}
+
+	t.Setenv("S3_USE_SSL", "maybe")
+	t.Setenv("S3_URL_EXPIRY", "-5m")
+	if cfg = Load(); !cfg.S3UseSSL || cfg.S3URLExpiry != 15*time.Minute {
+		t.Fatalf("expected fallbacks for invalid values, got %v %v", cfg.S3UseSSL, cfg.S3URLExpiry)
+	}
+}
+
+func TestValidateRequiresNewsletterSecretInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("NEWSLETTER_SECRET", "")
+
+	cfg := Load()
+	if cfg.NewsletterSecret != "" {
+		t.Fatalf("expected no default secret in production, got %q", cfg.NewsletterSecret)
+	}
+	if err := cfg.Validate(); !errors.Is(err, ErrNewsletterSecretRequired) {
+		t.Fatalf("expected ErrNewsletterSecretRequired, got %v", err)
+	}
+
+	t.Setenv("NEWSLETTER_SECRET", "s3cret")
+	if err := Load().Validate(); err != nil {
+		t.Fatalf("unexpected error with secret set: %v", err)
+	}
+}
+
+func TestValidateAllowsDevNewsletterSecretOutsideProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("NEWSLETTER_SECRET", "")
+
+	cfg := Load()
+	if cfg.NewsletterSecret == "" {
+		t.Fatal("expected a development secret")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
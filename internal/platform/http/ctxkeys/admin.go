@@ -0,0 +1,17 @@
+package ctxkeys
+
+import (
+	"github.com/gin-gonic/gin"
+
+	authdomain "proto-gin-web/internal/contexts/admin/auth/domain"
+)
+
+// AdminProfileFromContext extracts the authenticated admin profile when AdminAuth ran.
+func AdminProfileFromContext(c *gin.Context) (authdomain.Admin, bool) {
+	if v, ok := c.Get(AdminProfile); ok {
+		if profile, ok := v.(authdomain.Admin); ok {
+			return profile, true
+		}
+	}
+	return authdomain.Admin{}, false
+}
@@ -2,3 +2,10 @@ package ctxkeys
 
 // CSPNonce is the Gin context key for the CSP nonce value.
 const CSPNonce = "csp_nonce"
+
+// MenuLoader is the Gin context key for the func(name string) menu resolver that
+// layouts call to render navigation.
+const MenuLoader = "menu_loader"
+
+// AdminProfile is the Gin context key for the authdomain.Admin that AdminAuth resolved.
+const AdminProfile = "admin_profile"
@@ -198,8 +198,6 @@ func NewIPRateLimiter(maxRequests int, window time.Duration) gin.HandlerFunc {
 // TODO: For high-traffic endpoints, consider replacing this mutex-backed map with a sharded map,
 // atomic counters, or a dedicated rate-limiting backend (e.g., Redis) to avoid contention.
 
-type adminProfileKey struct{}
-
 const (
 	defaultSessionCookieAge  = 30 * 60
 	defaultRememberCookieAge = 30 * 24 * 60 * 60
@@ -222,21 +220,15 @@ func AdminAuth(cfg config.Config, sessionMgr *authsession.Manager, adminSvc admi
 			abortUnauthorized(c)
 			return
 		}
-		ctx := context.WithValue(c.Request.Context(), adminProfileKey{}, profile)
-		c.Request = c.Request.WithContext(ctx)
-		c.Set("admin_profile", profile)
+		c.Set(ctxkeys.AdminProfile, profile)
 		c.Next()
 	}
 }
 
 // AdminProfileFromContext extracts the authenticated admin profile when AdminAuth ran.
+// It is kept for callers outside the adapters; new code uses ctxkeys directly.
 func AdminProfileFromContext(c *gin.Context) (authdomain.Admin, bool) {
-	if v := c.Request.Context().Value(adminProfileKey{}); v != nil {
-		if profile, ok := v.(authdomain.Admin); ok {
-			return profile, true
-		}
-	}
-	return authdomain.Admin{}, false
+	return ctxkeys.AdminProfileFromContext(c)
 }
 
 func resolveAdminSession(c *gin.Context, cfg config.Config, sessionMgr *authsession.Manager, adminSvc adminusecase.AdminService) (authdomain.Admin, error) {
@@ -1,11 +1,14 @@
 package responder
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"log/slog"
+
+	"proto-gin-web/internal/platform/validation"
 )
 
 // JSONSuccess wraps payload with ok=true to keep API responses consistent.
@@ -19,6 +22,19 @@ func JSONSuccess(c *gin.Context, status int, payload any) {
 	})
 }
 
+// JSONSuccessWarnings wraps payload like JSONSuccess and lists warnings next to it:
+// problems worth telling the client about that did not stop the request.
+func JSONSuccessWarnings(c *gin.Context, status int, payload any, warnings any) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	c.JSON(status, gin.H{
+		"ok":       true,
+		"data":     payload,
+		"warnings": warnings,
+	})
+}
+
 // JSONError emits a standard error envelope.
 func JSONError(c *gin.Context, status int, message string) {
 	if status == 0 {
@@ -31,6 +47,41 @@ func JSONError(c *gin.Context, status int, message string) {
 	})
 }
 
+// JSONErrorData emits the error envelope together with a payload describing the
+// conflicting state, e.g. who holds a lock.
+func JSONErrorData(c *gin.Context, status int, message string, payload any) {
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	logJSONError(c, status, message)
+	c.JSON(status, gin.H{
+		"ok":    false,
+		"error": message,
+		"data":  payload,
+	})
+}
+
+// JSONValidationError emits a 422 error envelope that also lists the invalid fields.
+func JSONValidationError(c *gin.Context, message string, fields any) {
+	logJSONError(c, http.StatusUnprocessableEntity, message)
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"ok":     false,
+		"error":  message,
+		"fields": fields,
+	})
+}
+
+// JSONInvalidInput emits the 422 envelope when err carries a validation.Error and
+// reports whether it did, leaving every other error to the caller.
+func JSONInvalidInput(c *gin.Context, err error) bool {
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return false
+	}
+	JSONValidationError(c, "validation failed", verr.Fields)
+	return true
+}
+
 func logJSONError(c *gin.Context, status int, message string) {
 	logger := slog.Default()
 	rid := c.Writer.Header().Get("X-Request-ID")
@@ -17,15 +17,25 @@ import (
 	adminroutes "proto-gin-web/internal/contexts/admin/ui/adapters/http"
 	adminuiusecase "proto-gin-web/internal/contexts/admin/ui/usecase"
 	adminusecase "proto-gin-web/internal/contexts/admin/auth/usecase"
+	mediausecase "proto-gin-web/internal/contexts/admin/media/usecase"
+	menupublic "proto-gin-web/internal/contexts/blog/menu/adapters/public"
+	menuusecase "proto-gin-web/internal/contexts/blog/menu/usecase"
+	newsletterpublic "proto-gin-web/internal/contexts/blog/newsletter/adapters/public"
+	newsletterusecase "proto-gin-web/internal/contexts/blog/newsletter/usecase"
+	pageroutes "proto-gin-web/internal/contexts/blog/page/adapters/public"
+	pageusecase "proto-gin-web/internal/contexts/blog/page/usecase"
 	apiroutes "proto-gin-web/internal/contexts/blog/post/adapters/api"
 	publicroutes "proto-gin-web/internal/contexts/blog/post/adapters/public"
 	postusecase "proto-gin-web/internal/contexts/blog/post/usecase"
+	taxonomyapi "proto-gin-web/internal/contexts/blog/taxonomy/adapters/api"
+	taxonomyusecase "proto-gin-web/internal/contexts/blog/taxonomy/usecase"
 	"proto-gin-web/internal/platform/config"
 	helper "proto-gin-web/internal/platform/http/templates"
+	"proto-gin-web/internal/platform/storage"
 )
 
 // NewRouter wires middleware, templates, and routes.
-func NewRouter(cfg config.Config, postSvc postusecase.PostService, adminSvc adminusecase.AdminService, adminContentSvc *admincontentusecase.Service, adminUISvc *adminuiusecase.Service, sessionMgr *authsession.Manager) *gin.Engine {
+func NewRouter(cfg config.Config, postSvc postusecase.PostService, pageSvc pageusecase.PageService, menuSvc menuusecase.MenuService, taxonomySvc taxonomyusecase.TaxonomyService, adminSvc adminusecase.AdminService, adminContentSvc *admincontentusecase.Service, adminUISvc *adminuiusecase.Service, newsletterSvc newsletterusecase.NewsletterService, images mediausecase.ImageLookup, uploads storage.Storage, sessionMgr *authsession.Manager) *gin.Engine {
 	if cfg.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -45,6 +55,7 @@ func NewRouter(cfg config.Config, postSvc postusecase.PostService, adminSvc admi
 	r.Use(RequestID())
 	r.Use(RecoveryWithRequestID())
 	r.Use(RequestLogger())
+	r.Use(menupublic.Middleware(menuSvc))
 
 	// Gin template funcs
 	r.SetFuncMap(template.FuncMap{
@@ -58,10 +69,17 @@ func NewRouter(cfg config.Config, postSvc postusecase.PostService, adminSvc admi
 	})
 
 	r.HTMLRender = helper.LoadTemplates("internal/platform/http/templates", "layouts/*.tmpl", "includes/*.tmpl")
-	r.Static("/static", "web/static")
+	// only S3 has URLs of its own to redirect to
+	static := StaticFiles("web/static", uploads, cfg.StorageRedirect && cfg.StorageDriver == storage.DriverS3)
+	r.GET("/static/*filepath", static)
+	r.HEAD("/static/*filepath", static)
 
-	publicroutes.RegisterRoutes(r, cfg, postSvc)
+	publicroutes.RegisterRoutes(r, cfg, postSvc, pageSvc, taxonomySvc, images)
+	pageroutes.RegisterRoutes(r, cfg, pageSvc)
 	apiroutes.RegisterRoutes(r, postSvc)
+	taxonomyapi.RegisterRoutes(r, taxonomySvc)
+	subscribeLimiter := NewIPRateLimiter(5, time.Minute)
+	newsletterpublic.RegisterRoutes(r, cfg, newsletterSvc, subscribeLimiter)
 	loginLimiter := NewIPRateLimiter(5, time.Minute)
 	registerLimiter := NewIPRateLimiter(3, time.Minute)
 	sessionGuard := AdminAuth(cfg, sessionMgr, adminSvc)
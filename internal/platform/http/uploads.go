@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"proto-gin-web/internal/platform/storage"
+)
+
+const uploadsRoute = "/uploads/"
+
+// StaticFiles serves /static/*filepath from dir, except /static/uploads/ which is read
+// from the upload storage so every API instance serves the same files. With redirect
+// set, uploads are answered with a redirect to a signed storage URL instead of being
+// streamed through the API.
+func StaticFiles(dir string, uploads storage.Storage, redirect bool) gin.HandlerFunc {
+	files := http.StripPrefix("/static", http.FileServer(gin.Dir(dir, false)))
+	return func(c *gin.Context) {
+		key, ok := strings.CutPrefix(c.Param("filepath"), uploadsRoute)
+		if !ok {
+			files.ServeHTTP(c.Writer, c.Request)
+			return
+		}
+		if redirect {
+			redirectUpload(c, uploads, key)
+			return
+		}
+		serveUpload(c, uploads, key)
+	}
+}
+
+func serveUpload(c *gin.Context, uploads storage.Storage, key string) {
+	body, obj, err := uploads.Get(c.Request.Context(), key)
+	if err != nil {
+		uploadError(c, key, err)
+		return
+	}
+	defer body.Close()
+
+	h := c.Writer.Header()
+	if obj.ContentType != "" {
+		h.Set("Content-Type", obj.ContentType)
+	}
+	// stored names are never reused for other content
+	h.Set("Cache-Control", "public, max-age=86400")
+	if rs, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, key, obj.ModTime, rs)
+		return
+	}
+	h.Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	if !obj.ModTime.IsZero() {
+		h.Set("Last-Modified", obj.ModTime.UTC().Format(http.TimeFormat))
+	}
+	c.Status(http.StatusOK)
+	if c.Request.Method != http.MethodHead {
+		_, _ = io.Copy(c.Writer, body)
+	}
+}
+
+func redirectUpload(c *gin.Context, uploads storage.Storage, key string) {
+	url, err := uploads.URL(c.Request.Context(), key)
+	if err != nil {
+		uploadError(c, key, err)
+		return
+	}
+	// shorter than the signature lifetime so a cached redirect never points at an
+	// expired URL
+	c.Header("Cache-Control", "private, max-age=60")
+	c.Redirect(http.StatusFound, url)
+}
+
+func uploadError(c *gin.Context, key string, err error) {
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		c.String(http.StatusNotFound, "404 page not found")
+		return
+	}
+	slog.Error("serve upload failed",
+		slog.String("request_id", GetRequestID(c)),
+		slog.String("key", key),
+		slog.Any("err", err),
+	)
+	c.Status(http.StatusInternalServerError)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"proto-gin-web/internal/platform/storage"
+)
+
+func newStaticRouter(t *testing.T, redirect bool) (*gin.Engine, *storage.Local) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	uploads := storage.NewLocal(t.TempDir(), "/files/")
+	if err := uploads.Put(context.Background(), "1.png", strings.NewReader("png-bytes"), 9, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	static := StaticFiles(dir, uploads, redirect)
+	r.GET("/static/*filepath", static)
+	r.HEAD("/static/*filepath", static)
+	return r, uploads
+}
+
+func TestStaticFilesServesUploadsFromStorage(t *testing.T) {
+	r, _ := newStaticRouter(t, false)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := get("/static/app.css"); w.Code != http.StatusOK || w.Body.String() != "body{}" {
+		t.Fatalf("expected the static file, got %d %q", w.Code, w.Body.String())
+	}
+	w := get("/static/uploads/1.png")
+	if w.Code != http.StatusOK || w.Body.String() != "png-bytes" || w.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("expected the upload, got %d %q %v", w.Code, w.Body.String(), w.Header())
+	}
+	for _, path := range []string{"/static/uploads/2.png", "/static/uploads/../app.css", "/static/uploads/"} {
+		if w := get(path); w.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestStaticFilesRedirectsToStorageURL(t *testing.T) {
+	r, _ := newStaticRouter(t, true)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/uploads/1.png", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/files/1.png" {
+		t.Fatalf("expected a redirect to the storage URL, got %d %v", w.Code, w.Header())
+	}
+}
@@ -5,15 +5,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	authdomain "proto-gin-web/internal/contexts/admin/auth/domain"
 	"proto-gin-web/internal/platform/http/ctxkeys"
 )
 
-// RenderHTML renders a view with the provided data.
+// RenderHTML renders a view with the provided data. The menu resolver, when present,
+// is exposed as .NavMenu so layouts can call it with a menu name.
 func RenderHTML(c *gin.Context, status int, name string, data gin.H) {
 	if data == nil {
 		data = gin.H{}
 	}
+	if loader, ok := c.Get(ctxkeys.MenuLoader); ok {
+		if _, set := data["NavMenu"]; !set {
+			data["NavMenu"] = loader
+		}
+	}
 	c.HTML(status, name, data)
 }
 
@@ -22,14 +27,12 @@ func WithAdminContext(c *gin.Context, data gin.H) gin.H {
 	if data == nil {
 		data = gin.H{}
 	}
-	if profile, ok := c.Get("admin_profile"); ok {
-		if admin, ok := profile.(authdomain.Admin); ok {
-			if admin.DisplayName != "" {
-				data["AdminUser"] = admin.DisplayName
-			}
-			if admin.Email != "" {
-				data["AdminEmail"] = admin.Email
-			}
+	if admin, ok := ctxkeys.AdminProfileFromContext(c); ok {
+		if admin.DisplayName != "" {
+			data["AdminUser"] = admin.DisplayName
+		}
+		if admin.Email != "" {
+			data["AdminEmail"] = admin.Email
 		}
 	}
 	if adminUser, err := c.Cookie("admin_user"); err == nil && adminUser != "" {
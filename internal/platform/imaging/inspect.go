@@ -0,0 +1,228 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/webp"
+)
+
+var (
+	// ErrUnsupported is returned for data that is not a JPEG, PNG, GIF or WebP image,
+	// whatever its file name claims.
+	ErrUnsupported = errors.New("imaging: not a JPEG, PNG, GIF or WebP image")
+	// ErrCorrupt is returned for images whose data cannot be decoded.
+	ErrCorrupt = errors.New("imaging: image data is corrupt")
+	// ErrTooLarge is returned for images whose dimensions exceed the Limits.
+	ErrTooLarge = errors.New("imaging: image dimensions exceed the limit")
+)
+
+// formats maps the content types http.DetectContentType sniffs from magic bytes to the
+// decoder name image.DecodeConfig reports for them.
+var formats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// Limits bounds the images Inspect accepts. A few kilobytes of compressed data can
+// declare dimensions that take gigabytes to decode, so both are checked from the header
+// alone.
+type Limits struct {
+	MaxPixels int
+	MaxSide   int
+}
+
+// Info describes an encoded image. Frames is the number of frames of a GIF and 1 for
+// every other format.
+type Info struct {
+	MIMEType string
+	Width    int
+	Height   int
+	Frames   int
+}
+
+// Inspect identifies data by its magic bytes and reads its header, rejecting it before
+// any pixels are decoded when it is not a supported image or is larger than limits.
+func Inspect(data []byte, limits Limits) (Info, error) {
+	mimeType := http.DetectContentType(data)
+	format, ok := formats[mimeType]
+	if !ok {
+		return Info{}, ErrUnsupported
+	}
+	cfg, name, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || name != format {
+		return Info{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return Info{}, ErrCorrupt
+	}
+	if cfg.Width > limits.MaxSide || cfg.Height > limits.MaxSide || cfg.Width*cfg.Height > limits.MaxPixels {
+		return Info{}, fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+	info := Info{MIMEType: mimeType, Width: cfg.Width, Height: cfg.Height, Frames: 1}
+	if format == "gif" {
+		if info.Frames, err = gifFrames(data); err != nil {
+			return Info{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+	}
+	return info, nil
+}
+
+// Decode decodes the image Inspect described, the first frame of a GIF, turning a JPEG
+// upright according to its EXIF orientation. Only call it on data Inspect accepted.
+func Decode(data []byte, info Info) (image.Image, error) {
+	var (
+		img image.Image
+		err error
+	)
+	r := bytes.NewReader(data)
+	switch info.MIMEType {
+	case "image/jpeg":
+		if img, err = jpeg.Decode(r); err == nil {
+			img = Orient(img, jpegOrientation(data))
+		}
+	case "image/png":
+		img, err = png.Decode(r)
+	case "image/gif":
+		img, err = gif.Decode(r)
+	case "image/webp":
+		img, err = webp.Decode(r)
+	default:
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	return img, nil
+}
+
+// gifFrames counts the frames of a GIF by walking its blocks without decompressing any
+// of them, so an animation cannot be used to make the server decode unbounded data.
+func gifFrames(data []byte) (int, error) {
+	// header and logical screen descriptor
+	const screenEnd = 13
+	if len(data) < screenEnd {
+		return 0, errors.New("gif: truncated header")
+	}
+	pos := screenEnd
+	if flags := data[10]; flags&0x80 != 0 {
+		pos += 3 << (flags&0x07 + 1)
+	}
+	frames := 0
+	for pos < len(data) {
+		switch data[pos] {
+		case 0x3B: // trailer
+			return frames, nil
+		case 0x21: // extension: label, then sub-blocks
+			next, err := skipSubBlocks(data, pos+2)
+			if err != nil {
+				return 0, err
+			}
+			pos = next
+		case 0x2C: // image descriptor, optional local colour table, LZW code size
+			if pos+10 > len(data) {
+				return 0, errors.New("gif: truncated image descriptor")
+			}
+			flags := data[pos+9]
+			pos += 10
+			if flags&0x80 != 0 {
+				pos += 3 << (flags&0x07 + 1)
+			}
+			next, err := skipSubBlocks(data, pos+1)
+			if err != nil {
+				return 0, err
+			}
+			pos = next
+			frames++
+		default:
+			return 0, fmt.Errorf("gif: unknown block 0x%02x", data[pos])
+		}
+	}
+	// a missing trailer is common and tolerated by decoders
+	if frames == 0 {
+		return 0, errors.New("gif: no image data")
+	}
+	return frames, nil
+}
+
+func skipSubBlocks(data []byte, pos int) (int, error) {
+	for {
+		if pos >= len(data) {
+			return 0, errors.New("gif: truncated block")
+		}
+		size := int(data[pos])
+		pos++
+		if size == 0 {
+			return pos, nil
+		}
+		pos += size
+	}
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG, 1 (upright) when it has none.
+func jpegOrientation(data []byte) int {
+	pos := 2 // SOI
+	for pos+4 <= len(data) && data[pos] == 0xFF {
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 { // image data starts; no EXIF past here
+			break
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + size
+		if size < 2 || end > len(data) {
+			break
+		}
+		if marker == 0xE1 {
+			if o := exifOrientation(data[pos+4 : end]); o != 0 {
+				return o
+			}
+		}
+		pos = end
+	}
+	return 1
+}
+
+// exifOrientation reads tag 0x0112 from the first IFD of an APP1 payload, or returns 0.
+func exifOrientation(app1 []byte) int {
+	const header = "Exif\x00\x00"
+	if len(app1) < len(header)+8 || string(app1[:len(header)]) != header {
+		return 0
+	}
+	tiff := app1[len(header):]
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var testLimits = Limits{MaxPixels: 1_000_000, MaxSide: 2000}
+
+// withEXIFOrientation inserts an APP1 segment carrying orientation o after the SOI.
+func withEXIFOrientation(jpg []byte, o uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2a\x00\x00\x00\x08")
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{1, 0x0112, 3})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{o, 0})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0))
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, 0xFF, 0xE1)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+	out = append(out, payload...)
+	return append(out, jpg[2:]...)
+}
+
+// pngHeader is a PNG with only a signature and an IHDR chunk declaring w×h.
+func pngHeader(w, h uint32) []byte {
+	ihdr := binary.BigEndian.AppendUint32(nil, w)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, h)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+	chunk := append([]byte("IHDR"), ihdr...)
+	out := []byte("\x89PNG\r\n\x1a\n")
+	out = binary.BigEndian.AppendUint32(out, uint32(len(ihdr)))
+	out = append(out, chunk...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(chunk))
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestInspectTrustsContentNotNames(t *testing.T) {
+	valid := encodePNG(t, image.NewRGBA(image.Rect(0, 0, 3, 2)))
+	info, err := Inspect(valid, testLimits)
+	if err != nil || info.MIMEType != "image/png" || info.Width != 3 || info.Height != 2 || info.Frames != 1 {
+		t.Fatalf("unexpected info %+v (%v)", info, err)
+	}
+
+	for name, data := range map[string][]byte{
+		"html": []byte("<!DOCTYPE html><script>alert(1)</script>"),
+		"svg":  []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+		"text": []byte("just text"),
+	} {
+		if _, err := Inspect(data, testLimits); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got %v", name, err)
+		}
+	}
+	if _, err := Inspect(valid[:20], testLimits); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt for a truncated header, got %v", err)
+	}
+}
+
+func TestInspectRejectsOversizedDimensionsFromHeader(t *testing.T) {
+	// only the header exists, so nothing could be decoded anyway
+	if _, err := Inspect(pngHeader(1500, 1500), testLimits); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge for the pixel count, got %v", err)
+	}
+	if _, err := Inspect(pngHeader(3000, 10), testLimits); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge for the side, got %v", err)
+	}
+	if _, err := Inspect(pngHeader(1000, 1000), testLimits); err != nil {
+		t.Fatalf("expected the limit itself to pass, got %v", err)
+	}
+}
+
+func TestInspectCountsGIFFrames(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	encode := func(frames int) []byte {
+		anim := &gif.GIF{}
+		for i := 0; i < frames; i++ {
+			anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, 4, 4), palette))
+			anim.Delay = append(anim.Delay, 5)
+		}
+		var buf bytes.Buffer
+		if err := gif.EncodeAll(&buf, anim); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	for _, frames := range []int{1, 3} {
+		info, err := Inspect(encode(frames), testLimits)
+		if err != nil || info.Frames != frames {
+			t.Fatalf("expected %d frames, got %+v (%v)", frames, info, err)
+		}
+	}
+	data := encode(2)
+	if _, err := Inspect(data[:len(data)-8], testLimits); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt for a truncated GIF, got %v", err)
+	}
+}
+
+func TestDecodeTurnsJPEGUpright(t *testing.T) {
+	// red left half, blue right half, stored needing a 90° clockwise turn
+	src := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 8 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := withEXIFOrientation(buf.Bytes(), 6)
+
+	info, err := Inspect(data, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := Decode(data, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 8, 16) {
+		t.Fatalf("expected 8x16 upright, got %v", img.Bounds())
+	}
+	top := color.RGBAModel.Convert(img.At(4, 2)).(color.RGBA)
+	bottom := color.RGBAModel.Convert(img.At(4, 13)).(color.RGBA)
+	if top.R < 200 || bottom.B < 200 {
+		t.Fatalf("expected red on top and blue below, got %v and %v", top, bottom)
+	}
+}
+
+func TestOrientCoversAllEightValues(t *testing.T) {
+	// a 2x1 image with a marked first pixel; record where it lands
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{R: 255, A: 255})
+	want := map[int]image.Point{1: {0, 0}, 2: {1, 0}, 3: {1, 0}, 4: {0, 0}, 5: {0, 0}, 6: {0, 0}, 7: {0, 1}, 8: {0, 1}}
+	for o, at := range want {
+		img := Orient(src, o)
+		found := false
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if r, _, _, _ := img.At(x, y).RGBA(); r > 0 {
+					found = image.Pt(x, y) == at
+				}
+			}
+		}
+		if !found {
+			t.Errorf("orientation %d: expected the marked pixel at %v in %v", o, at, b)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package imaging scales decoded images down for responsive variants using only the
+// standard library.
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit returns the height an image of w×h has when scaled to width, keeping its aspect
+// ratio and never dropping below one pixel.
+func Fit(w, h, width int) int {
+	height := (h*width + w/2) / w
+	if height < 1 {
+		return 1
+	}
+	return height
+}
+
+// Resize scales src to width pixels wide, keeping its aspect ratio. Every target pixel
+// is the area-weighted average of the source pixels it covers, which keeps downscaled
+// photos free of the aliasing nearest-neighbour sampling produces. It is meant for
+// shrinking; a width at or above the source width yields the source as an RGBA image.
+func Resize(src image.Image, width int) *image.RGBA {
+	rgba := ToRGBA(src)
+	sw, sh := rgba.Rect.Dx(), rgba.Rect.Dy()
+	if width >= sw || width < 1 {
+		return rgba
+	}
+	height := Fit(sw, sh, width)
+
+	// horizontal pass into a width×sh buffer of premultiplied channels, then vertical
+	cols := spans(sw, width)
+	tmp := make([]float32, width*sh*4)
+	for y := 0; y < sh; y++ {
+		row := rgba.Pix[y*rgba.Stride:]
+		for x, span := range cols {
+			var r, g, bl, a float32
+			for _, t := range span {
+				p := row[t.index*4:]
+				r += float32(p[0]) * t.weight
+				g += float32(p[1]) * t.weight
+				bl += float32(p[2]) * t.weight
+				a += float32(p[3]) * t.weight
+			}
+			o := (y*width + x) * 4
+			tmp[o], tmp[o+1], tmp[o+2], tmp[o+3] = r, g, bl, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	rows := spans(sh, height)
+	for y, span := range rows {
+		out := dst.Pix[y*dst.Stride:]
+		for x := 0; x < width; x++ {
+			var r, g, bl, a float32
+			for _, t := range span {
+				o := (t.index*width + x) * 4
+				r += tmp[o] * t.weight
+				g += tmp[o+1] * t.weight
+				bl += tmp[o+2] * t.weight
+				a += tmp[o+3] * t.weight
+			}
+			p := out[x*4:]
+			p[0], p[1], p[2], p[3] = clamp(r), clamp(g), clamp(bl), clamp(a)
+		}
+	}
+	return dst
+}
+
+// ToRGBA returns img as an RGBA image with its origin at 0,0, converting it only when
+// it is not one already. Convert once when resizing the same image to several widths.
+func ToRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+type tap struct {
+	index  int
+	weight float32
+}
+
+// spans lists, for each of the dst target positions, the source positions it covers
+// and their share of it; the shares of one target add up to one.
+func spans(src, dst int) [][]tap {
+	scale := float64(src) / float64(dst)
+	out := make([][]tap, dst)
+	for i := range out {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for j := int(start); j < src && float64(j) < end; j++ {
+			lo, hi := float64(j), float64(j+1)
+			if lo < start {
+				lo = start
+			}
+			if hi > end {
+				hi = end
+			}
+			if hi > lo {
+				out[i] = append(out[i], tap{index: j, weight: float32((hi - lo) / scale)})
+			}
+		}
+	}
+	return out
+}
+
+func clamp(v float32) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	default:
+		return uint8(v + 0.5)
+	}
+}
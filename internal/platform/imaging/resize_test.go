@@ -0,0 +1,62 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFitKeepsAspectRatio(t *testing.T) {
+	cases := []struct{ w, h, width, want int }{
+		{4000, 3000, 640, 480},
+		{1000, 333, 320, 107},
+		{5000, 2, 320, 1},
+	}
+	for _, c := range cases {
+		if got := Fit(c.w, c.h, c.width); got != c.want {
+			t.Errorf("Fit(%d, %d, %d) = %d, want %d", c.w, c.h, c.width, got, c.want)
+		}
+	}
+}
+
+func TestResizeAveragesCoveredPixels(t *testing.T) {
+	// alternating black and white columns average to mid grey
+	src := image.NewGray(image.Rect(0, 0, 8, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x += 2 {
+			src.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	dst := Resize(src, 4)
+	if dst.Bounds().Dx() != 4 || dst.Bounds().Dy() != 2 {
+		t.Fatalf("expected 4x2, got %v", dst.Bounds())
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if c := dst.RGBAAt(x, y); c.R < 127 || c.R > 128 || c.A != 255 {
+				t.Fatalf("pixel %d,%d = %v, want mid grey", x, y, c)
+			}
+		}
+	}
+}
+
+func TestResizeHandlesUnevenScaleAndOffsetBounds(t *testing.T) {
+	src := image.NewRGBA(image.Rect(10, 10, 17, 13))
+	for y := 10; y < 13; y++ {
+		for x := 10; x < 17; x++ {
+			src.SetRGBA(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	dst := Resize(src, 3)
+	if dst.Bounds() != image.Rect(0, 0, 3, 1) {
+		t.Fatalf("expected 3x1 at the origin, got %v", dst.Bounds())
+	}
+	for x := 0; x < 3; x++ {
+		if c := dst.RGBAAt(x, 0); c != (color.RGBA{R: 200, G: 100, B: 50, A: 255}) {
+			t.Fatalf("pixel %d = %v, want the uniform colour", x, c)
+		}
+	}
+	if same := Resize(src, 20); same.Bounds() != image.Rect(0, 0, 7, 3) {
+		t.Fatalf("expected an unscaled copy, got %v", same.Bounds())
+	}
+}
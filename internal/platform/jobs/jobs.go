@@ -0,0 +1,104 @@
+// Package jobs runs periodic background work inside the API process, such as
+// archiving posts whose unpublish date has passed. Every instance runs every job, so
+// jobs must be safe to run concurrently and idempotent.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a unit of work repeated every Interval. A failed run is logged and retried on
+// the next tick.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Next, when set, replaces Interval: the job waits until Next(now) instead of running
+	// right away, and after every run until Next of the time it finished.
+	Next func(after time.Time) time.Time
+	Run  func(ctx context.Context) error
+}
+
+// DailyAt returns a Next schedule that runs a job once a day at hour:minute in the
+// local time zone.
+func DailyAt(hour, minute int) func(after time.Time) time.Time {
+	return func(after time.Time) time.Time {
+		next := time.Date(after.Year(), after.Month(), after.Day(), hour, minute, 0, 0, after.Location())
+		if !next.After(after) {
+			next = time.Date(after.Year(), after.Month(), after.Day()+1, hour, minute, 0, 0, after.Location())
+		}
+		return next
+	}
+}
+
+// Runner starts jobs on their own goroutines and stops them together.
+type Runner struct {
+	log  *slog.Logger
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+// NewRunner creates a Runner that reports failures to log.
+func NewRunner(log *slog.Logger) *Runner {
+	return &Runner{log: log}
+}
+
+// Add registers a job; it must be called before Start.
+func (r *Runner) Add(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+// Start runs every job once right away and then on its interval, or on its Next
+// schedule, until ctx is done.
+func (r *Runner) Start(ctx context.Context) {
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go func(job Job) {
+			defer r.wg.Done()
+			if job.Next != nil {
+				r.loopScheduled(ctx, job)
+				return
+			}
+			r.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until every job has returned after its context was cancelled.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+func (r *Runner) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		r.run(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) loopScheduled(ctx context.Context, job Job) {
+	for {
+		timer := time.NewTimer(time.Until(job.Next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		r.run(ctx, job)
+	}
+}
+
+func (r *Runner) run(ctx context.Context, job Job) {
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		r.log.Error("background job failed", slog.String("job", job.Name), slog.Any("err", err))
+	}
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunnerRepeatsJobsUntilCancelled(t *testing.T) {
+	var ok, failing atomic.Int32
+	runner := NewRunner(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	runner.Add(Job{Name: "ok", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+		ok.Add(1)
+		return nil
+	}})
+	runner.Add(Job{Name: "failing", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+		failing.Add(1)
+		return errors.New("boom")
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+	deadline := time.Now().Add(time.Second)
+	for (ok.Load() < 3 || failing.Load() < 3) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	runner.Wait()
+
+	if ok.Load() < 3 || failing.Load() < 3 {
+		t.Fatalf("expected both jobs to keep running, got ok=%d failing=%d", ok.Load(), failing.Load())
+	}
+	stopped := ok.Load()
+	time.Sleep(20 * time.Millisecond)
+	if ok.Load() != stopped {
+		t.Fatal("job kept running after Wait returned")
+	}
+}
+
+func TestRunnerFollowsNextSchedule(t *testing.T) {
+	var runs atomic.Int32
+	runner := NewRunner(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	runner.Add(Job{
+		Name: "scheduled",
+		Next: func(after time.Time) time.Time { return after.Add(20 * time.Millisecond) },
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+	time.Sleep(5 * time.Millisecond)
+	if runs.Load() != 0 {
+		t.Fatal("scheduled job should wait for its first slot")
+	}
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	runner.Wait()
+	if runs.Load() < 2 {
+		t.Fatalf("expected the job to run on its schedule, got %d runs", runs.Load())
+	}
+}
+
+func TestDailyAt(t *testing.T) {
+	next := DailyAt(3, 0)
+	cases := []struct {
+		after, want time.Time
+	}{
+		{time.Date(2024, 5, 1, 1, 30, 0, 0, time.UTC), time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC), time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		if got := next(tc.after); !got.Equal(tc.want) {
+			t.Errorf("DailyAt(3, 0)(%v) = %v, want %v", tc.after, got, tc.want)
+		}
+	}
+}
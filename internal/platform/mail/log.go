@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogMailer logs every message instead of sending it and, when dir is set, writes it
+// there as an .eml file that mail clients can open. It is meant for development.
+type LogMailer struct {
+	log  *slog.Logger
+	from string
+	dir  string
+	now  func() time.Time
+}
+
+var _ Mailer = (*LogMailer)(nil)
+
+// NewLogMailer creates a LogMailer writing to log and, unless empty, dir.
+func NewLogMailer(log *slog.Logger, from, dir string) *LogMailer {
+	return &LogMailer{log: log, from: from, dir: dir, now: time.Now}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	now := m.now()
+	attrs := []any{slog.String("to", msg.To), slog.String("subject", msg.Subject)}
+	if m.dir != "" {
+		if err := os.MkdirAll(m.dir, 0o755); err != nil {
+			return err
+		}
+		name := filepath.Join(m.dir, fmt.Sprintf("%s-%09d.eml", now.UTC().Format("20060102T150405"), now.Nanosecond()))
+		if err := os.WriteFile(name, encode(m.from, msg, now), 0o644); err != nil {
+			return err
+		}
+		attrs = append(attrs, slog.String("file", name))
+	}
+	m.log.InfoContext(ctx, "mail logged instead of sent", attrs...)
+	return nil
+}
@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSMTPMailerSendsThroughServer(t *testing.T) {
+	server := startSMTPStandIn(t)
+	host, port, _ := net.SplitHostPort(server.addr)
+	mailer := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "Blog <blog@example.com>"})
+
+	err := mailer.Send(context.Background(), Message{
+		To:      "reader@example.com",
+		Subject: "Nouveautés",
+		Text:    "Hello\nRead more at https://example.com/posts/hello",
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/u?t=1>"},
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	got := <-server.received
+	if got.from != "blog@example.com" || got.to != "reader@example.com" {
+		t.Fatalf("unexpected envelope %q -> %q", got.from, got.to)
+	}
+	for _, want := range []string{
+		"From: Blog <blog@example.com>\r\n",
+		"To: reader@example.com\r\n",
+		"Subject: =?utf-8?q?Nouveaut=C3=A9s?=\r\n",
+		"List-Unsubscribe: <https://example.com/u?t=1>\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\nHello\r\nRead more at https://example.com/posts/hello",
+	} {
+		if !strings.Contains(got.data, want) {
+			t.Errorf("expected %q in message:\n%s", want, got.data)
+		}
+	}
+}
+
+func TestSMTPMailerRejectsBadRecipient(t *testing.T) {
+	mailer := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: "1", From: "blog@example.com"})
+	if err := mailer.Send(context.Background(), Message{To: "not an address"}); err == nil {
+		t.Fatal("expected an invalid recipient to fail before dialing")
+	}
+}
+
+func TestEncodeStripsHeaderInjection(t *testing.T) {
+	raw := string(encode("blog@example.com", Message{To: "a@example.com", Subject: "Hi\r\nBcc: x@example.com"}, testTime))
+	if strings.Contains(raw, "\r\nBcc:") {
+		t.Fatalf("header injection survived:\n%s", raw)
+	}
+}
+
+func TestLogMailerWritesEML(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewLogMailer(slog.New(slog.NewTextHandler(io.Discard, nil)), "blog@example.com", dir)
+	if err := mailer.Send(context.Background(), Message{To: "reader@example.com", Subject: "Hi", Text: "Body"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v", files)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil || !strings.Contains(string(raw), "To: reader@example.com") {
+		t.Fatalf("unexpected file %q (%v)", raw, err)
+	}
+}
+
+var testTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type receivedMail struct {
+	from, to, data string
+}
+
+type smtpStandIn struct {
+	addr     string
+	received chan receivedMail
+}
+
+// startSMTPStandIn accepts one connection and speaks just enough SMTP to take a
+// message.
+func startSMTPStandIn(t *testing.T) *smtpStandIn {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	s := &smtpStandIn{addr: ln.Addr().String(), received: make(chan receivedMail, 1)}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		var got receivedMail
+		reply("220 stand-in ready")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch upper := strings.ToUpper(cmd); {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 stand-in")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				got.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+				reply("250 ok")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				got.to = strings.Trim(cmd[len("RCPT TO:"):], "<> ")
+				reply("250 ok")
+			case upper == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				got.data = data.String()
+				reply("250 queued")
+			case upper == "QUIT":
+				reply("221 bye")
+				s.received <- got
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return s
+}
@@ -0,0 +1,50 @@
+// Package markdown renders user-authored Markdown to sanitized HTML for public views.
+package markdown
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	bf "github.com/russross/blackfriday/v2"
+)
+
+// Render converts md to HTML and strips anything outside the UGC policy. Literal
+// "\n" and "\r\n" escape sequences left over from seed data are treated as newlines.
+func Render(md string) template.HTML {
+	unsafe := bf.Run([]byte(normalize(md)))
+	safe := bluemonday.UGCPolicy().SanitizeBytes(unsafe)
+	return template.HTML(string(safe))
+}
+
+// htmlURLAttr matches the href and src attributes of raw HTML embedded in Markdown.
+var htmlURLAttr = regexp.MustCompile(`(?i)\b(?:href|src)\s*=\s*["']([^"']+)["']`)
+
+// Links returns the destinations of the links and images in md, including href and src
+// attributes of embedded HTML, in document order.
+func Links(md string) []string {
+	var out []string
+	doc := bf.New(bf.WithExtensions(bf.CommonExtensions)).Parse([]byte(normalize(md)))
+	doc.Walk(func(node *bf.Node, entering bool) bf.WalkStatus {
+		if !entering {
+			return bf.GoToNext
+		}
+		switch node.Type {
+		case bf.Link, bf.Image:
+			out = append(out, string(node.LinkData.Destination))
+		case bf.HTMLBlock, bf.HTMLSpan:
+			for _, m := range htmlURLAttr.FindAllSubmatch(node.Literal, -1) {
+				out = append(out, string(m[1]))
+			}
+		}
+		return bf.GoToNext
+	})
+	return out
+}
+
+func normalize(md string) string {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	md = strings.ReplaceAll(md, "\\r\\n", "\n")
+	return strings.ReplaceAll(md, "\\n", "\n")
+}
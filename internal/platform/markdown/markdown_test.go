@@ -0,0 +1,24 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinks(t *testing.T) {
+	md := "See [the intro](/posts/intro) and ![diagram](/static/uploads/1.png \"Diagram\").\\n\\n" +
+		"<a href=\"/posts?tag=go\">Go</a> <img src='/static/uploads/2.png'>\n\n" +
+		"Visit https://example.com/docs.\n\n" +
+		"    [not a link](/in/code)\n"
+
+	want := []string{"/posts/intro", "/static/uploads/1.png", "/posts?tag=go", "/static/uploads/2.png", "https://example.com/docs"}
+	if got := Links(md); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Links() = %q, want %q", got, want)
+	}
+}
+
+func TestLinksEmpty(t *testing.T) {
+	if got := Links("Just text."); len(got) != 0 {
+		t.Fatalf("expected no links, got %q", got)
+	}
+}
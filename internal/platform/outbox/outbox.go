@@ -0,0 +1,74 @@
+// Package outbox records domain events in the transaction of the change that caused
+// them and relays them to in-process handlers afterwards. A handler sees every event
+// at least once, so handlers must tolerate duplicates.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Position orders messages for the relay: by writing transaction, then by id.
+type Position struct {
+	TxID int64
+	ID   int64
+}
+
+// Message is one recorded domain event. Key names the entity it is about, such as a
+// post slug, so handlers can collapse or route events without decoding the payload.
+type Message struct {
+	ID         int64           `json:"id"`
+	TxID       int64           `json:"-"`
+	Type       string          `json:"type"`
+	Key        string          `json:"key"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Position returns where the message sits in relay order.
+func (m Message) Position() Position {
+	return Position{TxID: m.TxID, ID: m.ID}
+}
+
+// NewMessage encodes payload into a message of the given type that occurred now.
+func NewMessage(eventType, key string, payload any) (Message, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Type: eventType, Key: key, Payload: raw, OccurredAt: time.Now().UTC()}, nil
+}
+
+// Transactor runs work in a database transaction carried by the context it passes to
+// fn. Repositories called with that context join the transaction, which commits when
+// fn returns nil and rolls back otherwise.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Writer appends messages to the outbox.
+type Writer interface {
+	Transactor
+	Append(ctx context.Context, messages ...Message) error
+}
+
+// Record runs fn in a transaction and appends the messages it returns before commit,
+// so the change and its events are stored together or not at all. With a nil writer
+// fn runs on its own and its messages are dropped.
+func Record(ctx context.Context, w Writer, fn func(ctx context.Context) ([]Message, error)) error {
+	if w == nil {
+		_, err := fn(ctx)
+		return err
+	}
+	return w.WithinTx(ctx, func(ctx context.Context) error {
+		messages, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		return w.Append(ctx, messages...)
+	})
+}
@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOffsetLocked reports that another relay is dispatching to the handler right now.
+var ErrOffsetLocked = errors.New("outbox: handler offset locked")
+
+const batchSize = 100
+
+// Store reads the outbox and keeps each handler's offset. ClaimOffset and SaveOffset
+// run inside WithinTx: claiming locks the handler's offset until the transaction ends.
+type Store interface {
+	Transactor
+	// ClaimOffset returns the handler's offset, starting a new handler at the messages
+	// written from now on. It returns ErrOffsetLocked when another transaction holds it.
+	ClaimOffset(ctx context.Context, handler string) (Position, error)
+	// Fetch returns up to limit messages after pos in relay order, leaving out those
+	// whose transaction may still be followed by an earlier one.
+	Fetch(ctx context.Context, after Position, limit int32) ([]Message, error)
+	SaveOffset(ctx context.Context, handler string, pos Position) error
+	// Cleanup deletes messages that occurred before the cutoff and that every named
+	// handler has passed.
+	Cleanup(ctx context.Context, before time.Time, handlers []string) (int64, error)
+}
+
+// Handler consumes outbox messages. Types limits it to those message types; an empty
+// list means every type. Handle runs in the transaction that advances the handler's
+// offset, so database writes it makes through ctx commit with the offset.
+type Handler struct {
+	Name   string
+	Types  []string
+	Handle func(ctx context.Context, msg Message) error
+}
+
+func (h Handler) wants(eventType string) bool {
+	if len(h.Types) == 0 {
+		return true
+	}
+	for _, t := range h.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Relay dispatches outbox messages to registered handlers in order. A handler error
+// rolls back its batch, offset included, and the batch is retried on the next
+// Dispatch; other handlers carry on.
+type Relay struct {
+	store    Store
+	handlers []Handler
+}
+
+// NewRelay creates a relay reading from store.
+func NewRelay(store Store) *Relay {
+	return &Relay{store: store}
+}
+
+// Register adds a handler; it must be called before the first Dispatch. Names must be
+// unique and stable, since offsets are stored under them.
+func (r *Relay) Register(h Handler) {
+	r.handlers = append(r.handlers, h)
+}
+
+// Dispatch hands every pending message to every handler and returns how many messages
+// were handled.
+func (r *Relay) Dispatch(ctx context.Context) (int, error) {
+	handled := 0
+	var errs []error
+	for _, h := range r.handlers {
+		n, err := r.dispatch(ctx, h)
+		handled += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("outbox handler %s: %w", h.Name, err))
+		}
+	}
+	return handled, errors.Join(errs...)
+}
+
+// dispatch works through the handler's pending messages one batch, and one
+// transaction, at a time.
+func (r *Relay) dispatch(ctx context.Context, h Handler) (int, error) {
+	handled := 0
+	for {
+		var fetched, n int
+		err := r.store.WithinTx(ctx, func(ctx context.Context) error {
+			pos, err := r.store.ClaimOffset(ctx, h.Name)
+			if err != nil {
+				return err
+			}
+			messages, err := r.store.Fetch(ctx, pos, batchSize)
+			if err != nil || len(messages) == 0 {
+				return err
+			}
+			fetched = len(messages)
+			for _, msg := range messages {
+				if h.wants(msg.Type) {
+					if err := h.Handle(ctx, msg); err != nil {
+						return fmt.Errorf("message %d: %w", msg.ID, err)
+					}
+					n++
+				}
+			}
+			return r.store.SaveOffset(ctx, h.Name, messages[len(messages)-1].Position())
+		})
+		if errors.Is(err, ErrOffsetLocked) {
+			return handled, nil
+		}
+		if err != nil {
+			return handled, err
+		}
+		handled += n
+		if fetched < batchSize || ctx.Err() != nil {
+			return handled, nil
+		}
+	}
+}
+
+// Cleanup deletes messages older than retention that every registered handler has
+// passed, and returns how many were deleted.
+func (r *Relay) Cleanup(ctx context.Context, retention time.Duration) (int64, error) {
+	names := make([]string, len(r.handlers))
+	for i, h := range r.handlers {
+		names[i] = h.Name
+	}
+	return r.store.Cleanup(ctx, time.Now().Add(-retention), names)
+}
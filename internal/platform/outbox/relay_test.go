@@ -0,0 +1,228 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecordAppendsMessagesWithTheChange(t *testing.T) {
+	w := &fakeStore{}
+	msg, err := NewMessage("PostPublished", "hello", map[string]string{"slug": "hello"})
+	if err != nil {
+		t.Fatalf("new message: %v", err)
+	}
+	if err := Record(context.Background(), w, func(ctx context.Context) ([]Message, error) {
+		return []Message{msg}, nil
+	}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if len(w.messages) != 1 || w.messages[0].Type != "PostPublished" || string(w.messages[0].Payload) != `{"slug":"hello"}` {
+		t.Fatalf("unexpected outbox: %+v", w.messages)
+	}
+
+	boom := errors.New("boom")
+	err = Record(context.Background(), w, func(ctx context.Context) ([]Message, error) {
+		return []Message{msg}, boom
+	})
+	if !errors.Is(err, boom) || len(w.messages) != 1 {
+		t.Fatalf("failed change must not append, err=%v messages=%d", err, len(w.messages))
+	}
+	if w.rollbacks != 1 {
+		t.Fatalf("expected the failed change to roll back, got %d rollbacks", w.rollbacks)
+	}
+}
+
+func TestRecordWithoutWriterRunsTheChange(t *testing.T) {
+	ran := false
+	if err := Record(context.Background(), nil, func(ctx context.Context) ([]Message, error) {
+		ran = true
+		return []Message{{Type: "PostUpdated"}}, nil
+	}); err != nil || !ran {
+		t.Fatalf("expected fn to run, ran=%v err=%v", ran, err)
+	}
+}
+
+func TestRelayDispatchesInOrderAndTracksOffsets(t *testing.T) {
+	store := &fakeStore{}
+	store.add(1, 1, "PostPublished")
+	store.add(1, 2, "TaxonomyChanged")
+	store.add(2, 3, "PostUpdated")
+
+	var posts, all []int64
+	relay := NewRelay(store)
+	relay.Register(Handler{Name: "posts", Types: []string{"PostPublished", "PostUpdated"}, Handle: func(ctx context.Context, msg Message) error {
+		posts = append(posts, msg.ID)
+		return nil
+	}})
+	relay.Register(Handler{Name: "all", Handle: func(ctx context.Context, msg Message) error {
+		all = append(all, msg.ID)
+		return nil
+	}})
+
+	n, err := relay.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if n != 5 || !equalIDs(posts, 1, 3) || !equalIDs(all, 1, 2, 3) {
+		t.Fatalf("unexpected dispatch n=%d posts=%v all=%v", n, posts, all)
+	}
+	if store.offsets["posts"] != (Position{TxID: 2, ID: 3}) {
+		t.Fatalf("expected offset past skipped types, got %+v", store.offsets["posts"])
+	}
+
+	if n, err := relay.Dispatch(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected nothing left, n=%d err=%v", n, err)
+	}
+}
+
+func TestRelayRetriesFailedBatch(t *testing.T) {
+	store := &fakeStore{}
+	store.add(1, 1, "PostUpdated")
+	store.add(1, 2, "PostUpdated")
+
+	fail := true
+	var seen, other []int64
+	relay := NewRelay(store)
+	relay.Register(Handler{Name: "flaky", Handle: func(ctx context.Context, msg Message) error {
+		seen = append(seen, msg.ID)
+		if msg.ID == 2 && fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	}})
+	relay.Register(Handler{Name: "steady", Handle: func(ctx context.Context, msg Message) error {
+		other = append(other, msg.ID)
+		return nil
+	}})
+
+	if _, err := relay.Dispatch(context.Background()); err == nil {
+		t.Fatal("expected the handler error to be reported")
+	}
+	if _, ok := store.offsets["flaky"]; ok {
+		t.Fatalf("failed batch must not move the offset, got %+v", store.offsets["flaky"])
+	}
+	if !equalIDs(other, 1, 2) {
+		t.Fatalf("other handlers must carry on, got %v", other)
+	}
+
+	fail = false
+	if _, err := relay.Dispatch(context.Background()); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !equalIDs(seen, 1, 2, 1, 2) {
+		t.Fatalf("expected the whole batch again, got %v", seen)
+	}
+}
+
+func TestRelaySkipsLockedHandler(t *testing.T) {
+	store := &fakeStore{locked: map[string]bool{"busy": true}}
+	store.add(1, 1, "PostUpdated")
+	relay := NewRelay(store)
+	relay.Register(Handler{Name: "busy", Handle: func(ctx context.Context, msg Message) error {
+		t.Fatal("locked handler must not run")
+		return nil
+	}})
+	if n, err := relay.Dispatch(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected a quiet skip, n=%d err=%v", n, err)
+	}
+}
+
+func TestRelayCleanupPassesRegisteredHandlers(t *testing.T) {
+	store := &fakeStore{}
+	relay := NewRelay(store)
+	relay.Register(Handler{Name: "a", Handle: func(context.Context, Message) error { return nil }})
+	relay.Register(Handler{Name: "b", Handle: func(context.Context, Message) error { return nil }})
+
+	if _, err := relay.Cleanup(context.Background(), 7*24*time.Hour); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+	if len(store.cleanupHandlers) != 2 || store.cleanupHandlers[0] != "a" || store.cleanupHandlers[1] != "b" {
+		t.Fatalf("unexpected handlers %v", store.cleanupHandlers)
+	}
+	if age := time.Since(store.cleanupBefore); age < 7*24*time.Hour || age > 7*24*time.Hour+time.Minute {
+		t.Fatalf("unexpected cutoff %v", store.cleanupBefore)
+	}
+}
+
+func equalIDs(got []int64, want ...int64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeStore keeps the outbox in memory. WithinTx restores offsets and messages when fn
+// fails, like a rollback would.
+type fakeStore struct {
+	messages        []Message
+	offsets         map[string]Position
+	locked          map[string]bool
+	rollbacks       int
+	cleanupBefore   time.Time
+	cleanupHandlers []string
+}
+
+func (s *fakeStore) add(txID, id int64, eventType string) {
+	s.messages = append(s.messages, Message{ID: id, TxID: txID, Type: eventType})
+}
+
+func (s *fakeStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	offsets := make(map[string]Position, len(s.offsets))
+	for k, v := range s.offsets {
+		offsets[k] = v
+	}
+	messages := len(s.messages)
+	if err := fn(ctx); err != nil {
+		s.offsets = offsets
+		s.messages = s.messages[:messages]
+		s.rollbacks++
+		return err
+	}
+	return nil
+}
+
+func (s *fakeStore) Append(ctx context.Context, messages ...Message) error {
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+func (s *fakeStore) ClaimOffset(ctx context.Context, handler string) (Position, error) {
+	if s.locked[handler] {
+		return Position{}, ErrOffsetLocked
+	}
+	return s.offsets[handler], nil
+}
+
+func (s *fakeStore) Fetch(ctx context.Context, after Position, limit int32) ([]Message, error) {
+	var out []Message
+	for _, m := range s.messages {
+		if m.TxID > after.TxID || (m.TxID == after.TxID && m.ID > after.ID) {
+			out = append(out, m)
+		}
+		if len(out) == int(limit) {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) SaveOffset(ctx context.Context, handler string, pos Position) error {
+	if s.offsets == nil {
+		s.offsets = make(map[string]Position)
+	}
+	s.offsets[handler] = pos
+	return nil
+}
+
+func (s *fakeStore) Cleanup(ctx context.Context, before time.Time, handlers []string) (int64, error) {
+	s.cleanupBefore = before
+	s.cleanupHandlers = handlers
+	return 0, nil
+}
@@ -0,0 +1,26 @@
+package seo
+
+// Crumb is one step of a breadcrumb trail.
+type Crumb struct {
+	Name string
+	URL  string
+}
+
+// BreadcrumbList returns schema.org BreadcrumbList structured data for the trail,
+// ready to be encoded as JSON-LD. The last crumb is the current page.
+func BreadcrumbList(crumbs []Crumb) map[string]any {
+	items := make([]map[string]any, len(crumbs))
+	for i, c := range crumbs {
+		items[i] = map[string]any{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"name":     c.Name,
+			"item":     c.URL,
+		}
+	}
+	return map[string]any{
+		"@context":        "https://schema.org",
+		"@type":           "BreadcrumbList",
+		"itemListElement": items,
+	}
+}
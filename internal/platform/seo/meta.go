@@ -13,6 +13,9 @@ type Meta struct {
 	SiteName    string
 	ImageURL    string
 	Type        string // website | article
+	// Canonical, when set, is emitted as <link rel="canonical">; listings set it so
+	// their paginated and filtered variants point at one URL per page.
+	Canonical string
 	// Twitter
 	TwitterCard    string // summary | summary_large_image
 	TwitterSite    string // @site
@@ -68,6 +71,9 @@ func (m Meta) Tags() string {
 	if m.Description != "" {
 		b.WriteString(`<meta name="description" content="` + esc(m.Description) + `">`)
 	}
+	if m.Canonical != "" {
+		b.WriteString(`<link rel="canonical" href="` + esc(m.Canonical) + `">`)
+	}
 	// OpenGraph
 	if m.Type != "" {
 		b.WriteString(`<meta property="og:type" content="` + esc(m.Type) + `">`)
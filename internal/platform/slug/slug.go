@@ -0,0 +1,160 @@
+// Package slug builds URL slugs from free text: it transliterates Unicode to ASCII
+// (pinyin for Han characters, Hepburn-style romaji for kana, Revised Romanization
+// for Hangul), lowercases, collapses separators and resolves collisions with
+// numeric suffixes.
+package slug
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength caps generated slugs, leaving headroom for uniqueness suffixes.
+const MaxLength = 96
+
+// maxAttempts bounds the number of suffixes Unique tries before giving up.
+const maxAttempts = 1000
+
+var (
+	// ErrEmpty is returned when the input produces no usable characters.
+	ErrEmpty = errors.New("slug: nothing to build a slug from")
+	// ErrExhausted is returned when every suffix candidate is taken.
+	ErrExhausted = errors.New("slug: no unique candidate available")
+)
+
+// ExistsFunc reports whether a slug is already in use.
+type ExistsFunc func(ctx context.Context, slug string) (bool, error)
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Make converts text into a lowercase, hyphen-separated ASCII slug. It returns an
+// empty string when nothing in text can be transliterated.
+func Make(text string) string {
+	runes := []rune(norm.NFC.String(text))
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r < unicode.MaxASCII:
+			writeASCII(&b, r)
+		case unicode.Is(unicode.Han, r):
+			if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 {
+				b.WriteByte('-')
+				b.WriteString(py[0])
+				b.WriteByte('-')
+			} else {
+				b.WriteByte('-')
+			}
+		case isHangulSyllable(r):
+			b.WriteString(romanizeHangul(r))
+		case isKana(r):
+			i += writeKana(&b, runes, i)
+		default:
+			if s, ok := specialLetters[r]; ok {
+				b.WriteString(s)
+				continue
+			}
+			writeDecomposed(&b, r)
+		}
+	}
+	return finish(b.String())
+}
+
+// Unique returns base, or base with the first free numeric suffix ("-2", "-3", ...)
+// according to exists.
+func Unique(ctx context.Context, base string, exists ExistsFunc) (string, error) {
+	if base == "" {
+		return "", ErrEmpty
+	}
+	for n := 1; n <= maxAttempts; n++ {
+		candidate := base
+		if n > 1 {
+			suffix := "-" + strconv.Itoa(n)
+			candidate = strings.TrimRight(truncate(base, MaxLength-len(suffix)), "-") + suffix
+		}
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrExhausted
+}
+
+func writeASCII(b *strings.Builder, r rune) {
+	switch {
+	case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		b.WriteRune(r)
+	case r >= 'A' && r <= 'Z':
+		b.WriteRune(unicode.ToLower(r))
+	case r == '&':
+		b.WriteString("-and-")
+	case r == '\'':
+		// drop apostrophes so "don't" becomes "dont"
+	default:
+		b.WriteByte('-')
+	}
+}
+
+// writeDecomposed strips diacritics via NFKD and keeps whatever ASCII remains.
+func writeDecomposed(b *strings.Builder, r rune) {
+	wrote := false
+	for _, d := range norm.NFKD.String(string(r)) {
+		switch {
+		case unicode.Is(unicode.Mn, d):
+			continue
+		case d < unicode.MaxASCII:
+			writeASCII(b, d)
+			wrote = true
+		default:
+			if s, ok := specialLetters[d]; ok {
+				b.WriteString(s)
+				wrote = true
+			}
+		}
+	}
+	if !wrote {
+		b.WriteByte('-')
+	}
+}
+
+// finish collapses separator runs, trims them from both ends and enforces MaxLength.
+func finish(raw string) string {
+	var b strings.Builder
+	b.Grow(len(raw))
+	pendingHyphen := false
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c == '-' {
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteByte(c)
+	}
+	return strings.TrimRight(truncate(b.String(), MaxLength), "-")
+}
+
+// truncate shortens s to at most n bytes, preferring to cut at a hyphen.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := s[:n]
+	if i := strings.LastIndexByte(cut, '-'); i > n/2 {
+		cut = cut[:i]
+	}
+	return cut
+}
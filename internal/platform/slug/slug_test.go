@@ -0,0 +1,68 @@
+package slug
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	cases := map[string]string{
+		"Hello, World!":            "hello-world",
+		"  Go -- 1.24   release  ": "go-1-24-release",
+		"Crème brûlée & Straße":    "creme-brulee-and-strasse",
+		"Don't panic":              "dont-panic",
+		"Привет, мир":              "privet-mir",
+		"你好世界":                     "ni-hao-shi-jie",
+		"Go 语言":                    "go-yu-yan",
+		"こんにちは":                    "konnichiha",
+		"キャッシュ":                    "kyasshu",
+		"안녕하세요":                    "annyeonghaseyo",
+		"🚀🚀":                       "",
+	}
+	for in, want := range cases {
+		if got := Make(in); got != want {
+			t.Errorf("Make(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMakeTruncatesAtHyphen(t *testing.T) {
+	long := ""
+	for i := 0; i < 40; i++ {
+		long += "word "
+	}
+	got := Make(long)
+	if len(got) > MaxLength {
+		t.Fatalf("expected at most %d bytes, got %d", MaxLength, len(got))
+	}
+	if got[len(got)-1] == '-' {
+		t.Fatalf("expected no trailing hyphen, got %q", got)
+	}
+}
+
+func TestUniqueAppendsSuffix(t *testing.T) {
+	taken := map[string]bool{"hello": true, "hello-2": true}
+	got, err := Unique(context.Background(), "hello", func(ctx context.Context, s string) (bool, error) {
+		return taken[s], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "hello-3" {
+		t.Fatalf("expected hello-3, got %q", got)
+	}
+}
+
+func TestUniqueErrors(t *testing.T) {
+	if _, err := Unique(context.Background(), "", nil); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+	boom := errors.New("boom")
+	_, err := Unique(context.Background(), "x", func(context.Context, string) (bool, error) {
+		return false, boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected lookup error, got %v", err)
+	}
+}
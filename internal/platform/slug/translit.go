@@ -0,0 +1,151 @@
+package slug
+
+import "strings"
+
+// specialLetters covers letters that NFKD does not reduce to ASCII: Latin ligatures
+// and stroked letters, plus Cyrillic and Greek transliterations.
+var specialLetters = map[rune]string{
+	// Latin
+	'ß': "ss", 'ẞ': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe",
+	'ø': "o", 'Ø': "o", 'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d",
+	'ł': "l", 'Ł': "l", 'þ': "th", 'Þ': "th", 'ı': "i", 'ħ': "h", 'Ħ': "h",
+	'ŋ': "ng", 'Ŋ': "ng", 'ŧ': "t", 'Ŧ': "t", 'ſ': "s",
+
+	// Cyrillic (Russian, Ukrainian, Belarusian, Serbian, Macedonian)
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+	'А': "a", 'Б': "b", 'В': "v", 'Г': "g", 'Д': "d", 'Е': "e", 'Ё': "yo", 'Ж': "zh",
+	'З': "z", 'И': "i", 'Й': "y", 'К': "k", 'Л': "l", 'М': "m", 'Н': "n", 'О': "o",
+	'П': "p", 'Р': "r", 'С': "s", 'Т': "t", 'У': "u", 'Ф': "f", 'Х': "kh", 'Ц': "ts",
+	'Ч': "ch", 'Ш': "sh", 'Щ': "shch", 'Ъ': "", 'Ы': "y", 'Ь': "", 'Э': "e", 'Ю': "yu",
+	'Я': "ya", 'Є': "ye", 'І': "i", 'Ї': "yi", 'Ґ': "g", 'Ў': "u", 'Ђ': "dj", 'Ј': "j",
+	'Љ': "lj", 'Њ': "nj", 'Ћ': "c", 'Џ': "dz", 'Ѓ': "gj", 'Ќ': "kj", 'Ѕ': "dz",
+
+	// Greek (ELOT 743, simplified)
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+	'Α': "a", 'Β': "v", 'Γ': "g", 'Δ': "d", 'Ε': "e", 'Ζ': "z", 'Η': "i", 'Θ': "th",
+	'Ι': "i", 'Κ': "k", 'Λ': "l", 'Μ': "m", 'Ν': "n", 'Ξ': "x", 'Ο': "o", 'Π': "p",
+	'Ρ': "r", 'Σ': "s", 'Τ': "t", 'Υ': "y", 'Φ': "f", 'Χ': "ch", 'Ψ': "ps", 'Ω': "o",
+}
+
+// Hangul syllables are composed arithmetically from leading consonant, vowel and
+// optional trailing consonant; see the Unicode standard, section 3.12.
+const (
+	hangulBase   = 0xAC00
+	hangulLast   = 0xD7A3
+	hangulVowels = 21
+	hangulTails  = 28
+)
+
+var (
+	hangulLeads   = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMids    = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulTailsRR = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+func romanizeHangul(r rune) string {
+	idx := int(r - hangulBase)
+	lead := idx / (hangulVowels * hangulTails)
+	mid := (idx % (hangulVowels * hangulTails)) / hangulTails
+	tail := idx % hangulTails
+	return hangulLeads[lead] + hangulMids[mid] + hangulTailsRR[tail]
+}
+
+// kana maps hiragana to Hepburn romaji; katakana is folded onto hiragana first.
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゎ': "wa",
+}
+
+// smallY combines with a preceding i-row kana, e.g. き+ゃ → kya, し+ゃ → sha.
+var smallY = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+const (
+	sokuon     = 'っ'
+	longVowel  = 'ー'
+	hiraFirst  = 0x3041
+	hiraLast   = 0x3096
+	kataFirst  = 0x30A1
+	kataLast   = 0x30F6
+	kataOffset = kataFirst - hiraFirst
+)
+
+func isKana(r rune) bool {
+	return (r >= hiraFirst && r <= hiraLast) || (r >= kataFirst && r <= kataLast) || r == longVowel
+}
+
+func toHiragana(r rune) rune {
+	if r >= kataFirst && r <= kataLast {
+		return r - kataOffset
+	}
+	return r
+}
+
+// writeKana romanizes the kana at runes[i] and returns how many extra runes it consumed.
+func writeKana(b *strings.Builder, runes []rune, i int) int {
+	r := toHiragana(runes[i])
+	switch r {
+	case longVowel:
+		// the long vowel mark only lengthens the previous vowel; dropping it keeps slugs short
+		return 0
+	case sokuon:
+		// geminate the consonant of the following kana (っか → kka)
+		if i+1 < len(runes) && isKana(runes[i+1]) {
+			if next := kana[toHiragana(runes[i+1])]; next != "" && next[0] != 'a' && next[0] != 'i' && next[0] != 'u' && next[0] != 'e' && next[0] != 'o' {
+				if strings.HasPrefix(next, "ch") {
+					b.WriteByte('t')
+				} else {
+					b.WriteByte(next[0])
+				}
+			}
+		}
+		return 0
+	}
+	base, ok := kana[r]
+	if !ok {
+		if tail, small := smallY[r]; small {
+			b.WriteString("y" + tail)
+			return 0
+		}
+		b.WriteByte('-')
+		return 0
+	}
+	if i+1 < len(runes) {
+		if tail, small := smallY[toHiragana(runes[i+1])]; small && strings.HasSuffix(base, "i") && len(base) > 1 {
+			stem := strings.TrimSuffix(base, "i")
+			if stem == "sh" || stem == "ch" || stem == "j" {
+				b.WriteString(stem + tail)
+			} else {
+				b.WriteString(stem + "y" + tail)
+			}
+			return 1
+		}
+	}
+	b.WriteString(base)
+	return 0
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const defaultURLExpiry = 15 * time.Minute
+
+// S3Config points S3 at a bucket of an S3-compatible service such as AWS S3 or MinIO.
+// Endpoint is a host[:port] without scheme. PublicEndpoint, when set, is the host signed
+// URLs are issued for, for when browsers reach the service under another name than the
+// API does. Prefix is prepended to every key.
+type S3Config struct {
+	Endpoint       string
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	Prefix         string
+	AccessKey      string
+	SecretKey      string
+	UseSSL         bool
+	// URLExpiry is how long signed URLs stay valid; it defaults to 15 minutes.
+	URLExpiry time.Duration
+}
+
+// S3 stores objects in an S3-compatible bucket.
+type S3 struct {
+	client *minio.Client
+	signer *minio.Client
+	cfg    S3Config
+}
+
+var _ Storage = (*S3)(nil)
+
+// NewS3 creates an S3 backend. It does not contact the service.
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("storage: s3 endpoint and bucket are required")
+	}
+	if cfg.Region == "" {
+		// a known region lets URLs be signed without asking the service for it
+		cfg.Region = "us-east-1"
+	}
+	if cfg.URLExpiry <= 0 {
+		cfg.URLExpiry = defaultURLExpiry
+	}
+	if cfg.Prefix != "" && !strings.HasSuffix(cfg.Prefix, "/") {
+		cfg.Prefix += "/"
+	}
+	newClient := func(endpoint string) (*minio.Client, error) {
+		client, err := minio.New(endpoint, &minio.Options{
+			Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+			Secure: cfg.UseSSL,
+			Region: cfg.Region,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("storage: s3 client for %s: %w", endpoint, err)
+		}
+		return client, nil
+	}
+	client, err := newClient(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	signer := client
+	if cfg.PublicEndpoint != "" && cfg.PublicEndpoint != cfg.Endpoint {
+		if signer, err = newClient(cfg.PublicEndpoint); err != nil {
+			return nil, err
+		}
+	}
+	return &S3{client: client, signer: signer, cfg: cfg}, nil
+}
+
+// EnsureBucket creates the bucket when it does not exist.
+func (s *S3) EnsureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	if err != nil {
+		return fmt.Errorf("storage: check bucket %s: %w", s.cfg.Bucket, err)
+	}
+	if exists {
+		return nil
+	}
+	if err := s.client.MakeBucket(ctx, s.cfg.Bucket, minio.MakeBucketOptions{Region: s.cfg.Region}); err != nil {
+		return fmt.Errorf("storage: create bucket %s: %w", s.cfg.Bucket, err)
+	}
+	return nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	name, err := s.name(key)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, s.cfg.Bucket, name, body, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("storage: put %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	name, err := s.name(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	obj, err := s.client.GetObject(ctx, s.cfg.Bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, Object{}, s.err("get", key, err)
+	}
+	// GetObject is lazy; Stat makes the request and surfaces a missing key
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, Object{}, s.err("get", key, err)
+	}
+	return obj, s.object(info), nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (Object, error) {
+	name, err := s.name(key)
+	if err != nil {
+		return Object{}, err
+	}
+	info, err := s.client.StatObject(ctx, s.cfg.Bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return Object{}, s.err("stat", key, err)
+	}
+	return s.object(info), nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	name, err := s.name(key)
+	if err != nil {
+		return err
+	}
+	if err := s.client.RemoveObject(ctx, s.cfg.Bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		return s.err("delete", key, err)
+	}
+	return nil
+}
+
+func (s *S3) URL(ctx context.Context, key string) (string, error) {
+	name, err := s.name(key)
+	if err != nil {
+		return "", err
+	}
+	u, err := s.signer.PresignedGetObject(ctx, s.cfg.Bucket, name, s.cfg.URLExpiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("storage: sign %s: %w", key, err)
+	}
+	return u.String(), nil
+}
+
+func (s *S3) List(ctx context.Context, fn func(Object) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	// stops the listing goroutine when fn returns early
+	defer cancel()
+	for info := range s.client.ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{Prefix: s.cfg.Prefix, Recursive: true}) {
+		if info.Err != nil {
+			return fmt.Errorf("storage: list %s: %w", s.cfg.Bucket, info.Err)
+		}
+		if err := fn(s.object(info)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *S3) name(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.cfg.Prefix + key, nil
+}
+
+func (s *S3) object(info minio.ObjectInfo) Object {
+	return Object{
+		Key:         strings.TrimPrefix(info.Key, s.cfg.Prefix),
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModTime:     info.LastModified,
+	}
+}
+
+func (s *S3) err(op, key string, err error) error {
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return fmt.Errorf("storage: %s %s: %w", op, key, err)
+}
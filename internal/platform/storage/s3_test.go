@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestS3Backend runs against a real S3-compatible service, for example
+//
+//	docker compose up -d minio
+//	STORAGE_S3_TEST_ENDPOINT=localhost:9000 go test ./internal/platform/storage/
+//
+// using the minioadmin credentials unless STORAGE_S3_ACCESS_KEY and
+// STORAGE_S3_SECRET_KEY are set. Each run uses a fresh key prefix.
+func TestS3Backend(t *testing.T) {
+	endpoint := os.Getenv("STORAGE_S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("STORAGE_S3_TEST_ENDPOINT not set")
+	}
+	envOr := func(key, fallback string) string {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+		return fallback
+	}
+	store, err := NewS3(S3Config{
+		Endpoint:  endpoint,
+		Bucket:    envOr("STORAGE_S3_BUCKET", "uploads-test"),
+		Prefix:    fmt.Sprintf("test-%d", time.Now().UnixNano()),
+		AccessKey: envOr("STORAGE_S3_ACCESS_KEY", "minioadmin"),
+		SecretKey: envOr("STORAGE_S3_SECRET_KEY", "minioadmin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.EnsureBucket(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	exerciseBackend(t, store)
+}
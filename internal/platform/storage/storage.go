@@ -0,0 +1,157 @@
+// Package storage keeps uploaded files in a pluggable Storage: a local directory during
+// development, or an S3-compatible bucket when several API instances share the files.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"proto-gin-web/internal/platform/config"
+)
+
+// Driver names accepted by Open.
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	// ErrNotFound is returned when a key does not exist.
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or leave their prefix.
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Object describes a stored file. ModTime is zero when the backend does not report it.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage stores files under slash-separated keys such as "1700000000.png".
+type Storage interface {
+	// Put stores body under key, replacing any existing object. size may be -1 when
+	// unknown.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object; the caller closes the reader. Readers of the local backend
+	// also implement io.Seeker.
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	Stat(ctx context.Context, key string) (Object, error)
+	// Delete removes the object; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns where a browser can fetch the object: the public path for the local
+	// backend, a time-limited signed URL for S3.
+	URL(ctx context.Context, key string) (string, error)
+	// List calls fn for every stored object in key order, stopping at the first error.
+	List(ctx context.Context, fn func(Object) error) error
+}
+
+// Options selects and configures a backend for Open.
+type Options struct {
+	Driver   string
+	LocalDir string
+	// URLPrefix is the public path the local directory is served under.
+	URLPrefix string
+	S3        S3Config
+}
+
+// OptionsFromConfig reads the backend selection from cfg; the local directory is served
+// under urlPrefix.
+func OptionsFromConfig(cfg config.Config, urlPrefix string) Options {
+	return Options{
+		Driver:    cfg.StorageDriver,
+		LocalDir:  cfg.StorageLocalDir,
+		URLPrefix: urlPrefix,
+		S3: S3Config{
+			Endpoint:       cfg.S3Endpoint,
+			PublicEndpoint: cfg.S3PublicEndpoint,
+			Region:         cfg.S3Region,
+			Bucket:         cfg.S3Bucket,
+			Prefix:         cfg.S3Prefix,
+			AccessKey:      cfg.S3AccessKey,
+			SecretKey:      cfg.S3SecretKey,
+			UseSSL:         cfg.S3UseSSL,
+			URLExpiry:      cfg.S3URLExpiry,
+		},
+	}
+}
+
+// Open returns the backend named by opts.Driver, creating the bucket of an S3 backend
+// when it does not exist yet.
+func Open(ctx context.Context, opts Options) (Storage, error) {
+	switch opts.Driver {
+	case DriverLocal, "":
+		return NewLocal(opts.LocalDir, opts.URLPrefix), nil
+	case DriverS3:
+		s3, err := NewS3(opts.S3)
+		if err != nil {
+			return nil, err
+		}
+		if err := s3.EnsureBucket(ctx); err != nil {
+			return nil, err
+		}
+		return s3, nil
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", opts.Driver)
+	}
+}
+
+// CleanKey validates a key, rejecting absolute paths, backslashes and ".." segments so a
+// key can never reach outside the local directory or bucket prefix.
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return path.Clean(key), nil
+}
+
+// CopyResult summarizes a Copy between backends.
+type CopyResult struct {
+	Copied  int
+	Skipped int
+	Failed  map[string]error
+}
+
+// Copy copies every object of src into dst, skipping keys dst already holds at the same
+// size so an interrupted copy can simply be run again. Objects are never removed from
+// src. A failure to list src stops the copy; failures of single objects are collected.
+func Copy(ctx context.Context, src, dst Storage) (CopyResult, error) {
+	result := CopyResult{Failed: map[string]error{}}
+	err := src.List(ctx, func(obj Object) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if existing, err := dst.Stat(ctx, obj.Key); err == nil && existing.Size == obj.Size {
+			result.Skipped++
+			return nil
+		}
+		if err := copyObject(ctx, src, dst, obj.Key); err != nil {
+			result.Failed[obj.Key] = err
+			return nil
+		}
+		result.Copied++
+		return nil
+	})
+	return result, err
+}
+
+func copyObject(ctx context.Context, src, dst Storage, key string) error {
+	body, obj, err := src.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return dst.Put(ctx, key, body, obj.Size, obj.ContentType)
+}
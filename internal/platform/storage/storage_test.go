@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// exerciseBackend checks the behaviour every Storage shares.
+func exerciseBackend(t *testing.T, store Storage) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "a.png", strings.NewReader("first"), 5, "image/png"); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := store.Put(ctx, "a.png", strings.NewReader("second"), -1, "image/png"); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+	if err := store.Put(ctx, "nested/b.txt", strings.NewReader("b"), 1, "text/plain"); err != nil {
+		t.Fatalf("put nested: %v", err)
+	}
+
+	body, obj, err := store.Get(ctx, "a.png")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "second" || obj.Size != 6 || obj.ContentType != "image/png" {
+		t.Fatalf("unexpected object %+v with %q", obj, data)
+	}
+	if _, _, err := store.Get(ctx, "missing.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := store.Stat(ctx, "missing.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from stat, got %v", err)
+	}
+	if err := store.Put(ctx, "../escape.png", strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+	if u, err := store.URL(ctx, "a.png"); err != nil || !strings.Contains(u, "a.png") {
+		t.Fatalf("unexpected url %q: %v", u, err)
+	}
+
+	var keys []string
+	if err := store.List(ctx, func(o Object) error {
+		keys = append(keys, o.Key)
+		return nil
+	}); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if strings.Join(keys, ",") != "a.png,nested/b.txt" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	for _, key := range []string{"a.png", "nested/b.txt", "missing.png"} {
+		if err := store.Delete(ctx, key); err != nil {
+			t.Fatalf("delete %s: %v", key, err)
+		}
+	}
+	if _, err := store.Stat(ctx, "a.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the object deleted, got %v", err)
+	}
+}
+
+func TestLocalBackend(t *testing.T) {
+	dir := t.TempDir()
+	exerciseBackend(t, NewLocal(dir, "/static/uploads/"))
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), tempPrefix) {
+			t.Fatalf("left a temporary file behind: %s", e.Name())
+		}
+	}
+	if u, _ := NewLocal(dir, "/static/uploads/").URL(context.Background(), "a.png"); u != "/static/uploads/a.png" {
+		t.Fatalf("unexpected local url %q", u)
+	}
+	// a directory that was never created lists as empty
+	if err := NewLocal(filepath.Join(dir, "none"), "/").List(context.Background(), func(Object) error {
+		t.Fatal("expected no objects")
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCleanKey(t *testing.T) {
+	for _, key := range []string{"a.png", "2024/05/a.png"} {
+		if _, err := CleanKey(key); err != nil {
+			t.Errorf("CleanKey(%q) = %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "/a.png", "a/../b", "..", `a\b`, "a//b", "./a"} {
+		if _, err := CleanKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("CleanKey(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestCopySkipsObjectsAlreadyPresent(t *testing.T) {
+	ctx := context.Background()
+	src := NewLocal(t.TempDir(), "/")
+	dst := NewLocal(t.TempDir(), "/")
+	for key, body := range map[string]string{"a.png": "aaa", "b.png": "bb", "c.png": "c"} {
+		if err := src.Put(ctx, key, strings.NewReader(body), int64(len(body)), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// same size counts as copied; a different size is overwritten
+	_ = dst.Put(ctx, "a.png", strings.NewReader("xxx"), 3, "")
+	_ = dst.Put(ctx, "b.png", strings.NewReader("stale"), 5, "")
+
+	result, err := Copy(ctx, src, dst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Copied != 2 || result.Skipped != 1 || len(result.Failed) != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	body, _, err := dst.Get(ctx, "b.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "bb" {
+		t.Fatalf("expected b.png replaced, got %q", data)
+	}
+}
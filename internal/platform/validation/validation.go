@@ -0,0 +1,66 @@
+// Package validation collects field-level input errors so that every invalid field
+// can be reported at once. Domains keep their own sentinel errors and add them per
+// field; callers match them with errors.Is and HTTP adapters list the fields.
+package validation
+
+import "strings"
+
+// FieldError describes a single invalid input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Err     error  `json:"-"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Error collects every field that failed validation.
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Error())
+	}
+	return "invalid input: " + strings.Join(parts, "; ")
+}
+
+// Unwrap exposes the wrapped sentinels to errors.Is.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// Add records err against field.
+func (e *Error) Add(field string, err error) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: err.Error(), Err: err})
+}
+
+// Has reports whether field already failed validation.
+func (e *Error) Has(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns nil when no field failed, otherwise the collected error.
+func (e *Error) Err() error {
+	if e == nil || len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
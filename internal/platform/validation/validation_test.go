@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCollectsFields(t *testing.T) {
+	errRequired := errors.New("title is required")
+	errTooLong := errors.New("slug is too long")
+
+	v := &Error{}
+	if v.Err() != nil {
+		t.Fatal("expected no error without fields")
+	}
+	v.Add("title", errRequired)
+	v.Add("slug", errTooLong)
+
+	err := fmt.Errorf("save: %w", v.Err())
+	var verr *Error
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 {
+		t.Fatalf("expected both fields, got %v", err)
+	}
+	if !errors.Is(err, errRequired) || !errors.Is(err, errTooLong) {
+		t.Fatalf("expected the sentinels to match, got %v", err)
+	}
+	if !v.Has("slug") || v.Has("summary") {
+		t.Fatalf("unexpected Has results for %v", v.Fields)
+	}
+	if got := v.Error(); got != "invalid input: title: title is required; slug: slug is too long" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}